@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	achievementDefsPath = "assets/achievements.json"
+	achievementsPath    = "achievements.json"
+
+	achievementToastTime = 4 * time.Second
+	achievementFontSize  = 24
+)
+
+// AchievementDef is an achievement earned once a lifetime stat reaches
+// Count, or once the world flag Flag is raised in any game. Title and
+// Description are locale keys.
+type AchievementDef struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Stat        string `json:"stat,omitempty"`
+	Count       int    `json:"count,omitempty"`
+	Flag        string `json:"flag,omitempty"`
+}
+
+// statValues read the lifetime stats achievements can count, by name
+var statValues = map[string]func() int{
+	"jumps":           func() int { return stats.Jumps },
+	"attacks":         func() int { return stats.Attacks },
+	"hits":            func() int { return stats.Hits },
+	"deaths":          func() int { return stats.Deaths },
+	"enemiesDefeated": func() int { return stats.EnemiesDefeated },
+	"itemsCollected":  func() int { return stats.ItemsCollected },
+	"meters":          func() int { return int(stats.Distance / statsPixelsPerMeter) },
+}
+
+// Achievements tracks which achievements the current profile has earned and
+// announces new ones with a toast.
+type Achievements struct {
+	defs     []AchievementDef
+	unlocked map[string]time.Time
+	toast    *AchievementDef
+	toastAt  time.Time
+	font     *Font
+}
+
+var achievements = &Achievements{unlocked: make(map[string]time.Time)}
+
+// LoadAchievementDefs reads a JSON array of achievement definitions.
+func LoadAchievementDefs(path string) ([]AchievementDef, error) {
+	data, err := ReadAsset(path)
+	if err != nil {
+		return nil, err
+	}
+	var defs []AchievementDef
+	if err := json.Unmarshal(data, &defs); err != nil {
+		return nil, fmt.Errorf("achievements %s: %w", path, err)
+	}
+	for _, d := range defs {
+		if _, ok := statValues[d.Stat]; d.Stat != "" && !ok {
+			log.Printf("achievements: %s counts unknown stat %q", d.ID, d.Stat)
+		}
+	}
+	return defs, nil
+}
+
+// SetDefs replaces the achievement definitions.
+func (a *Achievements) SetDefs(defs []AchievementDef) {
+	a.defs = defs
+}
+
+// Defs returns the achievements in display order.
+func (a *Achievements) Defs() []AchievementDef {
+	return a.defs
+}
+
+// Unlocked returns when id was earned, or false if it hasn't been.
+func (a *Achievements) Unlocked(id string) (time.Time, bool) {
+	at, ok := a.unlocked[id]
+	return at, ok
+}
+
+// Progress returns how far along def is, out of its goal.
+func (a *Achievements) Progress(def AchievementDef) (have, goal int) {
+	if value, ok := statValues[def.Stat]; ok {
+		goal = max(def.Count, 1)
+		return min(value(), goal), goal
+	}
+	if def.Flag != "" && worldFlags.Has(def.Flag) {
+		return 1, 1
+	}
+	return 0, 1
+}
+
+// HandleEvent checks for newly earned achievements after anything that
+// may have moved a stat or raised a flag. It counts what stats count.
+func (a *Achievements) HandleEvent(e Event) {
+	if !stats.recording() {
+		return
+	}
+	for i := range a.defs {
+		def := &a.defs[i]
+		if _, ok := a.unlocked[def.ID]; ok {
+			continue
+		}
+		if have, goal := a.Progress(*def); have < goal {
+			continue
+		}
+		a.unlocked[def.ID] = time.Now()
+		a.toast, a.toastAt = def, time.Now()
+		a.Save()
+	}
+}
+
+// Load replaces the earned achievements with the current profile's.
+func (a *Achievements) Load() {
+	clear(a.unlocked)
+	a.toast = nil
+	path := profilePath(achievementsPath)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("achievements: %v", err)
+		}
+		return
+	}
+	if err := json.Unmarshal(data, &a.unlocked); err != nil {
+		log.Printf("achievements: %s: %v", path, err)
+	}
+}
+
+// Save writes the earned achievements to the current profile.
+func (a *Achievements) Save() {
+	data, err := json.MarshalIndent(a.unlocked, "", "  ")
+	if err == nil {
+		err = writeFileAtomic(profilePath(achievementsPath), data)
+	}
+	if err != nil {
+		log.Printf("achievements: %v", err)
+	}
+}
+
+// Draw shows the latest achievement in the top right corner for a few
+// seconds after it is earned.
+func (a *Achievements) Draw() {
+	if a.toast == nil || time.Since(a.toastAt) > achievementToastTime {
+		return
+	}
+	if a.font == nil {
+		a.font = fonts.Acquire("", achievementFontSize)
+	}
+	panel := ui.Rect(UIRect{Anchor: AnchorTopRight, Offset: rl.NewVector2(24, 24), Size: rl.NewVector2(380, 76)})
+	if !DrawNinePatch(SkinPanel, panel, rl.White) {
+		rl.DrawRectangleRounded(panel, 0.2, 8, rl.Fade(rl.Black, 0.8))
+	}
+	a.font.Draw(T("achievement.unlocked"), rl.NewVector2(panel.X+16, panel.Y+10), 20, rl.Gold)
+	a.font.Draw(T(a.toast.Title), rl.NewVector2(panel.X+16, panel.Y+36), achievementFontSize, rl.RayWhite)
+}
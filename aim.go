@@ -0,0 +1,117 @@
+package main
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	// aimSet marks an InputFrame.Aim that carries a direction
+	aimSet = 1 << 31
+	// aimSteps is how finely the aim angle is stored, per full turn
+	aimSteps = 1024
+	// Right stick deflection needed before it aims
+	aimStickDeadzone = 0.5
+)
+
+// encodeAim stores an angle in radians in an InputFrame.Aim, quantized so
+// replays reproduce the exact direction.
+func encodeAim(angle float64) uint32 {
+	step := int(math.Round(angle/(2*math.Pi)*aimSteps)) % aimSteps
+	if step < 0 {
+		step += aimSteps
+	}
+	return aimSet | uint32(step)
+}
+
+// AimDir returns the unit direction the attack is aimed in, if it is aimed.
+func (f InputFrame) AimDir() (rl.Vector2, bool) {
+	if f.Aim&aimSet == 0 {
+		return rl.Vector2{}, false
+	}
+	angle := float64(f.Aim&^aimSet) / aimSteps * 2 * math.Pi
+	return rl.NewVector2(float32(math.Cos(angle)), float32(math.Sin(angle))), true
+}
+
+// pollAim reads the binding's aim: the right stick when pushed, otherwise
+// the mouse for the main player while the keyboard is in use.
+func (b *InputBinding) pollAim() uint32 {
+	if !settings.AimAttacks {
+		return 0
+	}
+	if rl.IsGamepadAvailable(b.Gamepad) {
+		x := rl.GetGamepadAxisMovement(b.Gamepad, rl.GamepadAxisRightX)
+		y := rl.GetGamepadAxisMovement(b.Gamepad, rl.GamepadAxisRightY)
+		if x*x+y*y > aimStickDeadzone*aimStickDeadzone {
+			return encodeAim(math.Atan2(float64(y), float64(x)))
+		}
+	}
+	if b != playerBinding || b.usingPad {
+		return 0
+	}
+	body := PlayerBounds()
+	center := rl.GetWorldToScreen2D(rl.NewVector2(body.X+body.Width/2, body.Y+body.Height/2), camera.View())
+	d := rl.Vector2Subtract(rl.GetMousePosition(), center)
+	if d.X == 0 && d.Y == 0 {
+		return 0
+	}
+	return encodeAim(math.Atan2(float64(d.Y), float64(d.X)))
+}
+
+// AttackBox is the area an attack reaches: a rectangle Reach long and Width
+// wide reaching out from Origin in direction Dir.
+type AttackBox struct {
+	Origin rl.Vector2
+	Dir    rl.Vector2
+	Reach  float32
+	Width  float32
+}
+
+// Corners returns the box's corners in order around it.
+func (a AttackBox) Corners() [4]rl.Vector2 {
+	side := rl.NewVector2(-a.Dir.Y*a.Width/2, a.Dir.X*a.Width/2)
+	tip := rl.Vector2Add(a.Origin, rl.Vector2Scale(a.Dir, a.Reach))
+	return [4]rl.Vector2{
+		rl.Vector2Add(a.Origin, side),
+		rl.Vector2Add(tip, side),
+		rl.Vector2Subtract(tip, side),
+		rl.Vector2Subtract(a.Origin, side),
+	}
+}
+
+// Overlaps reports whether the box touches r, testing the axes of both
+// shapes for a gap between them.
+func (a AttackBox) Overlaps(r rl.Rectangle) bool {
+	box := a.Corners()
+	rect := [4]rl.Vector2{
+		{X: r.X, Y: r.Y},
+		{X: r.X + r.Width, Y: r.Y},
+		{X: r.X + r.Width, Y: r.Y + r.Height},
+		{X: r.X, Y: r.Y + r.Height},
+	}
+	axes := []rl.Vector2{{X: 1}, {Y: 1}, a.Dir, {X: -a.Dir.Y, Y: a.Dir.X}}
+	for _, axis := range axes {
+		boxMin, boxMax := projectCorners(box, axis)
+		rectMin, rectMax := projectCorners(rect, axis)
+		if boxMax < rectMin || rectMax < boxMin {
+			return false
+		}
+	}
+	return true
+}
+
+func projectCorners(corners [4]rl.Vector2, axis rl.Vector2) (float32, float32) {
+	lo := rl.Vector2DotProduct(corners[0], axis)
+	hi := lo
+	for _, c := range corners[1:] {
+		d := rl.Vector2DotProduct(c, axis)
+		lo, hi = min(lo, d), max(hi, d)
+	}
+	return lo, hi
+}
+
+// Angle returns the box's direction in degrees.
+func (a AttackBox) Angle() float32 {
+	return float32(math.Atan2(float64(a.Dir.Y), float64(a.Dir.X))) * rl.Rad2deg
+}
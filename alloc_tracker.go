@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+const allocHistory = 60 // frames averaged in the overlay
+
+// allocPhase is the part of the frame allocations are attributed to
+type allocPhase int
+
+const (
+	allocUpdate allocPhase = iota // input, streaming and simulation ticks
+	allocDraw
+	allocPhaseCount
+)
+
+var allocPhaseNames = [allocPhaseCount]string{"Update", "Draw"}
+
+type allocSample struct {
+	objects uint64
+	bytes   uint64
+}
+
+// AllocTracker measures heap allocations per frame phase from
+// runtime.MemStats deltas. Reading MemStats briefly stops the world, so it
+// only samples while the debug overlay is visible.
+type AllocTracker struct {
+	last    allocSample
+	history [allocPhaseCount][allocHistory]allocSample
+	next    int
+	count   int
+	stats   runtime.MemStats
+	active  bool
+}
+
+var allocs = &AllocTracker{}
+
+func (a *AllocTracker) read() allocSample {
+	runtime.ReadMemStats(&a.stats)
+	return allocSample{objects: a.stats.Mallocs, bytes: a.stats.TotalAlloc}
+}
+
+// BeginFrame starts a frame's measurement.
+func (a *AllocTracker) BeginFrame() {
+	a.active = debugOverlay.Visible
+	if a.active {
+		a.last = a.read()
+	}
+}
+
+// Mark attributes everything allocated since the previous mark to phase.
+func (a *AllocTracker) Mark(phase allocPhase) {
+	if !a.active {
+		return
+	}
+	now := a.read()
+	a.history[phase][a.next] = allocSample{objects: now.objects - a.last.objects, bytes: now.bytes - a.last.bytes}
+	a.last = now
+	if phase == allocPhaseCount-1 {
+		a.next = (a.next + 1) % allocHistory
+		a.count = min(a.count+1, allocHistory)
+	}
+}
+
+// DebugLines shows average allocations per phase and GC state for the debug overlay.
+func (a *AllocTracker) DebugLines() []string {
+	var lines []string
+	for phase := range allocPhaseCount {
+		var objects, bytes, peak uint64
+		for i := 0; i < a.count; i++ {
+			s := a.history[phase][i]
+			objects += s.objects
+			bytes += s.bytes
+			peak = max(peak, s.bytes)
+		}
+		n := uint64(max(a.count, 1))
+		lines = append(lines, fmt.Sprintf("%-6s %6d allocs %7.1f KB/frame (peak %.1f KB)",
+			allocPhaseNames[phase], objects/n, float64(bytes/n)/1024, float64(peak)/1024))
+	}
+
+	s := &a.stats
+	lastPause := time.Duration(s.PauseNs[(s.NumGC+255)%256])
+	lines = append(lines,
+		fmt.Sprintf("Heap %.1f MB, next GC at %.1f MB", float64(s.HeapAlloc)/megabyte, float64(s.NextGC)/megabyte),
+		fmt.Sprintf("GC cycles %d, last pause %.3f ms", s.NumGC, ms(lastPause)),
+		fmt.Sprintf("GOGC %s, memory limit %s", gcPercentLabel(), memoryLimitLabel()),
+	)
+	return lines
+}
+
+func gcPercentLabel() string {
+	switch {
+	case settings.GCPercent == 0:
+		return "default"
+	case settings.GCPercent < 0:
+		return "off"
+	}
+	return fmt.Sprintf("%d%%", settings.GCPercent)
+}
+
+func memoryLimitLabel() string {
+	// A negative limit only queries the current one
+	limit := debug.SetMemoryLimit(-1)
+	if limit == math.MaxInt64 {
+		return "none"
+	}
+	return fmt.Sprintf("%d MB", limit/megabyte)
+}
+
+// ApplyGCSettings sets the collector's target percentage and soft memory
+// limit from settings. Zero values keep the Go defaults.
+func ApplyGCSettings(s Settings) {
+	if s.GCPercent != 0 {
+		debug.SetGCPercent(s.GCPercent)
+	}
+	if s.MemoryLimitMB > 0 {
+		debug.SetMemoryLimit(int64(s.MemoryLimitMB) * megabyte)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	animationDefsPath = "assets/animations.json"
+	playerFrameDir    = "assets/images/"
+	playerFrameSize   = 1024
+)
+
+// AnimationDef describes one sprite animation. Frames are file names under
+// assets/images and may repeat. RootMotion moves the player by [x, y] frame
+// pixels as each frame is reached, so the body goes where the animation
+// carries it; x is mirrored with the body and frames past its end stay put.
+// Events names the frames that publish an animation event when reached,
+// such as {"footstep": [2, 6]}.
+type AnimationDef struct {
+	Frames       []string         `json:"frames"`
+	FrameDelayMs int              `json:"frameDelayMs"`
+	RootMotion   [][2]float32     `json:"rootMotion,omitempty"`
+	Events       map[string][]int `json:"events,omitempty"`
+}
+
+// appliedAnimations remembers the defs in use so their frames can be released on reload
+var appliedAnimations = make(map[string]AnimationDef)
+
+// LoadAnimationDefs reads animation definitions keyed by name.
+func LoadAnimationDefs(path string) (map[string]AnimationDef, error) {
+	data, err := ReadAsset(path)
+	if err != nil {
+		return nil, err
+	}
+	var defs map[string]AnimationDef
+	if err := json.Unmarshal(data, &defs); err != nil {
+		return nil, fmt.Errorf("animations %s: %w", path, err)
+	}
+	return defs, nil
+}
+
+// ApplyAnimationDefs sets the players' animations from defs. Frames already
+// loaded are reused, so it is safe to call on live animations; playback
+// position is kept where the new frame count allows.
+func ApplyAnimationDefs(defs map[string]AnimationDef) {
+	for _, p := range localPlayers() {
+		for name, anim := range p.animations() {
+			if def, ok := defs[name]; ok {
+				applyAnimationDef(anim, def, appliedAnimations[name])
+			}
+		}
+	}
+	for name := range player.animations() {
+		if def, ok := defs[name]; ok {
+			appliedAnimations[name] = def
+		}
+	}
+}
+
+// animations returns the player's body animations by name
+func (p *Player) animations() map[string]*Animated {
+	return map[string]*Animated{
+		"stand": &p.Stand,
+		"hit":   &p.Hit,
+		"move":  &p.Move,
+	}
+}
+
+// applyAnimationDef loads the frames of def into anim. Animations with
+// enough frames are packed into one strip texture; shorter ones, or those
+// whose strip fails to build, keep one texture per frame.
+func applyAnimationDef(anim *Animated, def, old AnimationDef) {
+	oldStrip, oldNormalStrip := anim.Strip, anim.NormalStrip
+	oldFrames := anim.FrameTextures != nil
+
+	// Acquire before releasing so unchanged frames stay resident
+	anim.Strip, anim.NormalStrip = nil, nil
+	anim.FrameTextures, anim.NormalTextures = nil, nil
+	if len(def.Frames) >= stripMinFrames {
+		anim.Strip, anim.NormalStrip = acquireFrameStrips(def.Frames)
+	}
+	if anim.Strip == nil {
+		frames := make([]*Texture, 0, len(def.Frames))
+		normals := make([]*Texture, 0, len(def.Frames))
+		for _, name := range def.Frames {
+			frames = append(frames, tm.Acquire(playerFrameDir+name, playerFrameSize, playerFrameSize))
+			normals = append(normals, AcquireNormalMap(playerFrameDir+name, playerFrameSize, playerFrameSize))
+		}
+		anim.FrameTextures = frames
+		anim.NormalTextures = normals
+	}
+
+	tm.ReleaseAnimationStrip(oldStrip)
+	tm.ReleaseAnimationStrip(oldNormalStrip)
+	if oldFrames {
+		for _, name := range old.Frames {
+			tm.Release(playerFrameDir + name)
+			ReleaseNormalMap(playerFrameDir + name)
+		}
+	}
+
+	anim.FrameDelay = time.Duration(def.FrameDelayMs) * time.Millisecond
+	anim.RootMotion = nil
+	for _, d := range def.RootMotion {
+		anim.RootMotion = append(anim.RootMotion, rl.NewVector2(d[0], d[1]))
+	}
+	anim.Events = nil
+	for name, frames := range def.Events {
+		if anim.Events == nil {
+			anim.Events = make(map[int][]string)
+		}
+		for _, frame := range frames {
+			anim.Events[frame] = append(anim.Events[frame], name)
+		}
+	}
+	if anim.CurrentFrame >= anim.Frames() {
+		anim.CurrentFrame = 0
+	}
+}
+
+// acquireFrameStrips packs the named frames into a strip, along with a
+// strip of their normal maps when every frame has one.
+func acquireFrameStrips(names []string) (*AnimationStrip, *AnimationStrip) {
+	paths := make([]string, len(names))
+	normals := make([]string, len(names))
+	allNormals := true
+	for i, name := range names {
+		paths[i] = playerFrameDir + name
+		normals[i] = normalMapPath(paths[i])
+		allNormals = allNormals && AssetExists(normals[i])
+	}
+	strip := tm.AcquireAnimationStrip(paths, playerFrameSize, playerFrameSize)
+	if strip == nil || !allNormals {
+		return strip, nil
+	}
+	return strip, tm.AcquireAnimationStrip(normals, playerFrameSize, playerFrameSize)
+}
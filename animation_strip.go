@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	// maxStripSize bounds each side of a strip texture; most GPUs take 8192
+	maxStripSize = 8192
+	// Animations with fewer frames keep one texture per frame
+	stripMinFrames = 4
+)
+
+// AnimationStrip is a run of same-sized frames packed into one texture, so
+// drawing any frame binds the same texture. Frames go top to bottom in a
+// vertical strip, wrapping into further columns when taller than
+// maxStripSize.
+type AnimationStrip struct {
+	Texture     *Texture
+	FrameWidth  int32
+	FrameHeight int32
+	Rows        int
+	Count       int
+	key         string
+	// paths are the images the frames came from, for usage tracking
+	paths []string
+}
+
+// FrameRect returns the source rectangle of frame i within the strip.
+func (s *AnimationStrip) FrameRect(i int) rl.Rectangle {
+	col, row := i/s.Rows, i%s.Rows
+	return rl.NewRectangle(float32(int32(col)*s.FrameWidth), float32(int32(row)*s.FrameHeight), float32(s.FrameWidth), float32(s.FrameHeight))
+}
+
+// Drawn records that frame i was drawn, under the path of the image it
+// came from rather than the strip's.
+func (s *AnimationStrip) Drawn(i int) {
+	if i >= 0 && i < len(s.paths) {
+		assetUsage.Record(s.paths[i])
+	}
+}
+
+func stripKey(paths []string, width, height int32) string {
+	return fmt.Sprintf("strip:%dx%d:%s", width, height, strings.Join(paths, "|"))
+}
+
+// AcquireAnimationStrip loads the images at paths resized to width×height
+// and packs them into one texture. Like Acquire, calls for the same frames
+// share the texture and add a reference. It returns nil, holding nothing, if
+// a frame fails to load or the strip would exceed maxStripSize, so callers
+// can fall back to one texture per frame.
+func (tm *TextureManager) AcquireAnimationStrip(paths []string, width, height int32) *AnimationStrip {
+	if len(paths) == 0 || width <= 0 || height <= 0 || height > maxStripSize {
+		return nil
+	}
+	rows := min(len(paths), int(maxStripSize/height))
+	cols := (len(paths) + rows - 1) / rows
+	if int32(cols)*width > maxStripSize {
+		return nil
+	}
+	strip := &AnimationStrip{FrameWidth: width, FrameHeight: height, Rows: rows, Count: len(paths), key: stripKey(paths, width, height), paths: paths}
+
+	if handle, ok := tm.textures[strip.key]; ok {
+		handle.refs++
+		strip.Texture = handle
+		return strip
+	}
+
+	sheet := rl.GenImageColor(cols*int(width), rows*int(height), rl.Blank)
+	defer rl.UnloadImage(sheet)
+	for i, path := range paths {
+		img := loadImageAsset(path)
+		if img == nil || img.Data == nil {
+			return nil
+		}
+		rl.ImageResize(img, width, height)
+		src := rl.NewRectangle(0, 0, float32(width), float32(height))
+		rl.ImageDraw(sheet, img, src, strip.FrameRect(i), rl.White)
+		rl.UnloadImage(img)
+	}
+	strip.Texture = tm.Insert(strip.key, rl.LoadTextureFromImage(sheet), 1)
+	return strip
+}
+
+// ReleaseAnimationStrip drops one reference to the strip's texture.
+func (tm *TextureManager) ReleaseAnimationStrip(s *AnimationStrip) {
+	if s != nil {
+		tm.Release(s.key)
+	}
+}
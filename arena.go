@@ -0,0 +1,243 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	// arenaLevel is the manifest level whose waves the arena runs
+	arenaLevel       = "arena"
+	arenaBoard       = "arena"
+	arenaWaveScore   = 500
+	arenaKillScore   = 100
+	arenaBanner      = 2 * time.Second
+	arenaGateInset   = 40 // how far outside the walls enemies gather
+	arenaBoonChoices = 3  // upgrades offered between waves
+)
+
+type arenaState int
+
+const (
+	arenaFight arenaState = iota
+	arenaChoosing
+	arenaResults
+)
+
+// arenaBoon is an upgrade the player can pick between waves
+type arenaBoon struct {
+	Key   string
+	Apply func()
+}
+
+var arenaBoons = []arenaBoon{
+	{"arena.boon.health", func() { player.MaxHealth += 25; player.Health += 25 }},
+	{"arena.boon.heal", func() { player.Health = player.MaxHealth }},
+	{"arena.boon.speed", func() { player.Speed *= 1.15 }},
+	{"arena.boon.damage", func() { playerDamageBonus += 5 }},
+}
+
+// ArenaScene is the survival mode: the player is shut in the arena while
+// the spawn director sends ever larger and tougher waves through gates in
+// either wall. After each cleared wave they pick one of a few upgrades;
+// the run ends when they fall or outlast every wave, and the score goes to
+// the arena leaderboard.
+type ArenaScene struct {
+	GameScene
+
+	state   arenaState
+	def     WaveDef
+	start   uint64 // tick the run began
+	elapsed time.Duration
+	score   int64
+	cleared int // waves seen cleared, to offer upgrades once each
+	wave    int // wave the banner announced
+	bannerT time.Time
+	boons   *MenuList
+	font    *Font
+
+	// what the run changes, put back on leaving
+	level     string
+	speed     float32
+	maxHealth int
+}
+
+// NewArenaScene creates a survival run.
+func NewArenaScene() *ArenaScene {
+	return &ArenaScene{}
+}
+
+func (a *ArenaScene) Load(scope *AssetScope) {
+	a.font = scope.Font("", 32)
+	a.level, a.speed, a.maxHealth = currentLevel, player.Speed, player.MaxHealth
+	currentSlot = 0
+	ResetProgress()
+
+	waves := assets.manifest.Levels[arenaLevel].Waves
+	if waves == nil {
+		log.Printf("arena: level %q has no waves in the manifest", arenaLevel)
+		scenes.Replace(NewMainMenuScene())
+		return
+	}
+	a.def = *waves
+	if len(a.def.Points) == 0 {
+		a.def.Points = a.gates()
+	}
+	currentLevel = arenaLevel
+	director.Start(&a.def)
+	a.start = clock.Tick
+}
+
+// gates are spawn points just outside either wall, on the ground.
+func (a *ArenaScene) gates() []SpawnPoint {
+	world := camera.World
+	ground := player.DefPos.Y + PlayerBounds().Height
+	return []SpawnPoint{
+		{X: world.X - arenaGateInset, Y: ground},
+		{X: world.X + world.Width + arenaGateInset, Y: ground},
+	}
+}
+
+func (a *ArenaScene) Unload() {
+	director.Reset()
+	enemies = nil
+	inputContexts.Set(a, ContextMenu, false)
+	currentLevel = a.level
+	player.Speed, player.MaxHealth = a.speed, a.maxHealth
+	player.Health = min(player.Health, player.MaxHealth)
+	playerDamageBonus = 0
+	a.GameScene.Unload()
+}
+
+func (a *ArenaScene) Update() {
+	switch a.state {
+	case arenaChoosing:
+		a.boons.Update()
+		return
+	case arenaResults:
+		if rl.IsKeyPressed(rl.KeyEnter) || rl.IsKeyPressed(rl.KeyEscape) ||
+			rl.IsGamepadButtonPressed(gamepadIndex, rl.GamepadButtonRightFaceDown) {
+			scenes.Replace(NewMainMenuScene())
+		}
+		return
+	}
+
+	a.GameScene.Update()
+	a.elapsed = time.Duration(clock.Tick-a.start) * tickDuration
+	a.score = int64(director.Cleared)*arenaWaveScore + int64(director.Kills)*arenaKillScore
+	if director.Wave != a.wave {
+		a.wave = director.Wave
+		a.bannerT = time.Now()
+	}
+
+	switch {
+	case player.Health == 0 || director.Done:
+		a.finish()
+	case director.Cleared > a.cleared:
+		a.cleared = director.Cleared
+		a.offerBoons()
+	}
+}
+
+// offerBoons pauses between waves for the player to pick an upgrade, the
+// choices drawn from the gameplay random source.
+func (a *ArenaScene) offerBoons() {
+	a.state = arenaChoosing
+	var items []MenuItem
+	for _, i := range rng.Perm(len(arenaBoons))[:min(arenaBoonChoices, len(arenaBoons))] {
+		boon := arenaBoons[i]
+		items = append(items, MenuItem{Label: T(boon.Key), OnSelect: func() {
+			boon.Apply()
+			a.state = arenaFight
+			inputContexts.Set(a, ContextMenu, false)
+		}})
+	}
+	a.boons = &MenuList{
+		Layout:   UIRect{Anchor: AnchorCenter, Offset: rl.NewVector2(0, 40), Size: rl.NewVector2(480, 200)},
+		Font:     a.font,
+		FontSize: 32,
+		Spacing:  12,
+	}
+	a.boons.SetItems(items)
+	inputContexts.Set(a, ContextMenu, true)
+}
+
+func (a *ArenaScene) finish() {
+	a.state = arenaResults
+	director.Stop()
+	leaderboards.Submit(Submission{
+		Board:  arenaBoard,
+		TimeMs: a.elapsed.Milliseconds(),
+		Score:  a.score,
+	})
+}
+
+func (a *ArenaScene) Draw() {
+	a.GameScene.Draw()
+
+	hud := ui.Rect(UIRect{Anchor: AnchorTop, Offset: rl.NewVector2(0, 20), Size: rl.NewVector2(720, 40)})
+	status := fmt.Sprintf("%s  |  %s %d  |  %s",
+		fmt.Sprintf(T("arena.wave"), max(director.Wave, 1)),
+		T("arena.enemies"), EnemiesLeft(),
+		T("daily.score")+": "+locale.Int(a.score))
+	w := a.font.Measure(status, 28).X
+	a.font.Draw(status, rl.NewVector2(hud.X+(hud.Width-w)/2, hud.Y), 28, rl.RayWhite)
+	if next := director.NextWave(); next > 0 && a.state == arenaFight {
+		line := fmt.Sprintf(T("arena.next_wave"), int(next.Seconds()+0.999))
+		w := a.font.Measure(line, 24).X
+		a.font.Draw(line, rl.NewVector2(hud.X+(hud.Width-w)/2, hud.Y+40), 24, rl.LightGray)
+	}
+
+	switch a.state {
+	case arenaFight:
+		if since := time.Since(a.bannerT); a.wave > 0 && since < arenaBanner {
+			alpha := 1 - float32(since)/float32(arenaBanner)
+			title := fmt.Sprintf(T("arena.wave"), a.wave)
+			w := a.font.Measure(title, 64).X
+			a.font.Draw(title, rl.NewVector2((screenSize.X-w)/2, screenSize.Y/3), 64, rl.Fade(rl.Gold, alpha))
+		}
+	case arenaChoosing:
+		panel := ui.Rect(UIRect{Anchor: AnchorCenter, Size: rl.NewVector2(560, 320)})
+		if !DrawNinePatch(SkinPanel, panel, rl.White) {
+			rl.DrawRectangleRec(panel, rl.Fade(rl.Black, 0.85))
+		}
+		title := fmt.Sprintf(T("arena.cleared"), a.cleared)
+		w := a.font.Measure(title, 40).X
+		a.font.Draw(title, rl.NewVector2(panel.X+(panel.Width-w)/2, panel.Y+24), 40, rl.Gold)
+		a.boons.Draw()
+	case arenaResults:
+		a.drawResults()
+	}
+}
+
+// drawResults shows how the run went beside the arena leaderboard.
+func (a *ArenaScene) drawResults() {
+	panel := ui.Rect(UIRect{Anchor: AnchorCenter, Offset: rl.NewVector2(-300, 0), Size: rl.NewVector2(520, 300)})
+	if !DrawNinePatch(SkinPanel, panel, rl.White) {
+		rl.DrawRectangleRec(panel, rl.Fade(rl.Black, 0.85))
+	}
+	title := T("arena.over")
+	if director.Done {
+		title = T("arena.survived")
+	}
+	lines := []string{
+		title,
+		fmt.Sprintf(T("arena.reached"), director.Wave),
+		T("daily.score") + ": " + locale.Int(a.score),
+		formatRunTime(a.elapsed),
+		T("daily.continue"),
+	}
+	for i, line := range lines {
+		size := float32(28)
+		if i == 0 {
+			size = 44
+		}
+		w := a.font.Measure(line, size).X
+		a.font.Draw(line, rl.NewVector2(panel.X+(panel.Width-w)/2, panel.Y+24+float32(i)*52), size, rl.RayWhite)
+	}
+	board := ui.Rect(UIRect{Anchor: AnchorCenter, Offset: rl.NewVector2(290, 0), Size: rl.NewVector2(520, 460)})
+	leaderboards.DrawPanel(arenaBoard, board)
+}
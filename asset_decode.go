@@ -0,0 +1,28 @@
+package main
+
+import (
+	"image"
+
+	"raylibgo/imaging"
+)
+
+// decodeImageFile reads and decodes an image on the calling goroutine,
+// resizing it with filter when width and height are > 0. It never touches
+// the GPU, so it is safe to run off the main thread.
+func decodeImageFile(path string, width, height int32, filter imaging.Filter) (*image.NRGBA, error) {
+	file, err := OpenAsset(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	img, err := imaging.Decode(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if width > 0 && height > 0 && (int(width) != img.Rect.Dx() || int(height) != img.Rect.Dy()) {
+		img = imaging.Resize(img, int(width), int(height), filter)
+	}
+	return img, nil
+}
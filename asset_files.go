@@ -0,0 +1,51 @@
+package main
+
+import (
+	"io"
+	"io/fs"
+	"os"
+
+	"raylibgo/vfs"
+)
+
+// assetFiles is where every loader reads game data from. Loose files are the
+// base layer; the asset pack and mod overrides are mounted on top at startup.
+var assetFiles = newAssetFiles()
+
+func newAssetFiles() *vfs.FS {
+	files := vfs.New()
+	files.Mount(vfs.Dir("."))
+	return files
+}
+
+// modSource serves files replaced by loaded mods
+type modSource struct {
+	mods *ModManager
+}
+
+func (m modSource) Open(name string) (io.ReadCloser, error) {
+	resolved := m.mods.Resolve(name)
+	if resolved == name {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return os.Open(resolved)
+}
+
+func (m modSource) Exists(name string) bool {
+	return m.mods.Resolve(name) != name
+}
+
+// OpenAsset returns a stream of the asset at path.
+func OpenAsset(path string) (io.ReadCloser, error) {
+	return assetFiles.Open(path)
+}
+
+// ReadAsset returns the whole contents of the asset at path.
+func ReadAsset(path string) ([]byte, error) {
+	return assetFiles.ReadFile(path)
+}
+
+// AssetExists reports whether any mounted source has path.
+func AssetExists(path string) bool {
+	return assetFiles.Exists(path)
+}
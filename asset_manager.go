@@ -0,0 +1,439 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"image"
+	"log"
+	"runtime"
+	"slices"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"raylibgo/imaging"
+)
+
+const (
+	manifestPath   = "assets/manifest.json"
+	assetQueueSize = 256
+
+	// Default per-frame GPU upload limits
+	maxUploadsPerFrame = 4
+	uploadTimeBudget   = 2 * time.Millisecond
+)
+
+// AssetPriority orders load requests; lower values load first
+type AssetPriority int
+
+const (
+	PriorityCritical AssetPriority = iota
+	PriorityHigh
+	PriorityNormal
+	PriorityLow
+)
+
+// AssetState is the lifecycle stage of a requested asset
+type AssetState int
+
+const (
+	AssetPending AssetState = iota
+	AssetReady
+	AssetFailed
+	// AssetCanceled means the request's context ended before the texture was ready
+	AssetCanceled
+)
+
+// AssetHandle is one request for a texture through the AssetManager. Each
+// RequestAsset call returns its own handle. It is only read and written on
+// the main thread.
+type AssetHandle struct {
+	Path     string
+	Width    int32
+	Height   int32
+	Priority AssetPriority
+	State    AssetState
+	Texture  *Texture
+	Err      error
+
+	load     *assetLoad
+	stop     func() bool // stops watching the request's context
+	released bool
+}
+
+// assetLoad is a texture being decoded for one or more pending requests
+type assetLoad struct {
+	path     string
+	width    int32
+	height   int32
+	priority AssetPriority
+	source   string // file actually read, possibly a pre-scaled variant
+	filter   imaging.Filter
+	handles  []*AssetHandle // requests still waiting; main thread only
+	canceled atomic.Bool    // every request went away; workers skip it
+
+	// Guarded by the load queue's lock
+	queuedAt   time.Time
+	queueKey   time.Time
+	queueIndex int
+}
+
+// decodeResult carries a decoded image from the load worker back to the main thread
+type decodeResult struct {
+	load *assetLoad
+	img  *image.NRGBA
+	err  error
+}
+
+// canceledRequest reports a request whose context ended
+type canceledRequest struct {
+	handle *AssetHandle
+	err    error
+}
+
+// AssetEntry is one texture listed in the manifest. Filter picks the resize
+// kernel ("nearest", "bilinear" or "lanczos"). Variants are pre-scaled copies
+// made by tools/downscale for smaller target resolutions.
+type AssetEntry struct {
+	Path     string         `json:"path"`
+	Width    int32          `json:"width"`
+	Height   int32          `json:"height"`
+	Filter   string         `json:"filter,omitempty"`
+	SHA256   string         `json:"sha256,omitempty"`
+	Variants []AssetVariant `json:"variants,omitempty"`
+}
+
+// AssetVariant is a copy of an asset pre-scaled for a screen height. Variants
+// are uploaded at their own size; Width and Height on the handle stay the
+// logical size to draw at.
+type AssetVariant struct {
+	ScreenHeight int    `json:"screenHeight"`
+	Path         string `json:"path"`
+}
+
+// sourceFor picks the smallest variant made for at least the given screen
+// height, falling back to the original file.
+func (e AssetEntry) sourceFor(screenHeight int) string {
+	best := e.Path
+	bestHeight := 0
+	for _, v := range e.Variants {
+		if v.ScreenHeight >= screenHeight && (bestHeight == 0 || v.ScreenHeight < bestHeight) {
+			best = v.Path
+			bestHeight = v.ScreenHeight
+		}
+	}
+	return best
+}
+
+// AssetManifest lists asset groups and the level graph used for streaming
+type AssetManifest struct {
+	Groups map[string][]AssetEntry `json:"groups"`
+	Levels map[string]LevelNode    `json:"levels"`
+}
+
+// AssetManager loads textures in the background. Decoding and resizing run on
+// a pool of worker goroutines; GPU uploads happen on the main thread in ProcessUploads and the
+// results are tracked by the TextureManager. Decoded images are kept in an
+// ImageCache so released textures can be re-uploaded without decoding again.
+type AssetManager struct {
+	textures  *TextureManager
+	manifest  AssetManifest
+	entries   map[string]AssetEntry // manifest entries by path
+	pending   map[string]*assetLoad
+	loadQueue *assetQueue
+	decoded   chan decodeResult
+	canceled  chan canceledRequest
+	ready     []decodeResult // decoded, waiting for an upload slot
+	streamed  map[string][]*AssetHandle
+	images    *ImageCache
+
+	// streamedFrom is the level UpdateStreaming last ran in
+	streamedFrom string
+	budgetCheck  int // frames since texture memory was last checked
+
+	// MaxUploadsPerFrame and UploadTimeBudget cap GPU work per frame so a
+	// burst of finished decodes is spread over several frames
+	MaxUploadsPerFrame int
+	UploadTimeBudget   time.Duration
+
+	// Problems lists manifest files found missing or corrupted by VerifyAssets
+	Problems []AssetProblem
+
+	// loadTexture moves a decoded image to the GPU; tests, which have no GL
+	// context, replace it
+	loadTexture func(img *image.NRGBA) rl.Texture2D
+}
+
+var assets = NewAssetManager(tm)
+
+// NewAssetManager creates an AssetManager backed by textures and starts its
+// load workers, one per CPU except the one running the main thread
+func NewAssetManager(textures *TextureManager) *AssetManager {
+	return newAssetManager(textures, loadWorkerCount())
+}
+
+func newAssetManager(textures *TextureManager, workers int) *AssetManager {
+	am := &AssetManager{
+		textures:  textures,
+		pending:   make(map[string]*assetLoad),
+		loadQueue: newAssetQueue(),
+		decoded:   make(chan decodeResult, assetQueueSize),
+		canceled:  make(chan canceledRequest, assetQueueSize),
+		streamed:  make(map[string][]*AssetHandle),
+		images:    NewImageCache(int64(DefaultSettings().ImageCacheMB) * megabyte),
+
+		MaxUploadsPerFrame: maxUploadsPerFrame,
+		UploadTimeBudget:   uploadTimeBudget,
+
+		loadTexture: loadTextureNRGBA,
+	}
+	for range workers {
+		go am.loadWorker()
+	}
+	return am
+}
+
+func loadWorkerCount() int {
+	return max(runtime.NumCPU()-1, 1)
+}
+
+func (am *AssetManager) loadWorker() {
+	for {
+		am.decode(am.loadQueue.Pop())
+	}
+}
+
+// decode reads and resizes the image for load and hands it to the main
+// thread, unless every request for it went away first.
+func (am *AssetManager) decode(load *assetLoad) {
+	if load.canceled.Load() {
+		return
+	}
+	width, height := load.width, load.height
+	if load.source != load.path {
+		// Variants were resized offline
+		width, height = 0, 0
+	}
+	key := imageKey{source: load.source, width: width, height: height, filter: load.filter}
+	img, ok := am.images.Get(key)
+	var err error
+	if !ok {
+		img, err = decodeImageFile(load.source, width, height, load.filter)
+		if err == nil {
+			am.images.Put(key, img)
+		}
+	}
+	am.decoded <- decodeResult{load: load, img: img, err: err}
+}
+
+// LoadManifest reads the asset groups and level graph, adding the levels
+// that mods bring.
+func (am *AssetManager) LoadManifest(path string) error {
+	data, err := ReadAsset(path)
+	if err != nil {
+		return err
+	}
+	var manifest AssetManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return err
+	}
+	if manifest.Levels == nil {
+		manifest.Levels = make(map[string]LevelNode)
+	}
+	mods.AddLevels(manifest.Levels)
+	am.manifest = manifest
+	am.entries = make(map[string]AssetEntry)
+	for _, group := range manifest.Groups {
+		for _, entry := range group {
+			am.entries[entry.Path] = entry
+		}
+	}
+	return nil
+}
+
+// RequestAsset returns a handle for the texture at path, queueing a background
+// load if it isn't resident yet. Every request must be matched by a Release.
+// If ctx ends while the texture is still pending, the request is canceled and
+// the load is dropped once no other request needs it; the handle then reports
+// AssetCanceled.
+func (am *AssetManager) RequestAsset(ctx context.Context, path string, width, height int32, priority AssetPriority) *AssetHandle {
+	handle := &AssetHandle{Path: path, Width: width, Height: height, Priority: priority}
+	if err := ctx.Err(); err != nil {
+		handle.State = AssetCanceled
+		handle.Err = err
+		return handle
+	}
+
+	if _, ok := am.textures.Lookup(path); ok {
+		handle.State = AssetReady
+		handle.Texture = am.textures.Acquire(path, width, height)
+		return handle
+	}
+
+	load, ok := am.pending[path]
+	if ok {
+		am.loadQueue.Raise(load, priority)
+	} else {
+		load = &assetLoad{path: path, width: width, height: height, priority: priority, source: path, queueIndex: -1}
+		if entry, ok := am.entries[path]; ok {
+			load.source = entry.sourceFor(rl.GetScreenHeight())
+			load.filter = imaging.ParseFilter(entry.Filter)
+		}
+		am.pending[path] = load
+		am.loadQueue.Push(load)
+	}
+	load.handles = append(load.handles, handle)
+	handle.load = load
+
+	if ctx.Done() != nil {
+		handle.stop = context.AfterFunc(ctx, func() {
+			am.canceled <- canceledRequest{handle: handle, err: ctx.Err()}
+		})
+	}
+	return handle
+}
+
+// Release drops the request. Ready handles give up their texture reference;
+// pending ones stop waiting, and the load is dropped if nobody else wants it.
+// Releasing a handle twice has no effect.
+func (am *AssetManager) Release(handle *AssetHandle) {
+	if handle.released {
+		return
+	}
+	handle.released = true
+	if handle.stop != nil {
+		handle.stop()
+	}
+
+	switch handle.State {
+	case AssetReady:
+		am.textures.Release(handle.Path)
+	case AssetPending:
+		am.detach(handle)
+	}
+}
+
+// detach removes a pending handle from its load, canceling the load when it
+// was the last one waiting.
+func (am *AssetManager) detach(handle *AssetHandle) {
+	load := handle.load
+	handle.load = nil
+	load.handles = slices.DeleteFunc(load.handles, func(h *AssetHandle) bool { return h == handle })
+	if len(load.handles) > 0 {
+		return
+	}
+	load.canceled.Store(true)
+	am.loadQueue.Remove(load)
+	if am.pending[load.path] == load {
+		delete(am.pending, load.path)
+	}
+}
+
+// collectCanceled applies context cancellations reported since the last frame.
+func (am *AssetManager) collectCanceled() {
+	for {
+		select {
+		case req := <-am.canceled:
+			handle := req.handle
+			if handle.released || handle.State != AssetPending {
+				continue
+			}
+			handle.released = true
+			am.detach(handle)
+			handle.State = AssetCanceled
+			handle.Err = req.err
+		default:
+			return
+		}
+	}
+}
+
+// ProcessUploads moves decoded images to the GPU within the per-frame budget,
+// highest priority first. At least one upload runs each frame so loading always
+// progresses. It must run on the main thread.
+func (am *AssetManager) ProcessUploads() {
+	am.collectCanceled()
+	am.collectDecoded()
+	if len(am.ready) == 0 {
+		return
+	}
+	sort.SliceStable(am.ready, func(i, j int) bool {
+		return am.ready[i].load.priority < am.ready[j].load.priority
+	})
+
+	start := time.Now()
+	uploaded := 0
+	for len(am.ready) > 0 {
+		if uploaded > 0 && (uploaded >= am.MaxUploadsPerFrame || time.Since(start) >= am.UploadTimeBudget) {
+			break
+		}
+		res := am.ready[0]
+		am.ready = am.ready[1:]
+		am.upload(res)
+		uploaded++
+	}
+}
+
+func (am *AssetManager) collectDecoded() {
+	for {
+		select {
+		case res := <-am.decoded:
+			am.ready = append(am.ready, res)
+		default:
+			return
+		}
+	}
+}
+
+func (am *AssetManager) upload(res decodeResult) {
+	load := res.load
+	if load.canceled.Load() {
+		// Everyone released or canceled the request while it was loading
+		return
+	}
+	delete(am.pending, load.path)
+
+	if res.err != nil {
+		for _, h := range load.handles {
+			h.State = AssetFailed
+			h.Err = res.err
+		}
+		log.Printf("assets: %s: %v", load.path, res.err)
+		return
+	}
+
+	texture := am.textures.Insert(load.path, am.loadTexture(res.img), len(load.handles))
+	for _, h := range load.handles {
+		h.Texture = texture
+		h.State = AssetReady
+		h.load = nil
+	}
+	load.handles = nil
+	perf.Note("asset", load.path)
+}
+
+// loadTextureNRGBA uploads a decoded image as an RGBA texture.
+func loadTextureNRGBA(img *image.NRGBA) rl.Texture2D {
+	b := img.Rect
+	return rl.LoadTextureFromImage(rl.NewImage(img.Pix, int32(b.Dx()), int32(b.Dy()), 1, rl.UncompressedR8g8b8a8))
+}
+
+// RequestGroup requests every asset in a manifest group, all bound to ctx.
+func (am *AssetManager) RequestGroup(ctx context.Context, name string, priority AssetPriority) []*AssetHandle {
+	var handles []*AssetHandle
+	for _, entry := range am.manifest.Groups[name] {
+		handles = append(handles, am.RequestAsset(ctx, entry.Path, entry.Width, entry.Height, priority))
+	}
+	return handles
+}
+
+// Pending returns the number of assets still loading.
+func (am *AssetManager) Pending() int {
+	return len(am.pending)
+}
+
+func init() {
+	RegisterSystem(&SystemFuncs{ID: "assets", OnUpdate: assets.ProcessUploads})
+}
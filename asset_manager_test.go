@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"image"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const testAssetPath = "assets/images/stand3.png"
+
+// newTestAssetManager returns an AssetManager with the given number of load
+// workers whose uploads are counted instead of reaching the GPU.
+func newTestAssetManager(workers int) (*AssetManager, *atomic.Int32) {
+	am := newAssetManager(NewTextureManager(), workers)
+	uploads := &atomic.Int32{}
+	am.loadTexture = func(img *image.NRGBA) rl.Texture2D {
+		n := uploads.Add(1)
+		return rl.Texture2D{ID: uint32(n), Width: int32(img.Rect.Dx()), Height: int32(img.Rect.Dy())}
+	}
+	return am, uploads
+}
+
+// waitFor polls cond until it holds, failing the test after a second.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestAssetCancelBeforeWorkerPops(t *testing.T) {
+	am, uploads := newTestAssetManager(0)
+	ctx, cancel := context.WithCancel(context.Background())
+	h := am.RequestAsset(ctx, testAssetPath, 0, 0, PriorityNormal)
+	load := h.load
+	if am.loadQueue.Len() != 1 {
+		t.Fatalf("queued loads = %d, want 1", am.loadQueue.Len())
+	}
+
+	cancel()
+	waitFor(t, "the cancellation", func() bool {
+		am.ProcessUploads()
+		return h.State != AssetPending
+	})
+	if h.State != AssetCanceled || !errors.Is(h.Err, context.Canceled) {
+		t.Fatalf("state = %v, err = %v; want canceled", h.State, h.Err)
+	}
+	if am.loadQueue.Len() != 0 || am.Pending() != 0 {
+		t.Fatalf("queued = %d, pending = %d; want the load dropped", am.loadQueue.Len(), am.Pending())
+	}
+
+	// A worker that popped the load just before it was removed skips it
+	am.decode(load)
+	am.ProcessUploads()
+	if len(am.decoded) != 0 || uploads.Load() != 0 {
+		t.Fatalf("decoded = %d, uploads = %d; want nothing", len(am.decoded), uploads.Load())
+	}
+	am.Release(h)
+}
+
+func TestAssetCancelAfterDecode(t *testing.T) {
+	am, uploads := newTestAssetManager(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	h := am.RequestAsset(ctx, testAssetPath, 0, 0, PriorityNormal)
+	waitFor(t, "the decode", func() bool { return len(am.decoded) > 0 })
+
+	// The decode is done but the main thread hasn't collected it yet
+	cancel()
+	waitFor(t, "the cancellation", func() bool { return len(am.canceled) > 0 })
+	am.ProcessUploads()
+
+	if h.State != AssetCanceled {
+		t.Fatalf("state = %v, want canceled", h.State)
+	}
+	if uploads.Load() != 0 {
+		t.Fatalf("uploads = %d, want 0", uploads.Load())
+	}
+	if _, ok := am.textures.Lookup(testAssetPath); ok {
+		t.Fatal("canceled texture is resident")
+	}
+	if am.Pending() != 0 || len(am.ready) != 0 {
+		t.Fatalf("pending = %d, ready = %d; want 0", am.Pending(), len(am.ready))
+	}
+	am.Release(h)
+}
+
+func TestAssetCancelOneOfSharedLoad(t *testing.T) {
+	am, uploads := newTestAssetManager(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := am.RequestAsset(ctx, testAssetPath, 0, 0, PriorityNormal)
+	kept := am.RequestAsset(context.Background(), testAssetPath, 0, 0, PriorityNormal)
+	if canceled.load != kept.load {
+		t.Fatal("requests for the same path don't share a load")
+	}
+
+	waitFor(t, "the decode", func() bool { return len(am.decoded) > 0 })
+	cancel()
+	waitFor(t, "the cancellation", func() bool { return len(am.canceled) > 0 })
+	am.ProcessUploads()
+
+	if canceled.State != AssetCanceled {
+		t.Fatalf("canceled handle state = %v, want canceled", canceled.State)
+	}
+	if kept.State != AssetReady || kept.Texture == nil {
+		t.Fatalf("kept handle state = %v, want ready", kept.State)
+	}
+	if uploads.Load() != 1 {
+		t.Fatalf("uploads = %d, want 1", uploads.Load())
+	}
+	tex, ok := am.textures.Lookup(testAssetPath)
+	if !ok || tex != kept.Texture {
+		t.Fatal("texture isn't resident for the kept handle")
+	}
+	if tex.refs != 1 {
+		t.Fatalf("refs = %d, want 1 for the kept handle", tex.refs)
+	}
+
+	// Releasing the canceled handle again leaves the kept reference alone
+	am.Release(canceled)
+	if tex.refs != 1 {
+		t.Fatalf("refs after releasing the canceled handle = %d, want 1", tex.refs)
+	}
+}
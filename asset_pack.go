@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/hex"
+	"log"
+	"os"
+
+	"raylibgo/pack"
+	"raylibgo/vfs"
+)
+
+const assetPackPath = "assets.pak"
+
+// packKey is the hex AES-256 key for encrypted packs, set at build time with
+// -ldflags "-X main.packKey=...". Release builds ship it; dev builds leave it
+// empty and read loose files.
+var packKey string
+
+// assetPack holds the shipped assets when a pack file is present
+var assetPack *pack.Reader
+
+// OpenAssetPack opens the pack at path if it exists. Loose files keep working
+// when there is no pack.
+func OpenAssetPack(path string) {
+	if _, err := os.Stat(path); err != nil {
+		return
+	}
+
+	var key []byte
+	if packKey != "" {
+		var err error
+		if key, err = hex.DecodeString(packKey); err != nil {
+			log.Printf("pack: invalid build key: %v", err)
+			return
+		}
+	}
+
+	r, err := pack.Open(path, key)
+	if err != nil {
+		log.Printf("pack: %v", err)
+		return
+	}
+	assetPack = r
+	assetFiles.Mount(vfs.PackSource{Pack: r})
+	log.Printf("pack: %s with %d files", path, len(r.Names()))
+}
+
+// CloseAssetPack releases the pack file.
+func CloseAssetPack() {
+	if assetPack != nil {
+		assetFiles.Unmount(vfs.PackSource{Pack: assetPack})
+		assetPack.Close()
+		assetPack = nil
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// assetAgingStep is how long a queued request waits before it outranks new
+// requests one priority level above it, so low priority loads never starve
+const assetAgingStep = 2 * time.Second
+
+// assetQueue hands load requests to the workers in priority order, oldest
+// first within a priority. It is shared between the main thread and workers.
+type assetQueue struct {
+	mu    sync.Mutex
+	ready *sync.Cond
+	items assetHeap
+}
+
+func newAssetQueue() *assetQueue {
+	q := &assetQueue{}
+	q.ready = sync.NewCond(&q.mu)
+	return q
+}
+
+// queueKey orders requests: each priority level counts as assetAgingStep of waiting.
+func queueKey(load *assetLoad) time.Time {
+	return load.queuedAt.Add(time.Duration(load.priority) * assetAgingStep)
+}
+
+// Push queues a load and wakes a worker.
+func (q *assetQueue) Push(load *assetLoad) {
+	q.mu.Lock()
+	load.queuedAt = time.Now()
+	load.queueKey = queueKey(load)
+	heap.Push(&q.items, load)
+	q.mu.Unlock()
+	q.ready.Signal()
+}
+
+// Raise moves a load up to priority if that is more urgent.
+func (q *assetQueue) Raise(load *assetLoad, priority AssetPriority) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if priority >= load.priority {
+		return
+	}
+	load.priority = priority
+	if load.queueIndex >= 0 {
+		load.queueKey = queueKey(load)
+		heap.Fix(&q.items, load.queueIndex)
+	}
+}
+
+// Remove takes a load out of the queue if it hasn't reached a worker yet.
+func (q *assetQueue) Remove(load *assetLoad) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if load.queueIndex >= 0 {
+		heap.Remove(&q.items, load.queueIndex)
+	}
+}
+
+// Pop blocks until a request is queued and returns the most urgent one.
+func (q *assetQueue) Pop() *assetLoad {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for q.items.Len() == 0 {
+		q.ready.Wait()
+	}
+	return heap.Pop(&q.items).(*assetLoad)
+}
+
+// Len returns the number of requests waiting for a worker.
+func (q *assetQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Len()
+}
+
+type assetHeap []*assetLoad
+
+func (h assetHeap) Len() int           { return len(h) }
+func (h assetHeap) Less(i, j int) bool { return h[i].queueKey.Before(h[j].queueKey) }
+func (h assetHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].queueIndex = i
+	h[j].queueIndex = j
+}
+func (h *assetHeap) Push(x any) {
+	a := x.(*assetLoad)
+	a.queueIndex = len(*h)
+	*h = append(*h, a)
+}
+func (h *assetHeap) Pop() any {
+	old := *h
+	a := old[len(old)-1]
+	a.queueIndex = -1
+	*h = old[:len(old)-1]
+	return a
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+)
+
+// AssetScope tracks everything a scene loads so it can all be released in
+// one call when the scene unloads. Pending requests made through the scope
+// are canceled on Close.
+type AssetScope struct {
+	Name string
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	handles  []*AssetHandle
+	textures []string
+	fonts    []scopedFont
+	closed   bool
+}
+
+type scopedFont struct {
+	path string
+	size int32
+}
+
+// NewAssetScope creates an open scope, usually one per scene
+func NewAssetScope(name string) *AssetScope {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &AssetScope{Name: name, ctx: ctx, cancel: cancel}
+}
+
+// Context is canceled when the scope closes, for work tied to the scene's lifetime.
+func (s *AssetScope) Context() context.Context {
+	return s.ctx
+}
+
+// Acquire loads a texture synchronously and holds a reference until Close.
+func (s *AssetScope) Acquire(path string, width, height int32) *Texture {
+	s.textures = append(s.textures, path)
+	return tm.Acquire(path, width, height)
+}
+
+// Request loads a texture in the background and holds the request until Close.
+func (s *AssetScope) Request(path string, width, height int32, priority AssetPriority) *AssetHandle {
+	handle := assets.RequestAsset(s.ctx, path, width, height, priority)
+	s.handles = append(s.handles, handle)
+	return handle
+}
+
+// RequestGroup requests a manifest group and holds it until Close.
+func (s *AssetScope) RequestGroup(name string, priority AssetPriority) []*AssetHandle {
+	handles := assets.RequestGroup(s.ctx, name, priority)
+	s.handles = append(s.handles, handles...)
+	return handles
+}
+
+// Font loads a font and holds a reference until Close.
+func (s *AssetScope) Font(path string, size int32) *Font {
+	s.fonts = append(s.fonts, scopedFont{path: path, size: size})
+	return fonts.Acquire(path, size)
+}
+
+// Loaded reports whether every background request in the scope has finished.
+func (s *AssetScope) Loaded() bool {
+	for _, h := range s.handles {
+		if h.State == AssetPending {
+			return false
+		}
+	}
+	return true
+}
+
+// Close cancels pending requests and releases everything the scope acquired.
+// Closing twice has no effect.
+func (s *AssetScope) Close() {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	s.cancel()
+
+	for _, h := range s.handles {
+		assets.Release(h)
+	}
+	for _, path := range s.textures {
+		tm.Release(path)
+	}
+	for _, f := range s.fonts {
+		fonts.Release(f.path, f.size)
+	}
+	s.handles, s.textures, s.fonts = nil, nil, nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"slices"
+)
+
+// assetUsagePath collects draw counts across sessions for tools/assetreport
+const assetUsagePath = "asset_usage.json"
+
+// AssetUsage counts how often each texture asset is drawn while enabled
+// with -track-assets. Counts are added to the file on exit, so several play
+// sessions build up one picture of which assets are actually seen.
+type AssetUsage struct {
+	Enabled bool
+	draws   map[string]int64
+}
+
+var assetUsage = &AssetUsage{draws: make(map[string]int64)}
+
+// Record counts one draw of the asset at path.
+func (u *AssetUsage) Record(path string) {
+	if u.Enabled && path != "" {
+		u.draws[path]++
+	}
+}
+
+// Save adds this session's counts to the file at path.
+func (u *AssetUsage) Save(path string) error {
+	total := make(map[string]int64)
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &total); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	for p, n := range u.draws {
+		total[p] += n
+	}
+	data, err := json.MarshalIndent(total, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// DebugLines lists the most drawn assets of this session.
+func (u *AssetUsage) DebugLines() []string {
+	if !u.Enabled {
+		return []string{"Off, start with -track-assets"}
+	}
+	paths := slices.SortedFunc(maps.Keys(u.draws), func(a, b string) int {
+		return int(u.draws[b] - u.draws[a])
+	})
+	lines := []string{fmt.Sprintf("%d assets drawn", len(paths))}
+	for _, p := range paths[:min(len(paths), 8)] {
+		lines = append(lines, fmt.Sprintf("%8d %s", u.draws[p], p))
+	}
+	return lines
+}
+
+// Drawn records that t was drawn this frame.
+func (t *Texture) Drawn() {
+	assetUsage.Record(t.path)
+}
+
+func init() {
+	RegisterSystem(&SystemFuncs{
+		ID: "assetUsage",
+		OnInit: func() error {
+			assetUsage.Enabled = launch.TrackAssets
+			AddDebugSection("Asset usage", assetUsage.DebugLines)
+			return nil
+		},
+		OnShutdown: func() {
+			if !assetUsage.Enabled {
+				return
+			}
+			if err := assetUsage.Save(assetUsagePath); err != nil {
+				log.Printf("asset usage: %v", err)
+			}
+		},
+	})
+}
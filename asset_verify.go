@@ -0,0 +1,110 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// AssetProblem is a manifest file that is missing or fails its checksum
+type AssetProblem struct {
+	Path   string
+	Reason string
+}
+
+// assetProblemsDismissed hides the error panel once the player chooses to continue
+var assetProblemsDismissed bool
+
+// fileSHA256 returns the hex SHA-256 of the asset at path.
+func fileSHA256(path string) (string, error) {
+	file, err := OpenAsset(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	return readerSHA256(file)
+}
+
+// readerSHA256 returns the hex SHA-256 of everything read from r.
+func readerSHA256(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// VerifyAssets checks that every manifest file exists and, when the entry has
+// a checksum, that its contents match. Problems are kept for the error panel.
+func (am *AssetManager) VerifyAssets() []AssetProblem {
+	am.Problems = nil
+	for path, entry := range am.entries {
+		resolved := mods.Resolve(path)
+		if !AssetExists(path) {
+			am.Problems = append(am.Problems, AssetProblem{Path: path, Reason: "missing"})
+			continue
+		}
+		if entry.SHA256 == "" || resolved != path {
+			// Mods are allowed to replace files
+			continue
+		}
+		sum, err := fileSHA256(path)
+		if err != nil {
+			am.Problems = append(am.Problems, AssetProblem{Path: path, Reason: err.Error()})
+		} else if !strings.EqualFold(sum, entry.SHA256) {
+			am.Problems = append(am.Problems, AssetProblem{Path: path, Reason: "corrupted (checksum mismatch)"})
+		}
+	}
+	sort.Slice(am.Problems, func(i, j int) bool { return am.Problems[i].Path < am.Problems[j].Path })
+	return am.Problems
+}
+
+// HandleAssetProblems dismisses the error panel on Enter.
+func HandleAssetProblems() {
+	if len(assets.Problems) > 0 && !assetProblemsDismissed && rl.IsKeyPressed(rl.KeyEnter) {
+		assetProblemsDismissed = true
+	}
+}
+
+// DrawAssetProblems lists broken files in a centered panel until dismissed.
+func DrawAssetProblems() {
+	problems := assets.Problems
+	if len(problems) == 0 || assetProblemsDismissed {
+		return
+	}
+
+	const maxShown = 15
+	shown := min(len(problems), maxShown)
+	panel := ui.Rect(UIRect{Anchor: AnchorCenter, Size: rl.NewVector2(900, float32(160+shown*26))})
+	rl.DrawRectangleRec(panel, rl.Fade(rl.Black, 0.9))
+	rl.DrawRectangleLinesEx(panel, 3, rl.Red)
+
+	x, y := int32(panel.X)+30, int32(panel.Y)+24
+	rl.DrawText("Some game files are missing or damaged", x, y, 28, rl.Red)
+	y += 40
+	rl.DrawText("Reinstall or verify the game files. Affected files:", x, y, 20, rl.LightGray)
+	y += 34
+
+	for _, p := range problems[:shown] {
+		rl.DrawText(fmt.Sprintf("%s - %s", p.Path, p.Reason), x+10, y, 18, rl.White)
+		y += 26
+	}
+	if len(problems) > maxShown {
+		rl.DrawText(fmt.Sprintf("...and %d more", len(problems)-maxShown), x+10, y, 18, rl.Gray)
+	}
+	rl.DrawText("Press Enter to continue anyway", x, int32(panel.Y+panel.Height)-36, 20, rl.Yellow)
+}
+
+func init() {
+	RegisterSystem(&SystemFuncs{
+		ID:       "assetProblems",
+		Requires: []string{"drop"},
+		OnUpdate: HandleAssetProblems,
+		OnDraw:   DrawAssetProblems,
+	})
+}
@@ -0,0 +1,17 @@
+#version 330
+
+in vec2 fragTexCoord;
+in vec4 fragColor;
+
+uniform sampler2D texture0;
+uniform vec4 colDiffuse;
+uniform vec4 hitColor;
+uniform float strength;
+
+out vec4 finalColor;
+
+void main()
+{
+    vec4 texel = texture(texture0, fragTexCoord)*colDiffuse*fragColor;
+    finalColor = vec4(mix(texel.rgb, hitColor.rgb, strength), texel.a);
+}
@@ -0,0 +1,50 @@
+#version 330
+
+#define MAX_LIGHTS 8
+
+in vec2 fragTexCoord;
+in vec4 fragColor;
+
+uniform sampler2D texture0;
+uniform sampler2D normalMap;
+uniform vec4 colDiffuse;
+// World-space destination rectangle of the sprite
+uniform vec4 spriteRect;
+// Area of the frame within the texture, in texture coordinates
+uniform vec4 frameRect;
+// -1 when the sprite is drawn mirrored horizontally
+uniform float flip;
+uniform vec3 ambient;
+uniform float lightCount;
+uniform vec2 lightPos[MAX_LIGHTS];
+uniform vec3 lightColor[MAX_LIGHTS];
+uniform float lightRadius[MAX_LIGHTS];
+
+out vec4 finalColor;
+
+void main()
+{
+    vec4 texel = texture(texture0, fragTexCoord)*colDiffuse*fragColor;
+    vec3 normal = normalize(texture(normalMap, fragTexCoord).rgb*2.0 - 1.0);
+    normal.x *= flip;
+    // Normal maps are authored with +Y up; screen space grows downwards
+    normal.y = -normal.y;
+
+    vec2 local = (fragTexCoord - frameRect.xy)/frameRect.zw;
+    float u = flip < 0.0 ? 1.0 - local.x : local.x;
+    vec2 world = spriteRect.xy + vec2(u, local.y)*spriteRect.zw;
+
+    vec3 light = ambient;
+    for (int i = 0; i < MAX_LIGHTS; i++)
+    {
+        if (float(i) >= lightCount) break;
+        vec2 delta = lightPos[i] - world;
+        float dist = length(delta);
+        // Lights sit slightly in front of the sprite plane for a pseudo-3D look
+        vec3 dir = normalize(vec3(delta, lightRadius[i]*0.25));
+        float falloff = clamp(1.0 - dist/lightRadius[i], 0.0, 1.0);
+        light += lightColor[i]*max(dot(normal, dir), 0.0)*falloff*falloff;
+    }
+
+    finalColor = vec4(texel.rgb*light, texel.a);
+}
@@ -0,0 +1,27 @@
+#version 330
+
+in vec2 fragTexCoord;
+in vec4 fragColor;
+
+uniform sampler2D texture0;
+uniform vec4 colDiffuse;
+uniform vec4 hitColor;
+uniform vec2 texelSize;
+uniform float width;
+
+out vec4 finalColor;
+
+void main()
+{
+    vec4 texel = texture(texture0, fragTexCoord)*colDiffuse*fragColor;
+    vec2 offset = texelSize*width;
+
+    float neighbours = texture(texture0, fragTexCoord + vec2(offset.x, 0.0)).a
+        + texture(texture0, fragTexCoord - vec2(offset.x, 0.0)).a
+        + texture(texture0, fragTexCoord + vec2(0.0, offset.y)).a
+        + texture(texture0, fragTexCoord - vec2(0.0, offset.y)).a;
+
+    // Transparent pixels next to opaque ones become the outline
+    float edge = clamp(neighbours, 0.0, 1.0)*(1.0 - texel.a);
+    finalColor = mix(texel, vec4(hitColor.rgb, 1.0), edge);
+}
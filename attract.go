@@ -0,0 +1,79 @@
+package main
+
+import (
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// How long the main menu waits without input before attract mode starts
+const attractIdle = 30 * time.Second
+
+// AttractScene plays a recorded demo in fullscreen until it ends or the
+// player touches any input, then pops back to the menu. The game state is
+// reset afterwards so the demo leaves nothing behind.
+type AttractScene struct {
+	replay *Replay
+	font   *Font
+	onExit func()
+}
+
+// NewAttractScene creates a scene playing replay; onExit runs when it ends.
+func NewAttractScene(replay *Replay, onExit func()) *AttractScene {
+	return &AttractScene{replay: replay, onExit: onExit}
+}
+
+func (a *AttractScene) Load(scope *AssetScope) {
+	a.font = scope.Font("", 40)
+	ResetProgress()
+	if a.replay.Level != "" {
+		currentLevel = a.replay.Level
+	}
+	if err := rngSource.UnmarshalBinary(a.replay.RNG); err != nil {
+		rngSource.Seed(0, 0)
+	}
+	rewinder.Play(a.replay.Inputs())
+}
+
+func (a *AttractScene) Unload() {
+	rewinder.Reset()
+	ResetProgress()
+	rngSource.Seed(uint64(time.Now().UnixNano()), 0)
+	if a.onExit != nil {
+		a.onExit()
+	}
+}
+
+func (a *AttractScene) Update() {
+	if anyInputPressed() || !rewinder.Replaying() {
+		scenes.Pop()
+		return
+	}
+	if transition.Update() {
+		return
+	}
+	for range timeControl.Steps(FrameTime()) {
+		if hitstop.Consume() {
+			continue
+		}
+		Update()
+	}
+	camera.UpdateEffects(rl.GetFrameTime())
+}
+
+func (a *AttractScene) Draw() {
+	DrawBackgroundGIF(background)
+	rl.BeginMode2D(camera.View())
+	(&GameScene{}).drawWorld()
+	rl.EndMode2D()
+	weather.Draw()
+	transition.Draw()
+
+	// Blink the prompt once a second
+	if time.Now().UnixMilli()/500%2 == 0 {
+		text := T("attract.prompt")
+		w := a.font.Measure(text, 40).X
+		a.font.Draw(text, rl.NewVector2((screenSize.X-w)/2, screenSize.Y-120), 40, rl.RayWhite)
+	}
+	a.font.Draw(T("attract.demo"), rl.NewVector2(40, 40), 40, rl.Gold)
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	audioPanelKey = rl.KeyGrave
+
+	audioRowHeight  = 22
+	audioPanelWidth = 520
+	audioVoiceRows  = 10
+)
+
+// AudioPanel lists what is playing with its volume, pan, bus and time left,
+// and mutes, solos and sets the volume of the mixer's buses
+type AudioPanel struct {
+	Visible bool
+}
+
+var audioPanel = &AudioPanel{}
+
+// audioButton is a clickable cell of a bus row
+type audioButton struct {
+	label  string
+	rect   rl.Rectangle
+	on     bool
+	action func()
+}
+
+func (p *AudioPanel) bounds() rl.Rectangle {
+	rows := int(busCount) + audioVoiceRows + 4
+	return ui.Rect(UIRect{Anchor: AnchorBottomRight, Offset: rl.NewVector2(20, 20), Size: rl.NewVector2(audioPanelWidth, float32(rows*audioRowHeight+20))})
+}
+
+func (p *AudioPanel) busRow(bus AudioBus) rl.Rectangle {
+	b := p.bounds()
+	return rl.NewRectangle(b.X+12, b.Y+36+float32(int(bus)*audioRowHeight), b.Width-24, audioRowHeight)
+}
+
+func (p *AudioPanel) buttons(bus AudioBus) []audioButton {
+	row := p.busRow(bus)
+	cell := func(i int) rl.Rectangle {
+		return rl.NewRectangle(row.X+row.Width-float32(5-i)*34, row.Y+2, 30, row.Height-4)
+	}
+	return []audioButton{
+		{"-", cell(0), false, func() { mixer.SetVolume(bus, mixer.volume[bus]-0.1) }},
+		{"+", cell(1), false, func() { mixer.SetVolume(bus, mixer.volume[bus]+0.1) }},
+		{"M", cell(3), mixer.mute[bus], func() { mixer.ToggleMute(bus) }},
+		{"S", cell(4), mixer.solo[bus], func() { mixer.ToggleSolo(bus) }},
+	}
+}
+
+// HandleAudioPanel toggles the panel and handles its buttons.
+func HandleAudioPanel() {
+	if rl.IsKeyPressed(audioPanelKey) {
+		audioPanel.Visible = !audioPanel.Visible
+	}
+	if !audioPanel.Visible || !rl.IsMouseButtonPressed(rl.MouseButtonLeft) {
+		return
+	}
+	mouse := rl.GetMousePosition()
+	for bus := range busCount {
+		for _, button := range audioPanel.buttons(bus) {
+			if rl.CheckCollisionPointRec(mouse, button.rect) {
+				button.action()
+			}
+		}
+	}
+}
+
+func formatAudioTime(d time.Duration) string {
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
+
+// voiceLines describes everything playing, the music and video first.
+func voiceLines() []string {
+	var lines []string
+	if rl.IsMusicValid(music) && rl.IsMusicStreamPlaying(music) {
+		left := time.Duration((rl.GetMusicTimeLength(music) - rl.GetMusicTimePlayed(music)) * float32(time.Second))
+		lines = append(lines, fmt.Sprintf("%-18s %-6s vol %.2f  pan  0.00  %s left", filepath.Base(musicPath), BusMusic, mixer.MusicVolume(), formatAudioTime(left)))
+	}
+	if videoPlayer.Active() {
+		lines = append(lines, fmt.Sprintf("%-18s %-6s vol %.2f  pan  0.00  at %s", filepath.Base(videoPlayer.Path), BusVideo, mixer.Gain(BusVideo), formatAudioTime(videoPlayer.Position())))
+	}
+	for _, v := range mixer.Voices() {
+		name := filepath.Base(v.Name)
+		if v.Positional {
+			name += " @"
+		}
+		lines = append(lines, fmt.Sprintf("%-18s %-6s vol %.2f  pan %+.2f  %s left", name, v.Bus, v.Volume*mixer.Gain(v.Bus), v.Pan, formatAudioTime(v.Remaining())))
+	}
+	return lines
+}
+
+// Draw renders the bus controls and the list of voices.
+func (p *AudioPanel) Draw() {
+	if !p.Visible {
+		return
+	}
+	b := p.bounds()
+	rl.DrawRectangleRec(b, rl.Fade(rl.Black, 0.75))
+	rl.DrawText("Audio (`)", int32(b.X)+12, int32(b.Y)+10, 20, rl.Yellow)
+
+	mouse := rl.GetMousePosition()
+	for bus := range busCount {
+		row := p.busRow(bus)
+		color := rl.RayWhite
+		if mixer.Gain(bus) == 0 {
+			color = rl.Gray
+		}
+		rl.DrawText(fmt.Sprintf("%-6s %3.0f%%", bus, mixer.volume[bus]*100), int32(row.X), int32(row.Y)+3, 16, color)
+		for _, button := range p.buttons(bus) {
+			fill := rl.Gray
+			switch {
+			case button.on:
+				fill = rl.Orange
+			case rl.CheckCollisionPointRec(mouse, button.rect):
+				fill = rl.LightGray
+			}
+			rl.DrawRectangleRec(button.rect, fill)
+			rl.DrawText(button.label, int32(button.rect.X)+10, int32(button.rect.Y)+2, 16, rl.Black)
+		}
+	}
+
+	y := int32(p.busRow(busCount-1).Y) + audioRowHeight + 12
+	lines := voiceLines()
+	rl.DrawText(fmt.Sprintf("Playing: %d", len(lines)), int32(b.X)+12, y, 16, rl.Yellow)
+	for i, line := range lines {
+		if i == audioVoiceRows {
+			rl.DrawText(fmt.Sprintf("... %d more", len(lines)-i), int32(b.X)+12, y+int32((i+1)*audioRowHeight), 16, rl.Gray)
+			break
+		}
+		rl.DrawText(line, int32(b.X)+12, y+int32((i+1)*audioRowHeight), 16, rl.RayWhite)
+	}
+}
+
+func init() {
+	RegisterSystem(&SystemFuncs{ID: "audioPanel", Requires: []string{"debug"}, OnUpdate: HandleAudioPanel, OnDraw: audioPanel.Draw})
+}
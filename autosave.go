@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"sync/atomic"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	autosaveSlots    = 3
+	autosaveInterval = 5 * time.Minute
+	autosaveIcon     = "assets/images/icons/save.png"
+	// The indicator stays up at least this long so quick writes still register
+	autosaveIconHold = time.Second
+)
+
+// Autosaver writes the game to rotating autosave slots every few minutes and
+// whenever the player reaches a checkpoint. The world is captured on the main
+// thread and written on a background goroutine, replacing the oldest slot.
+type Autosaver struct {
+	active  bool
+	next    int // slot the next autosave goes to
+	areas   int // entered since the last checkpoint
+	timer   *Timer
+	writing atomic.Bool
+	doneAt  atomic.Int64 // unix nanoseconds the last write finished
+}
+
+var (
+	autosaver     = &Autosaver{}
+	autosaveImage *Texture
+)
+
+// autosavePath returns the file for autosave slot n, starting at 1.
+func autosavePath(n int) string {
+	return profilePath(fmt.Sprintf("saves/autosave%d.json", n))
+}
+
+// Start enables autosaves, continuing the rotation after the newest slot.
+func (a *Autosaver) Start() {
+	a.active = true
+	a.rearm()
+	a.next = 1
+	var oldest time.Time
+	for n := 1; n <= autosaveSlots; n++ {
+		info, err := os.Stat(autosavePath(n))
+		if err != nil {
+			a.next = n
+			return
+		}
+		if oldest.IsZero() || info.ModTime().Before(oldest) {
+			oldest = info.ModTime()
+			a.next = n
+		}
+	}
+}
+
+// Stop disables autosaves. A write already in flight still finishes.
+func (a *Autosaver) Stop() {
+	a.active = false
+	a.timer.Cancel()
+}
+
+// rearm restarts the interval, which counts only time spent playing.
+func (a *Autosaver) rearm() {
+	a.timer.Cancel()
+	a.timer = scheduler.Every(autosaveInterval, a.Save)
+}
+
+// HandleEvent treats entering a new area as a checkpoint, or every few
+// areas on harder difficulties.
+func (a *Autosaver) HandleEvent(e Event) {
+	if !a.active || e.Type != EventAreaEntered {
+		return
+	}
+	a.areas++
+	if a.areas >= rules.CheckpointEvery {
+		a.areas = 0
+		a.Save()
+	}
+}
+
+// Save captures the game now and writes it in the background. It does
+// nothing while the previous autosave is still being written.
+func (a *Autosaver) Save() {
+	if !a.writing.CompareAndSwap(false, true) {
+		return
+	}
+	if a.active {
+		a.rearm()
+	}
+
+	path := autosavePath(a.next)
+	a.next = a.next%autosaveSlots + 1
+	data := CaptureSave()
+
+	go func() {
+		defer func() {
+			a.doneAt.Store(time.Now().UnixNano())
+			a.writing.Store(false)
+		}()
+		raw, err := json.MarshalIndent(data, "", "  ")
+		if err == nil {
+			err = writeFileAtomic(path, raw)
+		}
+		if err != nil {
+			log.Printf("autosave: %s: %v", path, err)
+		}
+	}()
+}
+
+// Writing reports whether the indicator should be showing.
+func (a *Autosaver) Writing() bool {
+	if a.writing.Load() {
+		return true
+	}
+	return time.Since(time.Unix(0, a.doneAt.Load())) < autosaveIconHold
+}
+
+// DrawAutosaveIndicator pulses a save icon in the bottom-right corner while
+// an autosave is being written.
+func DrawAutosaveIndicator() {
+	if !autosaver.Writing() {
+		return
+	}
+	pos := ui.Rect(UIRect{Anchor: AnchorBottomRight, Offset: rl.NewVector2(20, 20), Size: rl.NewVector2(statusIconSize, statusIconSize)})
+	alpha := 0.6 + 0.4*float32(math.Sin(rl.GetTime()*6))
+
+	if autosaveImage == nil {
+		autosaveImage = tm.Acquire(autosaveIcon, statusIconSize, statusIconSize)
+	}
+	if autosaveImage.Loaded {
+		rl.DrawTexture(autosaveImage.Texture, int32(pos.X), int32(pos.Y), rl.Fade(rl.White, alpha))
+		autosaveImage.Drawn()
+	} else {
+		rl.DrawRectangleRec(pos, rl.Fade(rl.RayWhite, alpha))
+		rl.DrawText("S", int32(pos.X)+10, int32(pos.Y)+6, 20, rl.Black)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+// Neighbour bits used to pick an auto-tile variant
+const (
+	autoTileNorth = 1 << iota
+	autoTileEast
+	autoTileSouth
+	autoTileWest
+)
+
+// TerrainDef describes a paintable terrain. Its tileset row holds 16 variants
+// starting at FirstTile, indexed by which cardinal neighbours share the terrain
+// (north=1, east=2, south=4, west=8).
+type TerrainDef struct {
+	Name      string
+	FirstTile int
+	Solid     bool
+	Surface   string // what footsteps on it sound like, see surfaceDefs
+}
+
+// Terrains are looked up by the id stored in the terrain layer; id 0 is empty
+var terrains = map[int]TerrainDef{}
+
+// RegisterTerrain makes a terrain available for painting under id.
+func RegisterTerrain(id int, def TerrainDef) {
+	terrains[id] = def
+}
+
+// TerrainAt returns the terrain id at a cell, or 0 outside the map.
+func (t *Tilemap) TerrainAt(x, y int) int {
+	if !t.InBounds(x, y) {
+		return 0
+	}
+	return t.Terrain[y*t.Width+x]
+}
+
+// Paint sets the terrain of a cell (0 erases) and re-picks the edge tiles
+// of the cell and its neighbours.
+func (t *Tilemap) Paint(x, y, terrain int) {
+	if !t.InBounds(x, y) {
+		return
+	}
+	t.Terrain[y*t.Width+x] = terrain
+	t.SetSolid(x, y, terrains[terrain].Solid)
+
+	t.resolveAutoTile(x, y)
+	t.resolveAutoTile(x, y-1)
+	t.resolveAutoTile(x+1, y)
+	t.resolveAutoTile(x, y+1)
+	t.resolveAutoTile(x-1, y)
+}
+
+// ResolveAutoTiles recomputes every painted cell, e.g. after loading terrain data.
+func (t *Tilemap) ResolveAutoTiles() {
+	for y := 0; y < t.Height; y++ {
+		for x := 0; x < t.Width; x++ {
+			t.resolveAutoTile(x, y)
+		}
+	}
+}
+
+func (t *Tilemap) resolveAutoTile(x, y int) {
+	terrain := t.TerrainAt(x, y)
+	if !t.InBounds(x, y) {
+		return
+	}
+	if terrain == 0 {
+		t.SetTile(x, y, emptyTile)
+		return
+	}
+
+	mask := 0
+	if t.TerrainAt(x, y-1) == terrain {
+		mask |= autoTileNorth
+	}
+	if t.TerrainAt(x+1, y) == terrain {
+		mask |= autoTileEast
+	}
+	if t.TerrainAt(x, y+1) == terrain {
+		mask |= autoTileSouth
+	}
+	if t.TerrainAt(x-1, y) == terrain {
+		mask |= autoTileWest
+	}
+	t.SetTile(x, y, terrains[terrain].FirstTile+mask)
+}
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"raylibgo/buildinfo"
+)
+
+const (
+	benchmarkDir = "benchmarks"
+	// benchmarkWarmup frames run before timing starts, while textures
+	// upload and the caches fill
+	benchmarkWarmup = 60
+	benchmarkSeed   = 42
+)
+
+// BenchmarkOptions configure a benchmark run from the command line
+type BenchmarkOptions struct {
+	// Run starts the benchmark instead of the game
+	Run       bool
+	Sprites   int
+	Particles int
+	Lights    int
+	Frames    int
+	// Out is the JSON report path; empty names one after the time
+	Out string
+	// Hidden keeps the window out of sight, for regression runs on CI
+	// machines and build servers
+	Hidden bool
+}
+
+// BenchmarkReport is written as JSON when a benchmark ends. Times are in
+// milliseconds.
+type BenchmarkReport struct {
+	Time        time.Time      `json:"time"`
+	Build       buildinfo.Info `json:"build"`
+	OS          string         `json:"os"`
+	Arch        string         `json:"arch"`
+	CPUs        int            `json:"cpus"`
+	GoVersion   string         `json:"goVersion"`
+	Width       int            `json:"width"`
+	Height      int            `json:"height"`
+	RefreshRate int            `json:"refreshRate"`
+
+	Sprites   int `json:"sprites"`
+	Particles int `json:"particles"`
+	Lights    int `json:"lights"`
+	Frames    int `json:"frames"`
+
+	AvgMs    float64 `json:"avgMs"`
+	P50Ms    float64 `json:"p50Ms"`
+	P95Ms    float64 `json:"p95Ms"`
+	P99Ms    float64 `json:"p99Ms"`
+	MaxMs    float64 `json:"maxMs"`
+	AvgCPUMs float64 `json:"avgCpuMs"`
+	P95CPUMs float64 `json:"p95CpuMs"`
+	FPS      float64 `json:"fps"`
+}
+
+type benchSprite struct {
+	pos, vel rl.Vector2
+	anim     *Animated
+	offset   int
+	flip     bool
+}
+
+type benchParticle struct {
+	pos, vel rl.Vector2
+	color    rl.Color
+}
+
+// BenchmarkScene stresses the renderer with animated sprites, particles and
+// moving lights, times a fixed number of frames and reports the average
+// and percentile frame times to the log and a JSON file, then quits.
+// Everything is placed from a fixed seed so runs compare.
+type BenchmarkScene struct {
+	opts      BenchmarkOptions
+	rand      *rand.Rand
+	sprites   []benchSprite
+	particles []benchParticle
+	lights    []PointLight
+	material  SpriteMaterial
+	frame     int
+	total     []time.Duration
+	cpu       []time.Duration
+	done      bool
+	static    []PointLight
+	font      *Font
+}
+
+// NewBenchmarkScene creates a benchmark with the given counts.
+func NewBenchmarkScene(opts BenchmarkOptions) *BenchmarkScene {
+	return &BenchmarkScene{opts: opts}
+}
+
+func (b *BenchmarkScene) Load(scope *AssetScope) {
+	b.font = scope.Font("", 24)
+	b.rand = rand.New(rand.NewPCG(benchmarkSeed, 0))
+	b.static = lighting.Static
+	// Unthrottled, so the frame times measure the work rather than vsync
+	rl.SetTargetFPS(0)
+
+	anims := []*Animated{&player.Stand, &player.Move, &player.Hit}
+	for range b.opts.Sprites {
+		anim := anims[b.rand.IntN(len(anims))]
+		b.sprites = append(b.sprites, benchSprite{
+			pos:    b.randomPos(),
+			vel:    rl.NewVector2(b.rand.Float32()*4-2, b.rand.Float32()*4-2),
+			anim:   anim,
+			offset: b.rand.IntN(max(anim.Frames(), 1)),
+			flip:   b.rand.IntN(2) == 0,
+		})
+	}
+	for range b.opts.Particles {
+		b.particles = append(b.particles, benchParticle{
+			pos:   b.randomPos(),
+			vel:   rl.NewVector2(b.rand.Float32()*6-3, b.rand.Float32()*6-3),
+			color: rl.ColorFromHSV(b.rand.Float32()*360, 0.7, 1),
+		})
+	}
+	for range b.opts.Lights {
+		b.lights = append(b.lights, PointLight{
+			Pos:       b.randomPos(),
+			Color:     rl.ColorFromHSV(b.rand.Float32()*360, 0.5, 1),
+			Radius:    200 + b.rand.Float32()*300,
+			Intensity: 1,
+		})
+	}
+	log.Printf("benchmark: %d sprites, %d particles, %d lights, %d frames", b.opts.Sprites, b.opts.Particles, b.opts.Lights, b.opts.Frames)
+}
+
+func (b *BenchmarkScene) Unload() {
+	lighting.Static = b.static
+	// Let pacing set the target FPS again
+	pacing.monitor = -1
+	pacing.Detect()
+}
+
+func (b *BenchmarkScene) randomPos() rl.Vector2 {
+	return rl.NewVector2(b.rand.Float32()*screenSize.X, b.rand.Float32()*screenSize.Y)
+}
+
+// bounce moves pos by vel, turning back at the screen edges.
+func bounce(pos, vel *rl.Vector2) {
+	*pos = rl.Vector2Add(*pos, *vel)
+	if pos.X < 0 || pos.X > screenSize.X {
+		vel.X = -vel.X
+	}
+	if pos.Y < 0 || pos.Y > screenSize.Y {
+		vel.Y = -vel.Y
+	}
+}
+
+func (b *BenchmarkScene) Update() {
+	if b.done {
+		return
+	}
+	if rl.IsKeyPressed(rl.KeyEscape) {
+		log.Printf("benchmark: canceled after %d frames", b.frame)
+		b.finish()
+		return
+	}
+
+	// perf has timed the previous frame by now
+	if b.frame > benchmarkWarmup {
+		total, cpu := perf.Last()
+		b.total = append(b.total, total)
+		b.cpu = append(b.cpu, cpu)
+	}
+	b.frame++
+	if len(b.total) >= b.opts.Frames {
+		b.finish()
+		return
+	}
+
+	for i := range b.sprites {
+		bounce(&b.sprites[i].pos, &b.sprites[i].vel)
+	}
+	for i := range b.particles {
+		bounce(&b.particles[i].pos, &b.particles[i].vel)
+	}
+	for i := range b.lights {
+		// Lights circle slowly so the nearest set changes every frame
+		angle := float64(b.frame)*0.02 + float64(i)
+		b.lights[i].Pos = rl.Vector2Add(b.lights[i].Pos, rl.NewVector2(float32(2*math.Cos(angle)), float32(2*math.Sin(angle))))
+	}
+	lighting.Static = b.lights
+}
+
+func (b *BenchmarkScene) Draw() {
+	rl.ClearBackground(rl.DarkGray)
+	lighting.Collect()
+	for _, s := range b.sprites {
+		n := s.anim.Frames()
+		if n == 0 {
+			continue
+		}
+		frame := (s.offset + b.frame) % n
+		tex, src := s.anim.Frame(frame)
+		if tex == nil {
+			continue
+		}
+		dst := rl.NewRectangle(s.pos.X, s.pos.Y, src.Width*player.Scale, src.Height*player.Scale)
+		if s.flip {
+			src.X += src.Width
+			src.Width *= -1
+		}
+		DrawLitSprite(tex.Texture, s.anim.NormalFrame(frame), src, dst, 0, &b.material)
+	}
+	for _, p := range b.particles {
+		rl.DrawRectangleV(p.pos, rl.NewVector2(3, 3), p.color)
+	}
+	for _, l := range b.lights {
+		rl.DrawCircleLinesV(l.Pos, 6, l.Color)
+	}
+
+	line := fmt.Sprintf("Benchmark  frame %d/%d  %d FPS  (Escape to stop)", len(b.total), b.opts.Frames, rl.GetFPS())
+	rl.DrawRectangle(0, 0, int32(screenSize.X), 40, rl.Fade(rl.Black, 0.7))
+	b.font.Draw(line, rl.NewVector2(16, 8), 24, rl.RayWhite)
+}
+
+// finish reports the timed frames and quits.
+func (b *BenchmarkScene) finish() {
+	b.done = true
+	r := benchmarkReport(b.opts, b.total, b.cpu)
+	log.Printf("benchmark: %d frames, avg %.2f ms (%.0f FPS), p50 %.2f ms, p95 %.2f ms, p99 %.2f ms, max %.2f ms, cpu avg %.2f ms",
+		r.Frames, r.AvgMs, r.FPS, r.P50Ms, r.P95Ms, r.P99Ms, r.MaxMs, r.AvgCPUMs)
+
+	path := b.opts.Out
+	if path == "" {
+		path = filepath.Join(benchmarkDir, "bench-"+time.Now().Format("20060102-150405")+".json")
+	}
+	if err := r.Write(path); err != nil {
+		log.Printf("benchmark: %v", err)
+	} else {
+		log.Printf("benchmark: wrote %s", path)
+	}
+	quitRequested = true
+}
+
+// benchmarkReport summarizes timed frames.
+func benchmarkReport(opts BenchmarkOptions, total, cpu []time.Duration) *BenchmarkReport {
+	r := &BenchmarkReport{
+		Time:        time.Now(),
+		Build:       buildinfo.Get(),
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+		CPUs:        runtime.NumCPU(),
+		GoVersion:   runtime.Version(),
+		Width:       rl.GetScreenWidth(),
+		Height:      rl.GetScreenHeight(),
+		RefreshRate: pacing.RefreshRate(),
+		Sprites:     opts.Sprites,
+		Particles:   opts.Particles,
+		Lights:      opts.Lights,
+		Frames:      len(total),
+	}
+	if len(total) == 0 {
+		return r
+	}
+	r.AvgMs, r.AvgCPUMs = ms(average(total)), ms(average(cpu))
+	sorted := slices.Sorted(slices.Values(total))
+	r.P50Ms = ms(percentile(sorted, 50))
+	r.P95Ms = ms(percentile(sorted, 95))
+	r.P99Ms = ms(percentile(sorted, 99))
+	r.MaxMs = ms(sorted[len(sorted)-1])
+	r.P95CPUMs = ms(percentile(slices.Sorted(slices.Values(cpu)), 95))
+	if r.AvgMs > 0 {
+		r.FPS = 1000 / r.AvgMs
+	}
+	return r
+}
+
+func average(ds []time.Duration) time.Duration {
+	var sum time.Duration
+	for _, d := range ds {
+		sum += d
+	}
+	return sum / time.Duration(len(ds))
+}
+
+// percentile returns the p-th percentile of sorted durations, by nearest rank.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	i := (len(sorted)*p + 99) / 100
+	return sorted[min(max(i-1, 0), len(sorted)-1)]
+}
+
+// Write saves the report as indented JSON.
+func (r *BenchmarkReport) Write(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// BossPart is one separately drawn piece of a large boss sprite
+type BossPart struct {
+	Name     string
+	Texture  string
+	Size     rl.Vector2
+	Offset   rl.Vector2 // from the boss position
+	Rotation float32
+	Color    rl.Color // placeholder color when the texture is missing
+	tex      *Texture
+}
+
+// BossAttack is one step of a scripted attack pattern. Update is called every
+// tick while the step runs, with the time since it started.
+type BossAttack struct {
+	Name     string
+	Duration time.Duration
+	Update   func(b *Boss, elapsed time.Duration)
+}
+
+// BossPhase is active while the boss's health fraction is above HealthAbove.
+// Phases are ordered from first to last.
+type BossPhase struct {
+	Name        string
+	HealthAbove float32
+	Pattern     []BossAttack
+	Music       string
+	OnEnter     func(b *Boss)
+}
+
+// Boss is a multi-part enemy driven by a phase state machine
+type Boss struct {
+	Name      string
+	Pos       rl.Vector2
+	Size      rl.Vector2 // hurtbox from Pos
+	Health    int
+	MaxHealth int
+	Parts     []BossPart
+	Phases    []BossPhase
+	Arena     rl.Rectangle
+	Defeated  bool
+
+	phase      int
+	attack     int
+	attackTime time.Duration
+}
+
+// activeBoss is the boss currently fighting the player, if any
+var activeBoss *Boss
+
+// bossBuilders make each boss by name, so a saved fight can be rebuilt
+// with its attack patterns
+var bossBuilders = make(map[string]func() *Boss)
+
+// RegisterBoss makes the boss build returns loadable from saves by name.
+func RegisterBoss(name string, build func() *Boss) {
+	bossBuilders[name] = build
+}
+
+// BossSave is a fight in progress: where the boss is in its phases and
+// attack pattern
+type BossSave struct {
+	Name       string        `json:"name"`
+	Pos        rl.Vector2    `json:"pos"`
+	Health     int           `json:"health"`
+	Phase      int           `json:"phase"`
+	Attack     int           `json:"attack"`
+	AttackTime time.Duration `json:"attackTime"`
+}
+
+// CaptureBoss returns the active fight, or nil when there is none.
+func CaptureBoss() *BossSave {
+	b := activeBoss
+	if b == nil {
+		return nil
+	}
+	return &BossSave{Name: b.Name, Pos: b.Pos, Health: b.Health, Phase: b.phase, Attack: b.attack, AttackTime: b.attackTime}
+}
+
+// RestoreBoss ends any fight going and resumes the saved one, if any. The
+// phase's music plays again but its OnEnter doesn't run, since what it did
+// is part of the saved world.
+func RestoreBoss(s *BossSave) {
+	if activeBoss != nil {
+		EndBossFight()
+	}
+	if s == nil {
+		return
+	}
+	build, ok := bossBuilders[s.Name]
+	if !ok {
+		log.Printf("boss: %q isn't registered, the fight can't be resumed", s.Name)
+		return
+	}
+	b := build()
+	b.Pos = s.Pos
+	b.Health = s.Health
+	b.phase = s.Phase
+	if phase := b.Phase(); phase != nil {
+		b.attack = min(max(s.Attack, 0), max(len(phase.Pattern)-1, 0))
+		b.attackTime = s.AttackTime
+		if phase.Music != "" && OnBossMusic != nil {
+			OnBossMusic(phase.Music)
+		}
+	}
+	activeBoss = b
+	if b.Arena.Width > 0 && b.Arena.Height > 0 {
+		camera.Lock(b.Arena)
+	}
+}
+
+// OnBossMusic is called with the phase's track whenever a phase with music starts
+var OnBossMusic = PlayMusicTrack
+
+// StartBossFight activates the boss, locks the camera to its arena and enters the first phase.
+func StartBossFight(b *Boss) {
+	activeBoss = b
+	b.phase = -1
+	if b.Arena.Width > 0 && b.Arena.Height > 0 {
+		camera.Lock(b.Arena)
+	}
+	b.enterPhase(0)
+}
+
+// EndBossFight releases the arena lock.
+func EndBossFight() {
+	camera.Unlock()
+	activeBoss = nil
+}
+
+func (b *Boss) enterPhase(i int) {
+	if i == b.phase || i >= len(b.Phases) {
+		return
+	}
+	b.phase = i
+	b.attack = 0
+	b.attackTime = 0
+
+	phase := b.Phases[i]
+	if phase.Music != "" && OnBossMusic != nil {
+		OnBossMusic(phase.Music)
+	}
+	if phase.OnEnter != nil {
+		phase.OnEnter(b)
+	}
+}
+
+// Phase returns the current phase.
+func (b *Boss) Phase() *BossPhase {
+	if b.phase < 0 || b.phase >= len(b.Phases) {
+		return nil
+	}
+	return &b.Phases[b.phase]
+}
+
+// Hurtbox returns the area that takes damage.
+func (b *Boss) Hurtbox() rl.Rectangle {
+	return rl.NewRectangle(b.Pos.X, b.Pos.Y, b.Size.X, b.Size.Y)
+}
+
+// Damage lowers health, advancing phases or defeating the boss.
+func (b *Boss) Damage(amount int) {
+	if b.Defeated || amount <= 0 {
+		return
+	}
+	b.Health = max(b.Health-amount, 0)
+	SpawnDamageNumber(rl.NewVector2(b.Pos.X+b.Size.X/2, b.Pos.Y), amount, false)
+
+	if b.Health == 0 {
+		b.Defeated = true
+		events.Publish(Event{Type: EventEnemyDefeated, Target: b.Name, Pos: b.Pos})
+		EndBossFight()
+		return
+	}
+
+	fraction := float32(b.Health) / float32(b.MaxHealth)
+	for i := b.phase + 1; i < len(b.Phases); i++ {
+		if fraction > b.Phases[i].HealthAbove {
+			break
+		}
+		b.enterPhase(i)
+	}
+}
+
+// Update runs the current attack step and moves to the next when it ends.
+func (b *Boss) Update() {
+	phase := b.Phase()
+	if b.Defeated || phase == nil || len(phase.Pattern) == 0 {
+		return
+	}
+
+	step := phase.Pattern[b.attack]
+	b.attackTime += tickDuration
+	if step.Update != nil {
+		step.Update(b, b.attackTime)
+	}
+	if b.attackTime >= step.Duration {
+		b.attackTime = 0
+		b.attack = (b.attack + 1) % len(phase.Pattern)
+	}
+}
+
+// Draw renders every part, using a colored box for parts without a texture.
+func (b *Boss) Draw() {
+	box := b.Hurtbox()
+	DrawShadowBlob(box, box.Y+box.Height)
+	for i := range b.Parts {
+		part := &b.Parts[i]
+		dst := rl.NewRectangle(b.Pos.X+part.Offset.X, b.Pos.Y+part.Offset.Y, part.Size.X, part.Size.Y)
+
+		if part.tex == nil && part.Texture != "" {
+			part.tex = tm.Acquire(part.Texture, int32(part.Size.X), int32(part.Size.Y))
+		}
+		if part.tex != nil && part.tex.Loaded {
+			src := rl.NewRectangle(0, 0, float32(part.tex.Texture.Width), float32(part.tex.Texture.Height))
+			rl.DrawTexturePro(part.tex.Texture, src, dst, rl.NewVector2(0, 0), part.Rotation, rl.White)
+			part.tex.Drawn()
+		} else {
+			rl.DrawRectanglePro(dst, rl.NewVector2(0, 0), part.Rotation, part.Color)
+		}
+	}
+}
+
+// DrawBossHealthBar shows the active boss's name, phase and health along the bottom of the screen.
+func DrawBossHealthBar() {
+	b := activeBoss
+	if b == nil {
+		return
+	}
+
+	bar := ui.Rect(UIRect{Anchor: AnchorBottom, Offset: rl.NewVector2(0, 40), Size: rl.NewVector2(900, 24)})
+	fill := float32(b.Health) / float32(max(b.MaxHealth, 1))
+
+	rl.DrawText(b.Name, int32(bar.X), int32(bar.Y)-34, 28, rl.White)
+	if phase := b.Phase(); phase != nil && phase.Name != "" {
+		w := rl.MeasureText(phase.Name, 20)
+		rl.DrawText(phase.Name, int32(bar.X+bar.Width)-w, int32(bar.Y)-28, 20, rl.LightGray)
+	}
+	DrawHUDBar(bar, fill, rl.Red)
+}
@@ -0,0 +1,113 @@
+// Package buildinfo identifies the build a player is running, so bug
+// reports, crash reports and submitted scores can be traced to the exact
+// source. Release builds stamp it at link time:
+//
+//	go build -ldflags "-X raylibgo/buildinfo.Version=1.2.0 \
+//		-X raylibgo/buildinfo.Commit=$(git rev-parse HEAD) \
+//		-X raylibgo/buildinfo.Date=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Values left unset fall back to the VCS stamp go build records when built
+// inside a git checkout.
+package buildinfo
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+)
+
+// Set at link time with -X
+var (
+	Version = "dev"
+	Commit  string
+	Date    string
+)
+
+// Info describes a build
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	Date      string `json:"date,omitempty"`
+	Modified  bool   `json:"modified,omitempty"` // built from uncommitted changes
+	GoVersion string `json:"goVersion"`
+	Platform  string `json:"platform"`
+}
+
+var (
+	once    sync.Once
+	current Info
+)
+
+// Get returns the running build's info.
+func Get() Info {
+	once.Do(func() {
+		current = Info{
+			Version:   Version,
+			Commit:    Commit,
+			Date:      Date,
+			GoVersion: runtime.Version(),
+			Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+		}
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		for _, s := range info.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if current.Commit == "" {
+					current.Commit = s.Value
+				}
+			case "vcs.time":
+				if current.Date == "" {
+					current.Date = s.Value
+				}
+			case "vcs.modified":
+				current.Modified = s.Value == "true"
+			}
+		}
+	})
+	return current
+}
+
+// ShortCommit returns the first seven characters of the commit, or "" if
+// it is unknown.
+func (i Info) ShortCommit() string {
+	if len(i.Commit) > 7 {
+		return i.Commit[:7]
+	}
+	return i.Commit
+}
+
+// Short returns the version with the short commit, e.g. "1.2.0 (abc1234)",
+// for titles and other tight spaces.
+func (i Info) Short() string {
+	commit := i.ShortCommit()
+	if commit == "" {
+		return i.Version
+	}
+	if i.Modified {
+		commit += "+"
+	}
+	return fmt.Sprintf("%s (%s)", i.Version, commit)
+}
+
+// String returns everything known about the build on one line, e.g.
+// "1.2.0 (abc1234) built 2026-10-17T09:00:00Z go1.24.2 windows/amd64".
+func (i Info) String() string {
+	s := i.Short()
+	if i.Date != "" {
+		s += " built " + i.Date
+	}
+	return s + " " + i.GoVersion + " " + i.Platform
+}
+
+// UserAgent returns the User-Agent sent with HTTP requests, e.g.
+// "raylibgo/1.2.0 (abc1234; windows/amd64)".
+func (i Info) UserAgent() string {
+	if commit := i.ShortCommit(); commit != "" {
+		return fmt.Sprintf("raylibgo/%s (%s; %s)", i.Version, commit, i.Platform)
+	}
+	return fmt.Sprintf("raylibgo/%s (%s)", i.Version, i.Platform)
+}
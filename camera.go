@@ -0,0 +1,102 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// GameCamera follows a target inside the world bounds, or inside a locked
+// area such as a boss arena
+type GameCamera struct {
+	Camera rl.Camera2D
+	World  rl.Rectangle
+	lock   *rl.Rectangle
+	punch  float32 // extra zoom that decays back to BaseZoom
+	// BaseZoom is the resting zoom, lowered to frame several players
+	BaseZoom float32
+}
+
+// Zoom punch decay per second
+const cameraPunchDecay = 6
+
+var camera = &GameCamera{Camera: rl.Camera2D{Zoom: 1}, BaseZoom: 1}
+
+// Reset centers the camera on a world of the given size.
+func (c *GameCamera) Reset(world rl.Rectangle) {
+	c.World = world
+	c.lock = nil
+	c.Camera.Offset = rl.NewVector2(screenSize.X/2, screenSize.Y/2)
+	c.Camera.Target = rl.NewVector2(world.X+world.Width/2, world.Y+world.Height/2)
+	c.Camera.Zoom = 1
+	c.BaseZoom = 1
+}
+
+// Lock keeps the camera, and the player, inside area until Unlock.
+func (c *GameCamera) Lock(area rl.Rectangle) {
+	c.lock = &area
+}
+
+// Unlock returns to following within the world bounds.
+func (c *GameCamera) Unlock() {
+	c.lock = nil
+}
+
+// Bounds returns the area the camera and player are currently confined to.
+func (c *GameCamera) Bounds() rl.Rectangle {
+	if c.lock != nil {
+		return *c.lock
+	}
+	return c.World
+}
+
+// Punch briefly zooms the camera in by amount.
+func (c *GameCamera) Punch(amount float32) {
+	c.punch = max(c.punch, amount)
+}
+
+// UpdateEffects decays the zoom punch and screen shake using real frame time,
+// so they still play out while the simulation is frozen.
+func (c *GameCamera) UpdateEffects(dt float32) {
+	c.punch = max(0, c.punch-c.punch*cameraPunchDecay*dt)
+	if c.punch < 0.001 {
+		c.punch = 0
+	}
+	c.Camera.Zoom = c.BaseZoom + c.punch
+	c.Follow(c.Camera.Target)
+	screenShake.Update(dt)
+}
+
+// View returns the camera to render with, including screen shake.
+func (c *GameCamera) View() rl.Camera2D {
+	view := c.Camera
+	view.Offset = rl.Vector2Add(view.Offset, screenShake.offset)
+	view.Rotation += screenShake.angle
+	return view
+}
+
+// Visible returns the part of the world on screen, ignoring screen shake.
+func (c *GameCamera) Visible() rl.Rectangle {
+	halfW := c.Camera.Offset.X / c.Camera.Zoom
+	halfH := c.Camera.Offset.Y / c.Camera.Zoom
+	return rl.NewRectangle(c.Camera.Target.X-halfW, c.Camera.Target.Y-halfH, 2*halfW, 2*halfH)
+}
+
+// Follow moves the camera toward target, clamped so the view stays inside Bounds.
+func (c *GameCamera) Follow(target rl.Vector2) {
+	bounds := c.Bounds()
+	halfW := c.Camera.Offset.X / c.Camera.Zoom
+	halfH := c.Camera.Offset.Y / c.Camera.Zoom
+
+	c.Camera.Target = rl.NewVector2(
+		clampAxis(target.X, bounds.X+halfW, bounds.X+bounds.Width-halfW),
+		clampAxis(target.Y, bounds.Y+halfH, bounds.Y+bounds.Height-halfH),
+	)
+}
+
+// clampAxis clamps v to [lo, hi], centering when the range is inverted
+// because the area is smaller than the view.
+func clampAxis(v, lo, hi float32) float32 {
+	if lo > hi {
+		return (lo + hi) / 2
+	}
+	return max(lo, min(v, hi))
+}
@@ -0,0 +1,165 @@
+//go:build cheats
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Developer cheats, only compiled with -tags cheats. Ctrl+Shift+C unlocks
+// them; once unlocked they are toggled with Ctrl and a key:
+//
+//	N noclip  I invincible  J infinite jumps  L skip level
+//	E spawn dummy at cursor  M cycle speed multiplier
+type cheatState struct {
+	unlocked      bool
+	noclip        bool
+	invincible    bool
+	infiniteJumps bool
+	speedIndex    int
+}
+
+var cheats cheatState
+
+var cheatSpeeds = []float32{1, 2, 4, 0.5}
+
+const (
+	noclipSpeed = 8
+	// trainingDummy is the boss spawned under the cursor
+	trainingDummy = "Training Dummy"
+)
+
+func cheatNoclip() bool        { return cheats.noclip }
+func cheatInvincible() bool    { return cheats.invincible }
+func cheatInfiniteJumps() bool { return cheats.infiniteJumps }
+
+func cheatSpeedMultiplier() float32 {
+	return cheatSpeeds[cheats.speedIndex]
+}
+
+// cheatFly moves the player vertically while noclip is on. It reads the
+// keyboard directly, so noclip movement is not replayed by rewind.
+func cheatFly() {
+	player.VelocityY = 0
+	if rl.IsKeyDown(rl.KeyUp) || rl.IsKeyDown(rl.KeyW) {
+		player.Pos.Y -= noclipSpeed
+	}
+	if rl.IsKeyDown(rl.KeyDown) || rl.IsKeyDown(rl.KeyS) {
+		player.Pos.Y += noclipSpeed
+	}
+}
+
+// unlockCheats makes the cheat hotkeys available without the unlock combo.
+func unlockCheats() {
+	cheats.unlocked = true
+}
+
+// cheatKey reads a cheat hotkey at console priority and consumes it, so
+// Ctrl+J doesn't also open the quest log.
+func cheatKey(key int32) bool {
+	return inputContexts.KeyPressed(ContextConsole, key)
+}
+
+// HandleCheats unlocks the cheats and toggles them on their hotkeys.
+func HandleCheats() {
+	ctrl := rl.IsKeyDown(rl.KeyLeftControl) || rl.IsKeyDown(rl.KeyRightControl)
+	shift := rl.IsKeyDown(rl.KeyLeftShift) || rl.IsKeyDown(rl.KeyRightShift)
+	if !ctrl {
+		return
+	}
+	if shift && cheatKey(rl.KeyC) {
+		cheats.unlocked = !cheats.unlocked
+		if !cheats.unlocked {
+			cheats = cheatState{}
+		}
+		log.Printf("cheats: unlocked=%v", cheats.unlocked)
+		return
+	}
+	if !cheats.unlocked {
+		return
+	}
+
+	switch {
+	case cheatKey(rl.KeyN):
+		cheats.noclip = !cheats.noclip
+	case cheatKey(rl.KeyI):
+		cheats.invincible = !cheats.invincible
+	case cheatKey(rl.KeyJ):
+		cheats.infiniteJumps = !cheats.infiniteJumps
+	case cheatKey(rl.KeyM):
+		cheats.speedIndex = (cheats.speedIndex + 1) % len(cheatSpeeds)
+	case cheatKey(rl.KeyL):
+		skipLevel()
+	case cheatKey(rl.KeyE):
+		spawnDummyAtCursor()
+	}
+}
+
+// skipLevel moves on to the first level reachable from the current one.
+func skipLevel() {
+	next := assets.Neighbors(currentLevel)
+	if len(next) == 0 {
+		log.Printf("cheats: %s has no exits", currentLevel)
+		return
+	}
+	currentLevel = next[0]
+	events.Publish(Event{Type: EventAreaEntered, Target: currentLevel, Pos: player.Pos})
+}
+
+// spawnDummyAtCursor starts a fight with a stationary training dummy under the mouse.
+func spawnDummyAtCursor() {
+	if activeBoss != nil {
+		EndBossFight()
+	}
+	pos := rl.GetScreenToWorld2D(rl.GetMousePosition(), camera.View())
+	b := newTrainingDummy()
+	b.Pos = rl.NewVector2(pos.X-b.Size.X/2, pos.Y-b.Size.Y/2)
+	StartBossFight(b)
+}
+
+func newTrainingDummy() *Boss {
+	size := rl.NewVector2(120, 160)
+	return &Boss{
+		Name:      trainingDummy,
+		Size:      size,
+		Health:    500,
+		MaxHealth: 500,
+		Parts:     []BossPart{{Name: "body", Size: size, Color: rl.Brown}},
+		Phases:    []BossPhase{{Name: "idle"}},
+	}
+}
+
+// DrawCheats lists active cheats in the corner while they are unlocked.
+func DrawCheats() {
+	if !cheats.unlocked {
+		return
+	}
+	active := []string{"CHEATS"}
+	for _, c := range []struct {
+		on   bool
+		name string
+	}{
+		{cheats.noclip, "noclip"},
+		{cheats.invincible, "invincible"},
+		{cheats.infiniteJumps, "infinite jumps"},
+	} {
+		if c.on {
+			active = append(active, c.name)
+		}
+	}
+	if m := cheatSpeedMultiplier(); m != 1 {
+		active = append(active, fmt.Sprintf("speed x%g", m))
+	}
+
+	pos := ui.Rect(UIRect{Anchor: AnchorBottomRight, Offset: rl.NewVector2(20, 20), Size: rl.NewVector2(420, 20)})
+	rl.DrawText(strings.Join(active, "  "), int32(pos.X), int32(pos.Y), 20, rl.Orange)
+}
+
+func init() {
+	RegisterBoss(trainingDummy, newTrainingDummy)
+	RegisterSystem(&SystemFuncs{ID: "cheats", Requires: []string{"tweaks"}, OnUpdate: HandleCheats, OnDraw: DrawCheats})
+}
@@ -0,0 +1,12 @@
+//go:build !cheats
+
+package main
+
+// Cheats are compiled out unless the game is built with -tags cheats.
+
+func cheatNoclip() bool             { return false }
+func cheatInvincible() bool         { return false }
+func cheatInfiniteJumps() bool      { return false }
+func cheatSpeedMultiplier() float32 { return 1 }
+func cheatFly()                     {}
+func unlockCheats()                 {}
@@ -0,0 +1,29 @@
+package main
+
+import "time"
+
+// Simulation rate and the length of one step
+const (
+	ticksPerSecond = 60
+	tickDuration   = time.Second / ticksPerSecond
+)
+
+// SimClock is the simulation time source. It advances by a fixed step per
+// Update, so replays and rewinds reproduce the same animation timing.
+type SimClock struct {
+	Epoch time.Time
+	Tick  uint64
+}
+
+var clock = &SimClock{Epoch: time.Now()}
+
+// Now returns the simulation time of the current tick.
+func (c *SimClock) Now() time.Time {
+	return c.Epoch.Add(time.Duration(c.Tick) * tickDuration)
+}
+
+// Advance moves the clock forward one tick and returns the new time.
+func (c *SimClock) Advance() time.Time {
+	c.Tick++
+	return c.Now()
+}
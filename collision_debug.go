@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const collisionDebugKey = rl.KeyF12
+
+// Collider colors, one per kind of shape
+var (
+	colliderSolid   = rl.Gray
+	colliderHurtbox = rl.Lime
+	colliderHitbox  = rl.Red
+	colliderTrigger = rl.SkyBlue
+	colliderPickup  = rl.Gold
+	colliderProbe   = rl.Magenta
+	colliderArena   = rl.Purple
+)
+
+// showColliders is set while the collision view is toggled on
+var showColliders bool
+
+// HandleCollisionDebugToggle shows or hides the collision view.
+func HandleCollisionDebugToggle() {
+	if rl.IsKeyPressed(collisionDebugKey) {
+		showColliders = !showColliders
+	}
+}
+
+// drawCollider outlines r with its label above the top left corner.
+func drawCollider(r rl.Rectangle, color rl.Color, label string) {
+	rl.DrawRectangleRec(r, rl.Fade(color, 0.15))
+	rl.DrawRectangleLinesEx(r, 1, color)
+	if label != "" {
+		rl.DrawText(label, int32(r.X), int32(r.Y)-12, 10, color)
+	}
+}
+
+// drawAttackBox outlines an attack's rotated area.
+func drawAttackBox(box AttackBox, color rl.Color, label string) {
+	c := box.Corners()
+	for i := range c {
+		rl.DrawLineEx(c[i], c[(i+1)%len(c)], 1, color)
+	}
+	rl.DrawText(label, int32(c[0].X), int32(c[0].Y)-12, 10, color)
+}
+
+// DrawColliders draws every collider, hitbox, hurtbox and trigger in the
+// level, and the probe that finds the ground under the player. Call it
+// inside the world camera.
+func DrawColliders() {
+	if !showColliders {
+		return
+	}
+	view := camera.Visible()
+
+	// Solid tiles, only those in view
+	if t := levelMap(); t != nil {
+		from := t.WorldToTile(rl.NewVector2(view.X, view.Y))
+		to := t.WorldToTile(rl.NewVector2(view.X+view.Width, view.Y+view.Height))
+		for y := max(from.Y, 0); y <= min(to.Y, t.Height-1); y++ {
+			for x := max(from.X, 0); x <= min(to.X, t.Width-1); x++ {
+				if t.IsSolid(x, y) {
+					cell := rl.NewRectangle(float32(x)*t.TileSize, float32(y)*t.TileSize, t.TileSize, t.TileSize)
+					drawCollider(cell, colliderSolid, "")
+				}
+			}
+		}
+	}
+	body := PlayerBounds()
+	ground := player.DefPos.Y + body.Height
+	rl.DrawLineEx(rl.NewVector2(view.X, ground), rl.NewVector2(view.X+view.Width, ground), 1, colliderSolid)
+	rl.DrawText("ground", int32(view.X)+4, int32(ground)+2, 10, colliderSolid)
+
+	// Triggers
+	for _, exit := range assets.manifest.Levels[currentLevel].Exits {
+		if worldFlags.Check(exit.If) {
+			drawCollider(rl.NewRectangle(exit.X, exit.Y, exit.Width, exit.Height), colliderTrigger, "exit > "+exit.To)
+		}
+	}
+	for _, it := range interactables {
+		if worldFlags.Check(it.If) {
+			drawCollider(it.Bounds(), colliderTrigger, string(it.Kind)+" "+it.ID)
+		}
+	}
+	reach := rl.NewRectangle(body.X-interactRange, body.Y-interactRange, body.Width+interactRange*2, body.Height+interactRange*2)
+	rl.DrawRectangleLinesEx(reach, 1, rl.Fade(colliderTrigger, 0.4))
+	for _, p := range pickups {
+		if !p.Taken {
+			rl.DrawCircleLinesV(p.Pos, pickupRadius, colliderPickup)
+			rl.DrawText(p.Name, int32(p.Pos.X-pickupRadius), int32(p.Pos.Y-pickupRadius)-12, 10, colliderPickup)
+		}
+	}
+
+	// Hurtboxes and hitboxes
+	for _, e := range enemies {
+		if !e.Defeated {
+			drawCollider(e.Hurtbox(), colliderHurtbox, fmt.Sprintf("%s %d/%d", e.Name, e.Health, e.MaxHealth))
+		}
+	}
+	if b := activeBoss; b != nil && !b.Defeated {
+		drawCollider(b.Hurtbox(), colliderHurtbox, b.Name)
+		rl.DrawRectangleLinesEx(b.Arena, 2, colliderArena)
+		rl.DrawText("arena", int32(b.Arena.X)+4, int32(b.Arena.Y)+4, 10, colliderArena)
+	}
+	drawCollider(body, colliderHurtbox, "player")
+	hitColor := rl.Fade(colliderHitbox, 0.4)
+	if player.Hit.IsPlaying {
+		hitColor = colliderHitbox
+	}
+	drawAttackBox(PlayerHitbox(), hitColor, "hit")
+
+	// Ground probe: where landing is checked and the surface is sampled
+	feet := rl.NewVector2(body.X+body.Width/2, body.Y+body.Height)
+	probeColor := rl.Fade(colliderProbe, 0.5)
+	if player.OnGround {
+		probeColor = colliderProbe
+	}
+	rl.DrawLineEx(feet, rl.NewVector2(feet.X, ground), 1, probeColor)
+	rl.DrawCircleV(feet, 3, probeColor)
+	rl.DrawText(SurfaceAt(feet), int32(feet.X)+6, int32(feet.Y)+2, 10, probeColor)
+}
+
+// DrawColliderLegend names the collider colors in the bottom left corner.
+func DrawColliderLegend() {
+	if !showColliders {
+		return
+	}
+	entries := []struct {
+		label string
+		color rl.Color
+	}{
+		{"solid", colliderSolid}, {"hurtbox", colliderHurtbox}, {"hitbox", colliderHitbox},
+		{"trigger", colliderTrigger}, {"pickup", colliderPickup}, {"ground probe", colliderProbe},
+		{"boss arena", colliderArena},
+	}
+	height := float32(30 + 20*len(entries))
+	r := ui.Rect(UIRect{Anchor: AnchorBottomLeft, Offset: rl.NewVector2(20, 20), Size: rl.NewVector2(180, height)})
+	rl.DrawRectangleRec(r, rl.Fade(rl.Black, 0.6))
+	rl.DrawText("Colliders (F12)", int32(r.X)+8, int32(r.Y)+6, 16, rl.Yellow)
+	for i, e := range entries {
+		y := int32(r.Y) + 30 + int32(i*20)
+		rl.DrawRectangle(int32(r.X)+8, y+2, 12, 12, e.color)
+		rl.DrawText(e.label, int32(r.X)+28, y, 16, rl.RayWhite)
+	}
+}
+
+func init() {
+	RegisterSystem(&SystemFuncs{ID: "colliders", Requires: []string{"debug"}, OnUpdate: HandleCollisionDebugToggle, OnDraw: DrawColliderLegend})
+}
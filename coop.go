@@ -0,0 +1,255 @@
+package main
+
+import (
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	// Free space kept around both players when framing them together
+	coopFramePadding = 400
+	// Below this zoom the view splits in two instead of zooming further out
+	coopMinZoom    = 0.6
+	coopZoomSmooth = 0.1
+	coopSpawnGap   = 120
+)
+
+// Split keyboard: the first player keeps WASD and the second the arrows.
+// Each also gets a gamepad of their own.
+var coopBindings = [2]*InputBinding{
+	{
+		Keys: map[Action][]int32{
+			ActionLeft:     {rl.KeyA},
+			ActionRight:    {rl.KeyD},
+			ActionJump:     {rl.KeyW, rl.KeySpace},
+			ActionHit:      {rl.KeyF},
+			ActionInteract: {rl.KeyE},
+			ActionDash:     {rl.KeyQ},
+		},
+		Gamepad: 0,
+	},
+	{
+		Keys: map[Action][]int32{
+			ActionLeft:     {rl.KeyLeft},
+			ActionRight:    {rl.KeyRight},
+			ActionJump:     {rl.KeyUp},
+			ActionHit:      {rl.KeyRightControl, rl.KeyKp0},
+			ActionInteract: {rl.KeyRightShift},
+			ActionDash:     {rl.KeyKp1},
+		},
+		Gamepad: 1,
+	},
+}
+
+// Coop runs a second local player next to the main one. The second player
+// reuses the main player's movement and combat code by being swapped into
+// the player and input globals for the length of its step.
+type Coop struct {
+	Active bool
+	Player Player
+
+	input InputFrame
+	live  InputFrame
+	split bool
+	views [2]rl.RenderTexture2D
+	font  *Font
+}
+
+var coop = &Coop{}
+
+// Start adds the second player beside the main one. It loads its own
+// frames, equipment and skeleton, so nothing is shared with the main
+// player.
+func (c *Coop) Start() {
+	c.Active = true
+	c.Player = NewPlayer(rl.NewVector2(player.Pos.X+coopSpawnGap, player.Pos.Y))
+	c.Player.DefPos = player.DefPos
+	for name, anim := range c.Player.animations() {
+		applyAnimationDef(anim, appliedAnimations[name], AnimationDef{})
+	}
+	c.Player.RestoreEquipment(player.Equipment())
+	c.Player.loadSkeleton(playerSkeletonPath)
+	if c.Player.Stand.Frames() > 0 {
+		c.Player.Stand.IsPlaying = true
+		c.Player.Stand.StartTime = clock.Now()
+	}
+	c.split = false
+	playerBinding = coopBindings[0]
+}
+
+// Stop removes the second player and frees the split-screen targets.
+func (c *Coop) Stop() {
+	if !c.Active {
+		return
+	}
+	c.Active = false
+	playerBinding = soloBinding
+	for name, anim := range c.Player.animations() {
+		applyAnimationDef(anim, AnimationDef{}, appliedAnimations[name])
+	}
+	c.Player.RestoreEquipment(nil)
+	if c.Player.Skeleton != nil {
+		c.Player.Skeleton.Unload()
+	}
+	c.Player = Player{}
+	camera.BaseZoom = 1
+	for i, view := range c.views {
+		if rl.IsRenderTextureValid(view) {
+			rl.UnloadRenderTexture(view)
+		}
+		c.views[i] = rl.RenderTexture2D{}
+	}
+}
+
+// Sample polls the second player's binding once per rendered frame.
+func (c *Coop) Sample() {
+	if !c.Active {
+		return
+	}
+	frame := coopBindings[1].Poll()
+	c.live.Down = frame.Down
+	c.live.Pressed |= frame.Pressed
+	c.live.Aim = frame.Aim
+}
+
+// as runs fn with the second player and its input in the player globals.
+func (c *Coop) as(fn func()) {
+	player, c.Player = c.Player, player
+	input, c.input = c.input, input
+	fn()
+	player, c.Player = c.Player, player
+	input, c.input = c.input, input
+}
+
+// Update steps the second player one tick and reframes the camera.
+func (c *Coop) Update(now time.Time) {
+	if !c.Active {
+		return
+	}
+	c.input = c.live
+	c.live.Pressed = 0
+	c.as(func() {
+		player.State.IsMoving = false
+		player.Effects.Update(DamagePlayer)
+		player.Material.Update()
+		HandleMovement(now)
+		HandleDash()
+		ApplyGravity()
+		HandleJump()
+		HandleHitAnimation(now)
+		HandleStandAnimation(now)
+		// Portals and the prompt follow the main player
+		if input.IsPressed(ActionInteract) {
+			if it, _ := nearestInteractable(PlayerBounds()); it != nil {
+				Interact(it)
+			}
+		}
+	})
+
+	zoom := c.fitZoom()
+	c.split = zoom < coopMinZoom
+	if c.split {
+		zoom = 1
+	}
+	camera.BaseZoom += (zoom - camera.BaseZoom) * coopZoomSmooth
+}
+
+// fitZoom returns the zoom that keeps both players on screen.
+func (c *Coop) fitZoom() float32 {
+	dx := abs32(player.Pos.X-c.Player.Pos.X) + coopFramePadding
+	dy := abs32(player.Pos.Y-c.Player.Pos.Y) + coopFramePadding
+	return min(1, screenSize.X/dx, screenSize.Y/dy)
+}
+
+func abs32(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// localPlayers returns the main player and, in co-op, the second.
+func localPlayers() []*Player {
+	if coop.Active {
+		return []*Player{&player, &coop.Player}
+	}
+	return []*Player{&player}
+}
+
+// CameraTarget returns the point the shared camera follows: the main
+// player, or the midpoint of both players in co-op.
+func CameraTarget() rl.Vector2 {
+	if !coop.Active {
+		return player.Pos
+	}
+	return rl.Vector2Lerp(player.Pos, coop.Player.Pos, 0.5)
+}
+
+// Split reports whether the players are too far apart to share one view.
+func (c *Coop) Split() bool {
+	return c.Active && c.split
+}
+
+// DrawPlayer draws the second player.
+func (c *Coop) DrawPlayer() {
+	if c.Active {
+		c.as(DrawPlayer)
+	}
+}
+
+// DrawSplit renders drawWorld once per player into its half of the screen,
+// each half following its own player.
+func (c *Coop) DrawSplit(drawWorld func()) {
+	half := int32(screenSize.X / 2)
+	height := int32(screenSize.Y)
+	targets := [2]rl.Vector2{player.Pos, c.Player.Pos}
+
+	for i := range c.views {
+		if !rl.IsRenderTextureValid(c.views[i]) {
+			c.views[i] = rl.LoadRenderTexture(half, height)
+		}
+		view := GameCamera{
+			Camera:   rl.Camera2D{Offset: rl.NewVector2(float32(half)/2, float32(height)/2), Zoom: 1},
+			World:    camera.World,
+			lock:     camera.lock,
+			BaseZoom: 1,
+		}
+		view.Follow(targets[i])
+
+		rl.BeginTextureMode(c.views[i])
+		rl.ClearBackground(rl.Black)
+		DrawBackgroundGIF(background)
+		rl.BeginMode2D(view.Camera)
+		drawWorld()
+		rl.EndMode2D()
+		rl.EndTextureMode()
+	}
+
+	// Render textures are stored upside down
+	src := rl.NewRectangle(0, 0, float32(half), -float32(height))
+	rl.DrawTextureRec(c.views[0].Texture, src, rl.NewVector2(0, 0), rl.White)
+	rl.DrawTextureRec(c.views[1].Texture, src, rl.NewVector2(float32(half), 0), rl.White)
+	rl.DrawLineEx(rl.NewVector2(float32(half), 0), rl.NewVector2(float32(half), float32(height)), 4, rl.Black)
+}
+
+// DrawHUD shows each player's health in their own bottom corner.
+func (c *Coop) DrawHUD() {
+	if !c.Active {
+		return
+	}
+	if c.font == nil {
+		c.font = fonts.Acquire("", 24)
+	}
+	drawPlayerHUD(c.font, "P1", &player, AnchorBottomLeft, rl.SkyBlue)
+	drawPlayerHUD(c.font, "P2", &c.Player, AnchorBottomRight, rl.Orange)
+}
+
+func drawPlayerHUD(font *Font, name string, p *Player, anchor Anchor, color rl.Color) {
+	area := ui.Rect(UIRect{Anchor: anchor, Offset: rl.NewVector2(20, 20), Size: rl.NewVector2(260, 36)})
+	DrawRoundedRect(area, 8, rl.Fade(rl.Black, 0.6))
+	font.Draw(name, rl.NewVector2(area.X+8, area.Y+6), 24, color)
+	bar := rl.NewRectangle(area.X+52, area.Y+10, area.Width-64, 16)
+	fill := float32(p.Health) / float32(max(p.MaxHealth, 1))
+	DrawHUDBar(bar, fill, color)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"raylibgo/buildinfo"
+)
+
+const crashReportDir = "crash_reports"
+
+// ReportCrash writes a crash report if the game is panicking, then lets the
+// panic continue. Defer it first thing in main. The report starts with the
+// build, so it can be matched to the exact source.
+func ReportCrash() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	path := filepath.Join(crashReportDir, "crash-"+time.Now().Format("20060102-150405")+".txt")
+	if err := WriteCrashReport(path, r, debug.Stack()); err != nil {
+		log.Printf("crash: %v", err)
+	} else {
+		log.Printf("crash: wrote %s", path)
+	}
+	panic(r)
+}
+
+// WriteCrashReport saves the panic value and stack with the build and where
+// in the game it happened.
+func WriteCrashReport(path string, value any, stack []byte) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Crash report %s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(&b, "Build %s\n", buildinfo.Get())
+	fmt.Fprintf(&b, "%s/%s, %d CPUs\n", runtime.GOOS, runtime.GOARCH, runtime.NumCPU())
+	fmt.Fprintf(&b, "Level %q, scene %T\n\n", currentLevel, scenes.Top())
+	fmt.Fprintf(&b, "panic: %v\n\n%s", value, stack)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	creditsPath = "assets/credits.json"
+
+	creditsScrollSpeed = 60 // pixels per second at normal speed
+	creditsFastSpeed   = 6  // multiplier while fast-forwarding
+)
+
+// CreditEntry is one credited person or asset. License and Source are set
+// for third-party assets that require attribution.
+type CreditEntry struct {
+	Name    string `json:"name"`
+	Role    string `json:"role,omitempty"`
+	License string `json:"license,omitempty"`
+	Source  string `json:"source,omitempty"`
+}
+
+// CreditSection groups entries under a heading
+type CreditSection struct {
+	Title   string        `json:"title"`
+	Entries []CreditEntry `json:"entries"`
+}
+
+// LoadCredits reads the credits file from the asset files.
+func LoadCredits(path string) ([]CreditSection, error) {
+	data, err := ReadAsset(path)
+	if err != nil {
+		return nil, err
+	}
+	var sections []CreditSection
+	if err := json.Unmarshal(data, &sections); err != nil {
+		return nil, fmt.Errorf("credits %s: %w", path, err)
+	}
+	return sections, nil
+}
+
+type creditLine struct {
+	text  string
+	size  float32
+	color rl.Color
+	gap   float32 // space above the line
+}
+
+// CreditsScene scrolls the credits upwards and returns to the main menu
+// when they have passed or the player skips. Holding Down or the gamepad
+// A button speeds the scroll up; Up slows it to a stop.
+type CreditsScene struct {
+	font   *Font
+	lines  []creditLine
+	height float32
+	scroll float32
+}
+
+func (c *CreditsScene) Load(scope *AssetScope) {
+	c.font = scope.Font("", 32)
+	sections, err := LoadCredits(creditsPath)
+	if err != nil {
+		log.Printf("credits: %v", err)
+	}
+	c.lines = creditLines(sections)
+	for _, l := range c.lines {
+		c.height += l.gap + l.size
+	}
+	c.scroll = -screenSize.Y
+}
+
+func (c *CreditsScene) Unload() {}
+
+func creditLines(sections []CreditSection) []creditLine {
+	var lines []creditLine
+	for _, s := range sections {
+		lines = append(lines, creditLine{text: s.Title, size: 40, color: rl.Gold, gap: 64})
+		for _, e := range s.Entries {
+			text := e.Name
+			if e.Role != "" {
+				text = e.Role + " - " + e.Name
+			}
+			lines = append(lines, creditLine{text: text, size: 30, color: rl.RayWhite, gap: 12})
+			if e.License != "" || e.Source != "" {
+				lines = append(lines, creditLine{text: e.License + "  " + e.Source, size: 20, color: rl.LightGray, gap: 4})
+			}
+		}
+	}
+	return lines
+}
+
+func (c *CreditsScene) Update() {
+	speed := float32(1)
+	switch {
+	case rl.IsKeyDown(rl.KeyDown) || rl.IsGamepadButtonDown(gamepadIndex, rl.GamepadButtonRightFaceDown):
+		speed = creditsFastSpeed
+	case rl.IsKeyDown(rl.KeyUp) || rl.IsGamepadButtonDown(gamepadIndex, rl.GamepadButtonLeftFaceUp):
+		speed = 0
+	}
+	c.scroll += creditsScrollSpeed * speed * rl.GetFrameTime()
+
+	skip := rl.IsKeyPressed(rl.KeyEscape) || rl.IsKeyPressed(rl.KeyEnter) ||
+		rl.IsGamepadButtonPressed(gamepadIndex, rl.GamepadButtonRightFaceRight)
+	if skip || c.scroll > c.height {
+		scenes.Replace(NewMainMenuScene())
+	}
+}
+
+func (c *CreditsScene) Draw() {
+	y := -c.scroll
+	for _, l := range c.lines {
+		y += l.gap
+		if y > -l.size && y < screenSize.Y {
+			size := c.font.Measure(l.text, l.size)
+			c.font.Draw(l.text, rl.NewVector2((screenSize.X-size.X)/2, y), l.size, l.color)
+		}
+		y += l.size
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const cursorHideAfter = 3 * time.Second
+
+// CursorContext selects which cursor style is in use
+type CursorContext int
+
+const (
+	CursorGameplay CursorContext = iota
+	CursorMenu
+)
+
+// CursorStyle is a textured cursor with the pixel that marks the click point.
+// An empty Texture uses the system cursor.
+type CursorStyle struct {
+	Texture string
+	Size    int32
+	Hotspot rl.Vector2
+	// AutoHide hides the cursor after a period without mouse movement in fullscreen
+	AutoHide bool
+}
+
+// CursorManager shows the right cursor for the current context and hides it when idle
+type CursorManager struct {
+	styles    map[CursorContext]CursorStyle
+	textures  map[CursorContext]*Texture
+	context   CursorContext
+	lastMove  time.Time
+	hidden    bool
+	HideAfter time.Duration
+}
+
+var cursor = NewCursorManager()
+
+// NewCursorManager creates a CursorManager with the default styles
+func NewCursorManager() *CursorManager {
+	return &CursorManager{
+		styles: map[CursorContext]CursorStyle{
+			CursorGameplay: {
+				Texture:  "assets/images/cursors/crosshair.png",
+				Size:     32,
+				Hotspot:  rl.NewVector2(16, 16),
+				AutoHide: true,
+			},
+			CursorMenu: {},
+		},
+		textures:  make(map[CursorContext]*Texture),
+		lastMove:  time.Now(),
+		HideAfter: cursorHideAfter,
+	}
+}
+
+// SetStyle replaces the style used for a context.
+func (c *CursorManager) SetStyle(ctx CursorContext, style CursorStyle) {
+	if _, ok := c.textures[ctx]; ok {
+		tm.Release(c.styles[ctx].Texture)
+		delete(c.textures, ctx)
+	}
+	c.styles[ctx] = style
+}
+
+// SetContext switches between gameplay and menu cursors.
+func (c *CursorManager) SetContext(ctx CursorContext) {
+	if c.context != ctx {
+		c.context = ctx
+		c.lastMove = time.Now()
+	}
+}
+
+func (c *CursorManager) texture() *Texture {
+	style := c.styles[c.context]
+	if style.Texture == "" {
+		return nil
+	}
+	tex, ok := c.textures[c.context]
+	if !ok {
+		tex = tm.Acquire(style.Texture, style.Size, style.Size)
+		c.textures[c.context] = tex
+	}
+	if !tex.Loaded {
+		return nil
+	}
+	return tex
+}
+
+// Update tracks mouse activity and shows or hides the system cursor.
+func (c *CursorManager) Update() {
+	delta := rl.GetMouseDelta()
+	if delta.X != 0 || delta.Y != 0 || rl.IsMouseButtonPressed(rl.MouseButtonLeft) {
+		c.lastMove = time.Now()
+	}
+
+	style := c.styles[c.context]
+	idle := style.AutoHide && rl.IsWindowFullscreen() && time.Since(c.lastMove) > c.HideAfter
+	c.hidden = idle
+
+	// A custom texture replaces the system cursor
+	wantSystem := !idle && c.texture() == nil
+	if wantSystem && rl.IsCursorHidden() {
+		rl.ShowCursor()
+	} else if !wantSystem && !rl.IsCursorHidden() {
+		rl.HideCursor()
+	}
+}
+
+// UpdateCursor picks the cursor context from the open UI and updates the cursor.
+func UpdateCursor() {
+	if quests.Visible {
+		cursor.SetContext(CursorMenu)
+	} else {
+		cursor.SetContext(CursorGameplay)
+	}
+	cursor.Update()
+}
+
+// Draw renders the custom cursor on top of everything else.
+func (c *CursorManager) Draw() {
+	if c.hidden {
+		return
+	}
+	tex := c.texture()
+	if tex == nil {
+		return
+	}
+	style := c.styles[c.context]
+	pos := rl.Vector2Subtract(rl.GetMousePosition(), style.Hotspot)
+	rl.DrawTextureV(tex.Texture, pos, rl.White)
+	tex.Drawn()
+}
+
+func init() {
+	// The cursor draws over everything, including the optional cheat banner
+	RegisterSystem(&SystemFuncs{ID: "cursor", Requires: []string{"tweaks", "inspector", "audioPanel", "cheats", "tooltips"}, OnUpdate: UpdateCursor, OnDraw: cursor.Draw})
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"raylibgo/tween"
+)
+
+const (
+	cutsceneDefsPath = "assets/cutscenes.json"
+
+	// letterboxHeight is the height of each letterbox bar as a fraction of
+	// the screen
+	letterboxHeight  = 0.12
+	letterboxTime    = 400 * time.Millisecond
+	cutsceneFontSize = 20
+)
+
+// CameraKey is a point on a camera rail: at At milliseconds into the step
+// the camera looks at X, Y with Zoom (0 keeps the previous zoom). Ease
+// names the tween ease, such as "inOutQuad" or "hold" for a cut, that
+// shapes the move from the previous key to this one.
+type CameraKey struct {
+	At   int     `json:"at"`
+	X    float32 `json:"x"`
+	Y    float32 `json:"y"`
+	Zoom float32 `json:"zoom,omitempty"`
+	Ease string  `json:"ease,omitempty"`
+}
+
+// cameraPose is where the camera looks and how far it is zoomed in
+type cameraPose struct {
+	X, Y, Zoom float32
+}
+
+func lerpPose(a, b cameraPose, t float32) cameraPose {
+	return cameraPose{a.X + (b.X-a.X)*t, a.Y + (b.Y-a.Y)*t, a.Zoom + (b.Zoom-a.Zoom)*t}
+}
+
+// CutsceneStep is one beat of a cutscene. Its parts play together: the
+// camera runs along Camera, Speaker says Lines (locale keys) in the
+// dialogue box, a pre-rendered Video plays, and the step lasts at least
+// Wait milliseconds. It ends once all of them have, and then raises flag
+// Sets.
+type CutsceneStep struct {
+	Camera  []CameraKey `json:"camera,omitempty"`
+	Speaker string      `json:"speaker,omitempty"`
+	Lines   []string    `json:"lines,omitempty"`
+	Video   string      `json:"video,omitempty"`
+	Wait    int         `json:"wait,omitempty"`
+	Sets    string      `json:"sets,omitempty"`
+}
+
+// CutsceneDef is a scripted sequence of steps. A cutscene marked Once
+// plays a single time per save.
+type CutsceneDef struct {
+	Steps []CutsceneStep `json:"steps"`
+	Once  bool           `json:"once,omitempty"`
+}
+
+// LoadCutsceneDefs reads cutscene definitions keyed by name.
+func LoadCutsceneDefs(path string) (map[string]CutsceneDef, error) {
+	data, err := ReadAsset(path)
+	if err != nil {
+		return nil, err
+	}
+	var defs map[string]CutsceneDef
+	if err := json.Unmarshal(data, &defs); err != nil {
+		return nil, fmt.Errorf("cutscenes %s: %w", path, err)
+	}
+	for _, def := range defs {
+		for _, step := range def.Steps {
+			slices.SortStableFunc(step.Camera, func(a, b CameraKey) int { return a.At - b.At })
+		}
+	}
+	return defs, nil
+}
+
+// Cutscene plays scripted steps with the camera on rails and letterbox
+// bars. While it plays it holds the dialogue input context and gameplay
+// waits; Escape or the gamepad's Start button skips to the end.
+type Cutscene struct {
+	Name string
+
+	defs     map[string]CutsceneDef
+	def      *CutsceneDef
+	step     int
+	elapsed  time.Duration // into the current step
+	talking  bool
+	watching bool
+	// rail moves pose along the step's camera keys, starting from where
+	// the camera was when the step began
+	rail     *tween.Tween
+	pose     cameraPose
+	baseZoom float32 // restored when it ends
+	bars     float32 // letterbox, 0 hidden to 1 shown
+	slide    *tween.Tween
+	font     *Font
+}
+
+var cutscene = &Cutscene{}
+
+// SetDefs replaces the known cutscenes.
+func (c *Cutscene) SetDefs(defs map[string]CutsceneDef) {
+	c.defs = defs
+}
+
+// playedFlag is the world flag that remembers a Once cutscene was seen
+func playedFlag(name string) string {
+	return "cutscene:" + name
+}
+
+// Play starts the named cutscene. Unknown names, Once cutscenes already
+// seen and starting one while another plays do nothing.
+func (c *Cutscene) Play(name string) {
+	def, ok := c.defs[name]
+	if !ok || c.Active() || (def.Once && worldFlags.Has(playedFlag(name))) {
+		return
+	}
+	c.Name = name
+	c.def = &def
+	c.baseZoom = camera.BaseZoom
+	c.slide = tween.Float(&c.bars, 1, letterboxTime).Ease(tween.OutQuad)
+	inputContexts.Set(c, ContextDialogue, true)
+	c.begin(0)
+}
+
+// Active reports whether a cutscene is playing.
+func (c *Cutscene) Active() bool {
+	return c.def != nil
+}
+
+// begin starts step i, or ends the cutscene after the last.
+func (c *Cutscene) begin(i int) {
+	c.step = i
+	c.elapsed = 0
+	c.pose = cameraPose{camera.Camera.Target.X, camera.Camera.Target.Y, camera.BaseZoom}
+	c.rail = nil
+	if i >= len(c.def.Steps) {
+		c.played()
+		c.finish()
+		return
+	}
+	step := c.def.Steps[i]
+	at, zoom := 0, c.pose.Zoom
+	for _, key := range step.Camera {
+		if key.Zoom != 0 {
+			zoom = key.Zoom
+		}
+		move := tween.To(&c.pose, cameraPose{key.X, key.Y, zoom}, time.Duration(key.At-at)*time.Millisecond, lerpPose).Ease(tween.ByName(key.Ease))
+		at = key.At
+		if c.rail == nil {
+			c.rail = move
+		} else {
+			c.rail.Then(move)
+		}
+	}
+	c.talking = len(step.Lines) > 0
+	dialogue.Start(T(step.Speaker), step.Lines, func() { c.talking = false })
+	if step.Video != "" {
+		c.watching = true
+		videoPlayer.Play(step.Video, func() { c.watching = false })
+	}
+}
+
+// played remembers a Once cutscene was seen.
+func (c *Cutscene) played() {
+	if c.def.Once {
+		worldFlags.Set(playedFlag(c.Name), true)
+	}
+}
+
+// finish ends the cutscene and hands the camera back to the player.
+func (c *Cutscene) finish() {
+	c.def = nil
+	c.rail = nil
+	c.slide = tween.Float(&c.bars, 0, letterboxTime).Ease(tween.InQuad)
+	c.talking = false
+	if c.watching {
+		videoPlayer.Stop()
+	}
+	inputContexts.Set(c, ContextDialogue, false)
+	camera.BaseZoom = c.baseZoom
+	camera.Camera.Zoom = c.baseZoom
+	camera.Follow(CameraTarget())
+	// Don't carry a press held through the cutscene into gameplay
+	live = InputFrame{}
+	coop.live = InputFrame{}
+}
+
+// Skip jumps to the end, raising every flag the remaining steps would.
+func (c *Cutscene) Skip() {
+	if !c.Active() {
+		return
+	}
+	dialogue.Close()
+	for _, step := range c.def.Steps[c.step:] {
+		worldFlags.Set(step.Sets, true)
+	}
+	c.played()
+	c.finish()
+}
+
+// Reset abandons a cutscene without raising its flags, for when gameplay
+// is left.
+func (c *Cutscene) Reset() {
+	if c.Active() {
+		dialogue.Close()
+		c.finish()
+	}
+	c.slide.Stop()
+	c.bars = 0
+}
+
+// Update advances the cutscene and its dialogue. It reports whether
+// gameplay should hold this frame, which includes the frame it ends on.
+func (c *Cutscene) Update() bool {
+	dt := FrameTime()
+	c.slide.Update(dt)
+	if !c.Active() {
+		return false
+	}
+	if inputContexts.KeyPressed(ContextDialogue, rl.KeyEscape) ||
+		inputContexts.ButtonPressed(ContextDialogue, rl.GamepadButtonMiddleRight) {
+		c.Skip()
+		return true
+	}
+	c.elapsed += dt
+	step := c.def.Steps[c.step]
+	railDone := !c.rail.Update(dt)
+	camera.BaseZoom = c.pose.Zoom
+	camera.Camera.Target = rl.NewVector2(c.pose.X, c.pose.Y)
+	camera.UpdateEffects(rl.GetFrameTime())
+	if c.talking {
+		dialogue.Update()
+	}
+	if railDone && !c.talking && !c.watching && c.elapsed >= time.Duration(step.Wait)*time.Millisecond {
+		worldFlags.Set(step.Sets, true)
+		c.begin(c.step + 1)
+	}
+	return true
+}
+
+// HandleEvent plays a level's cutscene when the player enters it.
+func (c *Cutscene) HandleEvent(e Event) {
+	if e.Type != EventAreaEntered {
+		return
+	}
+	if name := assets.manifest.Levels[e.Target].Cutscene; name != "" {
+		c.Play(name)
+	}
+}
+
+// Draw shows the letterbox bars and, while playing, how to skip.
+func (c *Cutscene) Draw() {
+	if c.bars <= 0 {
+		return
+	}
+	h := screenSize.Y * letterboxHeight * c.bars
+	rl.DrawRectangleRec(rl.NewRectangle(0, 0, screenSize.X, h), rl.Black)
+	rl.DrawRectangleRec(rl.NewRectangle(0, screenSize.Y-h, screenSize.X, h), rl.Black)
+	if !c.Active() {
+		return
+	}
+	if c.font == nil {
+		c.font = fonts.Acquire("", cutsceneFontSize)
+	}
+	text := T("cutscene.skip")
+	size := c.font.Measure(text, cutsceneFontSize)
+	c.font.Draw(text, rl.NewVector2(screenSize.X-size.X-24, (h-size.Y)/2), cutsceneFontSize, rl.Fade(rl.LightGray, c.bars))
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	dailyPickupScore = 100
+	dailyEnemyScore  = 250
+	// Seconds under which clearing the challenge earns a time bonus
+	dailyParTime    = 180
+	dailyTimeBonus  = 10 // points per second under par
+	dailyEnemyHP    = 30
+	dailySpawnStart = 300 // keep spawns clear of the player's start
+)
+
+// DailySeed derives the challenge seed from the UTC date, so every player
+// gets the same variation on the same day.
+func DailySeed(date string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte("daily:" + date))
+	return h.Sum64()
+}
+
+// DailyChallengeScene is a seeded run of the level. The date picks the
+// pickup and enemy placement and the weather; the run ends once everything
+// is collected and defeated, or the player falls, and the score goes to the
+// day's leaderboard.
+type DailyChallengeScene struct {
+	GameScene
+
+	date    string
+	start   uint64 // tick the run began
+	elapsed time.Duration
+	done    bool
+	cleared bool
+	score   int64
+	pickups int
+	enemies int
+	font    *Font
+}
+
+// NewDailyChallengeScene creates today's challenge.
+func NewDailyChallengeScene() *DailyChallengeScene {
+	return &DailyChallengeScene{date: time.Now().UTC().Format(time.DateOnly)}
+}
+
+func (d *DailyChallengeScene) Load(scope *AssetScope) {
+	d.font = scope.Font("", 28)
+	currentSlot = 0
+	ResetProgress()
+
+	seed := DailySeed(d.date)
+	rngSource.Seed(seed, seed>>32|seed<<32)
+	d.generate()
+	d.start = clock.Tick
+}
+
+// generate places pickups and enemies along the level and picks the weather,
+// drawing only from the seeded gameplay RNG.
+func (d *DailyChallengeScene) generate() {
+	ground := player.DefPos.Y + PlayerBounds().Height
+	span := screenSize.X - dailySpawnStart - 100
+
+	d.pickups = 6 + rng.IntN(6)
+	for i := range d.pickups {
+		x := dailySpawnStart + rng.Float32()*span
+		y := ground - 40 - rng.Float32()*200
+		pickups = append(pickups, Pickup{Name: fmt.Sprintf("coin%d", i), Pos: rl.NewVector2(x, y)})
+	}
+
+	d.enemies = 2 + rng.IntN(3)
+	for i := range d.enemies {
+		x := dailySpawnStart + rng.Float32()*span
+		e := NewEnemy(fmt.Sprintf("daily%d", i), rl.NewVector2(x, ground-64), rules.EnemyHealthFor(dailyEnemyHP), 40+rng.Float32()*120)
+		e.Speed = 1 + rng.Float32()*2
+		enemies = append(enemies, e)
+	}
+
+	weather.Set(Weather(rng.IntN(int(WeatherWind) + 1)))
+}
+
+func (d *DailyChallengeScene) Update() {
+	if d.done {
+		if rl.IsKeyPressed(rl.KeyEnter) || rl.IsKeyPressed(rl.KeyEscape) ||
+			rl.IsGamepadButtonPressed(gamepadIndex, rl.GamepadButtonRightFaceDown) {
+			scenes.Replace(NewMainMenuScene())
+		}
+		return
+	}
+
+	d.GameScene.Update()
+	d.elapsed = time.Duration(clock.Tick-d.start) * tickDuration
+
+	d.cleared = PickupsLeft() == 0 && EnemiesLeft() == 0
+	if d.cleared || player.Health == 0 {
+		d.finish()
+	}
+}
+
+func (d *DailyChallengeScene) finish() {
+	d.done = true
+	d.score = int64(d.pickups-PickupsLeft())*dailyPickupScore + int64(d.enemies-EnemiesLeft())*dailyEnemyScore
+	if d.cleared {
+		d.score += int64(max(0, dailyParTime-int(d.elapsed.Seconds()))) * dailyTimeBonus
+	}
+	leaderboards.Submit(Submission{
+		Board:  "daily-" + d.date,
+		TimeMs: d.elapsed.Milliseconds(),
+		Score:  d.score,
+	})
+}
+
+func (d *DailyChallengeScene) Draw() {
+	d.GameScene.Draw()
+
+	hud := ui.Rect(UIRect{Anchor: AnchorTop, Offset: rl.NewVector2(0, 20), Size: rl.NewVector2(720, 40)})
+	status := fmt.Sprintf("%s %s  |  %s  |  %d/%d  %d/%d  |  %s",
+		T("daily.title"), d.date, T(weather.Kind.String()),
+		d.pickups-PickupsLeft(), d.pickups, d.enemies-EnemiesLeft(), d.enemies,
+		formatRunTime(d.elapsed))
+	w := d.font.Measure(status, 28).X
+	d.font.Draw(status, rl.NewVector2(hud.X+(hud.Width-w)/2, hud.Y), 28, rl.RayWhite)
+
+	if !d.done {
+		return
+	}
+	panel := ui.Rect(UIRect{Anchor: AnchorCenter, Size: rl.NewVector2(560, 240)})
+	if !DrawNinePatch(SkinPanel, panel, rl.White) {
+		rl.DrawRectangleRec(panel, rl.Fade(rl.Black, 0.85))
+	}
+	title := T("daily.failed")
+	if d.cleared {
+		title = T("daily.cleared")
+	}
+	lines := []string{title, T("daily.score") + ": " + locale.Int(int64(d.score)), formatRunTime(d.elapsed), T("daily.continue")}
+	for i, line := range lines {
+		size := float32(32)
+		if i == 0 {
+			size = 44
+		}
+		w := d.font.Measure(line, size).X
+		d.font.Draw(line, rl.NewVector2(panel.X+(panel.Width-w)/2, panel.Y+24+float32(i)*50), size, rl.RayWhite)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const debugOverlayKey = rl.KeyF3
+
+// DebugSection is a titled block of lines in the debug overlay
+type DebugSection struct {
+	Title string
+	Lines func() []string
+}
+
+// DebugOverlay draws diagnostic sections registered by other systems
+type DebugOverlay struct {
+	Visible  bool
+	sections []DebugSection
+}
+
+var debugOverlay = &DebugOverlay{}
+
+// AddDebugSection registers a block of lines shown while the overlay is visible.
+func AddDebugSection(title string, lines func() []string) {
+	debugOverlay.sections = append(debugOverlay.sections, DebugSection{Title: title, Lines: lines})
+}
+
+// HandleDebugOverlayToggle shows or hides the overlay.
+func HandleDebugOverlayToggle() {
+	if rl.IsKeyPressed(debugOverlayKey) {
+		debugOverlay.Visible = !debugOverlay.Visible
+	}
+}
+
+// Draw renders every section down the left side of the screen.
+func (d *DebugOverlay) Draw() {
+	if !d.Visible {
+		return
+	}
+
+	origin := ui.Rect(UIRect{Anchor: AnchorTopLeft, Offset: rl.NewVector2(20, 80)})
+	x, y := int32(origin.X), int32(origin.Y)
+	for _, section := range d.sections {
+		lines := section.Lines()
+		height := int32(28 + 20*len(lines))
+		rl.DrawRectangle(x-8, y-6, 420, height+4, rl.Fade(rl.Black, 0.6))
+		rl.DrawText(section.Title, x, y, 20, rl.Yellow)
+		y += 26
+		for _, line := range lines {
+			rl.DrawText(line, x+8, y, 16, rl.RayWhite)
+			y += 20
+		}
+		y += 14
+	}
+}
+
+func init() {
+	RegisterSystem(&SystemFuncs{
+		ID:       "debug",
+		Requires: []string{"startupNotices"},
+		OnInit: func() error {
+			AddDebugSection("Systems", func() []string { return []string{strings.Join(systems.Names(), " > ")} })
+			return nil
+		},
+		OnUpdate: func() {
+			HandleDebugOverlayToggle()
+			HandleVRAMEvict()
+			HandlePerfReport()
+		},
+		OnDraw: debugOverlay.Draw,
+	})
+}
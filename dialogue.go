@@ -0,0 +1,124 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	dialogueFontSize = 26
+	dialogueWidth    = 860
+	dialogueLines    = 3 // rows of text the box has room for
+)
+
+// Dialogue shows lines of text one at a time in a box at the bottom of the
+// screen. While it is open it holds the dialogue input context and
+// gameplay waits. Interact, Enter, the gamepad's confirm button or a click
+// move to the next line; Escape or B closes it.
+type Dialogue struct {
+	Speaker string
+	lines   []string
+	line    int
+	onDone  func()
+	font    *Font
+}
+
+var dialogue = &Dialogue{}
+
+// Start opens the dialogue with lines, which are locale keys and may use
+// rich text markup. onDone, if set, runs when it closes.
+func (d *Dialogue) Start(speaker string, lines []string, onDone func()) {
+	if len(lines) == 0 {
+		return
+	}
+	d.Speaker = speaker
+	d.lines = lines
+	d.line = 0
+	d.onDone = onDone
+	inputContexts.Set(d, ContextDialogue, true)
+	events.Publish(Event{Type: EventTextShown, Target: d.text()})
+}
+
+// Active reports whether the dialogue is open.
+func (d *Dialogue) Active() bool {
+	return d.lines != nil
+}
+
+func (d *Dialogue) text() string {
+	return T(d.lines[d.line])
+}
+
+// Close ends the dialogue.
+func (d *Dialogue) Close() {
+	if !d.Active() {
+		return
+	}
+	d.lines = nil
+	inputContexts.Set(d, ContextDialogue, false)
+	if done := d.onDone; done != nil {
+		d.onDone = nil
+		done()
+	}
+}
+
+// Update reads the dialogue's input. It reports whether gameplay should
+// hold this frame, which includes the frame the dialogue closes on so the
+// press that closed it isn't also played.
+func (d *Dialogue) Update() bool {
+	if !d.Active() {
+		return false
+	}
+	if inputContexts.KeyPressed(ContextDialogue, rl.KeyEscape) ||
+		inputContexts.ButtonPressed(ContextDialogue, rl.GamepadButtonRightFaceRight) {
+		d.Close()
+		return true
+	}
+	if d.advancePressed() {
+		d.line++
+		if d.line >= len(d.lines) {
+			d.Close()
+			return true
+		}
+		events.Publish(Event{Type: EventTextShown, Target: d.text()})
+	}
+	return true
+}
+
+func (d *Dialogue) advancePressed() bool {
+	pressed := rl.IsMouseButtonPressed(rl.MouseButtonLeft)
+	for _, key := range append([]int32{rl.KeyEnter}, keyBindings[ActionInteract]...) {
+		pressed = inputContexts.KeyPressed(ContextDialogue, key) || pressed
+	}
+	for _, button := range append([]int32{rl.GamepadButtonRightFaceDown}, gamepadBindings[ActionInteract]...) {
+		pressed = inputContexts.ButtonPressed(ContextDialogue, button) || pressed
+	}
+	return pressed
+}
+
+// Draw shows the current line and who is speaking.
+func (d *Dialogue) Draw() {
+	if !d.Active() {
+		return
+	}
+	if d.font == nil {
+		d.font = fonts.Acquire("", dialogueFontSize)
+	}
+	scale := ui.Scale()
+	lineHeight := d.font.Measure("Ag", dialogueFontSize).Y
+	panel := ui.Rect(UIRect{
+		Anchor: AnchorBottom,
+		Size:   rl.NewVector2(dialogueWidth*scale, lineHeight*dialogueLines+72),
+		Offset: rl.NewVector2(0, 32),
+	})
+	if !DrawNinePatch(SkinDialogue, panel, rl.White) {
+		DrawRoundedRect(panel, 12, rl.Fade(rl.Black, 0.85))
+	}
+	if d.Speaker != "" {
+		d.font.Draw(d.Speaker, rl.NewVector2(panel.X+24, panel.Y+12), dialogueFontSize, rl.Gold)
+	}
+	DrawRichText(d.font, d.text(), rl.NewVector2(panel.X+24, panel.Y+16+lineHeight), dialogueFontSize, panel.Width-48, rl.RayWhite)
+	if d.line < len(d.lines)-1 {
+		// More to come: a small arrow pointing down in the corner
+		tip := rl.NewVector2(panel.X+panel.Width-28, panel.Y+panel.Height-14)
+		rl.DrawTriangle(rl.NewVector2(tip.X-8, tip.Y-10), tip, rl.NewVector2(tip.X+8, tip.Y-10), rl.LightGray)
+	}
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// WaveEnemy is a kind of enemy a spawn director sends. Each spawn spends
+// Cost from the wave's budget; FromWave holds it back until that wave.
+type WaveEnemy struct {
+	Name     string  `json:"name"`
+	Cost     int     `json:"cost,omitempty"`
+	Health   int     `json:"health"`
+	Speed    float32 `json:"speed,omitempty"`
+	FromWave int     `json:"fromWave,omitempty"`
+}
+
+// WaveDef sets up waves of enemies in a level. The first wave has Budget
+// to spend and each after it Growth more; Waves ends the run after that
+// many, or never when zero. Enemies come SpawnGapMs apart, at most
+// MaxAlive at a time, at the spawn Points (where their feet rest) that are
+// off camera. Waves are CooldownMs apart. The longer the player survives
+// the tougher they get: health and speed grow by Escalation for every
+// EscalateMs.
+type WaveDef struct {
+	Enemies    []WaveEnemy  `json:"enemies"`
+	Points     []SpawnPoint `json:"points"`
+	Budget     int          `json:"budget"`
+	Growth     int          `json:"growth,omitempty"`
+	Waves      int          `json:"waves,omitempty"`
+	MaxAlive   int          `json:"maxAlive,omitempty"`
+	SpawnGapMs int          `json:"spawnGapMs,omitempty"`
+	CooldownMs int          `json:"cooldownMs,omitempty"`
+	Escalation float32      `json:"escalation,omitempty"`
+	EscalateMs int          `json:"escalateMs,omitempty"`
+}
+
+const (
+	defaultSpawnGap     = 800 * time.Millisecond
+	defaultWaveCooldown = 5 * time.Second
+	defaultEscalateTime = 30 * time.Second
+	defaultMaxAlive     = 8
+)
+
+func msOr(ms int, def time.Duration) time.Duration {
+	if ms > 0 {
+		return time.Duration(ms) * time.Millisecond
+	}
+	return def
+}
+
+// Director runs waves of enemies for levels whose manifest entry has
+// "waves", which makes them survival areas. It is paced by the scheduler
+// and picks enemies with the gameplay random source, so a run replays
+// exactly.
+type Director struct {
+	Def     *WaveDef
+	Wave    int // current wave, from 1
+	Cleared int // waves cleared
+	Kills   int // enemies defeated during the run
+	// Done is set once the last wave of a run with a wave count is cleared
+	Done bool
+
+	level   string
+	budget  int // left to spend on this wave
+	inWave  bool
+	ticks   uint64 // survived, for escalation
+	spawner *Timer
+	pause   *Timer
+}
+
+var director = &Director{}
+
+// Start begins a run of def in the current level from the first wave,
+// after the cooldown.
+func (d *Director) Start(def *WaveDef) {
+	d.Stop()
+	d.level = currentLevel
+	d.Def = def
+	d.Wave, d.Cleared, d.Kills = 0, 0, 0
+	d.Done = false
+	d.ticks = 0
+	d.pause = scheduler.After(msOr(def.CooldownMs, defaultWaveCooldown), d.startWave)
+}
+
+// WaveState is the run at one tick, for rewinding and saving. The zero
+// value is no run, so the level's waves start afresh.
+type WaveState struct {
+	Def     *WaveDef   `json:"def,omitempty"`
+	Level   string     `json:"level,omitempty"`
+	Wave    int        `json:"wave,omitempty"`
+	Cleared int        `json:"cleared,omitempty"`
+	Kills   int        `json:"kills,omitempty"`
+	Done    bool       `json:"done,omitempty"`
+	Budget  int        `json:"budget,omitempty"`
+	InWave  bool       `json:"inWave,omitempty"`
+	Ticks   uint64     `json:"ticks,omitempty"`
+	Spawner timerState `json:"spawner"`
+	Pause   timerState `json:"pause"`
+}
+
+// Capture returns the state of the run. The wave def is shared, since a
+// run never changes it.
+func (d *Director) Capture() WaveState {
+	return WaveState{
+		Def:     d.Def,
+		Level:   d.level,
+		Wave:    d.Wave,
+		Cleared: d.Cleared,
+		Kills:   d.Kills,
+		Done:    d.Done,
+		Budget:  d.budget,
+		InWave:  d.inWave,
+		Ticks:   d.ticks,
+		Spawner: d.spawner.state(),
+		Pause:   d.pause.state(),
+	}
+}
+
+// Restore puts the run back as captured, scheduling its timers again.
+func (d *Director) Restore(s WaveState) {
+	d.Stop()
+	d.Def = s.Def
+	d.level = s.Level
+	d.Wave, d.Cleared, d.Kills = s.Wave, s.Cleared, s.Kills
+	d.Done = s.Done
+	d.budget = s.Budget
+	d.inWave = s.InWave
+	d.ticks = s.Ticks
+	d.spawner = scheduler.resume(s.Spawner, d.spawn)
+	d.pause = scheduler.resume(s.Pause, d.startWave)
+}
+
+// clone copies the def so a save doesn't share its slices with the run.
+func (def *WaveDef) clone() *WaveDef {
+	if def == nil {
+		return nil
+	}
+	c := *def
+	c.Enemies = slices.Clone(def.Enemies)
+	c.Points = slices.Clone(def.Points)
+	return &c
+}
+
+// Stop ends the run, leaving spawned enemies where they are.
+func (d *Director) Stop() {
+	d.spawner.Cancel()
+	d.pause.Cancel()
+	d.Def = nil
+	d.inWave = false
+}
+
+// Reset stops the run and forgets the level, so the current level's waves
+// start afresh.
+func (d *Director) Reset() {
+	d.Stop()
+	d.level = ""
+}
+
+// Active reports whether a run is going.
+func (d *Director) Active() bool {
+	return d.Def != nil
+}
+
+// Update starts or stops a run on entering a level and ends waves once
+// every enemy is down.
+func (d *Director) Update() {
+	if d.level != currentLevel {
+		if def := assets.manifest.Levels[currentLevel].Waves; def != nil {
+			d.Start(plusWaves(def, ngCycle))
+		} else {
+			d.Stop()
+			d.level = currentLevel
+		}
+	}
+	if !d.Active() || d.Done {
+		return
+	}
+	for i, p := range d.Def.Points {
+		DrawBoxGizmo(rl.NewRectangle(p.X-8, p.Y-8, 16, 16), rl.Orange)
+		DrawTextGizmo(rl.NewVector2(p.X-8, p.Y-26), fmt.Sprint("spawn ", i), rl.Orange)
+	}
+	d.ticks++
+	if d.inWave && d.budget == 0 && EnemiesLeft() == 0 {
+		d.clearWave()
+	}
+}
+
+func (d *Director) startWave() {
+	d.Wave++
+	d.budget = max(d.Def.Budget+d.Def.Growth*(d.Wave-1), 1)
+	d.inWave = true
+	events.Publish(Event{Type: EventWaveStarted, Target: d.level, Amount: d.Wave, Pos: player.Pos})
+	d.spawner = scheduler.Every(msOr(d.Def.SpawnGapMs, defaultSpawnGap), d.spawn)
+	d.spawn()
+}
+
+func (d *Director) clearWave() {
+	d.inWave = false
+	d.Cleared++
+	d.spawner.Cancel()
+	enemies = slices.DeleteFunc(enemies, func(e *Enemy) bool { return e.Defeated })
+	events.Publish(Event{Type: EventWaveCleared, Target: d.level, Amount: d.Wave, Pos: player.Pos})
+	if d.Def.Waves > 0 && d.Wave >= d.Def.Waves {
+		d.Done = true
+		return
+	}
+	d.pause = scheduler.After(msOr(d.Def.CooldownMs, defaultWaveCooldown), d.startWave)
+}
+
+// HandleEvent counts the enemies defeated during a run.
+func (d *Director) HandleEvent(e Event) {
+	if e.Type == EventEnemyDefeated && d.Active() {
+		d.Kills++
+	}
+}
+
+// NextWave returns how long until the next wave starts, or 0 during one.
+func (d *Director) NextWave() time.Duration {
+	return d.pause.Remaining()
+}
+
+// Escalation returns how much tougher enemies are than at the start.
+func (d *Director) Escalation() float32 {
+	if !d.Active() {
+		return 1
+	}
+	every := msOr(d.Def.EscalateMs, defaultEscalateTime)
+	return 1 + d.Def.Escalation*float32(time.Duration(d.ticks)*tickDuration)/float32(every)
+}
+
+// spawn sends the next enemy of the wave, or holds while too many are up.
+func (d *Director) spawn() {
+	if d.budget <= 0 {
+		d.spawner.Cancel()
+		return
+	}
+	maxAlive := d.Def.MaxAlive
+	if maxAlive <= 0 {
+		maxAlive = defaultMaxAlive
+	}
+	if EnemiesLeft() >= maxAlive {
+		return
+	}
+	var choices []WaveEnemy
+	for _, kind := range d.Def.Enemies {
+		if max(kind.Cost, 1) <= d.budget && kind.FromWave <= d.Wave {
+			choices = append(choices, kind)
+		}
+	}
+	pos, ok := d.spawnPoint()
+	if len(choices) == 0 || !ok {
+		// Nothing affordable is left; the wave is fully sent
+		d.budget = 0
+		d.spawner.Cancel()
+		return
+	}
+	kind := choices[rng.IntN(len(choices))]
+	d.budget -= max(kind.Cost, 1)
+
+	scale := d.Escalation()
+	e := NewEnemy(kind.Name, pos, rules.EnemyHealthFor(int(float32(max(kind.Health, 1))*scale)), 0)
+	if kind.Speed > 0 {
+		e.Speed = kind.Speed
+	}
+	e.Speed *= scale
+	e.Pos = rl.NewVector2(pos.X-e.Size.X/2, pos.Y-e.Size.Y)
+	e.Chase = true
+	enemies = append(enemies, e)
+}
+
+// spawnPoint picks a random spawn point out of view, or the one farthest
+// from the player when all are visible.
+func (d *Director) spawnPoint() (rl.Vector2, bool) {
+	if len(d.Def.Points) == 0 {
+		return rl.Vector2{}, false
+	}
+	view := camera.Visible()
+	var hidden []rl.Vector2
+	far, farthest := rl.Vector2{}, float32(-1)
+	for _, p := range d.Def.Points {
+		pos := rl.NewVector2(p.X, p.Y)
+		if !rl.CheckCollisionPointRec(pos, view) {
+			hidden = append(hidden, pos)
+		}
+		if dist := rl.Vector2Distance(pos, player.Pos); dist > farthest {
+			far, farthest = pos, dist
+		}
+	}
+	if len(hidden) > 0 {
+		return hidden[rng.IntN(len(hidden))], true
+	}
+	return far, true
+}
+
+// DebugLines reports the wave in progress.
+func (d *Director) DebugLines() []string {
+	if !d.Active() {
+		return []string{"Director: idle"}
+	}
+	return []string{
+		fmt.Sprintf("Wave: %d  Budget left: %d  Alive: %d", d.Wave, d.budget, EnemiesLeft()),
+		fmt.Sprintf("Escalation: x%.2f  Next: %v", d.Escalation(), d.NextWave().Round(time.Second/10)),
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	dropPreviewSize     = 320
+	dropPreviewCloseKey = rl.KeyBackspace
+)
+
+// Path and texture of the last dropped image, if any
+var (
+	dropPreview    string
+	dropPreviewTex *Texture
+)
+
+// HandleDroppedFiles loads files dragged onto the window. PNGs are shown in a
+// preview panel and GIFs replace the animated background.
+func HandleDroppedFiles() {
+	if rl.IsKeyPressed(dropPreviewCloseKey) {
+		closeDropPreview()
+	}
+	if !rl.IsFileDropped() {
+		return
+	}
+
+	files := rl.LoadDroppedFiles()
+	defer rl.UnloadDroppedFiles()
+
+	for _, path := range files {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".png":
+			closeDropPreview()
+			tex := tm.Acquire(path, 0, 0)
+			if tex.Err != nil {
+				log.Printf("drop: %v", tex.Err)
+				tm.Release(path)
+				continue
+			}
+			dropPreview = path
+			dropPreviewTex = tex
+		case ".gif":
+			swapBackground(path)
+		default:
+			log.Printf("drop: unsupported file %s", path)
+		}
+	}
+}
+
+func closeDropPreview() {
+	if dropPreview != "" {
+		tm.Release(dropPreview)
+		dropPreview = ""
+		dropPreviewTex = nil
+	}
+}
+
+// swapBackground replaces the background with the GIF at path, keeping
+// the current one when it can't be read. The rewind history is cleared,
+// since its snapshots hold frames of the old background.
+func swapBackground(path string) {
+	next, err := LoadGIFAsAnimated(path, background.FrameDelay)
+	if err != nil {
+		log.Printf("drop: %v", err)
+		return
+	}
+	for _, frame := range background.FrameTextures {
+		rl.UnloadTexture(frame.Texture)
+	}
+	background = next
+	if background.FrameDelay == 0 {
+		background.FrameDelay = 100 * time.Millisecond
+	}
+	rewinder.Reset()
+}
+
+// DrawDropPreview shows the dropped image scaled to fit the preview panel.
+func DrawDropPreview() {
+	if dropPreviewTex == nil {
+		return
+	}
+	tex := dropPreviewTex
+
+	w := float32(tex.Texture.Width)
+	h := float32(tex.Texture.Height)
+	scale := min(dropPreviewSize/w, dropPreviewSize/h)
+
+	panel := ui.Rect(UIRect{
+		Anchor: AnchorBottomRight,
+		Offset: rl.NewVector2(20, 20),
+		Size:   rl.NewVector2(dropPreviewSize+20, dropPreviewSize+50),
+	})
+	rl.DrawRectangleRec(panel, rl.Fade(rl.Black, 0.75))
+	rl.DrawText(filepath.Base(dropPreview), int32(panel.X)+10, int32(panel.Y)+8, 18, rl.White)
+
+	dst := rl.NewRectangle(panel.X+10, panel.Y+40, w*scale, h*scale)
+	src := rl.NewRectangle(0, 0, w, h)
+	rl.DrawTexturePro(tex.Texture, src, dst, rl.NewVector2(0, 0), 0, rl.White)
+}
+
+func init() {
+	RegisterSystem(&SystemFuncs{ID: "drop", OnUpdate: HandleDroppedFiles, OnDraw: DrawDropPreview})
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"slices"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	enemyContactDamage   = 10
+	enemyContactCooldown = time.Second
+
+	// Chasing enemies hop onto platforms up to this many tiles high
+	enemyJumpTiles = 2
+	// Ticks between a chasing enemy's path requests
+	enemyRepathTicks = 30
+	enemyFallSpeed   = 6
+)
+
+// Enemy is a simple foe that patrols back and forth around its spawn point
+// and hurts the player on contact
+type Enemy struct {
+	Name      string
+	Pos       rl.Vector2
+	Size      rl.Vector2
+	Health    int
+	MaxHealth int
+	Speed     float32
+	Patrol    float32 // distance walked either side of the spawn point
+	// Chase walks the enemy toward the player instead of patrolling
+	Chase    bool
+	Defeated bool
+
+	origin   float32
+	dir      float32
+	cooldown time.Duration
+
+	// path holds the feet positions still to walk toward the player
+	path    []rl.Vector2
+	pathReq *PathRequest
+	repath  int
+}
+
+// enemies are the foes in the current level
+var enemies []*Enemy
+
+// NewEnemy creates an enemy standing at pos
+func NewEnemy(name string, pos rl.Vector2, health int, patrol float32) *Enemy {
+	return &Enemy{
+		Name:      name,
+		Pos:       pos,
+		Size:      rl.NewVector2(48, 64),
+		Health:    health,
+		MaxHealth: health,
+		Speed:     1.5,
+		Patrol:    patrol,
+		origin:    pos.X,
+		dir:       1,
+	}
+}
+
+// clone copies the enemy without its pending path request, which still
+// reports to the original.
+func (e *Enemy) clone() *Enemy {
+	c := *e
+	c.path = slices.Clone(e.path)
+	c.pathReq = nil
+	return &c
+}
+
+// Hurtbox returns the area that takes damage.
+func (e *Enemy) Hurtbox() rl.Rectangle {
+	return rl.NewRectangle(e.Pos.X, e.Pos.Y, e.Size.X, e.Size.Y)
+}
+
+// Damage lowers health and publishes EventEnemyDefeated when it runs out.
+func (e *Enemy) Damage(amount int) {
+	if e.Defeated || amount <= 0 {
+		return
+	}
+	e.Health = max(e.Health-amount, 0)
+	SpawnDamageNumber(rl.NewVector2(e.Pos.X+e.Size.X/2, e.Pos.Y), amount, false)
+	if e.Health == 0 {
+		e.Defeated = true
+		events.Publish(Event{Type: EventEnemyDefeated, Target: e.Name, Pos: e.Pos})
+	}
+}
+
+// Update walks the patrol route and hurts the player on contact.
+func (e *Enemy) Update() {
+	if e.Defeated {
+		return
+	}
+	if e.Chase {
+		e.requestPath()
+		if !e.followPath() {
+			box, target := e.Hurtbox(), PlayerBounds()
+			dx := (target.X + target.Width/2) - (box.X + box.Width/2)
+			if dx > e.Speed {
+				e.dir = 1
+			} else if dx < -e.Speed {
+				e.dir = -1
+			}
+			if dx > e.Speed || dx < -e.Speed {
+				e.Pos.X += e.Speed * e.dir
+			}
+			e.fall()
+		}
+	} else {
+		e.Pos.X += e.Speed * e.dir
+		if e.Pos.X > e.origin+e.Patrol || e.Pos.X < e.origin-e.Patrol {
+			e.dir = -e.dir
+		}
+	}
+
+	box := e.Hurtbox()
+	center := rl.NewVector2(box.X+box.Width/2, box.Y+box.Height/2)
+	DrawRayGizmo(center, rl.NewVector2(e.dir, 0), box.Width, rl.Orange)
+	if !e.Chase {
+		DrawBoxGizmo(rl.NewRectangle(e.origin-e.Patrol, box.Y+box.Height-4, 2*e.Patrol+box.Width, 4), rl.Yellow)
+	}
+	from := e.feet()
+	for _, p := range e.path {
+		d := rl.Vector2Subtract(p, from)
+		DrawRayGizmo(from, d, rl.Vector2Length(d), rl.SkyBlue)
+		from = p
+	}
+
+	e.cooldown = max(e.cooldown-tickDuration, 0)
+	if e.cooldown == 0 && rl.CheckCollisionRecs(PlayerBounds(), e.Hurtbox()) {
+		DamagePlayer(rules.EnemyDamageFor(enemyContactDamage))
+		e.cooldown = enemyContactCooldown
+	}
+}
+
+// feet returns the middle of the bottom edge of the enemy.
+func (e *Enemy) feet() rl.Vector2 {
+	return rl.NewVector2(e.Pos.X+e.Size.X/2, e.Pos.Y+e.Size.Y)
+}
+
+// requestPath asks the path queue for a route to the player every
+// enemyRepathTicks, on levels with a tile map. The route goes around solid
+// tiles and hops onto platforms a ground enemy can reach.
+func (e *Enemy) requestPath() {
+	t := levelMap()
+	if t == nil {
+		return
+	}
+	if e.repath--; e.repath > 0 || (e.pathReq != nil && !e.pathReq.Done) {
+		return
+	}
+	e.repath = enemyRepathTicks
+
+	// One pixel up, so the search starts in the cell the feet stand in
+	body := PlayerBounds()
+	from := rl.Vector2Subtract(e.feet(), rl.NewVector2(0, 1))
+	to := rl.NewVector2(body.X+body.Width/2, body.Y+body.Height-1)
+	e.pathReq = paths.Request(t, from, to, GroundWalkable(enemyJumpTiles), func(path []rl.Vector2) {
+		e.path = e.path[:0]
+		// The first waypoint is the cell the enemy is already in
+		for _, p := range path[min(1, len(path)):] {
+			e.path = append(e.path, rl.NewVector2(p.X, p.Y+t.TileSize/2))
+		}
+	})
+}
+
+// followPath walks toward the next waypoint, reporting false when there is
+// no path to follow.
+func (e *Enemy) followPath() bool {
+	if len(e.path) == 0 {
+		return false
+	}
+	feet := e.feet()
+	d := rl.Vector2Subtract(e.path[0], feet)
+	if d.X > 0 {
+		e.dir = 1
+	} else if d.X < 0 {
+		e.dir = -1
+	}
+	step := d
+	if dist := rl.Vector2Length(d); dist > e.Speed {
+		step = rl.Vector2Scale(d, e.Speed/dist)
+	} else {
+		e.path = e.path[1:]
+	}
+	e.Pos = rl.Vector2Add(e.Pos, step)
+	return true
+}
+
+// fall drops the enemy onto the ground or the solid tile below it, for
+// when it leaves a platform with no path to follow.
+func (e *Enemy) fall() {
+	feet := e.feet()
+	floor := player.DefPos.Y + PlayerBounds().Height
+	if t := levelMap(); t != nil {
+		cell := t.WorldToTile(feet)
+		for y := cell.Y; y < t.Height; y++ {
+			if t.IsSolid(cell.X, y) {
+				floor = min(floor, float32(y)*t.TileSize)
+				break
+			}
+		}
+	}
+	if feet.Y < floor {
+		e.Pos.Y = min(e.Pos.Y+enemyFallSpeed, floor-e.Size.Y)
+	}
+}
+
+// Draw renders the enemy with a health bar above it.
+func (e *Enemy) Draw() {
+	if e.Defeated {
+		return
+	}
+	box := e.Hurtbox()
+	outline := float32(2)
+	if highContrast {
+		outline = 4
+	}
+	DrawShadowBlob(box, box.Y+box.Height)
+	rl.DrawRectangleRec(box, rl.Maroon)
+	rl.DrawRectangleLinesEx(box, outline, contrast(rl.Red, contrastEnemy))
+	fill := float32(e.Health) / float32(max(e.MaxHealth, 1))
+	DrawHUDBar(rl.NewRectangle(box.X, box.Y-12, box.Width, 7), fill, rl.Red)
+}
+
+// UpdateEnemies updates every enemy in the level.
+func UpdateEnemies() {
+	for _, e := range enemies {
+		e.Update()
+	}
+}
+
+// DrawEnemies draws every enemy in the level.
+func DrawEnemies() {
+	for _, e := range enemies {
+		e.Draw()
+	}
+}
+
+// EnemiesLeft returns how many enemies are still standing.
+func EnemiesLeft() int {
+	n := 0
+	for _, e := range enemies {
+		if !e.Defeated {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// EventType names something that happened in the game
+type EventType string
+
+const (
+	// EventAny subscribes a handler to every event
+	EventAny EventType = "*"
+
+	EventPlayerJumped   EventType = "player_jumped"
+	EventPlayerAttacked EventType = "player_attacked"
+	EventItemCollected  EventType = "item_collected"
+	EventAreaEntered    EventType = "area_entered"
+	EventEnemyHit       EventType = "enemy_hit"
+	EventEnemyDefeated  EventType = "enemy_defeated"
+	EventPlayerDied     EventType = "player_died"
+	EventQuestCompleted EventType = "quest_completed"
+	// EventLevelExited fires when the player walks through an exit; Target is
+	// the level being left
+	EventLevelExited EventType = "level_exited"
+	// EventUIFocused fires when a menu row gains focus; Target is its label
+	EventUIFocused EventType = "ui_focused"
+	// EventTextShown fires when a prompt appears on screen; Target is its text
+	EventTextShown EventType = "text_shown"
+	// EventAnimation fires when the player's animation reaches a frame with
+	// an event; Target is the event's name and Pos the player's feet
+	EventAnimation EventType = "animation"
+	// EventInteracted fires when the player uses an interactable; Target is
+	// its id and Amount 1 if it is now on or open, 2 if off
+	EventInteracted EventType = "interacted"
+	// EventWaveStarted and EventWaveCleared fire as the spawn director begins
+	// and finishes a wave; Target is the level and Amount the wave number
+	EventWaveStarted EventType = "wave_started"
+	EventWaveCleared EventType = "wave_cleared"
+)
+
+// Event carries what happened, what it happened to and how much
+type Event struct {
+	Type   EventType
+	Target string
+	Amount int
+	Pos    rl.Vector2
+}
+
+// EventBus delivers published events to subscribed handlers
+type EventBus struct {
+	handlers map[EventType][]func(Event)
+}
+
+var events = NewEventBus()
+
+// NewEventBus creates and returns an empty EventBus
+func NewEventBus() *EventBus {
+	return &EventBus{
+		handlers: make(map[EventType][]func(Event)),
+	}
+}
+
+// Subscribe registers fn for events of type t, or for all events with EventAny.
+func (b *EventBus) Subscribe(t EventType, fn func(Event)) {
+	b.handlers[t] = append(b.handlers[t], fn)
+}
+
+// Publish calls every handler for the event's type, then the EventAny handlers.
+// An Amount of zero is treated as one.
+func (b *EventBus) Publish(e Event) {
+	if e.Amount == 0 {
+		e.Amount = 1
+	}
+	for _, fn := range b.handlers[e.Type] {
+		fn(e)
+	}
+	for _, fn := range b.handlers[EventAny] {
+		fn(e)
+	}
+}
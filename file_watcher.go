@@ -0,0 +1,145 @@
+package main
+
+import (
+	"log"
+	"os"
+	"time"
+)
+
+// How often watched files are checked for changes
+const watchInterval = 500 * time.Millisecond
+
+type watchedFile struct {
+	path     string
+	modTime  time.Time
+	onChange func(path string)
+}
+
+// FileWatcher polls loose asset files and calls back on the main thread when
+// one changes, so data can be reloaded while the game runs. Files served
+// from the asset pack never change and are not watched.
+type FileWatcher struct {
+	files     []*watchedFile
+	lastCheck time.Time
+}
+
+var watcher = &FileWatcher{}
+
+// Watch calls onChange whenever the file at path (or its mod override) is modified.
+func (w *FileWatcher) Watch(path string, onChange func(path string)) {
+	w.files = append(w.files, &watchedFile{path: path, modTime: fileModTime(path), onChange: onChange})
+}
+
+// Poll checks the watched files at most once per watchInterval.
+func (w *FileWatcher) Poll() {
+	if time.Since(w.lastCheck) < watchInterval {
+		return
+	}
+	w.lastCheck = time.Now()
+
+	for _, f := range w.files {
+		mod := fileModTime(f.path)
+		if mod.IsZero() || mod.Equal(f.modTime) {
+			continue
+		}
+		f.modTime = mod
+		log.Printf("watch: reloading %s", f.path)
+		f.onChange(f.path)
+	}
+}
+
+func fileModTime(path string) time.Time {
+	info, err := os.Stat(mods.Resolve(path))
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// WatchGameData registers hot reload for the data files designers edit.
+// Reloads that swap textures clear the rewind history, since snapshots may
+// point at frames that were just released.
+func WatchGameData() {
+	watcher.Watch(animationDefsPath, func(path string) {
+		defs, err := LoadAnimationDefs(path)
+		if err != nil {
+			log.Printf("watch: %v", err)
+			return
+		}
+		ApplyAnimationDefs(defs)
+		rewinder.Reset()
+	})
+	watcher.Watch(spriteLayerDefsPath, func(path string) {
+		defs, err := LoadSpriteLayerDefs(path)
+		if err != nil {
+			log.Printf("watch: %v", err)
+			return
+		}
+		ApplySpriteLayerDefs(defs)
+		rewinder.Reset()
+	})
+	watcher.Watch(playerSkeletonPath, func(path string) {
+		LoadPlayerSkeleton(path)
+	})
+	watcher.Watch(surfaceDefsPath, func(path string) {
+		defs, err := LoadSurfaceDefs(path)
+		if err != nil {
+			log.Printf("watch: %v", err)
+			return
+		}
+		ApplySurfaceDefs(defs)
+	})
+	watcher.Watch(manifestPath, func(path string) {
+		if err := assets.LoadManifest(path); err != nil {
+			log.Printf("watch: %v", err)
+		}
+		clear(levelMaps)
+		for _, p := range CheckAbilityGates(assets.manifest.Levels, startLevel) {
+			log.Printf("levels: %s", p)
+		}
+	})
+	watcher.Watch(questDefsPath, func(path string) {
+		defs, err := LoadQuestDefs(path)
+		if err != nil {
+			log.Printf("watch: %v", err)
+			return
+		}
+		quests.Register(defs...)
+	})
+	watcher.Watch(tutorialDefsPath, func(path string) {
+		defs, err := LoadTutorialDefs(path)
+		if err != nil {
+			log.Printf("watch: %v", err)
+			return
+		}
+		tutorials.SetDefs(defs)
+	})
+	watcher.Watch(cutsceneDefsPath, func(path string) {
+		defs, err := LoadCutsceneDefs(path)
+		if err != nil {
+			log.Printf("watch: %v", err)
+			return
+		}
+		cutscene.SetDefs(defs)
+	})
+	watcher.Watch(achievementDefsPath, func(path string) {
+		defs, err := LoadAchievementDefs(path)
+		if err != nil {
+			log.Printf("watch: %v", err)
+			return
+		}
+		achievements.SetDefs(defs)
+	})
+}
+
+func init() {
+	RegisterSystem(&SystemFuncs{
+		ID:       "watcher",
+		Requires: []string{"focus"},
+		OnUpdate: func() {
+			if focus.Focused() {
+				watcher.Poll()
+			}
+		},
+	})
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"strconv"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"raylibgo/tween"
+)
+
+const (
+	floatingTextPoolSize = 64
+	floatingTextLife     = 900 * time.Millisecond
+	floatingTextRise     = 1.2 // pixels per tick
+	floatingTextSize     = 28
+)
+
+// FloatingText is a short-lived label that rises from a world position and fades out
+type FloatingText struct {
+	Text   string
+	Pos    rl.Vector2
+	Color  rl.Color
+	Crit   bool
+	Age    time.Duration
+	Active bool
+}
+
+// FloatingTextPool owns a fixed set of floating texts that are reused as they expire
+type FloatingTextPool struct {
+	items []FloatingText
+	font  *Font
+}
+
+var floatingText = NewFloatingTextPool(floatingTextPoolSize)
+
+// NewFloatingTextPool creates a pool holding up to size texts
+func NewFloatingTextPool(size int) *FloatingTextPool {
+	return &FloatingTextPool{items: make([]FloatingText, size)}
+}
+
+// Spawn shows text at pos. When the pool is full the oldest text is replaced.
+func (p *FloatingTextPool) Spawn(pos rl.Vector2, text string, color rl.Color, crit bool) {
+	slot := 0
+	for i := range p.items {
+		if !p.items[i].Active {
+			slot = i
+			break
+		}
+		if p.items[i].Age > p.items[slot].Age {
+			slot = i
+		}
+	}
+
+	p.items[slot] = FloatingText{
+		Text:   text,
+		Pos:    pos,
+		Color:  color,
+		Crit:   crit,
+		Active: true,
+	}
+}
+
+// SpawnDamageNumber shows a damage amount, styled larger and brighter for crits.
+func SpawnDamageNumber(pos rl.Vector2, amount int, crit bool) {
+	text := strconv.Itoa(amount)
+	color := rl.White
+	if crit {
+		text += "!"
+		color = rl.Gold
+	}
+	floatingText.Spawn(pos, text, color, crit)
+}
+
+// Update ages and moves the texts by one simulation tick.
+func (p *FloatingTextPool) Update() {
+	for i := range p.items {
+		t := &p.items[i]
+		if !t.Active {
+			continue
+		}
+		t.Age += tickDuration
+		t.Pos.Y -= floatingTextRise
+		if t.Age >= floatingTextLife {
+			t.Active = false
+		}
+	}
+}
+
+// Draw renders active texts centered on their positions.
+func (p *FloatingTextPool) Draw() {
+	if p.font == nil {
+		p.font = fonts.Acquire("", floatingTextSize)
+	}
+
+	for i := range p.items {
+		t := &p.items[i]
+		if !t.Active {
+			continue
+		}
+
+		progress := float32(t.Age) / float32(floatingTextLife)
+		size := float32(floatingTextSize)
+		if t.Crit {
+			// Pop in large, then settle with a little bounce
+			size *= 2 - 0.6*tween.OutBack(min(progress*4, 1))
+		}
+
+		measure := p.font.Measure(t.Text, size)
+		pos := rl.NewVector2(t.Pos.X-measure.X/2, t.Pos.Y-measure.Y/2)
+		alpha := 1 - tween.InQuad(progress)
+
+		p.font.Draw(t.Text, rl.Vector2Add(pos, rl.NewVector2(2, 2)), size, rl.Fade(rl.Black, alpha*0.6))
+		p.font.Draw(t.Text, pos, size, rl.Fade(t.Color, alpha))
+	}
+}
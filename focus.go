@@ -0,0 +1,102 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// What the music does while the window is in the background
+const (
+	BackgroundAudioKeep = "keep"
+	BackgroundAudioDuck = "duck"
+	BackgroundAudioMute = "mute"
+)
+
+var backgroundAudioModes = []string{BackgroundAudioKeep, BackgroundAudioDuck, BackgroundAudioMute}
+
+const (
+	// Frame rate while unfocused; enough to notice focus coming back
+	backgroundFPS = 10
+	// Fraction of the music volume kept when ducking
+	duckVolume = 0.25
+)
+
+// FocusWatcher reacts to the window losing and regaining focus: it pauses
+// the simulation if configured, ducks or mutes the music and throttles the
+// frame rate, then restores everything on focus gain
+type FocusWatcher struct {
+	focused bool
+	font    *Font
+}
+
+var focus = &FocusWatcher{focused: true}
+
+// Update checks the window's focus and applies the change if it flipped.
+func (f *FocusWatcher) Update() {
+	// Benchmarks run at full speed even in the background or hidden
+	if launch.Bench.Run {
+		return
+	}
+	focused := rl.IsWindowFocused()
+	if focused == f.focused {
+		return
+	}
+	f.focused = focused
+	if focused {
+		f.resume()
+	} else {
+		f.suspend()
+	}
+}
+
+// Focused reports whether the window has focus.
+func (f *FocusWatcher) Focused() bool {
+	return f.focused
+}
+
+// Paused reports whether gameplay should hold while the window is unfocused.
+func (f *FocusWatcher) Paused() bool {
+	return !f.focused && settings.PauseOnFocusLoss
+}
+
+func (f *FocusWatcher) suspend() {
+	perf.Note("focus", "lost")
+	if rl.IsMusicValid(music) {
+		switch settings.BackgroundAudio {
+		case BackgroundAudioDuck:
+			rl.SetMusicVolume(music, mixer.MusicVolume()*duckVolume)
+		case BackgroundAudioMute:
+			rl.SetMusicVolume(music, 0)
+		}
+	}
+	rl.SetTargetFPS(backgroundFPS)
+}
+
+func (f *FocusWatcher) resume() {
+	perf.Note("focus", "gained")
+	if rl.IsMusicValid(music) {
+		rl.SetMusicVolume(music, mixer.MusicVolume())
+	}
+	// Force the refresh rate to be set again
+	pacing.monitor = -1
+	pacing.Detect()
+	// The frames spent in the background must not be caught up on
+	timeControl.Reset()
+}
+
+// Draw dims the screen and says the game is paused while it is.
+func (f *FocusWatcher) Draw() {
+	if !f.Paused() {
+		return
+	}
+	if f.font == nil {
+		f.font = fonts.Acquire("", 48)
+	}
+	rl.DrawRectangle(0, 0, int32(screenSize.X), int32(screenSize.Y), rl.Fade(rl.Black, 0.5))
+	text := T("game.paused")
+	size := f.font.Measure(text, 48)
+	f.font.Draw(text, rl.NewVector2((screenSize.X-size.X)/2, (screenSize.Y-size.Y)/2), 48, rl.RayWhite)
+}
+
+func init() {
+	RegisterSystem(&SystemFuncs{ID: "focus", OnUpdate: focus.Update})
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"raylibgo/i18n"
+)
+
+// FontManager manages loading, tracking, and unloading of fonts
+type FontManager struct {
+	fonts map[string]*Font
+}
+
+// Font holds a Raylib font and related metadata
+type Font struct {
+	Font    rl.Font
+	Size    int32
+	Loaded  bool
+	Err     error
+	builtin bool // raylib's default font, never unloaded
+	refs    int  // reference count
+
+	path      string
+	bold      *Font
+	boldTried bool
+	// baked holds the glyph data of an atlas baked by tools/fontbake
+	baked *bakedGlyphs
+}
+
+// bakedGlyphs back a font loaded from a baked atlas. They live in Go
+// memory, pinned while raylib holds pointers to them, so the font must be
+// unloaded with its texture only.
+type bakedGlyphs struct {
+	recs   []rl.Rectangle
+	glyphs []rl.GlyphInfo
+	pin    runtime.Pinner
+}
+
+// NewFontManager creates and returns a new FontManager
+func NewFontManager() *FontManager {
+	return &FontManager{
+		fonts: make(map[string]*Font),
+	}
+}
+
+// bakedFontDir holds atlases made by tools/fontbake, named <font>_<size>.fnt
+const bakedFontDir = "assets/fonts/baked"
+
+func fontKey(path string, size int32) string {
+	return fmt.Sprintf("%s@%d", path, size)
+}
+
+// Acquire loads the font at the given path and base size if not already loaded,
+// increments the reference count, and returns the font handle. An atlas baked
+// by tools/fontbake for that size is used instead of rasterizing the font.
+// An empty path, or a font that fails to load, falls back to raylib's default font.
+func (fm *FontManager) Acquire(path string, size int32) *Font {
+	key := fontKey(path, size)
+	if handle, ok := fm.fonts[key]; ok {
+		handle.refs++
+		return handle
+	}
+
+	handle := &Font{Size: size, refs: 1, path: path}
+	fm.fonts[key] = handle
+
+	if path != "" {
+		if font, baked, ok := loadBakedFont(path, size); ok {
+			handle.Font = font
+			handle.baked = baked
+			handle.Loaded = true
+			return handle
+		}
+		if data, err := ReadAsset(path); err == nil && len(data) > 0 {
+			font := rl.LoadFontFromMemory(filepath.Ext(path), data, size, nil)
+			if rl.IsFontValid(font) {
+				handle.Font = font
+				handle.Loaded = true
+				return handle
+			}
+		}
+		handle.Err = fmt.Errorf("failed to load font: %s", path)
+	}
+
+	handle.Font = rl.GetFontDefault()
+	handle.Loaded = true
+	handle.builtin = true
+	return handle
+}
+
+// Release decrements the reference count for the font at the given path and size.
+// If the reference count reaches zero, it unloads the font and removes it from the manager.
+func (fm *FontManager) Release(path string, size int32) {
+	key := fontKey(path, size)
+	handle, ok := fm.fonts[key]
+	if !ok {
+		return
+	}
+
+	handle.refs--
+	if handle.refs <= 0 {
+		handle.unload()
+		delete(fm.fonts, key)
+	}
+}
+
+// ReleaseAll unloads all loaded fonts and clears the font map.
+func (fm *FontManager) ReleaseAll() {
+	for key, handle := range fm.fonts {
+		handle.unload()
+		delete(fm.fonts, key)
+	}
+}
+
+func (f *Font) unload() {
+	switch {
+	case !f.Loaded || f.builtin:
+	case f.baked != nil:
+		rl.UnloadTexture(f.Font.Texture)
+		f.baked.pin.Unpin()
+		f.baked = nil
+	default:
+		rl.UnloadFont(f.Font)
+	}
+}
+
+// Draw renders text with the font at the given pixel size, enlarged by the
+// UI text scale. Right-to-left text is shaped and reordered for display first.
+func (f *Font) Draw(text string, pos rl.Vector2, size float32, color rl.Color) {
+	size *= ui.Scale()
+	rl.DrawTextEx(f.Font, i18n.Display(text), pos, size, size/10, color)
+}
+
+// Measure returns the width and height of text drawn at the given size.
+func (f *Font) Measure(text string, size float32) rl.Vector2 {
+	size *= ui.Scale()
+	return rl.MeasureTextEx(f.Font, i18n.Display(text), size, size/10)
+}
+
+// Bold returns the bold variant loaded from the same path with a "-Bold"
+// suffix, e.g. "Roboto-Bold.ttf", or nil when there is none. The variant
+// stays loaded until ReleaseAll.
+func (f *Font) Bold() *Font {
+	if !f.boldTried {
+		f.boldTried = true
+		ext := filepath.Ext(f.path)
+		if bold := strings.TrimSuffix(f.path, ext) + "-Bold" + ext; f.path != "" && AssetExists(bold) {
+			f.bold = fonts.Acquire(bold, f.Size)
+		}
+	}
+	return f.bold
+}
+
+// loadBakedFont loads the pre-rasterized atlas for the font at path and
+// size. Both the .fnt and its page are read through the asset system and
+// decoded in memory, so atlases in an encrypted pack never reach the disk.
+func loadBakedFont(file string, size int32) (rl.Font, *bakedGlyphs, bool) {
+	name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	fnt := fmt.Sprintf("%s/%s_%d.fnt", bakedFontDir, name, size)
+	if !AssetExists(fnt) {
+		return rl.Font{}, nil, false
+	}
+	data, err := ReadAsset(fnt)
+	if err != nil {
+		log.Printf("fonts: %v", err)
+		return rl.Font{}, nil, false
+	}
+	page, font, baked, err := parseBMFont(data)
+	if err != nil {
+		log.Printf("fonts: %s: %v", fnt, err)
+		return rl.Font{}, nil, false
+	}
+	page = path.Join(path.Dir(fnt), page)
+	png, err := ReadAsset(page)
+	if err != nil || len(png) == 0 {
+		log.Printf("fonts: %s: %v", page, err)
+		return rl.Font{}, nil, false
+	}
+	img := rl.LoadImageFromMemory(filepath.Ext(page), png, int32(len(png)))
+	if img == nil || img.Data == nil {
+		return rl.Font{}, nil, false
+	}
+	font.Texture = rl.LoadTextureFromImage(img)
+	rl.UnloadImage(img)
+	if !rl.IsTextureValid(font.Texture) {
+		return rl.Font{}, nil, false
+	}
+
+	baked.pin.Pin(&baked.recs[0])
+	baked.pin.Pin(&baked.glyphs[0])
+	font.Recs = &baked.recs[0]
+	font.Chars = &baked.glyphs[0]
+	return font, baked, true
+}
+
+// parseBMFont reads a text .fnt file as tools/fontbake writes it, returning
+// its page image, the font without its texture and the glyph data.
+func parseBMFont(data []byte) (string, rl.Font, *bakedGlyphs, error) {
+	var (
+		page  string
+		font  rl.Font
+		baked = &bakedGlyphs{}
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		tag, rest, _ := strings.Cut(scanner.Text(), " ")
+		switch tag {
+		case "common":
+			font.BaseSize = bmFontInt(rest, "lineHeight")
+		case "page":
+			if bmFontInt(rest, "id") == 0 {
+				page = bmFontField(rest, "file")
+			}
+		case "char":
+			baked.recs = append(baked.recs, rl.NewRectangle(
+				float32(bmFontInt(rest, "x")), float32(bmFontInt(rest, "y")),
+				float32(bmFontInt(rest, "width")), float32(bmFontInt(rest, "height"))))
+			baked.glyphs = append(baked.glyphs, rl.GlyphInfo{
+				Value:    bmFontInt(rest, "id"),
+				OffsetX:  bmFontInt(rest, "xoffset"),
+				OffsetY:  bmFontInt(rest, "yoffset"),
+				AdvanceX: bmFontInt(rest, "xadvance"),
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", rl.Font{}, nil, err
+	}
+	if page == "" || len(baked.glyphs) == 0 {
+		return "", rl.Font{}, nil, fmt.Errorf("no page or glyphs")
+	}
+	font.CharsCount = int32(len(baked.glyphs))
+	return page, font, baked, nil
+}
+
+// bmFontField returns the value of key in a line of key=value pairs,
+// without quotes.
+func bmFontField(line, key string) string {
+	for _, field := range strings.Fields(line) {
+		if k, v, ok := strings.Cut(field, "="); ok && k == key {
+			return strings.Trim(v, `"`)
+		}
+	}
+	return ""
+}
+
+func bmFontInt(line, key string) int32 {
+	n, _ := strconv.Atoi(bmFontField(line, key))
+	return int32(n)
+}
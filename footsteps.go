@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand/v2"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	surfaceDefsPath = "assets/surfaces.json"
+	// defaultSurface is underfoot where neither a tile nor the level says
+	defaultSurface = "stone"
+	// footstepEvent is the animation event that plays a step
+	footstepEvent = "footstep"
+
+	footstepParticles    = 64
+	footstepParticleLife = 24 // ticks
+	footstepPuff         = 5  // particles per step
+)
+
+// SurfaceDef is how stepping on a surface sounds and looks. A step plays
+// one of Sounds at random and kicks up particles of Color ("#rrggbb" or a
+// color name).
+type SurfaceDef struct {
+	Sounds []string `json:"sounds"`
+	Color  string   `json:"color"`
+}
+
+// surfaceDefs are the known surfaces by name. Terrains and levels refer to
+// them by name; assets/surfaces.json adds to or replaces these.
+var surfaceDefs = map[string]SurfaceDef{
+	"grass": {Sounds: footstepSounds("grass"), Color: "#5a8f3c"},
+	"stone": {Sounds: footstepSounds("stone"), Color: "#9a9a9a"},
+	"metal": {Sounds: footstepSounds("metal"), Color: "#c8d2dc"},
+}
+
+func footstepSounds(surface string) []string {
+	sounds := make([]string, 3)
+	for i := range sounds {
+		sounds[i] = fmt.Sprintf("assets/sounds/footsteps/%s%d.wav", surface, i+1)
+	}
+	return sounds
+}
+
+// LoadSurfaceDefs reads surface definitions keyed by name.
+func LoadSurfaceDefs(path string) (map[string]SurfaceDef, error) {
+	data, err := ReadAsset(path)
+	if err != nil {
+		return nil, err
+	}
+	var defs map[string]SurfaceDef
+	if err := json.Unmarshal(data, &defs); err != nil {
+		return nil, fmt.Errorf("surfaces %s: %w", path, err)
+	}
+	return defs, nil
+}
+
+// ApplySurfaceDefs adds defs to the known surfaces, replacing those with
+// the same name.
+func ApplySurfaceDefs(defs map[string]SurfaceDef) {
+	for name, def := range defs {
+		surfaceDefs[name] = def
+	}
+}
+
+// levelMaps caches the tile maps of levels that have one, by level name
+var levelMaps = make(map[string]*Tilemap)
+
+// levelMap returns the current level's tile map, or nil if it has none.
+func levelMap() *Tilemap {
+	path := assets.manifest.Levels[currentLevel].Map
+	if path == "" {
+		return nil
+	}
+	if t, ok := levelMaps[currentLevel]; ok {
+		return t
+	}
+	t, _, err := LoadLevelFile(path)
+	if err != nil {
+		log.Printf("surfaces: %v", err)
+	}
+	levelMaps[currentLevel] = t
+	return t
+}
+
+// SurfaceAt returns the surface under a world position: the terrain of the
+// tile there or just below it, otherwise the level's ground surface.
+func SurfaceAt(pos rl.Vector2) string {
+	if t := levelMap(); t != nil {
+		cell := t.WorldToTile(rl.NewVector2(pos.X, pos.Y+1))
+		for _, y := range []int{cell.Y, cell.Y - 1} {
+			if surface := terrains[t.TerrainAt(cell.X, y)].Surface; surface != "" {
+				return surface
+			}
+		}
+	}
+	if surface := assets.manifest.Levels[currentLevel].Surface; surface != "" {
+		return surface
+	}
+	return defaultSurface
+}
+
+type footstepParticle struct {
+	pos   rl.Vector2
+	vel   rl.Vector2
+	color rl.Color
+	age   int
+}
+
+// Footsteps plays a step sound and kicks up dust matching the surface
+// under the player whenever their animation reaches a footstep event.
+// Like weather, it has its own random source so gameplay randomness is
+// left alone.
+type Footsteps struct {
+	particles []footstepParticle
+	sounds    map[string]rl.Sound
+	rand      *rand.Rand
+}
+
+var footsteps = &Footsteps{
+	particles: make([]footstepParticle, 0, footstepParticles),
+	sounds:    make(map[string]rl.Sound),
+	rand:      rand.New(rand.NewPCG(3, 4)),
+}
+
+// HandleEvent steps on footstep animation events.
+func (f *Footsteps) HandleEvent(e Event) {
+	if e.Target != footstepEvent || !player.OnGround {
+		return
+	}
+	def, ok := surfaceDefs[SurfaceAt(e.Pos)]
+	if !ok {
+		def = surfaceDefs[defaultSurface]
+	}
+	if len(def.Sounds) > 0 {
+		f.play(def.Sounds[f.rand.IntN(len(def.Sounds))], e.Pos)
+	}
+	color, ok := parseRichColor(def.Color)
+	if !ok {
+		color = rl.Gray
+	}
+	for range footstepPuff {
+		if len(f.particles) == cap(f.particles) {
+			// Full: drop the oldest
+			f.particles = f.particles[:copy(f.particles, f.particles[1:])]
+		}
+		f.particles = append(f.particles, footstepParticle{
+			pos:   e.Pos,
+			vel:   rl.NewVector2((f.rand.Float32()-0.5)*3, -f.rand.Float32()*1.5),
+			color: color,
+		})
+	}
+}
+
+// play plays a sound file from pos, loading it the first time. Missing
+// files stay silent.
+func (f *Footsteps) play(path string, pos rl.Vector2) {
+	sound, ok := f.sounds[path]
+	if !ok {
+		if AssetExists(path) {
+			sound = loadSound(path)
+		}
+		f.sounds[path] = sound
+	}
+	mixer.PlayAt(path, BusSFX, sound, 1, pos)
+}
+
+// Step moves the particles one tick. It touches only the particles, so it
+// may run as a job.
+func (f *Footsteps) Step() {
+	alive := f.particles[:0]
+	for _, p := range f.particles {
+		p.age++
+		if p.age >= footstepParticleLife {
+			continue
+		}
+		p.pos = rl.Vector2Add(p.pos, p.vel)
+		p.vel.Y += 0.08
+		alive = append(alive, p)
+	}
+	f.particles = alive
+}
+
+// Draw renders the particles in world space.
+func (f *Footsteps) Draw() {
+	// Decoration only, like weather
+	if highContrast {
+		return
+	}
+	for _, p := range f.particles {
+		life := 1 - float32(p.age)/footstepParticleLife
+		rl.DrawCircleV(p.pos, 1+2*life, rl.Fade(p.color, 0.7*life))
+	}
+}
+
+// Unload frees the step sounds.
+func (f *Footsteps) Unload() {
+	for _, sound := range f.sounds {
+		if rl.IsSoundValid(sound) {
+			rl.UnloadSound(sound)
+		}
+	}
+	clear(f.sounds)
+}
+
+func init() {
+	RegisterSystem(&SystemFuncs{ID: "footsteps", OnShutdown: footsteps.Unload})
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	fallbackRefreshRate = 60
+	pacingHistory       = 120 // frames kept for pacing stats
+)
+
+// FramePacing matches the target FPS to the refresh rate of the monitor the
+// window is on and records frame times for the debug overlay
+type FramePacing struct {
+	monitor     int
+	refreshRate int
+	frameTimes  [pacingHistory]time.Duration
+	next        int
+	count       int
+}
+
+var pacing = &FramePacing{monitor: -1}
+
+// Detect reads the refresh rate of the window's monitor and sets the target
+// FPS to match when the window has moved to another display.
+func (p *FramePacing) Detect() {
+	monitor := rl.GetCurrentMonitor()
+	if monitor == p.monitor {
+		return
+	}
+	p.monitor = monitor
+	p.refreshRate = rl.GetMonitorRefreshRate(monitor)
+	if p.refreshRate <= 0 {
+		p.refreshRate = fallbackRefreshRate
+	}
+	rl.SetTargetFPS(int32(p.refreshRate))
+}
+
+// Update re-detects the display and records the last frame's duration.
+func (p *FramePacing) Update(frameTime time.Duration) {
+	p.Detect()
+
+	p.frameTimes[p.next] = frameTime
+	p.next = (p.next + 1) % pacingHistory
+	p.count = min(p.count+1, pacingHistory)
+}
+
+// RefreshRate returns the refresh rate of the current monitor.
+func (p *FramePacing) RefreshRate() int {
+	if p.refreshRate <= 0 {
+		return fallbackRefreshRate
+	}
+	return p.refreshRate
+}
+
+// FrameInterval returns the duration of one display refresh.
+func (p *FramePacing) FrameInterval() time.Duration {
+	return time.Second / time.Duration(p.RefreshRate())
+}
+
+// Quantize rounds an animation delay to a whole number of display refreshes,
+// so frame changes land on the same refresh every cycle instead of drifting.
+func (p *FramePacing) Quantize(delay time.Duration) time.Duration {
+	interval := p.FrameInterval()
+	frames := (delay + interval/2) / interval
+	return max(frames, 1) * interval
+}
+
+// Stats returns the average and worst frame time over the recent history and
+// how many frames took longer than one and a half refreshes.
+func (p *FramePacing) Stats() (avg, worst time.Duration, missed int) {
+	if p.count == 0 {
+		return 0, 0, 0
+	}
+	limit := p.FrameInterval() * 3 / 2
+	var total time.Duration
+	for i := 0; i < p.count; i++ {
+		t := p.frameTimes[i]
+		total += t
+		worst = max(worst, t)
+		if t > limit {
+			missed++
+		}
+	}
+	return total / time.Duration(p.count), worst, missed
+}
+
+// DebugLines describes the current pacing for the debug overlay.
+func (p *FramePacing) DebugLines() []string {
+	avg, worst, missed := p.Stats()
+	return []string{
+		fmt.Sprintf("Monitor %d: %s @ %d Hz", p.monitor, rl.GetMonitorName(p.monitor), p.RefreshRate()),
+		fmt.Sprintf("FPS %d (target %d)", rl.GetFPS(), p.RefreshRate()),
+		fmt.Sprintf("Frame avg %.2f ms, worst %.2f ms", ms(avg), ms(worst)),
+		fmt.Sprintf("Late frames %d / %d", missed, p.count),
+		fmt.Sprintf("Sim %d Hz, time scale %.2f", ticksPerSecond, timeControl.Scale()),
+	}
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func init() {
+	RegisterSystem(&SystemFuncs{
+		ID:       "pacing",
+		Requires: []string{"focus"},
+		OnUpdate: func() { pacing.Update(FrameTime()) },
+	})
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"log"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// GameScene runs the gameplay simulation. Escape saves to the current slot
+// and returns to the main menu. Coop adds a second local player; solo
+// sessions are recorded for attract mode and autosaved.
+type GameScene struct {
+	Coop bool
+}
+
+func (g *GameScene) Load(scope *AssetScope) {
+	if g.Coop {
+		coop.Start()
+		return
+	}
+	recorder.Start()
+	autosaver.Start()
+}
+
+func (g *GameScene) Unload() {
+	stats.Save()
+	autosaver.Stop()
+	coop.Stop()
+	pads.Reset()
+	cutscene.Reset()
+	videoPlayer.Stop()
+	dialogue.Close()
+	transition.Reset()
+	SaveLastReplay()
+}
+
+func (g *GameScene) Update() {
+	if videoPlayer.Update() || focus.Paused() || pads.Update() || transition.Update() || cutscene.Update() || dialogue.Update() {
+		return
+	}
+	SampleInput()
+	coop.Sample()
+	HandleRewind()
+	HandleTimeControls()
+	HandleQuestLogToggle()
+	HandleProgressToggle()
+	HandleQuickSave()
+	if inputContexts.KeyPressed(ContextGameplay, rl.KeyEscape) {
+		if currentSlot > 0 {
+			if err := SaveGame(slotPath(currentSlot)); err != nil {
+				log.Printf("save: %v", err)
+			}
+		}
+		scenes.Replace(NewMainMenuScene())
+		return
+	}
+
+	assets.UpdateStreaming(currentLevel, player.Pos)
+	for range timeControl.Steps(FrameTime()) {
+		if hitstop.Consume() {
+			continue
+		}
+		Update()
+	}
+	camera.UpdateEffects(rl.GetFrameTime())
+	UpdateWorldTooltips()
+}
+
+func (g *GameScene) Draw() {
+	if coop.Split() {
+		coop.DrawSplit(g.drawWorld)
+	} else {
+		renderScale.Begin()
+		DrawBackgroundGIF(background)
+		rl.BeginMode2D(camera.View())
+		g.drawWorld()
+		rl.EndMode2D()
+		renderScale.End()
+	}
+	weather.Draw()
+	cutscene.Draw()
+	transition.Draw()
+
+	// UI layer
+	DrawStatusIcons()
+	DrawBossHealthBar()
+	coop.DrawHUD()
+	quests.Draw()
+	tutorials.Draw()
+	speedrun.Draw()
+	DrawLeaderboard()
+	DrawAutosaveIndicator()
+	DrawInteractPrompt()
+	dialogue.Draw()
+	achievements.Draw()
+	videoPlayer.Draw()
+
+	// Debug layer
+	DrawRewindIndicator()
+	DrawTimeControls()
+
+	pads.Draw()
+	focus.Draw()
+}
+
+// drawWorld draws the world, FX and debug layers in world coordinates.
+func (g *GameScene) drawWorld() {
+	// World layer
+	lighting.Collect()
+	DrawPickups()
+	DrawInteractables()
+	DrawEnemies()
+	if activeBoss != nil {
+		activeBoss.Draw()
+	}
+	DrawPlayer()
+	coop.DrawPlayer()
+
+	// FX layer
+	footsteps.Draw()
+	floatingText.Draw()
+
+	// Debug layer
+	DrawColliders()
+	gizmos.Draw()
+}
@@ -1,23 +1,25 @@
 package main
 
 import (
+	"fmt"
 	"image/gif"
-	"os"
 	"time"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
-func LoadGIFAsAnimated(path string, frameDelay time.Duration) *Animated {
-	file, err := os.Open(path)
+// LoadGIFAsAnimated decodes every frame of the GIF at path into its own
+// texture.
+func LoadGIFAsAnimated(path string, frameDelay time.Duration) (*Animated, error) {
+	file, err := OpenAsset(path)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 	defer file.Close()
 
 	gifImg, err := gif.DecodeAll(file)
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("gif %s: %w", path, err)
 	}
 
 	var textures []*Texture
@@ -30,17 +32,18 @@ func LoadGIFAsAnimated(path string, frameDelay time.Duration) *Animated {
 			Texture: tex,
 			Loaded:  true,
 			refs:    1,
+			path:    path,
 		})
 	}
 
 	return &Animated{
 		CurrentFrame:  0,
 		IsPlaying:     true,
-		StartTime:     time.Now(),
+		StartTime:     clock.Now(),
 		FrameDelay:    frameDelay,
 		FrameTextures: textures,
 		Reversing:     false,
-	}
+	}, nil
 }
 
 func DrawBackgroundGIF(g *Animated) {
@@ -49,9 +52,14 @@ func DrawBackgroundGIF(g *Animated) {
 		return
 	}
 
-	updateAnimation(g, true, time.Now())
+	if highContrast {
+		rl.DrawRectangleRec(dst, rl.Black)
+		return
+	}
 
-	frame := g.FrameTextures[g.CurrentFrame]
+	// A rewind or a swap can leave the frame past the end of a shorter GIF
+	frame := g.FrameTextures[min(max(g.CurrentFrame, 0), len(g.FrameTextures)-1)]
 	src := rl.NewRectangle(0, 0, float32(frame.Texture.Width), float32(frame.Texture.Height))
 	rl.DrawTexturePro(frame.Texture, src, dst, rl.NewVector2(0, 0), 0, rl.White)
+	frame.Drawn()
 }
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"sync"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const gizmoFontSize = 14
+
+type gizmoKind int
+
+const (
+	gizmoBox gizmoKind = iota
+	gizmoRay
+	gizmoText
+)
+
+// gizmo is one debug shape in world coordinates
+type gizmo struct {
+	kind     gizmoKind
+	rect     rl.Rectangle
+	from, to rl.Vector2
+	text     string
+	color    rl.Color
+}
+
+// Gizmos collects debug shapes that systems ask for while they update and
+// draws them over the world while the debug overlay is visible. Each
+// simulation tick starts an empty list, so a paused game keeps showing the
+// last tick's shapes. Parallel jobs may add shapes too.
+type Gizmos struct {
+	mu     sync.Mutex
+	shapes []gizmo
+}
+
+var gizmos = &Gizmos{}
+
+func (g *Gizmos) add(s gizmo) {
+	if !debugOverlay.Visible {
+		return
+	}
+	g.mu.Lock()
+	g.shapes = append(g.shapes, s)
+	g.mu.Unlock()
+}
+
+// BeginTick forgets the shapes of the previous tick.
+func (g *Gizmos) BeginTick() {
+	g.mu.Lock()
+	g.shapes = g.shapes[:0]
+	g.mu.Unlock()
+}
+
+// DrawBoxGizmo outlines r.
+func DrawBoxGizmo(r rl.Rectangle, color rl.Color) {
+	gizmos.add(gizmo{kind: gizmoBox, rect: r, color: color})
+}
+
+// DrawRayGizmo draws an arrow length long from from in direction dir.
+func DrawRayGizmo(from, dir rl.Vector2, length float32, color rl.Color) {
+	to := rl.Vector2Add(from, rl.Vector2Scale(rl.Vector2Normalize(dir), length))
+	gizmos.add(gizmo{kind: gizmoRay, from: from, to: to, color: color})
+}
+
+// DrawTextGizmo writes text with its top left corner at pos.
+func DrawTextGizmo(pos rl.Vector2, text string, color rl.Color) {
+	gizmos.add(gizmo{kind: gizmoText, from: pos, text: text, color: color})
+}
+
+// Draw renders the shapes. Call it inside the world camera.
+func (g *Gizmos) Draw() {
+	if !debugOverlay.Visible {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, s := range g.shapes {
+		switch s.kind {
+		case gizmoBox:
+			rl.DrawRectangleLinesEx(s.rect, 1, s.color)
+		case gizmoRay:
+			rl.DrawLineEx(s.from, s.to, 2, s.color)
+			// Arrow head
+			back := rl.Vector2Scale(rl.Vector2Normalize(rl.Vector2Subtract(s.from, s.to)), 8)
+			rl.DrawLineEx(s.to, rl.Vector2Add(s.to, rl.Vector2Rotate(back, 0.5)), 2, s.color)
+			rl.DrawLineEx(s.to, rl.Vector2Add(s.to, rl.Vector2Rotate(back, -0.5)), 2, s.color)
+		case gizmoText:
+			rl.DrawText(s.text, int32(s.from.X), int32(s.from.Y), gizmoFontSize, s.color)
+		}
+	}
+}
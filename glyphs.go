@@ -0,0 +1,199 @@
+package main
+
+import (
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// InputDevice is the kind of device the player last used
+type InputDevice int
+
+const (
+	DeviceKeyboard InputDevice = iota
+	DeviceXbox
+	DevicePlayStation
+	DeviceGamepad
+)
+
+var activeDevice = DeviceKeyboard
+
+var deviceGlyphDirs = map[InputDevice]string{
+	DeviceKeyboard:    "keyboard",
+	DeviceXbox:        "xbox",
+	DevicePlayStation: "playstation",
+	DeviceGamepad:     "gamepad",
+}
+
+// Names used for actions in "[Jump]" markup
+var actionNames = map[string]Action{
+	"Left":     ActionLeft,
+	"Right":    ActionRight,
+	"Jump":     ActionJump,
+	"Attack":   ActionHit,
+	"Interact": ActionInteract,
+	"Dash":     ActionDash,
+}
+
+var keyLabels = map[int32]string{
+	rl.KeySpace: "Space",
+	rl.KeyLeft:  "Left",
+	rl.KeyRight: "Right",
+	rl.KeyUp:    "Up",
+	rl.KeyDown:  "Down",
+	rl.KeyEnter: "Enter",
+}
+
+var buttonLabels = map[InputDevice]map[int32]string{
+	DeviceXbox: {
+		rl.GamepadButtonRightFaceDown:  "A",
+		rl.GamepadButtonRightFaceRight: "B",
+		rl.GamepadButtonRightFaceLeft:  "X",
+		rl.GamepadButtonRightFaceUp:    "Y",
+	},
+	DevicePlayStation: {
+		rl.GamepadButtonRightFaceDown:  "Cross",
+		rl.GamepadButtonRightFaceRight: "Circle",
+		rl.GamepadButtonRightFaceLeft:  "Square",
+		rl.GamepadButtonRightFaceUp:    "Triangle",
+	},
+}
+
+var dpadLabels = map[int32]string{
+	rl.GamepadButtonLeftFaceUp:    "Up",
+	rl.GamepadButtonLeftFaceRight: "Right",
+	rl.GamepadButtonLeftFaceDown:  "Down",
+	rl.GamepadButtonLeftFaceLeft:  "Left",
+}
+
+// glyphTextures caches icons by path, including failed loads
+var glyphTextures = make(map[string]*Texture)
+
+// gamepadDevice guesses the controller family from its reported name.
+func gamepadDevice(name string) InputDevice {
+	name = strings.ToLower(name)
+	switch {
+	case strings.Contains(name, "xbox") || strings.Contains(name, "xinput"):
+		return DeviceXbox
+	case strings.Contains(name, "playstation") || strings.Contains(name, "dualshock") ||
+		strings.Contains(name, "dualsense") || strings.Contains(name, "ps4") || strings.Contains(name, "ps5"):
+		return DevicePlayStation
+	}
+	return DeviceGamepad
+}
+
+// GlyphLabel returns the name of the first binding of the action on the active device.
+func GlyphLabel(action Action) string {
+	if activeDevice == DeviceKeyboard {
+		keys := keyBindings[action]
+		if len(keys) == 0 {
+			return "?"
+		}
+		if label, ok := keyLabels[keys[0]]; ok {
+			return label
+		}
+		if keys[0] < 128 {
+			return string(rune(keys[0]))
+		}
+		return "?"
+	}
+
+	buttons := gamepadBindings[action]
+	if len(buttons) == 0 {
+		return "?"
+	}
+	if label, ok := buttonLabels[activeDevice][buttons[0]]; ok {
+		return label
+	}
+	if label, ok := dpadLabels[buttons[0]]; ok {
+		return label
+	}
+	return buttonLabels[DeviceXbox][buttons[0]]
+}
+
+// GlyphTexture returns the icon for the action on the active device. The
+// handle is not Loaded when the glyph set has no icon for that binding.
+func GlyphTexture(action Action, size int32) *Texture {
+	label := strings.ToLower(GlyphLabel(action))
+	path := "assets/images/glyphs/" + deviceGlyphDirs[activeDevice] + "/" + label + ".png"
+	if tex, ok := glyphTextures[path]; ok {
+		return tex
+	}
+	tex := tm.Acquire(path, size, size)
+	glyphTextures[path] = tex
+	return tex
+}
+
+// DrawGlyphText draws text where "[Action]" tokens are replaced by the
+// glyph of the action's binding, e.g. "Press [Jump] to jump".
+func DrawGlyphText(font *Font, text string, pos rl.Vector2, size float32, color rl.Color) {
+	x := pos.X
+	for text != "" {
+		open := strings.IndexByte(text, '[')
+		end := strings.IndexByte(text, ']')
+		if open < 0 || end < open {
+			font.Draw(text, rl.NewVector2(x, pos.Y), size, color)
+			return
+		}
+
+		if open > 0 {
+			font.Draw(text[:open], rl.NewVector2(x, pos.Y), size, color)
+			x += font.Measure(text[:open], size).X
+		}
+
+		name := text[open+1 : end]
+		if action, ok := actionNames[name]; ok {
+			x += drawGlyph(font, action, rl.NewVector2(x, pos.Y), size)
+		} else {
+			font.Draw(text[open:end+1], rl.NewVector2(x, pos.Y), size, color)
+			x += font.Measure(text[open:end+1], size).X
+		}
+		text = text[end+1:]
+	}
+}
+
+// MeasureGlyphText returns the width DrawGlyphText would use.
+func MeasureGlyphText(font *Font, text string, size float32) float32 {
+	width := float32(0)
+	for text != "" {
+		open := strings.IndexByte(text, '[')
+		end := strings.IndexByte(text, ']')
+		if open < 0 || end < open {
+			return width + font.Measure(text, size).X
+		}
+		width += font.Measure(text[:open], size).X
+		if action, ok := actionNames[text[open+1:end]]; ok {
+			width += glyphWidth(font, action, size)
+		} else {
+			width += font.Measure(text[open:end+1], size).X
+		}
+		text = text[end+1:]
+	}
+	return width
+}
+
+func glyphWidth(font *Font, action Action, size float32) float32 {
+	if GlyphTexture(action, int32(size)).Loaded {
+		return size + 4
+	}
+	return font.Measure(GlyphLabel(action), size*0.8).X + size*0.5 + 4
+}
+
+// drawGlyph draws the icon, or a key-cap box with the label when no icon exists,
+// and returns the width used.
+func drawGlyph(font *Font, action Action, pos rl.Vector2, size float32) float32 {
+	tex := GlyphTexture(action, int32(size))
+	if tex.Loaded {
+		rl.DrawTextureEx(tex.Texture, rl.NewVector2(pos.X+2, pos.Y), 0, size/float32(tex.Texture.Height), rl.White)
+		tex.Drawn()
+		return size + 4
+	}
+
+	label := GlyphLabel(action)
+	labelSize := size * 0.8
+	measure := font.Measure(label, labelSize)
+	box := rl.NewRectangle(pos.X+2, pos.Y, measure.X+size*0.5, size)
+	rl.DrawRectangleRounded(box, 0.3, 6, rl.Fade(rl.White, 0.9))
+	font.Draw(label, rl.NewVector2(box.X+size*0.25, box.Y+(size-measure.Y)/2), labelSize, rl.Black)
+	return box.Width + 4
+}
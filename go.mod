@@ -2,7 +2,10 @@ module raylibgo
 
 go 1.24.2
 
-require github.com/gen2brain/raylib-go/raylib v0.55.1
+require (
+	github.com/gen2brain/mpeg v0.6.1
+	github.com/gen2brain/raylib-go/raylib v0.55.1
+)
 
 require (
 	github.com/ebitengine/purego v0.7.1 // indirect
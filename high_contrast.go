@@ -0,0 +1,55 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	highContrastKey = rl.KeyF2
+
+	// Text grows by this factor while high contrast is on
+	highContrastTextScale = 1.4
+	// Sprite outline width in source texels
+	highContrastOutline = 2
+)
+
+// Colors entities are drawn or outlined with in high contrast mode
+var (
+	contrastPlayer = rl.Yellow
+	contrastEnemy  = rl.Red
+	contrastPickup = rl.White
+)
+
+// highContrast swaps the backdrop for a flat color, outlines entities in
+// bright colors, enlarges text and drops decorative particles, for players
+// with low vision
+var highContrast bool
+
+// SetHighContrast switches the mode and remembers it in the settings.
+func SetHighContrast(on bool) {
+	highContrast = on
+	settings.HighContrast = on
+	ui.TextScale = 1
+	if on {
+		ui.TextScale = highContrastTextScale
+	}
+}
+
+// HandleHighContrastToggle flips the mode when F2 is pressed.
+func HandleHighContrastToggle() {
+	if rl.IsKeyPressed(highContrastKey) {
+		SetHighContrast(!highContrast)
+	}
+}
+
+// contrast returns c, or the high contrast color when the mode is on.
+func contrast(c, high rl.Color) rl.Color {
+	if highContrast {
+		return high
+	}
+	return c
+}
+
+func init() {
+	RegisterSystem(&SystemFuncs{ID: "highContrast", OnUpdate: HandleHighContrastToggle})
+}
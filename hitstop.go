@@ -0,0 +1,40 @@
+package main
+
+import (
+	"time"
+)
+
+// Hitstop freezes the simulation for a few ticks on heavy hits. Exempt
+// systems keep updating so effects don't look frozen.
+type Hitstop struct {
+	ticksLeft int
+	exempt    []func()
+}
+
+var hitstop = &Hitstop{}
+
+// TriggerHitstop freezes the simulation for duration (rounded to whole ticks)
+// and punches the camera zoom in by zoom. Overlapping hits keep the longer freeze.
+func TriggerHitstop(duration time.Duration, zoom float32) {
+	ticks := int((duration + tickDuration - 1) / tickDuration)
+	hitstop.ticksLeft = max(hitstop.ticksLeft, ticks)
+	camera.Punch(zoom)
+}
+
+// Exempt registers an update that keeps running during hitstop.
+func (h *Hitstop) Exempt(update func()) {
+	h.exempt = append(h.exempt, update)
+}
+
+// Consume uses up one simulation tick if a freeze is active, running only the
+// exempt updates, and reports whether it did.
+func (h *Hitstop) Consume() bool {
+	if h.ticksLeft <= 0 {
+		return false
+	}
+	h.ticksLeft--
+	for _, update := range h.exempt {
+		update()
+	}
+	return true
+}
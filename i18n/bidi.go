@@ -0,0 +1,157 @@
+package i18n
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Languages written right to left
+var rtlLanguages = map[string]bool{"ar": true, "he": true, "fa": true, "ur": true}
+
+// IsRTL reports whether lang is written right to left.
+func IsRTL(lang string) bool {
+	return rtlLanguages[strings.SplitN(lang, "-", 2)[0]]
+}
+
+// RTL reports whether the current language is written right to left.
+func (c *Catalog) RTL() bool {
+	return IsRTL(c.lang)
+}
+
+type bidiClass int
+
+const (
+	bidiNeutral bidiClass = iota
+	bidiL                 // left-to-right letters
+	bidiR                 // right-to-left letters
+	bidiNumber            // digits, which read left to right even in RTL text
+)
+
+func classify(r rune) bidiClass {
+	switch {
+	case (r >= 0x0590 && r <= 0x08FF) || (r >= 0xFB1D && r <= 0xFDFF) || (r >= 0xFE70 && r <= 0xFEFF):
+		if r >= 0x0660 && r <= 0x0669 || r >= 0x06F0 && r <= 0x06F9 {
+			return bidiNumber
+		}
+		return bidiR
+	case unicode.IsDigit(r):
+		return bidiNumber
+	case unicode.IsLetter(r):
+		return bidiL
+	}
+	return bidiNeutral
+}
+
+var mirrored = map[rune]rune{
+	'(': ')', ')': '(', '[': ']', ']': '[', '{': '}', '}': '{',
+	'<': '>', '>': '<', '«': '»', '»': '«',
+}
+
+// Display turns logical text into the order its runes are drawn left to
+// right, shaping Arabic on the way. It implements the parts of the Unicode
+// bidi algorithm single-line UI text needs: the paragraph direction comes
+// from the first strong letter, neutrals between runs of the same direction
+// join them, numbers keep their digit order, and brackets in RTL runs are
+// mirrored. Text without RTL letters is returned unchanged.
+func Display(s string) string {
+	runes := []rune(Shape(s))
+	classes := make([]bidiClass, len(runes))
+	base := bidiNeutral
+	hasRTL := false
+	for i, r := range runes {
+		classes[i] = classify(r)
+		if base == bidiNeutral && (classes[i] == bidiL || classes[i] == bidiR) {
+			base = classes[i]
+		}
+		hasRTL = hasRTL || classes[i] == bidiR
+	}
+	if !hasRTL {
+		return s
+	}
+	baseLevel := 0
+	if base == bidiR {
+		baseLevel = 1
+	}
+
+	// Numbers count as the strong direction before them when resolving the
+	// neutrals around them
+	strong := make([]bidiClass, len(runes))
+	last := base
+	for i, c := range classes {
+		switch c {
+		case bidiL, bidiR:
+			last = c
+			strong[i] = c
+		case bidiNumber:
+			strong[i] = last
+		}
+	}
+
+	levels := make([]int, len(runes))
+	for i := range runes {
+		dir := strong[i]
+		if classes[i] == bidiNeutral {
+			dir = neutralDirection(strong, i, base)
+		}
+		switch {
+		case classes[i] == bidiNumber && (baseLevel == 1 || dir == bidiR):
+			levels[i] = 2
+		case dir == bidiR:
+			levels[i] = 1
+		case baseLevel == 1:
+			levels[i] = 2
+		default:
+			levels[i] = 0
+		}
+	}
+
+	for i, r := range runes {
+		if levels[i]%2 == 1 {
+			if m, ok := mirrored[r]; ok {
+				runes[i] = m
+			}
+		}
+	}
+
+	// Reverse every run at or above each level, from the highest down to 1
+	for level := 2; level >= 1; level-- {
+		for i := 0; i < len(runes); {
+			if levels[i] < level {
+				i++
+				continue
+			}
+			j := i
+			for j < len(runes) && levels[j] >= level {
+				j++
+			}
+			for a, b := i, j-1; a < b; a, b = a+1, b-1 {
+				runes[a], runes[b] = runes[b], runes[a]
+				levels[a], levels[b] = levels[b], levels[a]
+			}
+			i = j
+		}
+	}
+	return string(runes)
+}
+
+// neutralDirection resolves a neutral rune: it takes the direction of the
+// strong runes around it when both sides agree, and base otherwise.
+func neutralDirection(strong []bidiClass, i int, base bidiClass) bidiClass {
+	before, after := base, base
+	for j := i - 1; j >= 0; j-- {
+		if strong[j] != bidiNeutral {
+			before = strong[j]
+			break
+		}
+	}
+	for j := i + 1; j < len(strong); j++ {
+		if strong[j] != bidiNeutral {
+			after = strong[j]
+			break
+		}
+	}
+	if before == after {
+		return before
+	}
+	return base
+}
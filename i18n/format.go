@@ -0,0 +1,178 @@
+package i18n
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tables may override these keys to localize number and date formatting
+const (
+	keyGroup   = "format.group"   // thousands separator, e.g. "," or "."
+	keyDecimal = "format.decimal" // decimal separator
+	keyDate    = "format.date"    // time.Format layout for dates
+)
+
+// PluralRule picks the plural category of n: "zero", "one", "two", "few",
+// "many" or "other", following the CLDR categories
+type PluralRule func(n int) string
+
+// pluralRules covers the languages the game may ship; languages without a
+// rule use English's
+var pluralRules = map[string]PluralRule{
+	"en": pluralOneOther,
+	"de": pluralOneOther,
+	"es": pluralOneOther,
+	"fr": func(n int) string {
+		if n == 0 || n == 1 {
+			return "one"
+		}
+		return "other"
+	},
+	"ja": func(int) string { return "other" },
+	"ko": func(int) string { return "other" },
+	"zh": func(int) string { return "other" },
+	"ru": pluralSlavic,
+	"pl": func(n int) string {
+		switch {
+		case n == 1:
+			return "one"
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return "few"
+		}
+		return "many"
+	},
+}
+
+func pluralOneOther(n int) string {
+	if n == 1 {
+		return "one"
+	}
+	return "other"
+}
+
+func pluralSlavic(n int) string {
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return "one"
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return "few"
+	}
+	return "many"
+}
+
+// lookup returns the text for key, if any table has it.
+func (c *Catalog) lookup(key string) (string, bool) {
+	if s, ok := c.tables[c.lang][key]; ok {
+		return s, true
+	}
+	s, ok := c.tables[c.fallback][key]
+	return s, ok
+}
+
+func (c *Catalog) textOr(key, fallback string) string {
+	if s, ok := c.lookup(key); ok {
+		return s
+	}
+	return fallback
+}
+
+// Int formats n with the language's thousands separator.
+func (c *Catalog) Int(n int64) string {
+	digits := strconv.FormatInt(n, 10)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	group := c.textOr(keyGroup, ",")
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(group)
+		}
+		b.WriteRune(d)
+	}
+	return b.String()
+}
+
+// Float formats v with a fixed number of decimals and the language's
+// separators.
+func (c *Catalog) Float(v float64, decimals int) string {
+	scale := math.Pow10(decimals)
+	units := int64(math.Round(math.Abs(v) * scale))
+	whole := c.Int(units / int64(scale))
+	if v < 0 && units != 0 {
+		whole = "-" + whole
+	}
+	if decimals <= 0 {
+		return whole
+	}
+	frac := strconv.FormatInt(units%int64(scale), 10)
+	frac = strings.Repeat("0", decimals-len(frac)) + frac
+	return whole + c.textOr(keyDecimal, ".") + frac
+}
+
+// Timer formats d as m:ss.mmm, as race timers show it, with the language's
+// decimal separator.
+func (c *Catalog) Timer(d time.Duration) string {
+	ms := d.Milliseconds()
+	sign := ""
+	if ms < 0 {
+		sign, ms = "-", -ms
+	}
+	return sign + strconv.FormatInt(ms/60000, 10) + ":" +
+		pad2(ms/1000%60) + c.textOr(keyDecimal, ".") + pad3(ms%1000)
+}
+
+func pad2(n int64) string {
+	if n < 10 {
+		return "0" + strconv.FormatInt(n, 10)
+	}
+	return strconv.FormatInt(n, 10)
+}
+
+func pad3(n int64) string {
+	s := strconv.FormatInt(n, 10)
+	return strings.Repeat("0", 3-len(s)) + s
+}
+
+// Date formats t with the language's date layout.
+func (c *Catalog) Date(t time.Time) string {
+	return t.Format(c.textOr(keyDate, "2006-01-02"))
+}
+
+// Plural returns the text for n using the language's plural rule. The table
+// holds one entry per category, key + ".one", key + ".other" and so on, and
+// "{n}" in the text is replaced with the formatted number. A missing
+// category falls back to ".other".
+func (c *Catalog) Plural(key string, n int) string {
+	rule, ok := pluralRules[c.lang]
+	if !ok {
+		rule = pluralOneOther
+	}
+	text, ok := c.lookup(key + "." + rule(n))
+	if !ok {
+		text = c.T(key + ".other")
+	}
+	return strings.ReplaceAll(text, "{n}", c.Int(int64(n)))
+}
+
+// Ago describes how long before now t was, such as "5 minutes ago", using
+// the plural keys time.minutes_ago, time.hours_ago and time.days_ago. Anything
+// under a minute is time.just_now, and a month or more shows the date.
+func (c *Catalog) Ago(t, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return c.T("time.just_now")
+	case d < time.Hour:
+		return c.Plural("time.minutes_ago", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return c.Plural("time.hours_ago", int(d/time.Hour))
+	case d < 30*24*time.Hour:
+		return c.Plural("time.days_ago", int(d/(24*time.Hour)))
+	}
+	return c.Date(t)
+}
@@ -0,0 +1,110 @@
+// Package i18n holds translated text tables. Tables are flat JSON objects
+// mapping message keys to text, one file per language, e.g. locales/en.json.
+package i18n
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Table maps message keys to the text for one language
+type Table map[string]string
+
+// ParseTable decodes a JSON table.
+func ParseTable(data []byte) (Table, error) {
+	var t Table
+	err := json.Unmarshal(data, &t)
+	return t, err
+}
+
+// LoadDir reads every <lang>.json table in dir, keyed by language.
+func LoadDir(dir string) (map[string]Table, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	tables := make(map[string]Table, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		t, err := ParseTable(data)
+		if err != nil {
+			return nil, err
+		}
+		tables[strings.TrimSuffix(filepath.Base(path), ".json")] = t
+	}
+	return tables, nil
+}
+
+// Catalog looks messages up in the current language, then the fallback
+type Catalog struct {
+	tables   map[string]Table
+	lang     string
+	fallback string
+}
+
+// New creates an empty catalog using fallback for missing messages
+func New(fallback string) *Catalog {
+	return &Catalog{tables: make(map[string]Table), lang: fallback, fallback: fallback}
+}
+
+// Add registers or replaces the table for lang.
+func (c *Catalog) Add(lang string, t Table) {
+	c.tables[lang] = t
+}
+
+// SetLanguage switches the current language.
+func (c *Catalog) SetLanguage(lang string) {
+	c.lang = lang
+}
+
+// Language returns the current language.
+func (c *Catalog) Language() string {
+	return c.lang
+}
+
+// Languages returns the languages with a table, sorted.
+func (c *Catalog) Languages() []string {
+	langs := make([]string, 0, len(c.tables))
+	for lang := range c.tables {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// T returns the text for key, or key itself when no table has it.
+func (c *Catalog) T(key string) string {
+	if s, ok := c.tables[c.lang][key]; ok {
+		return s
+	}
+	if s, ok := c.tables[c.fallback][key]; ok {
+		return s
+	}
+	return key
+}
+
+// Codepoints returns every distinct rune used in the tables' text, sorted,
+// including the presentation forms Shape turns Arabic text into.
+func Codepoints(tables ...Table) []rune {
+	seen := make(map[rune]bool)
+	for _, t := range tables {
+		for _, text := range t {
+			for _, r := range text + Shape(text) {
+				seen[r] = true
+			}
+		}
+	}
+	runes := make([]rune, 0, len(seen))
+	for r := range seen {
+		runes = append(runes, r)
+	}
+	slices.Sort(runes)
+	return runes
+}
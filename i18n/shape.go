@@ -0,0 +1,139 @@
+package i18n
+
+// Fonts carry Arabic letters in their contextual presentation forms, and
+// raylib draws runes one by one without shaping, so Shape picks the right
+// form for each letter itself.
+
+// arabicForms lists each letter's isolated, final, initial and medial forms.
+// Letters with only two forms never join to the following letter.
+var arabicForms = map[rune][]rune{
+	0x0621: {0xFE80},
+	0x0622: {0xFE81, 0xFE82},
+	0x0623: {0xFE83, 0xFE84},
+	0x0624: {0xFE85, 0xFE86},
+	0x0625: {0xFE87, 0xFE88},
+	0x0626: {0xFE89, 0xFE8A, 0xFE8B, 0xFE8C},
+	0x0627: {0xFE8D, 0xFE8E},
+	0x0628: {0xFE8F, 0xFE90, 0xFE91, 0xFE92},
+	0x0629: {0xFE93, 0xFE94},
+	0x062A: {0xFE95, 0xFE96, 0xFE97, 0xFE98},
+	0x062B: {0xFE99, 0xFE9A, 0xFE9B, 0xFE9C},
+	0x062C: {0xFE9D, 0xFE9E, 0xFE9F, 0xFEA0},
+	0x062D: {0xFEA1, 0xFEA2, 0xFEA3, 0xFEA4},
+	0x062E: {0xFEA5, 0xFEA6, 0xFEA7, 0xFEA8},
+	0x062F: {0xFEA9, 0xFEAA},
+	0x0630: {0xFEAB, 0xFEAC},
+	0x0631: {0xFEAD, 0xFEAE},
+	0x0632: {0xFEAF, 0xFEB0},
+	0x0633: {0xFEB1, 0xFEB2, 0xFEB3, 0xFEB4},
+	0x0634: {0xFEB5, 0xFEB6, 0xFEB7, 0xFEB8},
+	0x0635: {0xFEB9, 0xFEBA, 0xFEBB, 0xFEBC},
+	0x0636: {0xFEBD, 0xFEBE, 0xFEBF, 0xFEC0},
+	0x0637: {0xFEC1, 0xFEC2, 0xFEC3, 0xFEC4},
+	0x0638: {0xFEC5, 0xFEC6, 0xFEC7, 0xFEC8},
+	0x0639: {0xFEC9, 0xFECA, 0xFECB, 0xFECC},
+	0x063A: {0xFECD, 0xFECE, 0xFECF, 0xFED0},
+	0x0641: {0xFED1, 0xFED2, 0xFED3, 0xFED4},
+	0x0642: {0xFED5, 0xFED6, 0xFED7, 0xFED8},
+	0x0643: {0xFED9, 0xFEDA, 0xFEDB, 0xFEDC},
+	0x0644: {0xFEDD, 0xFEDE, 0xFEDF, 0xFEE0},
+	0x0645: {0xFEE1, 0xFEE2, 0xFEE3, 0xFEE4},
+	0x0646: {0xFEE5, 0xFEE6, 0xFEE7, 0xFEE8},
+	0x0647: {0xFEE9, 0xFEEA, 0xFEEB, 0xFEEC},
+	0x0648: {0xFEED, 0xFEEE},
+	0x0649: {0xFEEF, 0xFEF0},
+	0x064A: {0xFEF1, 0xFEF2, 0xFEF3, 0xFEF4}}
+
+const (
+	arabicLam     = 0x0644
+	arabicTatweel = 0x0640
+)
+
+// lamAlef maps an alef variant to the isolated and final forms of its
+// ligature with a preceding lam
+var lamAlef = map[rune][2]rune{
+	0x0622: {0xFEF5, 0xFEF6},
+	0x0623: {0xFEF7, 0xFEF8},
+	0x0625: {0xFEF9, 0xFEFA},
+	0x0627: {0xFEFB, 0xFEFC},
+}
+
+// isTransparent reports whether r is a combining mark that doesn't affect
+// how its neighbours join.
+func isTransparent(r rune) bool {
+	return (r >= 0x064B && r <= 0x065F) || r == 0x0670
+}
+
+// joinsNext reports whether r connects to the letter after it.
+func joinsNext(r rune) bool {
+	return len(arabicForms[r]) == 4 || r == arabicTatweel
+}
+
+// joinsPrev reports whether r connects to the letter before it.
+func joinsPrev(r rune) bool {
+	return len(arabicForms[r]) >= 2 || r == arabicTatweel
+}
+
+// Shape replaces Arabic letters with their contextual presentation forms
+// and joins lam-alef pairs into ligatures. Other text is returned unchanged.
+func Shape(s string) string {
+	runes := []rune(s)
+	if !hasArabic(runes) {
+		return s
+	}
+
+	// neighbour returns the closest rune in direction dir that isn't a mark
+	neighbour := func(i, dir int) rune {
+		for j := i + dir; j >= 0 && j < len(runes); j += dir {
+			if !isTransparent(runes[j]) {
+				return runes[j]
+			}
+		}
+		return 0
+	}
+
+	out := make([]rune, 0, len(runes))
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		forms, ok := arabicForms[r]
+		if !ok {
+			out = append(out, r)
+			continue
+		}
+		prev := joinsNext(neighbour(i, -1))
+
+		if r == arabicLam && i+1 < len(runes) {
+			if lig, ok := lamAlef[runes[i+1]]; ok {
+				if prev {
+					out = append(out, lig[1])
+				} else {
+					out = append(out, lig[0])
+				}
+				i++
+				continue
+			}
+		}
+
+		next := joinsNext(r) && joinsPrev(neighbour(i, 1))
+		switch {
+		case prev && next:
+			out = append(out, forms[3])
+		case next:
+			out = append(out, forms[2])
+		case prev && len(forms) > 1:
+			out = append(out, forms[1])
+		default:
+			out = append(out, forms[0])
+		}
+	}
+	return string(out)
+}
+
+func hasArabic(runes []rune) bool {
+	for _, r := range runes {
+		if r >= 0x0600 && r <= 0x06FF {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"container/list"
+	"fmt"
+	"image"
+	"sync"
+
+	"raylibgo/imaging"
+)
+
+// imageKey identifies one decoded and resized image
+type imageKey struct {
+	source        string
+	width, height int32
+	filter        imaging.Filter
+}
+
+type imageCacheEntry struct {
+	key imageKey
+	img *image.NRGBA
+}
+
+// ImageCache keeps decoded images in RAM so textures released by a scene can
+// be uploaded again without touching the disk. The least recently used images
+// are dropped once the total size passes the limit. Cached images are shared
+// and must not be modified. It is safe for concurrent use.
+type ImageCache struct {
+	mu      sync.Mutex
+	limit   int64
+	size    int64
+	order   *list.List // front is most recently used
+	entries map[imageKey]*list.Element
+
+	hits, misses int
+}
+
+// NewImageCache creates a cache holding up to limit bytes of pixels
+func NewImageCache(limit int64) *ImageCache {
+	return &ImageCache{
+		limit:   limit,
+		order:   list.New(),
+		entries: make(map[imageKey]*list.Element),
+	}
+}
+
+// Get returns the cached image for key and marks it as recently used.
+func (c *ImageCache) Get(key imageKey) (*image.NRGBA, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.order.MoveToFront(el)
+	return el.Value.(*imageCacheEntry).img, true
+}
+
+// Put stores img under key, evicting old images to stay within the limit.
+// Images larger than the whole limit are not cached.
+func (c *ImageCache) Put(key imageKey, img *image.NRGBA) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	bytes := int64(len(img.Pix))
+	if bytes > c.limit {
+		return
+	}
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+	c.entries[key] = c.order.PushFront(&imageCacheEntry{key: key, img: img})
+	c.size += bytes
+	c.evict()
+}
+
+// SetLimit changes the RAM limit in bytes; zero disables the cache.
+func (c *ImageCache) SetLimit(limit int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.limit = limit
+	c.evict()
+}
+
+// Clear drops every cached image.
+func (c *ImageCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	clear(c.entries)
+	c.size = 0
+}
+
+func (c *ImageCache) evict() {
+	for c.size > c.limit {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *ImageCache) remove(el *list.Element) {
+	entry := c.order.Remove(el).(*imageCacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= int64(len(entry.img.Pix))
+}
+
+// DebugLines describes cache use for the debug overlay.
+func (c *ImageCache) DebugLines() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return []string{
+		fmt.Sprintf("%.1f / %.0f MB, %d images", float64(c.size)/megabyte, float64(c.limit)/megabyte, c.order.Len()),
+		fmt.Sprintf("hits %d  misses %d", c.hits, c.misses),
+	}
+}
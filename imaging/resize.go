@@ -0,0 +1,175 @@
+// Package imaging resizes decoded images on the CPU. It has no raylib
+// dependency so it can run on worker goroutines and in offline tools.
+package imaging
+
+import (
+	"image"
+	"math"
+)
+
+// Filter selects the resampling kernel
+type Filter int
+
+const (
+	Bilinear Filter = iota
+	Nearest
+	Lanczos
+)
+
+// ParseFilter maps manifest names to filters. Unknown or empty names use Bilinear.
+func ParseFilter(name string) Filter {
+	switch name {
+	case "nearest":
+		return Nearest
+	case "lanczos":
+		return Lanczos
+	}
+	return Bilinear
+}
+
+// Resize scales src to w x h with the given filter.
+func Resize(src *image.NRGBA, w, h int, filter Filter) *image.NRGBA {
+	switch filter {
+	case Nearest:
+		return resizeNearest(src, w, h)
+	case Lanczos:
+		return resizeLanczos(src, w, h)
+	}
+	return resizeBilinear(src, w, h)
+}
+
+func resizeNearest(src *image.NRGBA, w, h int) *image.NRGBA {
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	sw, sh := src.Rect.Dx(), src.Rect.Dy()
+	for y := 0; y < h; y++ {
+		sy := min(y*sh/h, sh-1)
+		for x := 0; x < w; x++ {
+			sx := min(x*sw/w, sw-1)
+			copy(dst.Pix[dst.PixOffset(x, y):][:4], src.Pix[src.PixOffset(sx, sy):][:4])
+		}
+	}
+	return dst
+}
+
+func resizeBilinear(src *image.NRGBA, w, h int) *image.NRGBA {
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	sw, sh := src.Rect.Dx(), src.Rect.Dy()
+	xRatio := float32(sw) / float32(w)
+	yRatio := float32(sh) / float32(h)
+
+	for y := 0; y < h; y++ {
+		fy := max(0, (float32(y)+0.5)*yRatio-0.5)
+		y0 := min(int(fy), sh-1)
+		y1 := min(y0+1, sh-1)
+		ty := fy - float32(y0)
+
+		for x := 0; x < w; x++ {
+			fx := max(0, (float32(x)+0.5)*xRatio-0.5)
+			x0 := min(int(fx), sw-1)
+			x1 := min(x0+1, sw-1)
+			tx := fx - float32(x0)
+
+			p00 := src.PixOffset(x0, y0)
+			p10 := src.PixOffset(x1, y0)
+			p01 := src.PixOffset(x0, y1)
+			p11 := src.PixOffset(x1, y1)
+			d := dst.PixOffset(x, y)
+			for c := 0; c < 4; c++ {
+				top := float32(src.Pix[p00+c])*(1-tx) + float32(src.Pix[p10+c])*tx
+				bottom := float32(src.Pix[p01+c])*(1-tx) + float32(src.Pix[p11+c])*tx
+				dst.Pix[d+c] = uint8(top*(1-ty) + bottom*ty + 0.5)
+			}
+		}
+	}
+	return dst
+}
+
+const lanczosLobes = 3
+
+func lanczos(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	if x <= -lanczosLobes || x >= lanczosLobes {
+		return 0
+	}
+	px := math.Pi * x
+	return lanczosLobes * math.Sin(px) * math.Sin(px/lanczosLobes) / (px * px)
+}
+
+// resizeLanczos runs a separable Lanczos-3 filter, horizontally then vertically.
+// When downscaling the kernel is widened so every source pixel contributes.
+func resizeLanczos(src *image.NRGBA, w, h int) *image.NRGBA {
+	sw, sh := src.Rect.Dx(), src.Rect.Dy()
+
+	// Horizontal pass into float rows
+	tmp := make([]float64, w*sh*4)
+	weights := lanczosWeights(sw, w)
+	for y := 0; y < sh; y++ {
+		for x := 0; x < w; x++ {
+			var acc [4]float64
+			for _, wt := range weights[x] {
+				p := src.PixOffset(wt.index, y)
+				for c := 0; c < 4; c++ {
+					acc[c] += float64(src.Pix[p+c]) * wt.weight
+				}
+			}
+			copy(tmp[(y*w+x)*4:], acc[:])
+		}
+	}
+
+	// Vertical pass into the destination
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	weights = lanczosWeights(sh, h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var acc [4]float64
+			for _, wt := range weights[y] {
+				p := (wt.index*w + x) * 4
+				for c := 0; c < 4; c++ {
+					acc[c] += tmp[p+c] * wt.weight
+				}
+			}
+			d := dst.PixOffset(x, y)
+			for c := 0; c < 4; c++ {
+				dst.Pix[d+c] = uint8(max(0, min(255, math.Round(acc[c]))))
+			}
+		}
+	}
+	return dst
+}
+
+type tap struct {
+	index  int
+	weight float64
+}
+
+// lanczosWeights returns the normalized source taps for every destination pixel.
+func lanczosWeights(srcSize, dstSize int) [][]tap {
+	scale := float64(srcSize) / float64(dstSize)
+	support := float64(lanczosLobes) * max(scale, 1)
+	step := max(scale, 1)
+
+	weights := make([][]tap, dstSize)
+	for i := range weights {
+		center := (float64(i)+0.5)*scale - 0.5
+		lo := int(math.Floor(center - support))
+		hi := int(math.Ceil(center + support))
+
+		var taps []tap
+		total := 0.0
+		for j := lo; j <= hi; j++ {
+			wt := lanczos((float64(j) - center) / step)
+			if wt == 0 {
+				continue
+			}
+			taps = append(taps, tap{index: max(0, min(j, srcSize-1)), weight: wt})
+			total += wt
+		}
+		for k := range taps {
+			taps[k].weight /= total
+		}
+		weights[i] = taps
+	}
+	return weights
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Action is a game command that can be bound to one or more keys
+type Action int
+
+const (
+	ActionLeft Action = iota
+	ActionRight
+	ActionJump
+	ActionHit
+	ActionInteract
+	ActionDash
+	actionCount
+)
+
+// InputFrame is the input sampled for a single simulation tick.
+// Each action occupies one bit in Down and Pressed. Aim is the attack
+// direction when attacks are aimed, see AimDir.
+type InputFrame struct {
+	Down    uint32
+	Pressed uint32
+	Aim     uint32
+}
+
+// keyBindings are the live keys for each action, set from an InputProfile
+var keyBindings = cloneBindings(inputProfiles[0].Keys)
+
+const gamepadDeadzone = 0.4
+
+// gamepadIndex is the main player's pad, which menus also read. It moves
+// when that player's pad disconnects and they pick up another one.
+var gamepadIndex int32 = 0
+
+// gamepadBindings are the live gamepad buttons for each action
+var gamepadBindings = cloneBindings(inputProfiles[0].Buttons)
+
+// Stick directions treated as held actions
+var gamepadAxisBindings = map[Action]float32{
+	ActionLeft:  -1,
+	ActionRight: 1,
+}
+
+// input is the frame the simulation is currently running on
+var input InputFrame
+
+// live is the latest keyboard sample. Presses stay pending until a tick
+// consumes them, so slowed or skipped ticks never drop a jump or attack.
+var live InputFrame
+
+// SampleInput polls the keyboard once per rendered frame.
+func SampleInput() {
+	frame := PollInput()
+	live.Down = frame.Down
+	live.Pressed |= frame.Pressed
+	live.Aim = frame.Aim
+}
+
+// TakeInput returns the live input for one tick and clears pending presses.
+func TakeInput() InputFrame {
+	frame := live
+	live.Pressed = 0
+	return frame
+}
+
+// InputBinding maps actions to keys and one gamepad for a single player
+type InputBinding struct {
+	Keys    map[Action][]int32
+	Gamepad int32
+
+	// lastAxisDown remembers which stick actions were held, so a fresh push counts as a press
+	lastAxisDown uint32
+	// usingPad is set while the gamepad, not the keyboard, was used last
+	usingPad bool
+}
+
+// soloBinding gives a single player the whole keyboard and the first gamepad
+var soloBinding = &InputBinding{Keys: keyBindings, Gamepad: gamepadIndex}
+
+// playerBinding is the binding the main player's input is read from
+var playerBinding = soloBinding
+
+// PollInput samples the main player's binding.
+func PollInput() InputFrame {
+	return playerBinding.Poll()
+}
+
+// Poll samples the keyboard and gamepad for every bound action and updates
+// the active input device.
+func (b *InputBinding) Poll() InputFrame {
+	var frame InputFrame
+	if !inputContexts.Allows(ContextGameplay) {
+		// Typing into a text field or an open menu must not move or attack
+		return frame
+	}
+	for action := Action(0); action < actionCount; action++ {
+		for _, key := range b.Keys[action] {
+			if inputContexts.Consumed(key) {
+				continue
+			}
+			if rl.IsKeyDown(key) {
+				frame.Down |= 1 << action
+			}
+			if rl.IsKeyPressed(key) {
+				frame.Pressed |= 1 << action
+				b.usingPad = false
+			}
+		}
+	}
+	if rl.GetKeyPressed() != 0 {
+		activeDevice = DeviceKeyboard
+	}
+	frame.Aim = b.pollAim()
+
+	if !rl.IsGamepadAvailable(b.Gamepad) {
+		return frame
+	}
+
+	padUsed := false
+	var axisDown uint32
+	for action := Action(0); action < actionCount; action++ {
+		for _, button := range gamepadBindings[action] {
+			if rl.IsGamepadButtonDown(b.Gamepad, button) {
+				frame.Down |= 1 << action
+			}
+			if rl.IsGamepadButtonPressed(b.Gamepad, button) {
+				frame.Pressed |= 1 << action
+				padUsed = true
+			}
+		}
+		if dir, ok := gamepadAxisBindings[action]; ok {
+			if rl.GetGamepadAxisMovement(b.Gamepad, rl.GamepadAxisLeftX)*dir > gamepadDeadzone {
+				axisDown |= 1 << action
+			}
+		}
+	}
+	frame.Down |= axisDown
+	frame.Pressed |= axisDown &^ b.lastAxisDown
+	if axisDown&^b.lastAxisDown != 0 {
+		padUsed = true
+	}
+	b.lastAxisDown = axisDown
+
+	if padUsed {
+		b.usingPad = true
+		activeDevice = gamepadDevice(rl.GetGamepadName(b.Gamepad))
+	}
+	return frame
+}
+
+// IsDown reports whether the action is held this tick.
+func (f InputFrame) IsDown(a Action) bool {
+	return f.Down&(1<<a) != 0
+}
+
+// IsPressed reports whether the action started this tick.
+func (f InputFrame) IsPressed(a Action) bool {
+	return f.Pressed&(1<<a) != 0
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// InputContext is a layer of the game that reads input, in priority order:
+// while a context is open, handlers in lower contexts see no input at all.
+type InputContext int
+
+const (
+	ContextGameplay InputContext = iota
+	ContextMenu
+	ContextDialogue
+	ContextConsole
+)
+
+var inputContextNames = []string{"gameplay", "menu", "dialogue", "console"}
+
+func (c InputContext) String() string {
+	return inputContextNames[c]
+}
+
+// InputContexts tracks which contexts are open and which presses have been
+// consumed this frame, so one press drives one handler only. Gameplay is
+// always open underneath the others.
+type InputContexts struct {
+	open     map[any]InputContext // by owner, so each UI opens and closes its own
+	consumed map[int32]bool       // keys and, offset by gamepadConsumeBase, buttons
+}
+
+// gamepadConsumeBase keeps consumed gamepad buttons apart from key codes
+const gamepadConsumeBase = 1 << 16
+
+var inputContexts = &InputContexts{
+	open:     make(map[any]InputContext),
+	consumed: make(map[int32]bool),
+}
+
+// BeginFrame forgets last frame's consumed presses.
+func (c *InputContexts) BeginFrame() {
+	clear(c.consumed)
+}
+
+// Set opens or closes ctx for owner. Setting it again is harmless.
+func (c *InputContexts) Set(owner any, ctx InputContext, open bool) {
+	if open {
+		c.open[owner] = ctx
+	} else {
+		delete(c.open, owner)
+	}
+}
+
+// Top returns the highest open context.
+func (c *InputContexts) Top() InputContext {
+	top := ContextGameplay
+	for _, ctx := range c.open {
+		top = max(top, ctx)
+	}
+	return top
+}
+
+// Allows reports whether handlers in ctx may read input.
+func (c *InputContexts) Allows(ctx InputContext) bool {
+	return ctx >= c.Top()
+}
+
+// Consumed reports whether key was already taken this frame.
+func (c *InputContexts) Consumed(key int32) bool {
+	return c.consumed[key]
+}
+
+// Consume marks key as handled for the rest of the frame.
+func (c *InputContexts) Consume(key int32) {
+	c.consumed[key] = true
+}
+
+// KeyPressed reports, and consumes, a press of key for a handler in ctx.
+func (c *InputContexts) KeyPressed(ctx InputContext, key int32) bool {
+	return c.take(ctx, key, rl.IsKeyPressed(key))
+}
+
+// KeyHit is KeyPressed that also repeats while the key is held.
+func (c *InputContexts) KeyHit(ctx InputContext, key int32) bool {
+	return c.take(ctx, key, keyHit(key))
+}
+
+// ButtonPressed reports, and consumes, a press of button on the main
+// player's gamepad for a handler in ctx.
+func (c *InputContexts) ButtonPressed(ctx InputContext, button int32) bool {
+	return c.take(ctx, gamepadConsumeBase+button, rl.IsGamepadButtonPressed(gamepadIndex, button))
+}
+
+func (c *InputContexts) take(ctx InputContext, id int32, pressed bool) bool {
+	if !pressed || !c.Allows(ctx) || c.consumed[id] {
+		return false
+	}
+	c.consumed[id] = true
+	return true
+}
+
+// DebugLines reports the context input goes to.
+func (c *InputContexts) DebugLines() []string {
+	return []string{"Context: " + c.Top().String()}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"maps"
+	"slices"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Names of the built-in input profiles; profileCustom starts from the
+// default profile and applies the bindings saved in the settings
+const (
+	profileDefault        = "default"
+	profileOneHandedLeft  = "one_handed_left"
+	profileOneHandedRight = "one_handed_right"
+	profileCustom         = "custom"
+)
+
+// InputProfile is a named set of bindings. Every action may list several
+// keys and buttons; all of them work at the same time, so a primary and an
+// alternate binding never have to be switched between.
+type InputProfile struct {
+	Name    string
+	Keys    map[Action][]int32
+	Buttons map[Action][]int32
+}
+
+// inputProfiles are the presets offered in the options, in menu order. The
+// one-handed presets keep every action within reach of a single hand on the
+// keyboard, or on one half of the gamepad.
+var inputProfiles = []InputProfile{
+	{
+		Name: profileDefault,
+		Keys: map[Action][]int32{
+			ActionLeft:     {rl.KeyLeft, rl.KeyA},
+			ActionRight:    {rl.KeyRight, rl.KeyD},
+			ActionJump:     {rl.KeySpace, rl.KeyUp},
+			ActionHit:      {rl.KeyF},
+			ActionInteract: {rl.KeyE},
+			ActionDash:     {rl.KeyLeftShift, rl.KeyC},
+		},
+		Buttons: map[Action][]int32{
+			ActionLeft:     {rl.GamepadButtonLeftFaceLeft},
+			ActionRight:    {rl.GamepadButtonLeftFaceRight},
+			ActionJump:     {rl.GamepadButtonRightFaceDown},
+			ActionHit:      {rl.GamepadButtonRightFaceLeft},
+			ActionInteract: {rl.GamepadButtonRightFaceUp},
+			ActionDash:     {rl.GamepadButtonRightTrigger1},
+		},
+	},
+	{
+		Name: profileOneHandedLeft,
+		Keys: map[Action][]int32{
+			ActionLeft:     {rl.KeyA},
+			ActionRight:    {rl.KeyD},
+			ActionJump:     {rl.KeyW, rl.KeySpace},
+			ActionHit:      {rl.KeyS, rl.KeyLeftShift},
+			ActionInteract: {rl.KeyE},
+			ActionDash:     {rl.KeyQ},
+		},
+		Buttons: map[Action][]int32{
+			ActionLeft:     {rl.GamepadButtonLeftFaceLeft},
+			ActionRight:    {rl.GamepadButtonLeftFaceRight},
+			ActionJump:     {rl.GamepadButtonLeftTrigger1, rl.GamepadButtonLeftFaceUp},
+			ActionHit:      {rl.GamepadButtonLeftTrigger2, rl.GamepadButtonLeftFaceDown},
+			ActionInteract: {rl.GamepadButtonLeftThumb},
+			ActionDash:     {rl.GamepadButtonMiddleLeft},
+		},
+	},
+	{
+		Name: profileOneHandedRight,
+		Keys: map[Action][]int32{
+			ActionLeft:     {rl.KeyLeft},
+			ActionRight:    {rl.KeyRight},
+			ActionJump:     {rl.KeyUp, rl.KeyRightShift},
+			ActionHit:      {rl.KeyDown, rl.KeyRightControl},
+			ActionInteract: {rl.KeyEnter},
+			ActionDash:     {rl.KeyRightAlt},
+		},
+		Buttons: map[Action][]int32{
+			ActionLeft:     {rl.GamepadButtonRightFaceLeft},
+			ActionRight:    {rl.GamepadButtonRightFaceRight},
+			ActionJump:     {rl.GamepadButtonRightFaceDown, rl.GamepadButtonRightTrigger1},
+			ActionHit:      {rl.GamepadButtonRightFaceUp, rl.GamepadButtonRightTrigger2},
+			ActionInteract: {rl.GamepadButtonRightThumb},
+			ActionDash:     {rl.GamepadButtonMiddleRight},
+		},
+	},
+	{Name: profileCustom},
+}
+
+// inputProfileNames returns the profile names in menu order.
+func inputProfileNames() []string {
+	names := make([]string, len(inputProfiles))
+	for i, p := range inputProfiles {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// ApplyInputProfile replaces the active bindings with the named profile. The
+// custom profile is the default one with the settings' overrides on top.
+// Unknown names fall back to the default profile.
+func ApplyInputProfile(name string) {
+	i := slices.Index(inputProfileNames(), name)
+	if i < 0 {
+		i = 0
+	}
+	profile := inputProfiles[i]
+	if profile.Name == profileCustom {
+		profile = inputProfiles[0]
+	}
+
+	setBindings(keyBindings, profile.Keys)
+	setBindings(gamepadBindings, profile.Buttons)
+	if name == profileCustom {
+		for action, keys := range settings.KeyBindings {
+			keyBindings[actionNames[action]] = slices.Clone(keys)
+		}
+		for action, buttons := range settings.ButtonBindings {
+			gamepadBindings[actionNames[action]] = slices.Clone(buttons)
+		}
+	}
+}
+
+// setBindings overwrites dst in place, since input bindings hold the map.
+func setBindings(dst, src map[Action][]int32) {
+	clear(dst)
+	for action, codes := range src {
+		dst[action] = slices.Clone(codes)
+	}
+}
+
+// cloneBindings copies a profile's bindings for use as the live map.
+func cloneBindings(src map[Action][]int32) map[Action][]int32 {
+	dst := maps.Clone(src)
+	for action, codes := range dst {
+		dst[action] = slices.Clone(codes)
+	}
+	return dst
+}
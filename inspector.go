@@ -0,0 +1,376 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	inspectorKey     = rl.KeyF1
+	inspectorPrevKey = rl.KeyPageUp
+	inspectorNextKey = rl.KeyPageDown
+
+	inspectorRowHeight  = 24
+	inspectorPanelWidth = 400
+	inspectorListRows   = 8
+)
+
+// InspectorField is one value of an inspected entity. Fields that can be
+// edited have Adjust, called with the number of steps to move the value by.
+type InspectorField struct {
+	Name   string
+	Value  func() string
+	Adjust func(steps int)
+}
+
+// InspectedEntity is a live entity the inspector can select
+type InspectedEntity struct {
+	Key    any // the entity's pointer, to keep the selection across frames
+	Label  string
+	Bounds rl.Rectangle // in world coordinates
+	Fields []InspectorField
+}
+
+// Inspector lists the live entities, shows the fields of the selected one
+// as they change, and edits them with the mouse: click an entity to select
+// it, then the - and + buttons or the wheel over a field. Hold shift to
+// step ten times as far.
+type Inspector struct {
+	Visible  bool
+	entities []InspectedEntity
+	selected any
+}
+
+var inspector = &Inspector{}
+
+// inspectorAnimation, when set, is the animation the player is drawn with
+// instead of the one their state picks
+var inspectorAnimation string
+
+var playerAnimationNames = []string{"", "stand", "move", "hit"}
+
+// playerAnimation returns the player animation called name.
+func playerAnimation(name string) *Animated {
+	switch name {
+	case "hit":
+		return &player.Hit
+	case "move":
+		return &player.Move
+	}
+	return &player.Stand
+}
+
+func floatField(name string, v *float32, step float32) InspectorField {
+	return InspectorField{
+		Name:   name,
+		Value:  func() string { return fmt.Sprintf("%.2f", *v) },
+		Adjust: func(steps int) { *v += step * float32(steps) },
+	}
+}
+
+func intField(name string, v *int, minValue int, maxValue func() int) InspectorField {
+	return InspectorField{
+		Name:   name,
+		Value:  func() string { return fmt.Sprint(*v) },
+		Adjust: func(steps int) { *v = min(max(*v+steps, minValue), maxValue()) },
+	}
+}
+
+func boolField(name string, v *bool) InspectorField {
+	return InspectorField{
+		Name:   name,
+		Value:  func() string { return fmt.Sprint(*v) },
+		Adjust: func(int) { *v = !*v },
+	}
+}
+
+func readOnlyField(name string, value func() string) InspectorField {
+	return InspectorField{Name: name, Value: value}
+}
+
+func unlimited() int { return 1 << 30 }
+
+func playerFields(p *Player) []InspectorField {
+	fields := []InspectorField{
+		floatField("pos.x", &p.Pos.X, 4),
+		floatField("pos.y", &p.Pos.Y, 4),
+		floatField("speed", &p.Speed, 0.5),
+		floatField("scale", &p.Scale, 0.1),
+		intField("health", &p.Health, 0, func() int { return p.MaxHealth }),
+		intField("maxHealth", &p.MaxHealth, 1, unlimited),
+		boolField("flip", &p.Flip),
+		readOnlyField("velocityY", func() string { return fmt.Sprintf("%.2f", p.VelocityY) }),
+		readOnlyField("onGround", func() string { return fmt.Sprint(p.OnGround) }),
+	}
+	if p != &player {
+		return fields
+	}
+	return append(fields,
+		InspectorField{
+			Name: "animation",
+			Value: func() string {
+				if inspectorAnimation == "" {
+					return "(state)"
+				}
+				return inspectorAnimation
+			},
+			Adjust: func(steps int) {
+				i := slices.Index(playerAnimationNames, inspectorAnimation) + steps
+				n := len(playerAnimationNames)
+				inspectorAnimation = playerAnimationNames[(i%n+n)%n]
+			},
+		},
+		InspectorField{
+			Name: "frame",
+			Value: func() string {
+				anim := playerAnimation(inspectorAnimation)
+				return fmt.Sprintf("%d/%d", anim.CurrentFrame, anim.Frames())
+			},
+			Adjust: func(steps int) {
+				anim := playerAnimation(inspectorAnimation)
+				if n := anim.Frames(); n > 0 {
+					anim.CurrentFrame = ((anim.CurrentFrame+steps)%n + n) % n
+				}
+			},
+		},
+	)
+}
+
+// collect lists the entities alive this frame.
+func (in *Inspector) collect() {
+	in.entities = in.entities[:0]
+	in.entities = append(in.entities, InspectedEntity{Key: &player, Label: "player", Bounds: PlayerBounds(), Fields: playerFields(&player)})
+	if coop.Active {
+		p := &coop.Player
+		_, src := p.Stand.Frame(0)
+		bounds := rl.NewRectangle(p.Pos.X, p.Pos.Y, src.Width*p.Scale, src.Height*p.Scale)
+		in.entities = append(in.entities, InspectedEntity{Key: p, Label: "player 2", Bounds: bounds, Fields: playerFields(p)})
+	}
+	for _, e := range enemies {
+		if e.Defeated {
+			continue
+		}
+		in.entities = append(in.entities, InspectedEntity{
+			Key: e, Label: "enemy " + e.Name, Bounds: e.Hurtbox(),
+			Fields: []InspectorField{
+				floatField("pos.x", &e.Pos.X, 4),
+				floatField("pos.y", &e.Pos.Y, 4),
+				floatField("speed", &e.Speed, 0.5),
+				floatField("patrol", &e.Patrol, 8),
+				intField("health", &e.Health, 1, func() int { return e.MaxHealth }),
+				boolField("chase", &e.Chase),
+			},
+		})
+	}
+	if b := activeBoss; b != nil && !b.Defeated {
+		in.entities = append(in.entities, InspectedEntity{
+			Key: b, Label: "boss " + b.Name, Bounds: b.Hurtbox(),
+			Fields: []InspectorField{
+				floatField("pos.x", &b.Pos.X, 4),
+				floatField("pos.y", &b.Pos.Y, 4),
+				intField("health", &b.Health, 1, func() int { return b.MaxHealth }),
+				readOnlyField("phase", func() string {
+					if phase := b.Phase(); phase != nil {
+						return phase.Name
+					}
+					return "-"
+				}),
+			},
+		})
+	}
+	for i := range pickups {
+		p := &pickups[i]
+		if p.Taken {
+			continue
+		}
+		in.entities = append(in.entities, InspectedEntity{
+			Key: p, Label: "pickup " + p.Name,
+			Bounds: rl.NewRectangle(p.Pos.X-pickupRadius, p.Pos.Y-pickupRadius, 2*pickupRadius, 2*pickupRadius),
+			Fields: []InspectorField{
+				floatField("pos.x", &p.Pos.X, 4),
+				floatField("pos.y", &p.Pos.Y, 4),
+			},
+		})
+	}
+}
+
+// current returns the index of the selected entity, or -1.
+func (in *Inspector) current() int {
+	for i, e := range in.entities {
+		if e.Key == in.selected {
+			return i
+		}
+	}
+	return -1
+}
+
+func (in *Inspector) bounds() rl.Rectangle {
+	rows := inspectorListRows + 2
+	if i := in.current(); i >= 0 {
+		rows += len(in.entities[i].Fields) + 1
+	}
+	return ui.Rect(UIRect{Anchor: AnchorTopRight, Offset: rl.NewVector2(20, 80), Size: rl.NewVector2(inspectorPanelWidth, float32(rows*inspectorRowHeight+20))})
+}
+
+// listStart returns the first entity listed, scrolled to keep the
+// selection in view.
+func (in *Inspector) listStart() int {
+	return min(max(in.current()-inspectorListRows/2, 0), max(len(in.entities)-inspectorListRows, 0))
+}
+
+func (in *Inspector) listRow(i int) rl.Rectangle {
+	b := in.bounds()
+	return rl.NewRectangle(b.X+8, b.Y+36+float32(i*inspectorRowHeight), b.Width-16, inspectorRowHeight)
+}
+
+func (in *Inspector) fieldRow(i int) rl.Rectangle {
+	b := in.bounds()
+	return rl.NewRectangle(b.X+8, b.Y+36+float32((inspectorListRows+1+i)*inspectorRowHeight), b.Width-16, inspectorRowHeight)
+}
+
+// stepButtons returns the - and + buttons of a field row.
+func stepButtons(row rl.Rectangle) (minus, plus rl.Rectangle) {
+	plus = rl.NewRectangle(row.X+row.Width-24, row.Y+2, 22, row.Height-4)
+	minus = plus
+	minus.X -= 26
+	return minus, plus
+}
+
+// HandleInspector toggles the inspector, picks entities and edits fields.
+func HandleInspector() {
+	if rl.IsKeyPressed(inspectorKey) {
+		inspector.Visible = !inspector.Visible
+	}
+	if !inspector.Visible {
+		// Closing hands the player's animation back to their state
+		inspectorAnimation = ""
+		return
+	}
+	in := inspector
+	in.collect()
+
+	if n := len(in.entities); n > 0 {
+		switch {
+		case rl.IsKeyPressed(inspectorNextKey):
+			in.selected = in.entities[(in.current()+1)%n].Key
+		case rl.IsKeyPressed(inspectorPrevKey):
+			in.selected = in.entities[(max(in.current(), 0)+n-1)%n].Key
+		}
+	}
+
+	steps := 1
+	if rl.IsKeyDown(rl.KeyLeftShift) || rl.IsKeyDown(rl.KeyRightShift) {
+		steps = 10
+	}
+	mouse := rl.GetMousePosition()
+	clicked := rl.IsMouseButtonPressed(rl.MouseButtonLeft)
+	if !rl.CheckCollisionPointRec(mouse, in.bounds()) {
+		if clicked {
+			world := rl.GetScreenToWorld2D(mouse, camera.View())
+			// The last listed is drawn on top, so it wins
+			for i := len(in.entities) - 1; i >= 0; i-- {
+				if rl.CheckCollisionPointRec(world, in.entities[i].Bounds) {
+					in.selected = in.entities[i].Key
+					break
+				}
+			}
+		}
+		return
+	}
+
+	start := in.listStart()
+	for i := 0; i < inspectorListRows && start+i < len(in.entities); i++ {
+		if clicked && rl.CheckCollisionPointRec(mouse, in.listRow(i)) {
+			in.selected = in.entities[start+i].Key
+			return
+		}
+	}
+	sel := in.current()
+	if sel < 0 {
+		return
+	}
+	wheel := int(rl.GetMouseWheelMove())
+	for i, f := range in.entities[sel].Fields {
+		row := in.fieldRow(i)
+		if f.Adjust == nil || !rl.CheckCollisionPointRec(mouse, row) {
+			continue
+		}
+		minus, plus := stepButtons(row)
+		switch {
+		case clicked && rl.CheckCollisionPointRec(mouse, minus):
+			f.Adjust(-steps)
+		case clicked && rl.CheckCollisionPointRec(mouse, plus):
+			f.Adjust(steps)
+		case wheel != 0:
+			f.Adjust(wheel * steps)
+		}
+	}
+}
+
+// Draw renders the entity list, the selected entity's fields, and an
+// outline around it in the world.
+func (in *Inspector) Draw() {
+	if !in.Visible {
+		return
+	}
+	sel := in.current()
+	if sel >= 0 {
+		r := in.entities[sel].Bounds
+		view := camera.View()
+		a := rl.GetWorldToScreen2D(rl.NewVector2(r.X, r.Y), view)
+		b := rl.GetWorldToScreen2D(rl.NewVector2(r.X+r.Width, r.Y+r.Height), view)
+		rl.DrawRectangleLinesEx(rl.NewRectangle(a.X, a.Y, b.X-a.X, b.Y-a.Y), 2, rl.Magenta)
+	}
+
+	b := in.bounds()
+	rl.DrawRectangleRec(b, rl.Fade(rl.Black, 0.75))
+	title := fmt.Sprintf("Inspector (F1)  %d entities", len(in.entities))
+	rl.DrawText(title, int32(b.X)+12, int32(b.Y)+10, 20, rl.Yellow)
+
+	start := in.listStart()
+	for i := 0; i < inspectorListRows && start+i < len(in.entities); i++ {
+		r := in.listRow(i)
+		color := rl.RayWhite
+		if start+i == sel {
+			rl.DrawRectangleRec(r, rl.Fade(rl.Magenta, 0.35))
+		} else if rl.CheckCollisionPointRec(rl.GetMousePosition(), r) {
+			rl.DrawRectangleRec(r, rl.Fade(rl.Gray, 0.35))
+		}
+		rl.DrawText(in.entities[start+i].Label, int32(r.X)+4, int32(r.Y)+4, 16, color)
+	}
+	if sel < 0 {
+		rl.DrawText("Click an entity or PgUp/PgDn", int32(b.X)+12, int32(in.fieldRow(0).Y)-16, 16, rl.Gray)
+		return
+	}
+
+	for i, f := range in.entities[sel].Fields {
+		row := in.fieldRow(i)
+		color := rl.RayWhite
+		if f.Adjust == nil {
+			color = rl.Gray
+		}
+		rl.DrawText(f.Name, int32(row.X)+4, int32(row.Y)+4, 16, color)
+		rl.DrawText(f.Value(), int32(row.X)+160, int32(row.Y)+4, 16, color)
+		if f.Adjust == nil {
+			continue
+		}
+		minus, plus := stepButtons(row)
+		for _, button := range []struct {
+			r     rl.Rectangle
+			label string
+		}{{minus, "-"}, {plus, "+"}} {
+			fill := rl.Gray
+			if rl.CheckCollisionPointRec(rl.GetMousePosition(), button.r) {
+				fill = rl.LightGray
+			}
+			rl.DrawRectangleRec(button.r, fill)
+			rl.DrawText(button.label, int32(button.r.X)+7, int32(button.r.Y)+1, 18, rl.Black)
+		}
+	}
+}
+
+func init() {
+	RegisterSystem(&SystemFuncs{ID: "inspector", Requires: []string{"debug"}, OnUpdate: HandleInspector, OnDraw: inspector.Draw})
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	// interactRange is how close, in world pixels, the player's body must
+	// come to an interactable to use it
+	interactRange    = 40
+	interactFontSize = 22
+)
+
+// InteractKind is what an interactable is and so what using it does
+type InteractKind string
+
+const (
+	InteractDoor  InteractKind = "door"  // opens and closes
+	InteractLever InteractKind = "lever" // flips on and off
+	InteractSign  InteractKind = "sign"  // shows its lines
+	InteractNPC   InteractKind = "npc"   // talks: shows its lines under its name
+	// InteractPortal takes the player to its destination when touched
+	InteractPortal InteractKind = "portal"
+)
+
+// interactPrompts are the locale keys of the prompt shown next to each kind
+var interactPrompts = map[InteractKind]string{
+	InteractDoor:  "interact.open",
+	InteractLever: "interact.pull",
+	InteractSign:  "interact.read",
+	InteractNPC:   "interact.talk",
+}
+
+// InteractableDef places an interactable in a level of the manifest. The
+// rectangle is in world pixels. Lines are locale keys shown by signs and
+// NPCs; Name is the locale key of an NPC's name. Doors and portals with a
+// destination lead to spawn point Spawn of level To, or of the current
+// level when To is empty.
+//
+// World flags tie it to the rest of the game: it only exists while If
+// holds, a door or lever keeps its state in flag Flag across visits, and
+// finishing a sign's or NPC's dialogue raises Sets. Branches give other
+// lines under other conditions; the first that holds is used. A sign or
+// NPC with a Cutscene plays it instead of saying its lines.
+type InteractableDef struct {
+	ID       string       `json:"id"`
+	Kind     InteractKind `json:"kind"`
+	X        float32      `json:"x"`
+	Y        float32      `json:"y"`
+	Width    float32      `json:"width"`
+	Height   float32      `json:"height"`
+	Name     string       `json:"name,omitempty"`
+	Lines    []string     `json:"lines,omitempty"`
+	To       string       `json:"to,omitempty"`
+	Spawn    string       `json:"spawn,omitempty"`
+	Cutscene string       `json:"cutscene,omitempty"`
+
+	If       string           `json:"if,omitempty"`
+	Flag     string           `json:"flag,omitempty"`
+	Sets     string           `json:"sets,omitempty"`
+	Branches []DialogueBranch `json:"branches,omitempty"`
+}
+
+// DialogueBranch is lines said only while the condition If holds
+type DialogueBranch struct {
+	If    string   `json:"if"`
+	Lines []string `json:"lines"`
+}
+
+// lines returns what a sign or NPC says given the world flags.
+func (d InteractableDef) lines() []string {
+	for _, b := range d.Branches {
+		if worldFlags.Check(b.If) {
+			return b.Lines
+		}
+	}
+	return d.Lines
+}
+
+// Leads reports whether using it takes the player somewhere.
+func (d InteractableDef) Leads() bool {
+	return d.To != "" || d.Spawn != ""
+}
+
+// Interactable is something in the level the player can use. On is set
+// while a door is open or a lever is pulled.
+type Interactable struct {
+	InteractableDef
+	On bool
+}
+
+// Bounds returns the interactable's area in world space.
+func (it *Interactable) Bounds() rl.Rectangle {
+	return rl.NewRectangle(it.X, it.Y, it.Width, it.Height)
+}
+
+var (
+	// interactables are those in interactablesLevel, the current level
+	// once UpdateInteractables has run
+	interactables      []*Interactable
+	interactablesLevel string
+	// nearInteractable is the one the player would use, or nil
+	nearInteractable *Interactable
+	// interactHandlers run when the interactable with their id is used
+	interactHandlers = make(map[string]func(*Interactable))
+	// portalBlocked is set while the player stands in a portal they
+	// already went through or arrived in, so it waits for them to leave
+	portalBlocked bool
+)
+
+// OnInteract registers fn to run whenever the interactable id is used, for
+// behaviour beyond what its kind does.
+func OnInteract(id string, fn func(*Interactable)) {
+	interactHandlers[id] = fn
+}
+
+// spawnInteractables replaces the interactables with the current level's.
+func spawnInteractables() {
+	interactables = interactables[:0]
+	for _, def := range assets.manifest.Levels[currentLevel].Interactables {
+		interactables = append(interactables, &Interactable{InteractableDef: def, On: worldFlags.Has(def.Flag)})
+	}
+	interactablesLevel = currentLevel
+	nearInteractable = nil
+}
+
+// ResetInteractables forgets the spawned interactables so the level's are
+// spawned afresh.
+func ResetInteractables() {
+	interactables = interactables[:0]
+	interactablesLevel = ""
+	nearInteractable = nil
+}
+
+// UpdateInteractables finds the interactable nearest the player within
+// reach and uses it when Interact is pressed.
+func UpdateInteractables() {
+	if interactablesLevel != currentLevel {
+		spawnInteractables()
+	}
+	var portal *Interactable
+	nearInteractable, portal = nearestInteractable(PlayerBounds())
+	if portal == nil {
+		portalBlocked = false
+	} else if !portalBlocked {
+		portalBlocked = true
+		Interact(portal)
+	}
+	if nearInteractable != nil && input.IsPressed(ActionInteract) {
+		Interact(nearInteractable)
+	}
+}
+
+// nearestInteractable returns the interactable nearest body within reach,
+// and the portal body stands in, if any.
+func nearestInteractable(body rl.Rectangle) (near, portal *Interactable) {
+	reach := rl.NewRectangle(body.X-interactRange, body.Y-interactRange, body.Width+interactRange*2, body.Height+interactRange*2)
+	center := rl.NewVector2(body.X+body.Width/2, body.Y+body.Height/2)
+	best := float32(0)
+	for _, it := range interactables {
+		if !worldFlags.Check(it.If) {
+			continue
+		}
+		b := it.Bounds()
+		if it.Kind == InteractPortal {
+			if rl.CheckCollisionRecs(body, b) {
+				portal = it
+			}
+			continue
+		}
+		if !rl.CheckCollisionRecs(reach, b) {
+			continue
+		}
+		d := rl.Vector2Distance(center, rl.NewVector2(b.X+b.Width/2, b.Y+b.Height/2))
+		if near == nil || d < best {
+			near, best = it, d
+		}
+	}
+	return near, portal
+}
+
+// Interact uses it: doors and levers toggle, signs and NPCs open the
+// dialogue, and doors and portals with a destination start a transition
+// there. EventInteracted is published afterwards with the
+// interactable's id, and Amount 1 when it ended up on, 2 when off.
+func Interact(it *Interactable) {
+	switch {
+	case it.Leads():
+		it.On = true
+		transition.Start(it.To, it.Spawn)
+	case it.Kind == InteractDoor, it.Kind == InteractLever:
+		it.On = !it.On
+		worldFlags.Set(it.Flag, it.On)
+	case it.Cutscene != "" && (it.Kind == InteractSign || it.Kind == InteractNPC):
+		cutscene.Play(it.Cutscene)
+	case it.Kind == InteractSign, it.Kind == InteractNPC:
+		sets := it.Sets
+		dialogue.Start(T(it.Name), it.lines(), func() { worldFlags.Set(sets, true) })
+	}
+	amount := 2
+	if it.On {
+		amount = 1
+	}
+	events.Publish(Event{Type: EventInteracted, Target: it.ID, Amount: amount, Pos: rl.NewVector2(it.X+it.Width/2, it.Y+it.Height/2)})
+	if fn, ok := interactHandlers[it.ID]; ok {
+		fn(it)
+	}
+}
+
+// DrawInteractables draws every interactable in world space.
+func DrawInteractables() {
+	for _, it := range interactables {
+		if !worldFlags.Check(it.If) {
+			continue
+		}
+		b := it.Bounds()
+		switch it.Kind {
+		case InteractDoor:
+			if it.On {
+				rl.DrawRectangleLinesEx(b, 3, rl.Brown)
+			} else {
+				DrawRoundedRect(b, 4, rl.Brown)
+				rl.DrawCircleV(rl.NewVector2(b.X+b.Width*0.8, b.Y+b.Height/2), 3, rl.Gold)
+			}
+		case InteractLever:
+			base := rl.NewVector2(b.X+b.Width/2, b.Y+b.Height)
+			tilt := float32(-0.6)
+			if it.On {
+				tilt = 0.6
+			}
+			top := rl.NewVector2(base.X+tilt*b.Height, b.Y+b.Height*0.2)
+			DrawCapsule(base, top, 3, rl.Gray)
+			rl.DrawCircleV(top, 6, rl.Red)
+			DrawRoundedRect(rl.NewRectangle(b.X, b.Y+b.Height-8, b.Width, 8), 3, rl.DarkGray)
+		case InteractSign:
+			post := rl.NewRectangle(b.X+b.Width/2-3, b.Y+b.Height/2, 6, b.Height/2)
+			rl.DrawRectangleRec(post, rl.DarkBrown)
+			DrawRoundedRect(rl.NewRectangle(b.X, b.Y, b.Width, b.Height*0.55), 4, rl.Beige)
+		case InteractPortal:
+			pulse := float32(clock.Tick%60) / 60
+			DrawRoundedRect(b, min(b.Width, b.Height)/2, rl.Fade(rl.Violet, 0.4+0.2*pulse))
+		case InteractNPC:
+			radius := b.Width / 2
+			DrawCapsule(rl.NewVector2(b.X+radius, b.Y+radius), rl.NewVector2(b.X+radius, b.Y+b.Height-radius), radius, rl.SkyBlue)
+		}
+		if it == nearInteractable {
+			rl.DrawRectangleLinesEx(b, 2, rl.Fade(rl.White, 0.6))
+		}
+	}
+}
+
+var interactFont *Font
+
+// DrawInteractPrompt shows the prompt for the interactable in reach, with
+// the glyph of the Interact binding, above it on screen.
+func DrawInteractPrompt() {
+	it := nearInteractable
+	if it == nil || dialogue.Active() || transition.Active() || cutscene.Active() {
+		return
+	}
+	if interactFont == nil {
+		interactFont = fonts.Acquire("", interactFontSize)
+	}
+	key := interactPrompts[it.Kind]
+	switch {
+	case it.Leads():
+		key = "interact.enter"
+	case it.Kind == InteractDoor && it.On:
+		key = "interact.close"
+	}
+	text := T(key)
+	size := MeasureRichText(interactFont, text, interactFontSize, 0)
+	top := rl.GetWorldToScreen2D(rl.NewVector2(it.X+it.Width/2, it.Y), camera.View())
+	panel := rl.NewRectangle(top.X-size.X/2-10, top.Y-size.Y-20, size.X+20, size.Y+8)
+	DrawRoundedRect(panel, 6, rl.Fade(rl.Black, 0.7))
+	DrawRichText(interactFont, text, rl.NewVector2(panel.X+10, panel.Y+4), interactFontSize, 0, rl.RayWhite)
+}
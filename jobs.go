@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// JobScheduler runs independent jobs on worker goroutines. Jobs queued with
+// Go start right away and Wait is the sync point: after it returns every job
+// has finished and the main goroutine may touch their data again. Jobs in one
+// batch must not share state with each other or with the main goroutine.
+type JobScheduler struct {
+	workers int
+	queue   chan *job
+	batch   []*job
+	wg      sync.WaitGroup
+	timings []string
+}
+
+type job struct {
+	name  string
+	run   func()
+	took  time.Duration
+	panic any
+}
+
+// jobs uses every core but the one running the main loop
+var jobs = NewJobScheduler(runtime.NumCPU() - 1)
+
+// NewJobScheduler starts workers goroutines. With no workers, jobs run
+// inline in Wait.
+func NewJobScheduler(workers int) *JobScheduler {
+	s := &JobScheduler{workers: max(workers, 0)}
+	if s.workers > 0 {
+		s.queue = make(chan *job)
+		for range s.workers {
+			go s.work()
+		}
+	}
+	return s
+}
+
+func (s *JobScheduler) work() {
+	for j := range s.queue {
+		s.runJob(j)
+		s.wg.Done()
+	}
+}
+
+func (s *JobScheduler) runJob(j *job) {
+	defer func() { j.panic = recover() }()
+	start := time.Now()
+	j.run()
+	j.took = time.Since(start)
+}
+
+// Go queues run to start on a worker.
+func (s *JobScheduler) Go(name string, run func()) {
+	j := &job{name: name, run: run}
+	s.batch = append(s.batch, j)
+	if s.workers == 0 {
+		return
+	}
+	s.wg.Add(1)
+	s.queue <- j
+}
+
+// Wait blocks until every queued job has finished. A job that panicked
+// panics again here, on the main goroutine.
+func (s *JobScheduler) Wait() {
+	if s.workers == 0 {
+		for _, j := range s.batch {
+			s.runJob(j)
+		}
+	} else {
+		s.wg.Wait()
+	}
+
+	s.timings = s.timings[:0]
+	for _, j := range s.batch {
+		if j.panic != nil {
+			panic(fmt.Sprintf("job %s: %v", j.name, j.panic))
+		}
+		s.timings = append(s.timings, fmt.Sprintf("%-14s %.3f ms", j.name, ms(j.took)))
+	}
+	s.batch = s.batch[:0]
+}
+
+// DebugLines shows the worker count and how long each job of the last
+// batch took.
+func (s *JobScheduler) DebugLines() []string {
+	return append([]string{fmt.Sprintf("%d workers", s.workers)}, s.timings...)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Environment variables read as defaults for the matching flags
+const launchEnvPrefix = "RAYLIBGO_"
+
+// LaunchOptions configure a run of the game from the command line, so testers
+// and CI can start it in a specific setup
+type LaunchOptions struct {
+	Windowed  bool
+	Width     int
+	Height    int
+	Level     string
+	Mute      bool
+	SafeMode  bool // skip shaders
+	AssetsDir string
+	Cheats    bool
+	// TrackAssets records which assets are drawn, for tools/assetreport
+	TrackAssets bool
+	Bench       BenchmarkOptions
+	// Version prints the build and exits
+	Version bool
+	// ResolutionSet is true when the size came from a flag or the environment
+	// rather than the default, so it wins over the saved window size
+	ResolutionSet bool
+}
+
+var launch = LaunchOptions{
+	Width:  1920,
+	Height: 1080,
+	Bench:  BenchmarkOptions{Sprites: 2000, Particles: 20000, Lights: 8, Frames: 600},
+}
+
+// ParseLaunchOptions reads flags from args. Each flag can also be set with an
+// environment variable, e.g. RAYLIBGO_WINDOWED=1 or RAYLIBGO_RESOLUTION=1280x720;
+// flags win over the environment.
+func ParseLaunchOptions(args []string) (LaunchOptions, error) {
+	opts := launch
+	fs := flag.NewFlagSet("raylibgo", flag.ContinueOnError)
+
+	resolution := fmt.Sprintf("%dx%d", opts.Width, opts.Height)
+	fs.BoolVar(&opts.Windowed, "windowed", envBool("WINDOWED"), "run in a window instead of fullscreen")
+	fs.StringVar(&resolution, "resolution", envString("RESOLUTION", resolution), "window size as WIDTHxHEIGHT")
+	fs.StringVar(&opts.Level, "level", envString("LEVEL", ""), "level to start in")
+	fs.BoolVar(&opts.Mute, "mute", envBool("MUTE"), "start with audio muted")
+	fs.BoolVar(&opts.SafeMode, "safe-mode", envBool("SAFE_MODE"), "skip loading shaders")
+	fs.StringVar(&opts.AssetsDir, "assets-dir", envString("ASSETS_DIR", ""), "directory to read assets/ files from")
+	fs.BoolVar(&opts.TrackAssets, "track-assets", envBool("TRACK_ASSETS"), "record drawn assets to "+assetUsagePath)
+	fs.BoolVar(&opts.Cheats, "cheats", envBool("CHEATS"), "unlock cheats (builds with -tags cheats only)")
+	fs.BoolVar(&opts.Bench.Run, "benchmark", envBool("BENCHMARK"), "run the stress-test benchmark and quit")
+	fs.IntVar(&opts.Bench.Sprites, "bench-sprites", envInt("BENCH_SPRITES", opts.Bench.Sprites), "animated sprites in the benchmark")
+	fs.IntVar(&opts.Bench.Particles, "bench-particles", envInt("BENCH_PARTICLES", opts.Bench.Particles), "particles in the benchmark")
+	fs.IntVar(&opts.Bench.Lights, "bench-lights", envInt("BENCH_LIGHTS", opts.Bench.Lights), "moving lights in the benchmark")
+	fs.IntVar(&opts.Bench.Frames, "bench-frames", envInt("BENCH_FRAMES", opts.Bench.Frames), "frames the benchmark times")
+	fs.StringVar(&opts.Bench.Out, "bench-out", envString("BENCH_OUT", ""), "benchmark JSON report path (default "+benchmarkDir+"/bench-TIME.json)")
+	fs.BoolVar(&opts.Version, "version", false, "print the version, commit and build date and exit")
+	fs.BoolVar(&opts.Bench.Hidden, "bench-hidden", envBool("BENCH_HIDDEN"), "run the benchmark with the window hidden")
+
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+
+	w, h, ok := strings.Cut(resolution, "x")
+	width, errW := strconv.Atoi(w)
+	height, errH := strconv.Atoi(h)
+	if !ok || errW != nil || errH != nil || width <= 0 || height <= 0 {
+		return opts, fmt.Errorf("invalid resolution %q, want WIDTHxHEIGHT", resolution)
+	}
+	opts.Width, opts.Height = width, height
+	if opts.Bench.Sprites < 0 || opts.Bench.Particles < 0 || opts.Bench.Lights < 0 || opts.Bench.Frames <= 0 {
+		return opts, fmt.Errorf("benchmark counts can't be negative and bench-frames must be positive")
+	}
+	_, opts.ResolutionSet = os.LookupEnv(launchEnvPrefix + "RESOLUTION")
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "resolution" {
+			opts.ResolutionSet = true
+		}
+	})
+	return opts, nil
+}
+
+func envString(name, fallback string) string {
+	if v, ok := os.LookupEnv(launchEnvPrefix + name); ok {
+		return v
+	}
+	return fallback
+}
+
+func envInt(name string, fallback int) int {
+	if v, err := strconv.Atoi(os.Getenv(launchEnvPrefix + name)); err == nil {
+		return v
+	}
+	return fallback
+}
+
+func envBool(name string) bool {
+	v, err := strconv.ParseBool(os.Getenv(launchEnvPrefix + name))
+	return err == nil && v
+}
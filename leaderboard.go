@@ -0,0 +1,388 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"raylibgo/buildinfo"
+)
+
+const (
+	leaderboardQueuePath = "saves/leaderboard_queue.json"
+	leaderboardShowKey   = rl.KeyTab
+	leaderboardTopCount  = 10
+
+	// How long fetched entries are shown before being refreshed
+	leaderboardCacheTTL = time.Minute
+	// How often queued submissions are retried while offline
+	leaderboardRetryEvery = 30 * time.Second
+	leaderboardTimeout    = 10 * time.Second
+)
+
+// Submission is one result sent to a board. Boards are ranked by the backend,
+// by Score when set and by TimeMs otherwise.
+type Submission struct {
+	Board  string    `json:"board"`
+	Player string    `json:"player"`
+	TimeMs int64     `json:"timeMs,omitempty"`
+	Score  int64     `json:"score,omitempty"`
+	At     time.Time `json:"at"`
+	// Build is the game build the result was made with, so results from
+	// a buggy build can be found and removed
+	Build string `json:"build,omitempty"`
+}
+
+// LeaderboardEntry is one row of a board as returned by the backend
+type LeaderboardEntry struct {
+	Rank   int    `json:"rank"`
+	Player string `json:"player"`
+	TimeMs int64  `json:"timeMs,omitempty"`
+	Score  int64  `json:"score,omitempty"`
+}
+
+type cachedBoard struct {
+	entries  []LeaderboardEntry
+	fetched  time.Time
+	fetching bool
+	err      error
+}
+
+// Leaderboards talks to the HTTP backend at BaseURL. Submissions are queued
+// on disk first and sent in the background, so results made offline are
+// delivered once the backend is reachable again. Fetched boards are cached.
+//
+// The backend accepts POST {BaseURL}/boards/{board}/scores and serves
+// GET {BaseURL}/boards/{board}/top?limit=N, authorized with the API key
+// as a bearer token.
+type Leaderboards struct {
+	BaseURL string
+	APIKey  string
+	Client  *http.Client
+
+	mu        sync.Mutex
+	queue     []Submission
+	flushing  bool
+	lastFlush time.Time
+	boards    map[string]*cachedBoard
+
+	levelStart uint64 // tick the current level was entered
+	font       *Font
+}
+
+var leaderboards = NewLeaderboards()
+
+// NewLeaderboards creates a client with no backend; Configure sets one
+func NewLeaderboards() *Leaderboards {
+	return &Leaderboards{
+		Client: &http.Client{Timeout: leaderboardTimeout},
+		boards: make(map[string]*cachedBoard),
+	}
+}
+
+// Configure points the client at a backend and loads the offline queue.
+func (lb *Leaderboards) Configure(baseURL, apiKey string) {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	lb.BaseURL = baseURL
+	lb.APIKey = apiKey
+
+	data, err := os.ReadFile(leaderboardQueuePath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("leaderboard: %v", err)
+		}
+		return
+	}
+	if err := json.Unmarshal(data, &lb.queue); err != nil {
+		log.Printf("leaderboard: %s: %v", leaderboardQueuePath, err)
+	}
+}
+
+// Enabled reports whether a backend is configured.
+func (lb *Leaderboards) Enabled() bool {
+	return lb.BaseURL != ""
+}
+
+// Submit queues a result and starts sending it.
+func (lb *Leaderboards) Submit(s Submission) {
+	if !lb.Enabled() {
+		return
+	}
+	if s.Player == "" {
+		s.Player = settings.PlayerName
+	}
+	if s.At.IsZero() {
+		s.At = time.Now()
+	}
+	if s.Build == "" {
+		s.Build = buildinfo.Get().Short()
+	}
+	lb.mu.Lock()
+	lb.queue = append(lb.queue, s)
+	lb.saveQueueLocked()
+	lb.mu.Unlock()
+	lb.flush()
+}
+
+// HandleEvent submits the time spent in each level as the player leaves it.
+func (lb *Leaderboards) HandleEvent(e Event) {
+	switch e.Type {
+	case EventAreaEntered:
+		lb.levelStart = clock.Tick
+	case EventLevelExited:
+		elapsed := time.Duration(clock.Tick-lb.levelStart) * tickDuration
+		lb.Submit(Submission{Board: e.Target, TimeMs: elapsed.Milliseconds()})
+	}
+}
+
+// StartLevel restarts the level time, as when a new game begins.
+func (lb *Leaderboards) StartLevel() {
+	lb.levelStart = clock.Tick
+}
+
+// Update retries queued submissions periodically. Call once per frame.
+func (lb *Leaderboards) Update() {
+	lb.mu.Lock()
+	retry := len(lb.queue) > 0 && !lb.flushing && time.Since(lb.lastFlush) >= leaderboardRetryEvery
+	lb.mu.Unlock()
+	if retry && lb.Enabled() {
+		lb.flush()
+	}
+}
+
+// Pending returns how many submissions are waiting to be sent.
+func (lb *Leaderboards) Pending() int {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	return len(lb.queue)
+}
+
+func (lb *Leaderboards) saveQueueLocked() {
+	data, err := json.MarshalIndent(lb.queue, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(leaderboardQueuePath), 0o755)
+	}
+	if err == nil {
+		err = os.WriteFile(leaderboardQueuePath, data, 0o644)
+	}
+	if err != nil {
+		log.Printf("leaderboard: saving queue: %v", err)
+	}
+}
+
+// flush sends queued submissions in order on a background goroutine,
+// stopping at the first one the backend can't be reached for.
+func (lb *Leaderboards) flush() {
+	lb.mu.Lock()
+	if lb.flushing || len(lb.queue) == 0 {
+		lb.mu.Unlock()
+		return
+	}
+	lb.flushing = true
+	lb.mu.Unlock()
+
+	go func() {
+		for {
+			lb.mu.Lock()
+			if len(lb.queue) == 0 {
+				break
+			}
+			next := lb.queue[0]
+			lb.mu.Unlock()
+
+			retry, err := lb.post(next)
+			if err != nil {
+				log.Printf("leaderboard: %v", err)
+			}
+
+			lb.mu.Lock()
+			if retry {
+				break
+			}
+			lb.queue = lb.queue[1:]
+			lb.saveQueueLocked()
+			delete(lb.boards, next.Board)
+			lb.mu.Unlock()
+		}
+		lb.flushing = false
+		lb.lastFlush = time.Now()
+		lb.mu.Unlock()
+	}()
+}
+
+// post sends one submission. It reports retry when the request should be
+// attempted again later; rejected submissions are dropped.
+func (lb *Leaderboards) post(s Submission) (retry bool, err error) {
+	body, err := json.Marshal(s)
+	if err != nil {
+		return false, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), leaderboardTimeout)
+	defer cancel()
+	req, err := lb.newRequest(ctx, http.MethodPost, "/boards/"+url.PathEscape(s.Board)+"/scores", body)
+	if err != nil {
+		return false, err
+	}
+	resp, err := lb.Client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		return true, fmt.Errorf("submit %s: %s", s.Board, resp.Status)
+	default:
+		return false, fmt.Errorf("submit %s: rejected: %s", s.Board, resp.Status)
+	}
+}
+
+func (lb *Leaderboards) newRequest(ctx context.Context, method, path string, body []byte) (*http.Request, error) {
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, lb.BaseURL+path, r)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", buildinfo.Get().UserAgent())
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if lb.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+lb.APIKey)
+	}
+	return req, nil
+}
+
+// Top returns the cached top entries of board, starting a refresh in the
+// background when the cache is missing or stale. The error is the last
+// fetch failure, if any.
+func (lb *Leaderboards) Top(board string) ([]LeaderboardEntry, error) {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	cached, ok := lb.boards[board]
+	if !ok {
+		cached = &cachedBoard{}
+		lb.boards[board] = cached
+	}
+	if lb.Enabled() && !cached.fetching && time.Since(cached.fetched) >= leaderboardCacheTTL {
+		cached.fetching = true
+		go lb.fetch(board, cached)
+	}
+	return cached.entries, cached.err
+}
+
+func (lb *Leaderboards) fetch(board string, cached *cachedBoard) {
+	entries, err := lb.get(board)
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	cached.fetching = false
+	cached.fetched = time.Now()
+	cached.err = err
+	if err == nil {
+		cached.entries = entries
+	}
+}
+
+func (lb *Leaderboards) get(board string) ([]LeaderboardEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), leaderboardTimeout)
+	defer cancel()
+	path := fmt.Sprintf("/boards/%s/top?limit=%d", url.PathEscape(board), leaderboardTopCount)
+	req, err := lb.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := lb.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("leaderboard %s: %s", board, resp.Status)
+	}
+	var entries []LeaderboardEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("leaderboard %s: %w", board, err)
+	}
+	return entries, nil
+}
+
+// DrawPanel renders the top entries of board inside bounds.
+func (lb *Leaderboards) DrawPanel(board string, bounds rl.Rectangle) {
+	if lb.font == nil {
+		lb.font = fonts.Acquire("", 24)
+	}
+	if !DrawNinePatch(SkinPanel, bounds, rl.White) {
+		rl.DrawRectangleRec(bounds, rl.Fade(rl.Black, 0.8))
+	}
+	x, y := bounds.X+20, bounds.Y+16
+	lb.font.Draw(T("leaderboard.title")+" - "+board, rl.NewVector2(x, y), 32, rl.Gold)
+	y += 48
+
+	entries, err := lb.Top(board)
+	switch {
+	case !lb.Enabled():
+		lb.font.Draw(T("leaderboard.offline"), rl.NewVector2(x, y), 24, rl.Gray)
+		return
+	case len(entries) == 0 && err != nil:
+		lb.font.Draw(T("leaderboard.unreachable"), rl.NewVector2(x, y), 24, rl.Gray)
+		return
+	case len(entries) == 0:
+		lb.font.Draw(T("leaderboard.empty"), rl.NewVector2(x, y), 24, rl.Gray)
+		return
+	}
+
+	for _, e := range entries {
+		result := formatRunTime(time.Duration(e.TimeMs) * time.Millisecond)
+		if e.Score != 0 {
+			result = locale.Int(e.Score)
+		}
+		color := rl.RayWhite
+		if e.Player == settings.PlayerName {
+			color = rl.SkyBlue
+		}
+		lb.font.Draw(fmt.Sprintf("%2d. %s", e.Rank, e.Player), rl.NewVector2(x, y), 24, color)
+		w := lb.font.Measure(result, 24).X
+		lb.font.Draw(result, rl.NewVector2(bounds.X+bounds.Width-20-w, y), 24, color)
+		y += 30
+	}
+	if n := lb.Pending(); n > 0 {
+		lb.font.Draw(locale.Plural("leaderboard.pending", n), rl.NewVector2(x, bounds.Y+bounds.Height-36), 20, rl.Gray)
+	}
+}
+
+// DrawLeaderboard shows the current level's board while Tab is held.
+func DrawLeaderboard() {
+	if !rl.IsKeyDown(leaderboardShowKey) {
+		return
+	}
+	bounds := ui.Rect(UIRect{Anchor: AnchorCenter, Size: rl.NewVector2(560, 460)})
+	leaderboards.DrawPanel(currentLevel, bounds)
+}
+
+func init() {
+	RegisterSystem(&SystemFuncs{
+		ID:       "leaderboards",
+		Requires: []string{"focus"},
+		OnUpdate: func() {
+			if focus.Focused() {
+				leaderboards.Update()
+			}
+		},
+	})
+}
@@ -0,0 +1,94 @@
+// Package level defines the on-disk level format: a grid of terrain ids in
+// tile units plus the player spawn and the exits to other levels. Terrain
+// id 0 is empty; other ids refer to terrains registered by the game.
+package level
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Version is the format version written by Write
+const Version = 1
+
+// Point is a cell coordinate
+type Point struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Exit is an area, in tiles, that leads to another level
+type Exit struct {
+	To     string `json:"to"`
+	X      int    `json:"x"`
+	Y      int    `json:"y"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// File is a level as stored on disk. Terrain holds Width*Height ids row by row.
+type File struct {
+	Version  int     `json:"version"`
+	Width    int     `json:"width"`
+	Height   int     `json:"height"`
+	TileSize float32 `json:"tileSize"`
+	Terrain  []int   `json:"terrain"`
+	Spawn    Point   `json:"spawn"`
+	Exits    []Exit  `json:"exits,omitempty"`
+}
+
+// New creates an empty level of the given size in tiles
+func New(width, height int, tileSize float32) *File {
+	return &File{
+		Version:  Version,
+		Width:    width,
+		Height:   height,
+		TileSize: tileSize,
+		Terrain:  make([]int, width*height),
+	}
+}
+
+// InBounds reports whether a cell is inside the level.
+func (f *File) InBounds(x, y int) bool {
+	return x >= 0 && y >= 0 && x < f.Width && y < f.Height
+}
+
+// At returns the terrain id of a cell, or 0 outside the level.
+func (f *File) At(x, y int) int {
+	if !f.InBounds(x, y) {
+		return 0
+	}
+	return f.Terrain[y*f.Width+x]
+}
+
+// Set changes the terrain id of a cell; cells outside the level are ignored.
+func (f *File) Set(x, y, terrain int) {
+	if f.InBounds(x, y) {
+		f.Terrain[y*f.Width+x] = terrain
+	}
+}
+
+// Read decodes and validates a level.
+func Read(r io.Reader) (*File, error) {
+	var f File
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, fmt.Errorf("level: %w", err)
+	}
+	if f.Version > Version {
+		return nil, fmt.Errorf("level: version %d is newer than supported %d", f.Version, Version)
+	}
+	if f.Width <= 0 || f.Height <= 0 || len(f.Terrain) != f.Width*f.Height {
+		return nil, fmt.Errorf("level: terrain has %d cells, want %dx%d", len(f.Terrain), f.Width, f.Height)
+	}
+	if f.TileSize <= 0 {
+		return nil, fmt.Errorf("level: tile size %v must be positive", f.TileSize)
+	}
+	return &f, nil
+}
+
+// Write encodes f.
+func Write(w io.Writer, f *File) error {
+	enc := json.NewEncoder(w)
+	return enc.Encode(f)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+
+	"raylibgo/level"
+	"raylibgo/procgen"
+)
+
+// LoadLevelFile reads a level file from the asset files into a tilemap,
+// resolving auto-tiles and collision from the registered terrains.
+func LoadLevelFile(path string) (*Tilemap, *level.File, error) {
+	data, err := ReadAsset(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := level.Read(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	return TilemapFromLevel(f), f, nil
+}
+
+// TilemapFromLevel builds a tilemap from level data.
+func TilemapFromLevel(f *level.File) *Tilemap {
+	t := NewTilemap(f.Width, f.Height, f.TileSize)
+	copy(t.Terrain, f.Terrain)
+	for i, id := range t.Terrain {
+		t.Collision[i] = terrains[id].Solid
+	}
+	t.ResolveAutoTiles()
+	return t
+}
+
+// LevelExits converts a level's exits from tiles to world space.
+func LevelExits(f *level.File) []LevelExit {
+	exits := make([]LevelExit, 0, len(f.Exits))
+	for _, e := range f.Exits {
+		exits = append(exits, LevelExit{
+			To:     e.To,
+			X:      float32(e.X) * f.TileSize,
+			Y:      float32(e.Y) * f.TileSize,
+			Width:  float32(e.Width) * f.TileSize,
+			Height: float32(e.Height) * f.TileSize,
+		})
+	}
+	return exits
+}
+
+// PlayerMovement describes the current movement tuning for level generation.
+func PlayerMovement() procgen.Movement {
+	return procgen.Movement{Gravity: gravity, JumpForce: jumpForce, Speed: player.Speed}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Distance from an exit at which the next level starts streaming in
+const prestreamDistance = 400
+
+// LevelExit is an area that leads to another level. It is closed unless
+// the world flag condition If holds.
+type LevelExit struct {
+	To     string  `json:"to"`
+	X      float32 `json:"x"`
+	Y      float32 `json:"y"`
+	Width  float32 `json:"width"`
+	Height float32 `json:"height"`
+	If     string  `json:"if,omitempty"`
+}
+
+// LevelNode is a level in the manifest's adjacency graph. Map optionally
+// names the level's tile file; Surface is the ground's footstep surface
+// where no tile says otherwise.
+type LevelNode struct {
+	Group   string      `json:"group"`
+	Exits   []LevelExit `json:"exits"`
+	Map     string      `json:"map,omitempty"`
+	Surface string      `json:"surface,omitempty"`
+	// Interactables are the doors, levers, signs and NPCs in the level
+	Interactables []InteractableDef `json:"interactables,omitempty"`
+	// Spawns are where doors and portals leading here put the player
+	Spawns map[string]SpawnPoint `json:"spawns,omitempty"`
+	// Cutscene plays when the player enters the level
+	Cutscene string `json:"cutscene,omitempty"`
+	// Waves makes the level a survival area the spawn director fills
+	Waves *WaveDef `json:"waves,omitempty"`
+	// Requires lists the abilities needed to get through the level;
+	// Abilities places the pickups that unlock them
+	Requires  []string        `json:"requires,omitempty"`
+	Abilities []AbilityPickup `json:"abilities,omitempty"`
+	// Collectibles are the optional pickups counted on the progress screen
+	Collectibles []Collectible `json:"collectibles,omitempty"`
+}
+
+// startLevel is where a new game begins
+const startLevel = "start"
+
+// currentLevel is the level the player is in
+var currentLevel = startLevel
+
+// inExit is set while the player overlaps an exit, so standing in one
+// doesn't fire it every tick
+var inExit bool
+
+// CheckLevelExits moves the player into the next level when they walk into
+// one of the current level's exits.
+func CheckLevelExits() {
+	body := PlayerBounds()
+	for _, exit := range assets.manifest.Levels[currentLevel].Exits {
+		if !worldFlags.Check(exit.If) || !rl.CheckCollisionRecs(body, rl.NewRectangle(exit.X, exit.Y, exit.Width, exit.Height)) {
+			continue
+		}
+		if inExit {
+			return
+		}
+		inExit = true
+		if _, ok := assets.manifest.Levels[exit.To]; !ok {
+			return
+		}
+		from := currentLevel
+		currentLevel = exit.To
+		events.Publish(Event{Type: EventLevelExited, Target: from, Pos: player.Pos})
+		events.Publish(Event{Type: EventAreaEntered, Target: currentLevel, Pos: player.Pos})
+		return
+	}
+	inExit = false
+}
+
+// ways returns the exits of level along with its doors and portals that
+// lead to other levels, as exits.
+func (am *AssetManager) ways(level string) []LevelExit {
+	node := am.manifest.Levels[level]
+	ways := node.Exits
+	for _, it := range node.Interactables {
+		if it.To != "" && it.To != level {
+			ways = append(ways, LevelExit{To: it.To, X: it.X, Y: it.Y, Width: it.Width, Height: it.Height})
+		}
+	}
+	return ways
+}
+
+// Neighbors returns the levels reachable from level.
+func (am *AssetManager) Neighbors(level string) []string {
+	var names []string
+	for _, way := range am.ways(level) {
+		names = append(names, way.To)
+	}
+	return names
+}
+
+// UpdateStreaming starts loading the asset group of any level whose exit,
+// door or portal the player is near. Each level is streamed once; the
+// handles stay referenced so the textures are resident when the player
+// walks through. When the player changes level, the groups of levels no
+// longer adjacent are released.
+func (am *AssetManager) UpdateStreaming(level string, pos rl.Vector2) {
+	if level != am.streamedFrom {
+		am.streamedFrom = level
+		am.EvictUnused(level)
+	}
+	am.evictForBudget(level, pos)
+	for _, exit := range am.ways(level) {
+		if _, ok := am.streamed[exit.To]; ok {
+			continue
+		}
+		area := rl.NewRectangle(exit.X, exit.Y, exit.Width, exit.Height)
+		if distanceToRect(pos, area) > prestreamDistance {
+			continue
+		}
+		target, ok := am.manifest.Levels[exit.To]
+		if !ok {
+			continue
+		}
+		am.streamed[exit.To] = am.RequestGroup(context.Background(), target.Group, PriorityLow)
+	}
+}
+
+// distanceToRect returns how far p is from the nearest point of r.
+func distanceToRect(p rl.Vector2, r rl.Rectangle) float32 {
+	nearest := rl.NewVector2(
+		max(r.X, min(p.X, r.X+r.Width)),
+		max(r.Y, min(p.Y, r.Y+r.Height)),
+	)
+	return rl.Vector2Distance(p, nearest)
+}
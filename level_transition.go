@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	levelFadeTime = 300 * time.Millisecond
+	// levelStreamWait is the longest the screen stays black waiting for
+	// the target level's assets; whatever is missing pops in afterwards
+	levelStreamWait = 2 * time.Second
+)
+
+// SpawnPoint is a named place in a level where the player can arrive
+type SpawnPoint struct {
+	X float32 `json:"x"`
+	Y float32 `json:"y"`
+}
+
+type transitionPhase int
+
+const (
+	transitionNone transitionPhase = iota
+	transitionFadeOut
+	transitionLoading
+	transitionFadeIn
+)
+
+// LevelTransition fades to black, moves the player to a spawn point in
+// another level or the same one, and fades back in. The player keeps
+// their health, effects and equipment; gameplay waits while it plays.
+type LevelTransition struct {
+	To    string // target level, "" for the current one
+	Spawn string
+
+	phase   transitionPhase
+	since   time.Time
+	handles []*AssetHandle
+}
+
+var transition = &LevelTransition{}
+
+// Start begins a transition to spawn in level to. Starting one while
+// another plays does nothing.
+func (t *LevelTransition) Start(to, spawn string) {
+	if t.Active() {
+		return
+	}
+	if to == currentLevel {
+		to = ""
+	}
+	if _, ok := assets.manifest.Levels[to]; to != "" && !ok {
+		return
+	}
+	t.To, t.Spawn = to, spawn
+	t.phase = transitionFadeOut
+	t.since = time.Now()
+	t.handles = nil
+}
+
+// Active reports whether a transition is playing.
+func (t *LevelTransition) Active() bool {
+	return t.phase != transitionNone
+}
+
+// Update advances the transition. It reports whether gameplay should hold
+// this frame, which includes the frame it ends on.
+func (t *LevelTransition) Update() bool {
+	switch t.phase {
+	case transitionNone:
+		return false
+	case transitionFadeOut:
+		if time.Since(t.since) < levelFadeTime {
+			return true
+		}
+		if t.To != "" {
+			// Usually prestreamed on the way to the door already
+			handles, ok := assets.streamed[t.To]
+			if !ok {
+				handles = assets.RequestGroup(context.Background(), assets.manifest.Levels[t.To].Group, PriorityHigh)
+				assets.streamed[t.To] = handles
+			}
+			t.handles = handles
+		}
+		t.phase = transitionLoading
+		t.since = time.Now()
+	case transitionLoading:
+		if !t.loaded() && time.Since(t.since) < levelStreamWait {
+			return true
+		}
+		t.arrive()
+		t.phase = transitionFadeIn
+		t.since = time.Now()
+	case transitionFadeIn:
+		if time.Since(t.since) >= levelFadeTime {
+			t.phase = transitionNone
+		}
+	}
+	return true
+}
+
+func (t *LevelTransition) loaded() bool {
+	for _, h := range t.handles {
+		if h.State == AssetPending {
+			return false
+		}
+	}
+	return true
+}
+
+// arrive switches level if needed and puts the player at the spawn point.
+// Without one they keep their place in the same level, or start at the
+// default position in another.
+func (t *LevelTransition) arrive() {
+	if t.To != "" {
+		from := currentLevel
+		currentLevel = t.To
+		events.Publish(Event{Type: EventLevelExited, Target: from, Pos: player.Pos})
+		player.Pos = player.DefPos
+	}
+	if spawn, ok := assets.manifest.Levels[currentLevel].Spawns[t.Spawn]; ok {
+		player.Pos = rl.NewVector2(spawn.X, spawn.Y)
+	}
+	player.VelocityY = 0
+	if coop.Active {
+		coop.Player.Pos = rl.NewVector2(player.Pos.X+coopSpawnGap, player.Pos.Y)
+		coop.Player.VelocityY = 0
+	}
+	// Don't carry a held press or a standing-in-exit state into the arrival
+	live = InputFrame{}
+	coop.live = InputFrame{}
+	inExit = true
+	portalBlocked = true
+	stats.Teleported()
+	if !rewinder.Replaying() {
+		// Snapshots don't know the level, so rewinding can't cross this
+		rewinder.Reset()
+	}
+	camera.Follow(CameraTarget())
+	if t.To != "" {
+		events.Publish(Event{Type: EventAreaEntered, Target: currentLevel, Pos: player.Pos})
+	}
+}
+
+// Reset abandons a transition, for when gameplay is left.
+func (t *LevelTransition) Reset() {
+	t.phase = transitionNone
+	t.handles = nil
+}
+
+// Draw darkens the screen while the transition plays.
+func (t *LevelTransition) Draw() {
+	alpha := float32(1)
+	switch t.phase {
+	case transitionNone:
+		return
+	case transitionFadeOut:
+		alpha = float32(time.Since(t.since)) / float32(levelFadeTime)
+	case transitionFadeIn:
+		alpha = 1 - float32(time.Since(t.since))/float32(levelFadeTime)
+	}
+	rl.DrawRectangle(0, 0, int32(screenSize.X), int32(screenSize.Y), rl.Fade(rl.Black, rl.Clamp(alpha, 0, 1)))
+}
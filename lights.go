@@ -0,0 +1,196 @@
+package main
+
+import (
+	"slices"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// maxSpriteLights must match MAX_LIGHTS in assets/shaders/lit.fs
+const maxSpriteLights = 8
+
+// normalMapSuffix is appended to a sprite's file name, before the
+// extension, to find its optional normal map (character.png → character_n.png)
+const normalMapSuffix = "_n"
+
+// PointLight lights sprites that have a normal map within Radius of Pos.
+type PointLight struct {
+	Pos       rl.Vector2
+	Color     rl.Color
+	Radius    float32
+	Intensity float32
+}
+
+// LightSet holds the lights affecting the current frame
+type LightSet struct {
+	Ambient rl.Color
+	// Static lights stay until cleared, e.g. torches placed by a level
+	Static []PointLight
+	// KeyLight follows the player at an offset of Pos so normal maps read
+	// with depth even where no other light is near
+	KeyLight PointLight
+	frame    []PointLight
+}
+
+var lighting = &LightSet{
+	Ambient:  rl.NewColor(90, 90, 110, 255),
+	KeyLight: PointLight{Pos: rl.NewVector2(-300, -400), Color: rl.NewColor(255, 240, 220, 255), Radius: 1400, Intensity: 1},
+}
+
+// litShader combines a sprite with its normal map and the nearest lights
+type litShader struct {
+	shader    rl.Shader
+	loaded    bool
+	normalMap int32
+	rect      int32
+	frame     int32
+	flip      int32
+	ambient   int32
+	count     int32
+	positions int32
+	colors    int32
+	radii     int32
+}
+
+var spriteLighting litShader
+
+func loadLitShader(path string) litShader {
+	code, err := ReadAsset(path)
+	if err != nil {
+		return litShader{}
+	}
+	shader := rl.LoadShaderFromMemory("", string(code))
+	if !rl.IsShaderValid(shader) {
+		return litShader{}
+	}
+	return litShader{
+		shader:    shader,
+		loaded:    true,
+		normalMap: rl.GetShaderLocation(shader, "normalMap"),
+		rect:      rl.GetShaderLocation(shader, "spriteRect"),
+		frame:     rl.GetShaderLocation(shader, "frameRect"),
+		flip:      rl.GetShaderLocation(shader, "flip"),
+		ambient:   rl.GetShaderLocation(shader, "ambient"),
+		count:     rl.GetShaderLocation(shader, "lightCount"),
+		positions: rl.GetShaderLocation(shader, "lightPos"),
+		colors:    rl.GetShaderLocation(shader, "lightColor"),
+		radii:     rl.GetShaderLocation(shader, "lightRadius"),
+	}
+}
+
+// normalMapPath returns where the normal map for a sprite would live.
+func normalMapPath(path string) string {
+	for i := len(path) - 1; i >= 0 && path[i] != '/'; i-- {
+		if path[i] == '.' {
+			return path[:i] + normalMapSuffix + path[i:]
+		}
+	}
+	return path + normalMapSuffix
+}
+
+// AcquireNormalMap loads the normal map for a sprite, or returns nil when
+// the sprite has none so it keeps drawing unlit.
+func AcquireNormalMap(path string, width, height int32) *Texture {
+	normal := normalMapPath(path)
+	if !AssetExists(normal) {
+		return nil
+	}
+	return tm.Acquire(normal, width, height)
+}
+
+// ReleaseNormalMap releases a normal map acquired with AcquireNormalMap.
+func ReleaseNormalMap(path string) {
+	if normal := normalMapPath(path); AssetExists(normal) {
+		tm.Release(normal)
+	}
+}
+
+// Collect gathers this frame's lights: the static ones, the key light
+// relative to the player and a glow for every remaining pickup.
+func (l *LightSet) Collect() {
+	l.frame = append(l.frame[:0], l.Static...)
+	key := l.KeyLight
+	key.Pos = rl.Vector2Add(player.Pos, key.Pos)
+	l.frame = append(l.frame, key)
+	for _, p := range pickups {
+		if !p.Taken {
+			l.frame = append(l.frame, PointLight{Pos: p.Pos, Color: rl.Gold, Radius: 160, Intensity: 0.8})
+		}
+	}
+}
+
+// nearest returns up to maxSpriteLights lights that reach rect, closest first.
+func (l *LightSet) nearest(rect rl.Rectangle) []PointLight {
+	center := rl.NewVector2(rect.X+rect.Width/2, rect.Y+rect.Height/2)
+	reach := max(rect.Width, rect.Height) / 2
+	var near []PointLight
+	for _, light := range l.frame {
+		if rl.Vector2Distance(light.Pos, center) < light.Radius+reach {
+			near = append(near, light)
+		}
+	}
+	slices.SortFunc(near, func(a, b PointLight) int {
+		da := rl.Vector2DistanceSqr(a.Pos, center)
+		db := rl.Vector2DistanceSqr(b.Pos, center)
+		switch {
+		case da < db:
+			return -1
+		case da > db:
+			return 1
+		}
+		return 0
+	})
+	return near[:min(len(near), maxSpriteLights)]
+}
+
+// DrawLitSprite draws tex into dst lit by the nearby lights using normal.
+// Sprites without a normal map, or drawn while the shader is unavailable
+// or high contrast mode is on, fall back to draw unlit through material.
+func DrawLitSprite(tex rl.Texture2D, normal *Texture, src, dst rl.Rectangle, rotation float32, material *SpriteMaterial) {
+	s := &spriteLighting
+	if normal == nil || !normal.Loaded || !s.loaded || highContrast || material.framesLeft > 0 {
+		material.Begin(tex)
+		rl.DrawTexturePro(tex, src, dst, rl.NewVector2(0, 0), rotation, rl.White)
+		material.End()
+		return
+	}
+
+	near := lighting.nearest(dst)
+	positions := make([]float32, 0, 2*maxSpriteLights)
+	colors := make([]float32, 0, 3*maxSpriteLights)
+	radii := make([]float32, 0, maxSpriteLights)
+	for _, light := range near {
+		c := rl.ColorNormalize(light.Color)
+		positions = append(positions, light.Pos.X, light.Pos.Y)
+		colors = append(colors, c.X*light.Intensity, c.Y*light.Intensity, c.Z*light.Intensity)
+		radii = append(radii, light.Radius)
+	}
+	ambient := rl.ColorNormalize(lighting.Ambient)
+	flip := float32(1)
+	if src.Width < 0 {
+		flip = -1
+	}
+	// The frame's unflipped area in texture coordinates, for strips that
+	// hold several frames
+	frame := []float32{
+		min(src.X, src.X+src.Width) / float32(tex.Width),
+		min(src.Y, src.Y+src.Height) / float32(tex.Height),
+		abs32(src.Width) / float32(tex.Width),
+		abs32(src.Height) / float32(tex.Height),
+	}
+
+	rl.BeginShaderMode(s.shader)
+	rl.SetShaderValueTexture(s.shader, s.normalMap, normal.Texture)
+	rl.SetShaderValue(s.shader, s.rect, []float32{dst.X, dst.Y, dst.Width, dst.Height}, rl.ShaderUniformVec4)
+	rl.SetShaderValue(s.shader, s.frame, frame, rl.ShaderUniformVec4)
+	rl.SetShaderValue(s.shader, s.flip, []float32{flip}, rl.ShaderUniformFloat)
+	rl.SetShaderValue(s.shader, s.ambient, []float32{ambient.X, ambient.Y, ambient.Z}, rl.ShaderUniformVec3)
+	rl.SetShaderValue(s.shader, s.count, []float32{float32(len(near))}, rl.ShaderUniformFloat)
+	if len(near) > 0 {
+		rl.SetShaderValueV(s.shader, s.positions, positions, rl.ShaderUniformVec2, int32(len(near)))
+		rl.SetShaderValueV(s.shader, s.colors, colors, rl.ShaderUniformVec3, int32(len(near)))
+		rl.SetShaderValueV(s.shader, s.radii, radii, rl.ShaderUniformFloat, int32(len(near)))
+	}
+	rl.DrawTexturePro(tex, src, dst, rl.NewVector2(0, 0), rotation, rl.White)
+	rl.EndShaderMode()
+}
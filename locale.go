@@ -0,0 +1,45 @@
+package main
+
+import (
+	"log"
+
+	"raylibgo/i18n"
+)
+
+const localesDir = "assets/locales/"
+
+// Languages shipped with the game; each has a table in localesDir
+var supportedLanguages = []string{"en", "ja"}
+
+// locale translates UI text into the language chosen in the settings
+var locale = i18n.New("en")
+
+// LoadLocales reads every supported language table and selects lang.
+func LoadLocales(lang string) {
+	for _, l := range supportedLanguages {
+		data, err := ReadAsset(localesDir + l + ".json")
+		if err != nil {
+			log.Printf("locale: %v", err)
+			continue
+		}
+		table, err := i18n.ParseTable(data)
+		if err != nil {
+			log.Printf("locale: %s: %v", l, err)
+			continue
+		}
+		locale.Add(l, table)
+	}
+	SetLanguage(lang)
+}
+
+// SetLanguage switches the UI language, mirroring the layout for languages
+// written right to left.
+func SetLanguage(lang string) {
+	locale.SetLanguage(lang)
+	ui.Mirrored = locale.RTL()
+}
+
+// T translates key in the current language.
+func T(key string) string {
+	return locale.T(key)
+}
@@ -1,25 +1,110 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"log"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"raylibgo/buildinfo"
+	"raylibgo/vfs"
 )
 
 var tm = &TextureManager{
 	textures: make(map[string]*Texture),
 }
 
+var fonts = NewFontManager()
+
+var mods = NewModManager()
+
+var paths = NewPathQueue()
+
 type Animated struct {
 	CurrentFrame  int
 	IsPlaying     bool
 	StartTime     time.Time
 	FrameDelay    time.Duration
 	FrameTextures []*Texture
-	Reversing     bool
+	FrameCount    int // used when frames live in an atlas instead of FrameTextures
+	// NormalTextures parallels FrameTextures; nil entries have no normal map
+	NormalTextures []*Texture
+	// Strip replaces FrameTextures when the frames are packed into one
+	// texture; NormalStrip then holds their normal maps in the same layout
+	Strip       *AnimationStrip
+	NormalStrip *AnimationStrip
+	Reversing   bool
+	// RootMotion is how far the body moves, in frame pixels, on reaching
+	// each frame
+	RootMotion []rl.Vector2
+	// Events are the animation events published on reaching each frame
+	Events map[int][]string
+}
+
+// Frames returns the number of frames in the animation.
+func (a *Animated) Frames() int {
+	if a.Strip != nil {
+		return a.Strip.Count
+	}
+	if len(a.FrameTextures) > 0 {
+		return len(a.FrameTextures)
+	}
+	return a.FrameCount
+}
+
+// Frame returns the texture holding frame i and the frame's source
+// rectangle within it, or nil if the frame is not loaded.
+func (a *Animated) Frame(i int) (*Texture, rl.Rectangle) {
+	if a.Strip != nil {
+		if i < 0 || i >= a.Strip.Count || !a.Strip.Texture.Loaded {
+			return nil, rl.Rectangle{}
+		}
+		return a.Strip.Texture, a.Strip.FrameRect(i)
+	}
+	if i < 0 || i >= len(a.FrameTextures) || !a.FrameTextures[i].Loaded {
+		return nil, rl.Rectangle{}
+	}
+	tex := a.FrameTextures[i]
+	return tex, rl.NewRectangle(0, 0, float32(tex.Texture.Width), float32(tex.Texture.Height))
+}
+
+// NormalFrame returns the normal map texture for frame i, laid out like
+// the texture Frame returns, or nil if the frame has none.
+func (a *Animated) NormalFrame(i int) *Texture {
+	if a.Strip != nil {
+		if a.NormalStrip == nil {
+			return nil
+		}
+		return a.NormalStrip.Texture
+	}
+	if i < 0 || i >= len(a.NormalTextures) {
+		return nil
+	}
+	return a.NormalTextures[i]
+}
+
+// FrameDrawn records that frame i and its normal map were drawn, under
+// their own paths even when packed into a strip.
+func (a *Animated) FrameDrawn(i int) {
+	if a.Strip != nil {
+		a.Strip.Drawn(i)
+		if a.NormalStrip != nil {
+			a.NormalStrip.Drawn(i)
+		}
+		return
+	}
+	if tex, _ := a.Frame(i); tex != nil {
+		tex.Drawn()
+	}
+	if normal := a.NormalFrame(i); normal != nil && normal.Loaded {
+		normal.Drawn()
+	}
 }
 
 type Player struct {
@@ -34,14 +119,40 @@ type Player struct {
 	Scale     float32
 	VelocityY float32
 	OnGround  bool
-	State     *PlayerState
+	// airTime is how long since the player left the ground, for coyote time
+	airTime time.Duration
+	// airJumped is set once the double jump is spent, until landing
+	airJumped bool
+	// dashLeft is how much of the current dash remains; dashWait how long
+	// until the next may start
+	dashLeft, dashWait time.Duration
+	State              *PlayerState
+	Health             int
+	MaxHealth          int
+	Effects            StatusEffects
+	Material           SpriteMaterial
+	// Layers are equipment drawn with the body, in Z order
+	Layers []*SpriteLayer
+	// Skeleton, when loaded, draws the body instead of the frames
+	Skeleton *SkeletonSprite
 }
 
+// Movement constants, adjustable from the tweak panel
+var (
+	gravity   float32 = 0.5
+	jumpForce float32 = -12
+)
+
 const (
-	gravity   = 0.5
-	jumpForce = -12
+	playerHitDamage = 10
+
+	// Falling faster than this shakes the camera on landing
+	landingShakeSpeed = 10
 )
 
+// playerDamageBonus is extra attack damage, from arena upgrades
+var playerDamageBonus int
+
 var (
 	player     Player
 	background *Animated
@@ -50,13 +161,42 @@ var (
 )
 
 func main() {
-	screenSize = rl.NewVector2(1920, 1080)
-	rl.InitWindow(int32(screenSize.X), int32(screenSize.Y), "Raylib - Mohamed Sheta")
-	rl.ToggleFullscreen()
-	rl.SetTargetFPS(60)
+	defer ReportCrash()
+	opts, err := ParseLaunchOptions(os.Args[1:])
+	if err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(0)
+		}
+		log.Fatal(err)
+	}
+	if opts.Version {
+		fmt.Println(buildinfo.Get())
+		return
+	}
+	launch = opts
+	log.Printf("build: %s", buildinfo.Get())
 
-	rl.InitAudioDevice()
+	screenSize = rl.NewVector2(float32(launch.Width), float32(launch.Height))
+	if launch.Bench.Run {
+		// Benchmarks compare runs, so they skip what varies between machines
+		launch.Windowed = true
+		if launch.Bench.Hidden {
+			rl.SetConfigFlags(rl.FlagWindowHidden)
+		}
+	}
+	InitWindowWithFallback()
+	// Escape belongs to the menus and the game scene, not to closing the window
+	rl.SetExitKey(0)
+	if !launch.Windowed {
+		rl.ToggleFullscreen()
+	}
+	pacing.Detect()
+
+	InitAudioWithFallback()
 	defer rl.CloseAudioDevice()
+	if launch.Mute {
+		rl.SetMasterVolume(0)
+	}
 
 	// Signal handling for safe shutdown
 	sig := make(chan os.Signal, 1)
@@ -69,64 +209,228 @@ func main() {
 		os.Exit(0)
 	}()
 
+	if s, err := LoadSettings(settingsPath); err == nil {
+		settings = s
+	} else if !os.IsNotExist(err) {
+		log.Printf("settings: %v", err)
+	}
+	settings.ValidateDisplay()
+	ApplySettings()
+	profiles.Load()
+	ApplyGCSettings(settings)
+	leaderboards.Configure(settings.LeaderboardURL, settings.LeaderboardKey)
+	ConfigureCloudSaves()
+	RunStartupUpdate()
+	OpenAssetPack(assetPackPath)
+	if launch.AssetsDir != "" {
+		assetFiles.Mount(vfs.Sub{Prefix: "assets/", Source: vfs.Dir(launch.AssetsDir)})
+	}
+	mods.Load("mods")
+	mods.Mount(assetFiles)
+	defer mods.Close(assetFiles)
+	defer CloseAssetPack()
+	LoadWindowIcons()
+
+	camera.Reset(rl.NewRectangle(0, 0, screenSize.X, screenSize.Y))
+	if launch.Level != "" {
+		currentLevel = launch.Level
+	}
+	if launch.Cheats {
+		unlockCheats()
+	}
+
+	LoadLocales(settings.Language)
 	LoadAssets()
 	defer UnloadAssets()
 	defer rl.CloseWindow()
+	defer scenes.UnloadAll()
+	if err := systems.Init(); err != nil {
+		log.Fatal(err)
+	}
+	defer systems.Shutdown()
 
-	LoadMusic()
+	if launch.Bench.Run {
+		scenes.Push(NewBenchmarkScene(launch.Bench))
+	} else {
+		LoadMusic()
+		scenes.Push(NewSplashScene())
+	}
+
+	for !rl.WindowShouldClose() && !quitRequested {
+		perf.BeginFrame()
+		allocs.BeginFrame()
+		inputContexts.BeginFrame()
+		systems.Update()
+		scenes.Update()
+		allocs.Mark(allocUpdate)
 
-	for !rl.WindowShouldClose() {
-		rl.UpdateMusicStream(music)
-		Update()
 		Draw()
+		allocs.Mark(allocDraw)
+		perf.EndFrame()
 	}
 }
 
 func LoadMusic() {
-	music = rl.LoadMusicStream("assets/music/m.mp3")
+	PlayMusicTrack("assets/music/m.mp3")
+}
+
+// musicData backs the current stream; raylib decodes from it while playing,
+// so it must stay referenced until the stream is unloaded. musicPath is
+// the track it was read from.
+var (
+	musicData []byte
+	musicPath string
+)
+
+// PlayMusicTrack replaces the current music stream with the track at path.
+func PlayMusicTrack(path string) {
+	if !audioReady {
+		return
+	}
+	data, err := ReadAsset(path)
+	if err != nil || len(data) == 0 {
+		log.Printf("music: %s: %v", path, err)
+		return
+	}
+	next := rl.LoadMusicStreamFromMemory(filepath.Ext(path), data, int32(len(data)))
+	if !rl.IsMusicValid(next) {
+		return
+	}
+	if rl.IsMusicValid(music) {
+		rl.StopMusicStream(music)
+		rl.UnloadMusicStream(music)
+	}
+	music = next
+	musicData, musicPath = data, path
+	rl.SetMusicVolume(music, mixer.MusicVolume())
 	rl.PlayMusicStream(music)
 }
 
+// NewPlayer returns a player standing at pos with full health and no
+// frames loaded yet.
+func NewPlayer(pos rl.Vector2) Player {
+	return Player{
+		Pos:       pos,
+		DefPos:    pos,
+		Speed:     5,
+		Rotation:  0,
+		Flip:      false,
+		Scale:     0.12,
+		State:     NewPlayerState(),
+		Health:    100,
+		MaxHealth: 100,
+		Material:  SpriteMaterial{HitEffect: HitEffectFlash, HitColor: rl.White, HitFrames: 8, OutlineWidth: 2},
+		Move:      Animated{Reversing: true},
+	}
+}
+
 func LoadAssets() {
-	player = Player{
-		Pos:      rl.NewVector2(10, screenSize.Y-120),
-		DefPos:   rl.NewVector2(10, screenSize.Y-120),
-		Speed:    5,
-		Rotation: 0,
-		Flip:     false,
-		Scale:    0.12,
-		State:    NewPlayerState(),
-		Hit:      Animated{FrameDelay: 80 * time.Millisecond},
-		Stand:    Animated{FrameDelay: 150 * time.Millisecond},
-		Move:     Animated{FrameDelay: 50 * time.Millisecond, Reversing: true},
-	}
-
-	var baseW int32 = 1024
-	var baseH int32 = 1024
-
-	loadFrames := func(paths []string, target *[]*Texture) {
-		for _, path := range paths {
-			fullPath := "assets/images/" + path
-			t := tm.Acquire(fullPath, baseW, baseH)
-			*target = append(*target, t)
+	player = NewPlayer(rl.NewVector2(10, screenSize.Y-120))
+
+	if defs, err := LoadAnimationDefs(animationDefsPath); err != nil {
+		log.Printf("animations: %v", err)
+	} else {
+		ApplyAnimationDefs(defs)
+	}
+	if AssetExists(spriteLayerDefsPath) {
+		if defs, err := LoadSpriteLayerDefs(spriteLayerDefsPath); err != nil {
+			log.Printf("layers: %v", err)
+		} else {
+			ApplySpriteLayerDefs(defs)
+		}
+	}
+	LoadPlayerSkeleton(playerSkeletonPath)
+	if AssetExists(surfaceDefsPath) {
+		if defs, err := LoadSurfaceDefs(surfaceDefsPath); err != nil {
+			log.Printf("surfaces: %v", err)
+		} else {
+			ApplySurfaceDefs(defs)
 		}
 	}
 
-	loadFrames([]string{"stand1.png", "stand2.png", "stand3.png", "stand4.png"}, &player.Stand.FrameTextures)
-	loadFrames([]string{"hit1.png", "hit2.png", "hit3.png", "hit4.png"}, &player.Hit.FrameTextures)
-	loadFrames([]string{"mv1.png", "mv2.png", "mv3.png", "mv4.png", "mv4.png", "mv5.png", "mv4.png", "mv6.png"}, &player.Move.FrameTextures)
-
-	if len(player.Stand.FrameTextures) > 0 {
+	if player.Stand.Frames() > 0 {
 		player.Stand.IsPlaying = true
-		player.Stand.StartTime = time.Now()
+		player.Stand.StartTime = clock.Now()
+	}
+
+	if bg, err := LoadGIFAsAnimated("assets/images/a.gif", 100*time.Millisecond); err != nil {
+		log.Printf("background: %v", err)
+		background = &Animated{FrameDelay: 100 * time.Millisecond}
+	} else {
+		background = bg
+	}
+
+	assets.images.SetLimit(int64(settings.ImageCacheMB) * megabyte)
+	if err := assets.LoadManifest(manifestPath); err != nil {
+		log.Printf("assets: %v", err)
 	}
+	for _, p := range assets.VerifyAssets() {
+		log.Printf("assets: %s: %s", p.Path, p.Reason)
+	}
+	for _, p := range CheckAbilityGates(assets.manifest.Levels, startLevel) {
+		log.Printf("levels: %s", p)
+	}
+
+	if !launch.SafeMode {
+		LoadSpriteShaders()
+	}
+
+	hitstop.Exempt(floatingText.Update)
+	AddDebugSection("Frame pacing", pacing.DebugLines)
+	AddDebugSection("Frame times", perf.DebugLines)
+	AddDebugSection("Allocations", allocs.DebugLines)
+	AddDebugSection("World flags", worldFlags.DebugLines)
+	AddDebugSection("GPU memory", assets.VRAMDebugLines)
+	AddDebugSection("Image cache", assets.images.DebugLines)
+	AddDebugSection("Render scale", renderScale.DebugLines)
+	AddDebugSection("Jobs", jobs.DebugLines)
+	AddDebugSection("Scheduler", scheduler.DebugLines)
+	AddDebugSection("Director", director.DebugLines)
+	AddDebugSection("Input", inputContexts.DebugLines)
 
-	background = LoadGIFAsAnimated("assets/images/a.gif", 100*time.Millisecond)
+	WatchGameData()
+	RegisterTweaks()
+	if err := tweaks.LoadTuning(tuningPath); err != nil && !os.IsNotExist(err) {
+		log.Printf("tweaks: %v", err)
+	}
+	events.Subscribe(EventAny, quests.HandleEvent)
+	events.Subscribe(EventLevelExited, speedrun.HandleEvent)
+	events.Subscribe(EventAreaEntered, leaderboards.HandleEvent)
+	events.Subscribe(EventLevelExited, leaderboards.HandleEvent)
+	events.Subscribe(EventAreaEntered, autosaver.HandleEvent)
+	events.Subscribe(EventAny, stats.HandleEvent)
+	events.Subscribe(EventAny, achievements.HandleEvent)
+	events.Subscribe(EventUIFocused, narrator.HandleEvent)
+	events.Subscribe(EventTextShown, narrator.HandleEvent)
+	events.Subscribe(EventItemCollected, HandleEquipmentPickup)
+	events.Subscribe(EventItemCollected, HandleAbilityPickup)
+	events.Subscribe(EventItemCollected, HandleCollectiblePickup)
+	events.Subscribe(EventAnimation, footsteps.HandleEvent)
+	events.Subscribe(EventEnemyDefeated, worldFlags.HandleEvent)
+	events.Subscribe(EventEnemyDefeated, director.HandleEvent)
+	events.Subscribe(EventAreaEntered, cutscene.HandleEvent)
+	events.Subscribe(EventQuestCompleted, HandleGameCompleted)
+	if defs, err := LoadQuestDefs(questDefsPath); err == nil {
+		quests.Register(defs...)
+	}
+	if defs, err := LoadCutsceneDefs(cutsceneDefsPath); err == nil {
+		cutscene.SetDefs(defs)
+	}
+	if defs, err := LoadAchievementDefs(achievementDefsPath); err == nil {
+		achievements.SetDefs(defs)
+	}
+	if defs, err := LoadTutorialDefs(tutorialDefsPath); err != nil {
+		log.Printf("tutorials: %v", err)
+	} else {
+		tutorials.SetDefs(defs)
+	}
 }
 
 func UnloadAssets() {
 	// Automatically unload all tracked textures
 	tm.ReleaseAll()
+	fonts.ReleaseAll()
+	UnloadSpriteShaders()
 
 	// Handle background separately if it's not managed by texture manager
 	for _, frame := range background.FrameTextures {
@@ -140,44 +444,59 @@ func LoadSafeTextureFromImage(path string, width int32, height int32) *Texture {
 }
 
 func Draw() {
+	ui.Update()
+
 	rl.BeginDrawing()
 	rl.ClearBackground(rl.Black)
 
-	DrawBackgroundGIF(background)
-
-	DrawPlayer()
+	scenes.Draw()
+	// UI, debug and cursor layers
+	systems.Draw()
 
+	perf.MarkCPUDone()
 	rl.EndDrawing()
 }
 
 func DrawPlayer() {
 	var anim *Animated
-	if player.Hit.IsPlaying && player.Hit.CurrentFrame < len(player.Hit.FrameTextures) {
-		anim = &player.Hit
-	} else if rl.IsKeyDown(rl.KeyLeft) || rl.IsKeyDown(rl.KeyRight) || rl.IsKeyDown(rl.KeyA) || rl.IsKeyDown(rl.KeyD) {
-		anim = &player.Move
+	var name string
+	if player.Hit.IsPlaying && player.Hit.CurrentFrame < player.Hit.Frames() {
+		anim, name = &player.Hit, "hit"
+	} else if input.IsDown(ActionLeft) || input.IsDown(ActionRight) {
+		anim, name = &player.Move, "move"
 	} else {
-		anim = &player.Stand
+		anim, name = &player.Stand, "stand"
+	}
+	if inspectorAnimation != "" {
+		anim, name = playerAnimation(inspectorAnimation), inspectorAnimation
 	}
 
-	if anim == nil || len(anim.FrameTextures) == 0 {
+	if anim == nil || anim.Frames() == 0 {
 		return
 	}
 
-	frame := anim.CurrentFrame % len(anim.FrameTextures)
-	tex := anim.FrameTextures[frame]
-	if !tex.Loaded {
+	frame := anim.CurrentFrame % anim.Frames()
+	tex, src := anim.Frame(frame)
+	if tex == nil {
 		return
 	}
 
-	width := float32(tex.Texture.Width) * player.Scale
-	height := float32(tex.Texture.Height) * player.Scale
-	src := rl.NewRectangle(0, 0, float32(tex.Texture.Width), float32(tex.Texture.Height))
+	width := src.Width * player.Scale
+	height := src.Height * player.Scale
 	if player.Flip {
+		src.X += src.Width
 		src.Width *= -1
-		src.X = float32(tex.Texture.Width)
 	}
 	dst := rl.NewRectangle(player.Pos.X, player.Pos.Y, width, height)
-	origin := rl.NewVector2(0, 0)
-	rl.DrawTexturePro(tex.Texture, src, dst, origin, player.Rotation, rl.White)
+	// The player stands on the ground at their default position
+	DrawSpriteShadow(tex.Texture, src, dst, player.DefPos.Y+height)
+	DrawSpriteLayers(name, frame, dst, true)
+	if DrawPlayerSkeleton(name, anim, dst) {
+		DrawSpriteLayers(name, frame, dst, false)
+		return
+	}
+	normal := anim.NormalFrame(frame)
+	DrawLitSprite(tex.Texture, normal, src, dst, player.Rotation, &player.Material)
+	anim.FrameDrawn(frame)
+	DrawSpriteLayers(name, frame, dst, false)
 }
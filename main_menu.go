@@ -0,0 +1,369 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"raylibgo/buildinfo"
+)
+
+const (
+	menuLogoPath    = "assets/images/logo.png"
+	menuAssetGroup  = "menu"
+	menuMoveSound   = "assets/sounds/ui_move.wav"
+	menuSelectSound = "assets/sounds/ui_select.wav"
+	saveSlotCount   = 3
+
+	// How far the backdrop drifts, as a fraction of the screen, when the mouse
+	// moves from the center to an edge
+	menuParallax = 0.02
+)
+
+// currentSlot is the save slot being played, or 0 when none is chosen
+var currentSlot int
+
+// quitRequested ends the main loop after the current frame
+var quitRequested bool
+
+// slotPath returns the save file for slot n, counting from 1.
+func slotPath(n int) string {
+	return profilePath(fmt.Sprintf("saves/slot%d.json", n))
+}
+
+func slotExists(n int) bool {
+	_, err := os.Stat(slotPath(n))
+	return err == nil
+}
+
+func anySlotExists() bool {
+	for n := 1; n <= saveSlotCount; n++ {
+		if slotExists(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// MainMenuScene is the title screen: an animated backdrop with the logo, the
+// game modes, Options, Credits and Quit, plus sub-pages for save slots and
+// options.
+type MainMenuScene struct {
+	logo    *Texture
+	font    *Font
+	sounds  *UISounds
+	page    *MenuList
+	plus    bool // the slot and difficulty pages start New Game Plus
+	started time.Time
+	idle    time.Time // last input, for starting attract mode
+}
+
+// NewMainMenuScene creates the title screen.
+func NewMainMenuScene() *MainMenuScene {
+	return &MainMenuScene{}
+}
+
+func (m *MainMenuScene) Load(scope *AssetScope) {
+	scope.RequestGroup(menuAssetGroup, PriorityHigh)
+	m.logo = scope.Acquire(menuLogoPath, 0, 0)
+	m.font = scope.Font("", 40)
+	m.sounds = LoadUISounds(menuMoveSound, menuSelectSound)
+	m.started = time.Now()
+	m.idle = m.started
+	m.showRoot()
+	cloudSaves.Sync()
+}
+
+func (m *MainMenuScene) Unload() {
+	m.sounds.Unload()
+}
+
+func (m *MainMenuScene) newPage(items []MenuItem, onBack func()) {
+	m.page = &MenuList{
+		Layout:   UIRect{Anchor: AnchorCenter, Offset: rl.NewVector2(0, 120), Size: rl.NewVector2(480, 320)},
+		Font:     m.font,
+		FontSize: 40,
+		Spacing:  16,
+		OnBack:   onBack,
+		Sounds:   m.sounds,
+	}
+	m.page.SetItems(items)
+}
+
+func (m *MainMenuScene) showRoot() {
+	m.newPage([]MenuItem{
+		{Label: T("menu.start"), OnSelect: func() { m.plus = false; m.showSlots(false) }},
+		{Label: T("menu.continue"), Disabled: !anySlotExists(), OnSelect: func() { m.showSlots(true) }},
+		{Label: T("menu.new_game_plus"), Disabled: !PlusUnlocked(), OnSelect: func() { m.plus = true; m.showSlots(false) }},
+		{Label: T("menu.coop"), OnSelect: m.playCoop},
+		{Label: T("menu.versus"), OnSelect: func() { scenes.Replace(NewVersusScene()) }},
+		{Label: T("menu.daily"), OnSelect: func() { scenes.Replace(NewDailyChallengeScene()) }},
+		{Label: T("menu.arena"), OnSelect: func() { scenes.Replace(NewArenaScene()) }},
+		{Label: T("menu.options"), OnSelect: m.showOptions},
+		{Label: T("menu.stats"), OnSelect: func() { scenes.Replace(&StatsScene{}) }},
+		{Label: T("menu.profile"), OnSelect: func() { scenes.Replace(NewProfileSelectScene()) }},
+		{Label: T("menu.credits"), OnSelect: func() { scenes.Replace(&CreditsScene{}) }},
+		{Label: T("menu.quit"), OnSelect: func() { quitRequested = true }},
+	}, nil)
+}
+
+// showSlots lists the save slots. When continuing only used slots can be
+// chosen; starting overwrites whatever the chosen slot held.
+func (m *MainMenuScene) showSlots(continuing bool) {
+	var items []MenuItem
+	for n := 1; n <= saveSlotCount; n++ {
+		info, err := os.Stat(slotPath(n))
+		used := err == nil
+		label := fmt.Sprintf("%s %d - ", T("menu.slot"), n)
+		if used {
+			label += fmt.Sprintf(T("menu.saved"), locale.Ago(info.ModTime(), time.Now()))
+		} else {
+			label += T("menu.empty")
+		}
+		items = append(items, MenuItem{
+			Label:    label,
+			Disabled: continuing && !used,
+			OnSelect: func() {
+				if continuing {
+					m.play(n, true)
+				} else {
+					m.showDifficulty(n)
+				}
+			},
+		})
+	}
+	items = append(items, MenuItem{Label: T("menu.back"), OnSelect: m.showRoot})
+	m.newPage(items, m.showRoot)
+}
+
+func (m *MainMenuScene) showOptions() {
+	shake := func(delta float32) func() {
+		return func() {
+			settings.ScreenShake = max(0, min(1, settings.ScreenShake+delta))
+			m.showOptions()
+		}
+	}
+	language := func(dir int) func() {
+		return func() {
+			langs := locale.Languages()
+			i := slices.Index(langs, settings.Language)
+			settings.Language = langs[((i+dir)%len(langs)+len(langs))%len(langs)]
+			SetLanguage(settings.Language)
+			m.showOptions()
+		}
+	}
+	toggleSpeedrun := func() {
+		settings.SpeedrunTimer = !settings.SpeedrunTimer
+		m.showOptions()
+	}
+	profile := func(dir int) func() {
+		return func() {
+			names := inputProfileNames()
+			i := slices.Index(names, settings.InputProfile)
+			settings.InputProfile = names[((i+dir)%len(names)+len(names))%len(names)]
+			ApplyInputProfile(settings.InputProfile)
+			m.showOptions()
+		}
+	}
+	shadows := func(dir int) func() {
+		return func() {
+			i := slices.Index(shadowQualities, settings.Shadows)
+			settings.Shadows = shadowQualities[((i+dir)%len(shadowQualities)+len(shadowQualities))%len(shadowQualities)]
+			m.showOptions()
+		}
+	}
+	scale := func(dir int) func() {
+		return func() {
+			settings.RenderScale = max(minRenderScale, min(maxRenderScale, settings.RenderScale+dir*renderScaleStep))
+			m.showOptions()
+		}
+	}
+	toggleDynamic := func() {
+		settings.DynamicResolution = !settings.DynamicResolution
+		m.showOptions()
+	}
+	toggleAim := func() {
+		settings.AimAttacks = !settings.AimAttacks
+		m.showOptions()
+	}
+	togglePauseOnFocusLoss := func() {
+		settings.PauseOnFocusLoss = !settings.PauseOnFocusLoss
+		m.showOptions()
+	}
+	backgroundAudio := func(dir int) func() {
+		return func() {
+			i := slices.Index(backgroundAudioModes, settings.BackgroundAudio)
+			settings.BackgroundAudio = backgroundAudioModes[((i+dir)%len(backgroundAudioModes)+len(backgroundAudioModes))%len(backgroundAudioModes)]
+			m.showOptions()
+		}
+	}
+	toggleContrast := func() {
+		SetHighContrast(!highContrast)
+		m.showOptions()
+	}
+	toggleNarration := func() {
+		settings.Narration = !settings.Narration
+		narrator.Enabled = settings.Narration
+		m.showOptions()
+	}
+	back := func() {
+		if err := SaveSettings(profilePath(settingsPath), settings); err != nil {
+			log.Printf("settings: %v", err)
+		}
+		m.showRoot()
+	}
+
+	selected := 0
+	if m.page != nil {
+		selected = m.page.Selected
+	}
+	m.newPage([]MenuItem{
+		{Label: fmt.Sprintf("%s < %d%% >", T("options.shake"), int(settings.ScreenShake*100+0.5)), OnLeft: shake(-0.1), OnRight: shake(0.1)},
+		{Label: fmt.Sprintf("%s < %s >", T("options.language"), locale.Language()), OnLeft: language(-1), OnRight: language(1)},
+		{Label: fmt.Sprintf("%s < %s >", T("options.controls"), T("controls."+settings.InputProfile)), OnLeft: profile(-1), OnRight: profile(1)},
+		{Label: fmt.Sprintf("%s < %s >", T("options.aimAttacks"), onOff(settings.AimAttacks)), Tooltip: T("tooltip.aimAttacks"), OnLeft: toggleAim, OnRight: toggleAim},
+		{Label: fmt.Sprintf("%s < %s >", T("options.speedrun"), onOff(settings.SpeedrunTimer)), OnLeft: toggleSpeedrun, OnRight: toggleSpeedrun},
+		{Label: fmt.Sprintf("%s < %s >", T("options.narration"), onOff(settings.Narration)), OnLeft: toggleNarration, OnRight: toggleNarration},
+		{Label: fmt.Sprintf("%s < %s >", T("options.shadows"), T("shadows."+settings.Shadows)), OnLeft: shadows(-1), OnRight: shadows(1)},
+		{Label: fmt.Sprintf("%s < %d%% >", T("options.renderScale"), settings.RenderScale), Tooltip: T("tooltip.renderScale"), OnLeft: scale(-1), OnRight: scale(1)},
+		{Label: fmt.Sprintf("%s < %s >", T("options.dynamicResolution"), onOff(settings.DynamicResolution)), Tooltip: T("tooltip.dynamicResolution"), OnLeft: toggleDynamic, OnRight: toggleDynamic},
+		{Label: fmt.Sprintf("%s < %s >", T("options.pauseOnFocusLoss"), onOff(settings.PauseOnFocusLoss)), Tooltip: T("tooltip.pauseOnFocusLoss"), OnLeft: togglePauseOnFocusLoss, OnRight: togglePauseOnFocusLoss},
+		{Label: fmt.Sprintf("%s < %s >", T("options.backgroundAudio"), T("backgroundAudio."+settings.BackgroundAudio)), Tooltip: T("tooltip.backgroundAudio"), OnLeft: backgroundAudio(-1), OnRight: backgroundAudio(1)},
+		{Label: fmt.Sprintf("%s < %s >", T("options.contrast"), onOff(highContrast)), Tooltip: T("tooltip.contrast"), OnLeft: toggleContrast, OnRight: toggleContrast},
+		{Label: T("menu.back"), OnSelect: back},
+	}, back)
+	if selected < len(m.page.Items) {
+		m.page.Selected = selected
+	}
+}
+
+func onOff(on bool) string {
+	if on {
+		return T("options.on")
+	}
+	return T("options.off")
+}
+
+// showDifficulty offers the difficulty presets for a new game on slot n.
+func (m *MainMenuScene) showDifficulty(n int) {
+	back := func() { m.showSlots(false) }
+	var items []MenuItem
+	for _, name := range difficulties {
+		items = append(items, MenuItem{
+			Label:   T("difficulty." + name),
+			Tooltip: T("difficulty." + name + ".tip"),
+			OnSelect: func() {
+				m.play(n, false)
+				if m.plus {
+					StartNewGamePlus()
+				}
+				SetDifficulty(name)
+			},
+		})
+	}
+	items = append(items, MenuItem{Label: T("menu.back"), OnSelect: back})
+	m.newPage(items, back)
+	m.page.Selected = 1
+}
+
+// play starts the game on slot n, loading it when continuing.
+func (m *MainMenuScene) play(n int, continuing bool) {
+	currentSlot = n
+	ResetProgress()
+	if continuing {
+		if err := LoadGame(slotPath(n)); err != nil {
+			log.Printf("save: %v", err)
+		}
+	}
+	scenes.Replace(&GameScene{})
+}
+
+// playCoop starts an unsaved game with a second local player.
+func (m *MainMenuScene) playCoop() {
+	currentSlot = 0
+	ResetProgress()
+	scenes.Replace(&GameScene{Coop: true})
+}
+
+func (m *MainMenuScene) Update() {
+	if conflict, ok := cloudSaves.NextConflict(); ok {
+		scenes.Push(NewSaveConflictScene(conflict, m.showRoot))
+		return
+	}
+	m.updateIdle()
+	m.page.Update()
+}
+
+// updateIdle starts attract mode once the menu has gone without input for
+// attractIdle. Without a demo to play it tries again after another wait.
+func (m *MainMenuScene) updateIdle() {
+	mouse := rl.GetMouseDelta()
+	if anyInputPressed() || mouse.X != 0 || mouse.Y != 0 {
+		m.idle = time.Now()
+		return
+	}
+	if time.Since(m.idle) < attractIdle {
+		return
+	}
+	m.idle = time.Now()
+	replay, err := LoadAttractReplay()
+	if err != nil {
+		return
+	}
+	scenes.Push(NewAttractScene(replay, func() { m.idle = time.Now() }))
+}
+
+func (m *MainMenuScene) Draw() {
+	m.drawBackdrop()
+
+	logoArea := ui.Rect(UIRect{Anchor: AnchorTop, Offset: rl.NewVector2(0, 80), Size: rl.NewVector2(640, 240)})
+	if m.logo.Loaded {
+		scale := min(logoArea.Width/float32(m.logo.Texture.Width), logoArea.Height/float32(m.logo.Texture.Height))
+		w := float32(m.logo.Texture.Width) * scale
+		h := float32(m.logo.Texture.Height) * scale
+		pos := rl.NewVector2(logoArea.X+(logoArea.Width-w)/2, logoArea.Y+(logoArea.Height-h)/2)
+		rl.DrawTextureEx(m.logo.Texture, pos, 0, scale, rl.White)
+		m.logo.Drawn()
+	} else {
+		const title = "Raylib - Mohamed Sheta"
+		size := m.font.Measure(title, 72)
+		m.font.Draw(title, rl.NewVector2(logoArea.X+(logoArea.Width-size.X)/2, logoArea.Y+(logoArea.Height-size.Y)/2), 72, rl.RayWhite)
+	}
+
+	m.page.Draw()
+
+	// The build, so a screenshot of the title screen says what was played
+	version := buildinfo.Get().Short()
+	size := m.font.Measure(version, 18)
+	pos := ui.Rect(UIRect{Anchor: AnchorBottomLeft, Offset: rl.NewVector2(12, 12), Size: size})
+	m.font.Draw(version, rl.NewVector2(pos.X, pos.Y), 18, rl.Fade(rl.RayWhite, 0.6))
+}
+
+// drawBackdrop plays the background GIF on its own clock, slightly enlarged
+// and shifted against the mouse for a parallax effect.
+func (m *MainMenuScene) drawBackdrop() {
+	if background == nil || len(background.FrameTextures) == 0 || highContrast {
+		return
+	}
+	frame := 0
+	if background.FrameDelay > 0 {
+		frame = int(time.Since(m.started)/background.FrameDelay) % len(background.FrameTextures)
+	}
+	tex := background.FrameTextures[frame].Texture
+	background.FrameTextures[frame].Drawn()
+
+	mouse := rl.GetMousePosition()
+	shift := rl.NewVector2(
+		(mouse.X/screenSize.X-0.5)*2*menuParallax*screenSize.X,
+		(mouse.Y/screenSize.Y-0.5)*2*menuParallax*screenSize.Y,
+	)
+	margin := rl.NewVector2(menuParallax*screenSize.X, menuParallax*screenSize.Y)
+	dst := rl.NewRectangle(-margin.X-shift.X, -margin.Y-shift.Y, screenSize.X+2*margin.X, screenSize.Y+2*margin.Y)
+	src := rl.NewRectangle(0, 0, float32(tex.Width), float32(tex.Height))
+	rl.DrawTexturePro(tex, src, dst, rl.NewVector2(0, 0), 0, rl.White)
+	rl.DrawRectangle(0, 0, int32(screenSize.X), int32(screenSize.Y), rl.Fade(rl.Black, 0.35))
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// AudioBus groups sounds whose volume is set together
+type AudioBus int
+
+const (
+	BusMusic AudioBus = iota
+	BusSFX
+	BusUI
+	BusVideo
+	busCount
+)
+
+var busNames = [busCount]string{"music", "sfx", "ui", "video"}
+
+func (b AudioBus) String() string {
+	return busNames[b]
+}
+
+// Positional sounds fade out over this many world pixels from the camera
+// center, and are panned fully to one side at half of it
+const mixerHearingRange = 1600
+
+// Voice is a sound started through the mixer
+type Voice struct {
+	Name    string
+	Bus     AudioBus
+	Volume  float32 // before the bus gain
+	Pan     float32 // -1 left to 1 right
+	Started time.Time
+	Length  time.Duration
+	// Pos is where a positional sound comes from, in world coordinates
+	Pos        rl.Vector2
+	Positional bool
+
+	sound rl.Sound
+}
+
+// Remaining returns how much of the voice is left to play.
+func (v *Voice) Remaining() time.Duration {
+	return max(v.Length-time.Since(v.Started), 0)
+}
+
+// Mixer routes sounds to buses, each with a volume that can be muted or
+// soloed, and pans and attenuates positional sounds by where they are
+// relative to the camera. It keeps the voices still playing so the audio
+// debug panel can list them.
+type Mixer struct {
+	volume [busCount]float32
+	mute   [busCount]bool
+	solo   [busCount]bool
+	voices []*Voice
+}
+
+var mixer = &Mixer{volume: [busCount]float32{1, 1, 1, 1}}
+
+// Gain returns the volume of bus after muting and soloing.
+func (m *Mixer) Gain(bus AudioBus) float32 {
+	if m.mute[bus] {
+		return 0
+	}
+	for b, solo := range m.solo {
+		if solo && AudioBus(b) != bus && !m.solo[bus] {
+			return 0
+		}
+	}
+	return m.volume[bus]
+}
+
+// MusicVolume returns the volume the music stream plays at.
+func (m *Mixer) MusicVolume() float32 {
+	return settings.MusicVolume * m.Gain(BusMusic)
+}
+
+// Play starts sound on bus at volume, centered.
+func (m *Mixer) Play(name string, bus AudioBus, sound rl.Sound, volume float32) {
+	m.start(&Voice{Name: name, Bus: bus, Volume: volume, sound: sound})
+}
+
+// PlayAt starts sound on bus coming from pos in the world.
+func (m *Mixer) PlayAt(name string, bus AudioBus, sound rl.Sound, volume float32, pos rl.Vector2) {
+	m.start(&Voice{Name: name, Bus: bus, Volume: volume, Pos: pos, Positional: true, sound: sound})
+}
+
+func (m *Mixer) start(v *Voice) {
+	if !rl.IsSoundValid(v.sound) {
+		return
+	}
+	if v.Positional {
+		v.Pan, v.Volume = m.place(v.Pos, v.Volume)
+	}
+	v.Started = time.Now()
+	if rate := v.sound.Stream.SampleRate; rate > 0 {
+		v.Length = time.Duration(float64(v.sound.FrameCount) / float64(rate) * float64(time.Second))
+	}
+	// Playing a sound again restarts it, so it is one voice
+	for i, old := range m.voices {
+		if old.sound == v.sound {
+			m.voices[i] = v
+			m.apply(v)
+			rl.PlaySound(v.sound)
+			return
+		}
+	}
+	m.voices = append(m.voices, v)
+	m.apply(v)
+	rl.PlaySound(v.sound)
+}
+
+// place returns the pan and attenuated volume of a sound at pos.
+func (m *Mixer) place(pos rl.Vector2, volume float32) (pan, attenuated float32) {
+	view := camera.Visible()
+	center := rl.NewVector2(view.X+view.Width/2, view.Y+view.Height/2)
+	pan = min(max((pos.X-center.X)/(mixerHearingRange/2), -1), 1)
+	falloff := 1 - rl.Vector2Distance(pos, center)/mixerHearingRange
+	return pan, volume * min(max(falloff, 0), 1)
+}
+
+func (m *Mixer) apply(v *Voice) {
+	rl.SetSoundVolume(v.sound, v.Volume*m.Gain(v.Bus))
+	rl.SetSoundPan(v.sound, 0.5+v.Pan/2)
+}
+
+// applyAll pushes changed bus gains to everything playing.
+func (m *Mixer) applyAll() {
+	for _, v := range m.voices {
+		m.apply(v)
+	}
+	if rl.IsMusicValid(music) {
+		rl.SetMusicVolume(music, m.MusicVolume())
+	}
+	videoPlayer.SetVolume(m.Gain(BusVideo))
+}
+
+// SetVolume changes the volume of bus.
+func (m *Mixer) SetVolume(bus AudioBus, volume float32) {
+	m.volume[bus] = min(max(volume, 0), 1)
+	m.applyAll()
+}
+
+// ToggleMute mutes or unmutes bus.
+func (m *Mixer) ToggleMute(bus AudioBus) {
+	m.mute[bus] = !m.mute[bus]
+	m.applyAll()
+}
+
+// ToggleSolo solos bus, or stops soloing it. While any bus is soloed only
+// the soloed buses are heard.
+func (m *Mixer) ToggleSolo(bus AudioBus) {
+	m.solo[bus] = !m.solo[bus]
+	m.applyAll()
+}
+
+// Update forgets voices that finished playing.
+func (m *Mixer) Update() {
+	playing := m.voices[:0]
+	for _, v := range m.voices {
+		if rl.IsSoundPlaying(v.sound) {
+			playing = append(playing, v)
+		}
+	}
+	clear(m.voices[len(playing):])
+	m.voices = playing
+}
+
+// Voices returns the sounds playing now.
+func (m *Mixer) Voices() []*Voice {
+	return m.voices
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"raylibgo/pack"
+	"raylibgo/vfs"
+)
+
+// Mod describes an add-on pack found under the mods directory.
+// Files inside the mod folder mirror the game layout, so
+// mods/knight/assets/images/stand1.png replaces assets/images/stand1.png.
+// Levels are level files, by game path, added to the level graph under
+// their file name. Packs are asset packs in the mod folder, built by
+// running tools/pack there without a key.
+type Mod struct {
+	Name     string   `json:"name"`
+	Version  string   `json:"version"`
+	Priority int      `json:"priority"`
+	Levels   []string `json:"levels"`
+	Packs    []string `json:"packs"`
+	Dir      string   `json:"-"`
+}
+
+// ModConflict records a game path provided by more than one mod.
+// The last mod in load order wins.
+type ModConflict struct {
+	Path   string
+	Winner string
+	Losers []string
+}
+
+// ModManager scans the mods directory and resolves asset paths against loaded mods
+type ModManager struct {
+	mods      []*Mod
+	overrides map[string]string // game path -> file inside a mod
+	owners    map[string][]string
+	packs     []*pack.Reader
+	Conflicts []ModConflict
+}
+
+// NewModManager creates and returns an empty ModManager
+func NewModManager() *ModManager {
+	return &ModManager{
+		overrides: make(map[string]string),
+		owners:    make(map[string][]string),
+	}
+}
+
+// Load scans dir for mod folders containing a mod.json and builds the override table.
+// The order comes from dir/load_order.txt (one folder name per line); mods not listed
+// there follow, sorted by priority and then name. A missing mods directory is not an error.
+func (mm *ModManager) Load(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	found := make(map[string]*Mod)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		modDir := filepath.Join(dir, entry.Name())
+		mod, err := readModInfo(modDir)
+		if err != nil {
+			log.Printf("mods: skipping %s: %v", modDir, err)
+			continue
+		}
+		found[entry.Name()] = mod
+	}
+
+	mm.mods = orderMods(found, readLoadOrder(filepath.Join(dir, "load_order.txt")))
+
+	for _, mod := range mm.mods {
+		mm.addFiles(mod)
+	}
+	mm.collectConflicts()
+}
+
+// Resolve returns the file that should be read for the given game path,
+// which is either a mod override or the path itself.
+func (mm *ModManager) Resolve(path string) string {
+	if override, ok := mm.overrides[filepath.ToSlash(path)]; ok {
+		return override
+	}
+	return path
+}
+
+// Mods returns the loaded mods in load order.
+func (mm *ModManager) Mods() []*Mod {
+	return mm.mods
+}
+
+// Mount adds the mods' files to files: first their asset packs in load
+// order, then the loose files, which win over any pack.
+func (mm *ModManager) Mount(files *vfs.FS) {
+	for _, mod := range mm.mods {
+		for _, name := range mod.Packs {
+			r, err := pack.Open(filepath.Join(mod.Dir, name), nil)
+			if err != nil {
+				log.Printf("mods: %s: %v", mod.Name, err)
+				continue
+			}
+			mm.packs = append(mm.packs, r)
+			files.Mount(vfs.PackSource{Pack: r})
+			log.Printf("mods: %s: pack %s with %d files", mod.Name, name, len(r.Names()))
+		}
+	}
+	files.Mount(modSource{mods: mm})
+}
+
+// Close unmounts and closes the mods' asset packs.
+func (mm *ModManager) Close(files *vfs.FS) {
+	for _, r := range mm.packs {
+		files.Unmount(vfs.PackSource{Pack: r})
+		r.Close()
+	}
+	mm.packs = nil
+}
+
+// AddLevels reads the mods' level files into levels, in load order. A level
+// named like an existing one replaces its map and exits and keeps the rest,
+// such as its interactables.
+func (mm *ModManager) AddLevels(levels map[string]LevelNode) {
+	for _, mod := range mm.mods {
+		for _, path := range mod.Levels {
+			path = filepath.ToSlash(path)
+			_, f, err := LoadLevelFile(path)
+			if err != nil {
+				log.Printf("mods: %s: %v", mod.Name, err)
+				continue
+			}
+			name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+			node := levels[name]
+			node.Map = path
+			node.Exits = LevelExits(f)
+			levels[name] = node
+		}
+	}
+}
+
+func (mm *ModManager) addFiles(mod *Mod) {
+	filepath.WalkDir(mod.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(mod.Dir, path)
+		if err != nil || rel == "mod.json" {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		mm.overrides[rel] = path
+		mm.owners[rel] = append(mm.owners[rel], mod.Name)
+		return nil
+	})
+}
+
+func (mm *ModManager) collectConflicts() {
+	mm.Conflicts = nil
+	for path, owners := range mm.owners {
+		if len(owners) < 2 {
+			continue
+		}
+		conflict := ModConflict{
+			Path:   path,
+			Winner: owners[len(owners)-1],
+			Losers: owners[:len(owners)-1],
+		}
+		mm.Conflicts = append(mm.Conflicts, conflict)
+		log.Printf("mods: %s provided by %v, using %s", path, owners, conflict.Winner)
+	}
+	sort.Slice(mm.Conflicts, func(i, j int) bool { return mm.Conflicts[i].Path < mm.Conflicts[j].Path })
+}
+
+func readModInfo(dir string) (*Mod, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "mod.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	mod := &Mod{}
+	if err := json.Unmarshal(data, mod); err != nil {
+		return nil, err
+	}
+	if mod.Name == "" {
+		mod.Name = filepath.Base(dir)
+	}
+	mod.Dir = dir
+	return mod, nil
+}
+
+func readLoadOrder(path string) []string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var order []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		order = append(order, line)
+	}
+	return order
+}
+
+func orderMods(found map[string]*Mod, order []string) []*Mod {
+	var mods []*Mod
+	for _, name := range order {
+		if mod, ok := found[name]; ok {
+			mods = append(mods, mod)
+			delete(found, name)
+		}
+	}
+
+	var rest []*Mod
+	for _, mod := range found {
+		rest = append(rest, mod)
+	}
+	sort.Slice(rest, func(i, j int) bool {
+		if rest[i].Priority != rest[j].Priority {
+			return rest[i].Priority < rest[j].Priority
+		}
+		return rest[i].Name < rest[j].Name
+	})
+
+	return append(mods, rest...)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"log"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// SpeechBackend speaks text aloud. Speak interrupts whatever is playing.
+type SpeechBackend interface {
+	Speak(text string) error
+	Stop()
+}
+
+// commandSpeech pipes text to a speech engine run as a separate process,
+// either the platform's built-in one or an external engine from the settings
+type commandSpeech struct {
+	name string
+	args []string
+
+	mu  sync.Mutex
+	cmd *exec.Cmd
+}
+
+// platformSpeech returns the platform's built-in engine: System.Speech on
+// Windows, say on macOS and espeak-ng elsewhere.
+func platformSpeech() *commandSpeech {
+	switch runtime.GOOS {
+	case "windows":
+		return &commandSpeech{name: "powershell", args: []string{"-NoProfile", "-Command",
+			"Add-Type -AssemblyName System.Speech; (New-Object System.Speech.Synthesis.SpeechSynthesizer).Speak([Console]::In.ReadToEnd())"}}
+	case "darwin":
+		return &commandSpeech{name: "say", args: []string{"-f", "-"}}
+	}
+	return &commandSpeech{name: "espeak-ng", args: []string{"--stdin"}}
+}
+
+func (c *commandSpeech) Speak(text string) error {
+	c.Stop()
+	cmd := exec.Command(c.name, c.args...)
+	cmd.Stdin = strings.NewReader(text)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.cmd = cmd
+	c.mu.Unlock()
+	go cmd.Wait()
+	return nil
+}
+
+func (c *commandSpeech) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Killing a process that already finished just returns an error
+	if c.cmd != nil {
+		c.cmd.Process.Kill()
+	}
+	c.cmd = nil
+}
+
+// Narrator reads focused UI and on-screen prompts aloud for players who
+// can't read the screen. It listens for EventUIFocused and EventTextShown.
+type Narrator struct {
+	Enabled bool
+	Backend SpeechBackend
+
+	failed bool // the backend couldn't start; logged once
+}
+
+var narrator = &Narrator{}
+
+// Configure picks the backend: command, when set, is an external engine
+// that reads the text on stdin, otherwise the platform's engine is used.
+func (n *Narrator) Configure(enabled bool, command string) {
+	n.Enabled = enabled
+	n.failed = false
+	if fields := strings.Fields(command); len(fields) > 0 {
+		n.Backend = &commandSpeech{name: fields[0], args: fields[1:]}
+		return
+	}
+	n.Backend = platformSpeech()
+}
+
+// HandleEvent speaks the text carried by UI events.
+func (n *Narrator) HandleEvent(e Event) {
+	n.Say(e.Target)
+}
+
+// Say speaks text, interrupting the previous line. Rich text markup is
+// stripped and "[Action]" glyphs are read as the name of the bound key or
+// button.
+func (n *Narrator) Say(text string) {
+	if !n.Enabled || n.Backend == nil || text == "" {
+		return
+	}
+	if err := n.Backend.Speak(plainRichText(text)); err != nil && !n.failed {
+		n.failed = true
+		log.Printf("narrator: %v", err)
+	}
+}
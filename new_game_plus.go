@@ -0,0 +1,109 @@
+package main
+
+import (
+	"log"
+	"time"
+)
+
+// Each New Game Plus cycle makes enemies this much tougher and harder
+// hitting again, and sends this much more into every wave
+const (
+	plusEnemyStep  = 0.5
+	plusBudgetStep = 0.5
+	// plusEarlier brings each kind of wave enemy in this many waves sooner
+	// per cycle
+	plusEarlier = 2
+)
+
+// ngCycle counts the times the game was finished before this run: 0 on a
+// first playthrough, 1 in New Game Plus, 2 in the one after
+var ngCycle int
+
+// ProfileCompletion is what a profile has achieved by finishing the game.
+// Finishing once unlocks New Game Plus, which starts with the Equipment and
+// Unlocks the last finished game ended with.
+type ProfileCompletion struct {
+	Cleared   int       `json:"cleared"`   // times finished, counting NG+ runs
+	BestCycle int       `json:"bestCycle"` // highest cycle finished
+	First     time.Time `json:"first"`
+	Equipment []string  `json:"equipment,omitempty"`
+	Unlocks   []string  `json:"unlocks,omitempty"`
+}
+
+// RecordCompletion notes that the current profile finished cycle, carrying
+// equipment and unlocks into its next New Game Plus.
+func (s *ProfileStore) RecordCompletion(cycle int, equipment, unlocked []string) {
+	p := s.current
+	if p == nil {
+		return
+	}
+	if p.Completion == nil {
+		p.Completion = &ProfileCompletion{First: time.Now()}
+	}
+	p.Completion.Cleared++
+	p.Completion.BestCycle = max(p.Completion.BestCycle, cycle)
+	p.Completion.Equipment = equipment
+	p.Completion.Unlocks = unlocked
+	s.save()
+}
+
+// PlusUnlocked reports whether the current profile has finished the game.
+func PlusUnlocked() bool {
+	p := profiles.Current()
+	return p != nil && p.Completion != nil
+}
+
+// StartNewGamePlus turns the fresh game just started into the cycle after
+// the profile's best, with the equipment and abilities it finished with.
+func StartNewGamePlus() {
+	c := profiles.Current().Completion
+	ngCycle = c.BestCycle + 1
+	player.RestoreEquipment(c.Equipment)
+	unlocks.Restore(c.Unlocks)
+}
+
+// plusRules toughens r for cycle.
+func plusRules(r GameRules, cycle int) GameRules {
+	scale := 1 + plusEnemyStep*float32(cycle)
+	r.EnemyHealth *= scale
+	r.EnemyDamage *= scale
+	return r
+}
+
+// plusWaves remixes a level's waves for cycle: bigger waves, stronger
+// kinds of enemy sooner, and spawn points mirrored across the level so
+// enemies come from unfamiliar sides. The first playthrough gets def as is.
+func plusWaves(def *WaveDef, cycle int) *WaveDef {
+	if cycle == 0 {
+		return def
+	}
+	remix := *def
+	remix.Budget = int(float32(def.Budget) * (1 + plusBudgetStep*float32(cycle)))
+	remix.Enemies = make([]WaveEnemy, len(def.Enemies))
+	for i, kind := range def.Enemies {
+		kind.FromWave = max(kind.FromWave-plusEarlier*cycle, 0)
+		remix.Enemies[i] = kind
+	}
+	world := camera.World
+	remix.Points = make([]SpawnPoint, len(def.Points))
+	for i, p := range def.Points {
+		remix.Points[i] = SpawnPoint{X: 2*world.X + world.Width - p.X, Y: p.Y}
+	}
+	return &remix
+}
+
+// HandleGameCompleted ends the game when an ending quest is completed:
+// the profile records it, the save slot keeps the finished game, and the
+// credits roll.
+func HandleGameCompleted(e Event) {
+	if e.Type != EventQuestCompleted || !quests.Ending(e.Target) {
+		return
+	}
+	profiles.RecordCompletion(ngCycle, player.Equipment(), unlocks.Names())
+	if currentSlot > 0 {
+		if err := SaveGame(slotPath(currentSlot)); err != nil {
+			log.Printf("save: %v", err)
+		}
+	}
+	scenes.Replace(&CreditsScene{})
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// NinePatch is a UI skin image cut into a 3x3 grid by its borders. When
+// drawn at any size the corners keep their size, the edges stretch along
+// one axis and the center fills the rest.
+type NinePatch struct {
+	Path string
+	// Border widths in texels
+	Left, Top, Right, Bottom int32
+
+	texture *Texture
+}
+
+// UI skins. A skin whose image is missing draws nothing, and callers fall
+// back to their flat look.
+var (
+	SkinPanel    = &NinePatch{Path: "assets/images/ui/panel.png", Left: 16, Top: 16, Right: 16, Bottom: 16}
+	SkinButton   = &NinePatch{Path: "assets/images/ui/button.png", Left: 12, Top: 8, Right: 12, Bottom: 8}
+	SkinDialogue = &NinePatch{Path: "assets/images/ui/dialogue.png", Left: 24, Top: 20, Right: 24, Bottom: 20}
+)
+
+// DrawNinePatch draws p stretched over dst and reports whether it did. It
+// draws nothing when the skin's image is missing or high contrast mode
+// wants plain panels.
+func DrawNinePatch(p *NinePatch, dst rl.Rectangle, tint rl.Color) bool {
+	if highContrast {
+		return false
+	}
+	if p.texture == nil {
+		if !AssetExists(p.Path) {
+			return false
+		}
+		p.texture = tm.Acquire(p.Path, 0, 0)
+	}
+	if !p.texture.Loaded {
+		return false
+	}
+
+	tex := p.texture.Texture
+	info := rl.NPatchInfo{
+		Source: rl.NewRectangle(0, 0, float32(tex.Width), float32(tex.Height)),
+		Left:   p.Left,
+		Top:    p.Top,
+		Right:  p.Right,
+		Bottom: p.Bottom,
+		Layout: rl.NPatchNinePatch,
+	}
+	rl.DrawTextureNPatch(tex, info, dst, rl.NewVector2(0, 0), 0, tint)
+	p.texture.Drawn()
+	return true
+}
@@ -0,0 +1,64 @@
+// Package pack reads and writes asset pack files: a single archive holding
+// many assets, optionally encrypted with AES-256-GCM.
+//
+// Layout (little endian):
+//
+//	magic "RLPK" | version u16 | flags u16 | count u32
+//	count x (nameLen u16 | name | offset u64 | stored u64 | size u64)
+//	entry data
+//
+// Encrypted entries start with an 8 byte random nonce prefix followed by
+// chunks of up to ChunkSize plaintext bytes, each sealed separately with
+// the nonce prefix plus the chunk index, so entries can be streamed.
+package pack
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	magic   = "RLPK"
+	version = 1
+
+	flagEncrypted = 1 << 0
+
+	// ChunkSize is the plaintext size of each encrypted chunk
+	ChunkSize = 64 * 1024
+
+	noncePrefixSize = 8
+)
+
+// ErrNotFound is returned for names that are not in the pack
+var ErrNotFound = errors.New("pack: file not found")
+
+// ErrKeyRequired is returned when opening an encrypted pack without a key
+var ErrKeyRequired = errors.New("pack: encrypted pack needs a key")
+
+type entry struct {
+	name   string
+	offset int64
+	stored int64 // bytes on disk, including nonces and tags
+	size   int64 // plaintext bytes
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("pack: key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func chunkNonce(prefix []byte, index uint32) []byte {
+	nonce := make([]byte, noncePrefixSize+4)
+	copy(nonce, prefix)
+	binary.LittleEndian.PutUint32(nonce[noncePrefixSize:], index)
+	return nonce
+}
@@ -0,0 +1,181 @@
+package pack
+
+import (
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// Reader gives access to the files in an opened pack
+type Reader struct {
+	file    *os.File
+	entries map[string]entry
+	aead    cipher.AEAD
+}
+
+// Open reads the pack index at path. key is required for encrypted packs
+// and ignored for plain ones.
+func Open(path string, key []byte) (*Reader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	r, err := newReader(file, key)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("pack %s: %w", path, err)
+	}
+	return r, nil
+}
+
+func newReader(file *os.File, key []byte) (*Reader, error) {
+	var header [12]byte
+	if _, err := io.ReadFull(file, header[:]); err != nil {
+		return nil, err
+	}
+	if string(header[:4]) != magic {
+		return nil, errors.New("not a pack file")
+	}
+	if v := binary.LittleEndian.Uint16(header[4:]); v != version {
+		return nil, fmt.Errorf("unsupported version %d", v)
+	}
+	flags := binary.LittleEndian.Uint16(header[6:])
+	count := binary.LittleEndian.Uint32(header[8:])
+
+	r := &Reader{file: file, entries: make(map[string]entry, count)}
+	if flags&flagEncrypted != 0 {
+		if key == nil {
+			return nil, ErrKeyRequired
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		r.aead = aead
+	}
+
+	var buf [24]byte
+	for i := uint32(0); i < count; i++ {
+		if _, err := io.ReadFull(file, buf[:2]); err != nil {
+			return nil, err
+		}
+		name := make([]byte, binary.LittleEndian.Uint16(buf[:2]))
+		if _, err := io.ReadFull(file, name); err != nil {
+			return nil, err
+		}
+		if _, err := io.ReadFull(file, buf[:24]); err != nil {
+			return nil, err
+		}
+		r.entries[string(name)] = entry{
+			name:   string(name),
+			offset: int64(binary.LittleEndian.Uint64(buf[0:])),
+			stored: int64(binary.LittleEndian.Uint64(buf[8:])),
+			size:   int64(binary.LittleEndian.Uint64(buf[16:])),
+		}
+	}
+	return r, nil
+}
+
+// Close releases the pack file.
+func (r *Reader) Close() error {
+	return r.file.Close()
+}
+
+// Has reports whether name is in the pack.
+func (r *Reader) Has(name string) bool {
+	_, ok := r.entries[name]
+	return ok
+}
+
+// Names returns every file name in the pack, sorted.
+func (r *Reader) Names() []string {
+	names := make([]string, 0, len(r.entries))
+	for name := range r.entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Size returns the plaintext size of name.
+func (r *Reader) Size(name string) (int64, error) {
+	e, ok := r.entries[name]
+	if !ok {
+		return 0, ErrNotFound
+	}
+	return e.size, nil
+}
+
+// Open returns a stream of the file's contents, decrypting chunk by chunk.
+// Streams read through the pack with ReadAt, so several can be open at once.
+func (r *Reader) Open(name string) (io.Reader, error) {
+	e, ok := r.entries[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	section := io.NewSectionReader(r.file, e.offset, e.stored)
+	if r.aead == nil {
+		return section, nil
+	}
+
+	prefix := make([]byte, noncePrefixSize)
+	if _, err := io.ReadFull(section, prefix); err != nil {
+		return nil, err
+	}
+	return &decryptReader{src: section, aead: r.aead, prefix: prefix, remaining: e.size}, nil
+}
+
+// ReadFile returns the whole contents of name.
+func (r *Reader) ReadFile(name string) ([]byte, error) {
+	stream, err := r.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	size, _ := r.Size(name)
+	data := make([]byte, size)
+	_, err = io.ReadFull(stream, data)
+	return data, err
+}
+
+type decryptReader struct {
+	src       io.Reader
+	aead      cipher.AEAD
+	prefix    []byte
+	chunk     uint32
+	buf       []byte
+	remaining int64 // plaintext bytes not yet decrypted
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	if len(d.buf) == 0 {
+		if d.remaining <= 0 {
+			return 0, io.EOF
+		}
+		if err := d.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, d.buf)
+	d.buf = d.buf[n:]
+	return n, nil
+}
+
+func (d *decryptReader) next() error {
+	plain := min(d.remaining, ChunkSize)
+	sealed := make([]byte, plain+int64(d.aead.Overhead()))
+	if _, err := io.ReadFull(d.src, sealed); err != nil {
+		return err
+	}
+	out, err := d.aead.Open(sealed[:0], chunkNonce(d.prefix, d.chunk), sealed, nil)
+	if err != nil {
+		return fmt.Errorf("pack: chunk %d: %w", d.chunk, err)
+	}
+	d.chunk++
+	d.remaining -= plain
+	d.buf = out
+	return nil
+}
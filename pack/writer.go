@@ -0,0 +1,89 @@
+package pack
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+)
+
+// File is a named asset to store in a pack
+type File struct {
+	Name string
+	Data []byte
+}
+
+// Write stores files in w. A non-nil key encrypts every entry with AES-256-GCM.
+func Write(w io.Writer, files []File, key []byte) error {
+	var aead cipher.AEAD
+	flags := uint16(0)
+	if key != nil {
+		var err error
+		if aead, err = newAEAD(key); err != nil {
+			return err
+		}
+		flags |= flagEncrypted
+	}
+
+	headerSize := int64(4 + 2 + 2 + 4)
+	for _, f := range files {
+		headerSize += 2 + int64(len(f.Name)) + 8 + 8 + 8
+	}
+
+	payloads := make([][]byte, len(files))
+	offset := headerSize
+	var index []byte
+	for i, f := range files {
+		data := f.Data
+		if aead != nil {
+			sealed, err := seal(aead, f.Data)
+			if err != nil {
+				return err
+			}
+			data = sealed
+		}
+		payloads[i] = data
+
+		index = binary.LittleEndian.AppendUint16(index, uint16(len(f.Name)))
+		index = append(index, f.Name...)
+		index = binary.LittleEndian.AppendUint64(index, uint64(offset))
+		index = binary.LittleEndian.AppendUint64(index, uint64(len(data)))
+		index = binary.LittleEndian.AppendUint64(index, uint64(len(f.Data)))
+		offset += int64(len(data))
+	}
+
+	header := []byte(magic)
+	header = binary.LittleEndian.AppendUint16(header, version)
+	header = binary.LittleEndian.AppendUint16(header, flags)
+	header = binary.LittleEndian.AppendUint32(header, uint32(len(files)))
+
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	if _, err := w.Write(index); err != nil {
+		return err
+	}
+	for _, p := range payloads {
+		if _, err := w.Write(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func seal(aead cipher.AEAD, data []byte) ([]byte, error) {
+	prefix := make([]byte, noncePrefixSize)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, err
+	}
+
+	out := append([]byte{}, prefix...)
+	for i := uint32(0); ; i++ {
+		n := min(len(data), ChunkSize)
+		out = aead.Seal(out, chunkNonce(prefix, i), data[:n], nil)
+		data = data[n:]
+		if len(data) == 0 {
+			return out, nil
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// maxGamepads is how many gamepad slots raylib tracks
+const maxGamepads = 4
+
+// PadWatcher pauses gameplay when a gamepad someone is playing with
+// disconnects, holding the dialogue input context until it recovers. Play resumes when that pad comes back, when any button is
+// pressed on another free pad, which then takes its place, or when a key
+// is pressed to carry on with the keyboard.
+type PadWatcher struct {
+	lost []*InputBinding
+	font *Font
+}
+
+var pads = &PadWatcher{}
+
+// activeBindings returns the bindings players are currently reading.
+func activeBindings() []*InputBinding {
+	if coop.Active {
+		return coopBindings[:]
+	}
+	return []*InputBinding{playerBinding}
+}
+
+// Update notices disconnects and handles the ways of recovering from one.
+// It reports whether gameplay should hold this frame, which includes the
+// frame play resumes on so the press that resumed it isn't also played.
+func (p *PadWatcher) Update() bool {
+	if len(p.lost) == 0 {
+		for _, b := range activeBindings() {
+			if b.usingPad && !rl.IsGamepadAvailable(b.Gamepad) {
+				p.lost = append(p.lost, b)
+			}
+		}
+		if len(p.lost) > 0 {
+			inputContexts.Set(p, ContextDialogue, true)
+			perf.Note("gamepad", "disconnected")
+			events.Publish(Event{Type: EventTextShown, Target: T("controller.disconnected")})
+		}
+		return len(p.lost) > 0
+	}
+
+	keyboard := rl.GetKeyPressed() != 0
+	lost := p.lost[:0]
+	for _, b := range p.lost {
+		switch {
+		case rl.IsGamepadAvailable(b.Gamepad):
+			// The same pad came back
+		case keyboard:
+			b.usingPad = false
+		default:
+			pad, ok := freePadPressed()
+			if !ok {
+				lost = append(lost, b)
+				continue
+			}
+			b.Gamepad = pad
+			if b == playerBinding {
+				gamepadIndex = pad
+			}
+		}
+	}
+	p.lost = lost
+	if len(p.lost) == 0 {
+		inputContexts.Set(p, ContextDialogue, false)
+		live = InputFrame{}
+		coop.live = InputFrame{}
+		perf.Note("gamepad", "resumed")
+	}
+	return true
+}
+
+// freePadPressed returns a connected pad no player is bound to on which a
+// button was just pressed.
+func freePadPressed() (int32, bool) {
+	bound := make(map[int32]bool)
+	for _, b := range activeBindings() {
+		bound[b.Gamepad] = true
+	}
+	for pad := int32(0); pad < maxGamepads; pad++ {
+		if bound[pad] || !rl.IsGamepadAvailable(pad) {
+			continue
+		}
+		for button := int32(rl.GamepadButtonLeftFaceUp); button <= rl.GamepadButtonRightThumb; button++ {
+			if rl.IsGamepadButtonPressed(pad, button) {
+				return pad, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Paused reports whether gameplay waits for a controller.
+func (p *PadWatcher) Paused() bool {
+	return len(p.lost) > 0
+}
+
+// Reset forgets disconnects, for when gameplay is left.
+func (p *PadWatcher) Reset() {
+	p.lost = nil
+	inputContexts.Set(p, ContextDialogue, false)
+}
+
+// Draw shows the disconnected dialog while gameplay waits.
+func (p *PadWatcher) Draw() {
+	if !p.Paused() {
+		return
+	}
+	if p.font == nil {
+		p.font = fonts.Acquire("", 32)
+	}
+	rl.DrawRectangle(0, 0, int32(screenSize.X), int32(screenSize.Y), rl.Fade(rl.Black, 0.5))
+
+	title := T("controller.disconnected")
+	if coop.Active {
+		for i, b := range coopBindings {
+			if b == p.lost[0] {
+				title = fmt.Sprintf("P%d: %s", i+1, title)
+			}
+		}
+	}
+	body := T("controller.reconnect")
+	wrap := 560 * ui.Scale()
+	bodySize := MeasureRichText(p.font, body, 22, wrap)
+	titleSize := p.font.Measure(title, 32)
+	width := max(titleSize.X, bodySize.X) + 64
+	panel := ui.Rect(UIRect{Anchor: AnchorCenter, Size: rl.NewVector2(width, titleSize.Y+bodySize.Y+72)})
+	if !DrawNinePatch(SkinDialogue, panel, rl.White) {
+		DrawRoundedRect(panel, 12, rl.Fade(rl.Black, 0.85))
+	}
+	p.font.Draw(title, rl.NewVector2(panel.X+(panel.Width-titleSize.X)/2, panel.Y+24), 32, rl.Gold)
+	DrawRichText(p.font, body, rl.NewVector2(panel.X+32, panel.Y+titleSize.Y+48), 22, wrap, rl.RayWhite)
+}
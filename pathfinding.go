@@ -0,0 +1,316 @@
+package main
+
+import (
+	"container/heap"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Number of A* node expansions the path queue may spend per frame
+const pathNodeBudget = 400
+
+// WalkableFunc reports whether an agent may occupy the given cell
+type WalkableFunc func(t *Tilemap, p TilePoint) bool
+
+// FreeWalkable allows every non-solid cell, suitable for flying agents.
+func FreeWalkable(t *Tilemap, p TilePoint) bool {
+	return !t.IsSolid(p.X, p.Y)
+}
+
+// GroundWalkable allows cells a ground agent can stand on, plus cells up to
+// jumpTiles above a standable cell so paths may hop onto platforms.
+func GroundWalkable(jumpTiles int) WalkableFunc {
+	return func(t *Tilemap, p TilePoint) bool {
+		if t.IsSolid(p.X, p.Y) {
+			return false
+		}
+		for dy := 0; dy <= jumpTiles; dy++ {
+			if t.IsSolid(p.X, p.Y+dy) {
+				return false
+			}
+			if t.IsStandable(p.X, p.Y+dy) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// PathRequest is a queued path search. Done is set once the search finishes;
+// Path holds world-space waypoints and is empty when no path exists.
+type PathRequest struct {
+	From     rl.Vector2
+	To       rl.Vector2
+	Walkable WalkableFunc
+	OnDone   func(path []rl.Vector2)
+	Path     []rl.Vector2
+	Done     bool
+
+	search *pathSearch
+}
+
+// PathQueue runs path requests incrementally so a burst of requests
+// never costs more than a fixed number of node expansions per frame.
+// Update only reads the tilemap, so it may run as a job; Deliver then
+// calls the callbacks on the main goroutine.
+type PathQueue struct {
+	pending  []*PathRequest
+	finished []*PathRequest
+}
+
+// NewPathQueue creates and returns an empty PathQueue
+func NewPathQueue() *PathQueue {
+	return &PathQueue{}
+}
+
+// Request queues a search on the given tilemap and returns its handle.
+// onDone may be nil; the result is also available on the request.
+func (pq *PathQueue) Request(t *Tilemap, from, to rl.Vector2, walkable WalkableFunc, onDone func([]rl.Vector2)) *PathRequest {
+	if walkable == nil {
+		walkable = FreeWalkable
+	}
+	req := &PathRequest{
+		From:     from,
+		To:       to,
+		Walkable: walkable,
+		OnDone:   onDone,
+	}
+	req.search = newPathSearch(t, t.WorldToTile(from), t.WorldToTile(to), walkable)
+	pq.pending = append(pq.pending, req)
+	return req
+}
+
+// Update advances queued searches in FIFO order until the budget is spent.
+func (pq *PathQueue) Update(budget int) {
+	for len(pq.pending) > 0 && budget > 0 {
+		req := pq.pending[0]
+		finished, used := req.search.step(budget)
+		budget -= used
+		if !finished {
+			return
+		}
+
+		if cells := req.search.result(); cells != nil {
+			req.Path = smoothPath(req.search.tilemap, cells, req.Walkable)
+		}
+		req.Done = true
+		req.search = nil
+		pq.pending = pq.pending[1:]
+		pq.finished = append(pq.finished, req)
+	}
+}
+
+// Deliver calls OnDone for every request finished since the last call.
+func (pq *PathQueue) Deliver() {
+	for _, req := range pq.finished {
+		if req.OnDone != nil {
+			req.OnDone(req.Path)
+		}
+	}
+	pq.finished = pq.finished[:0]
+}
+
+// Pending returns the number of searches not yet finished.
+func (pq *PathQueue) Pending() int {
+	return len(pq.pending)
+}
+
+type pathNode struct {
+	point TilePoint
+	f     int
+	index int
+}
+
+type pathHeap []*pathNode
+
+func (h pathHeap) Len() int           { return len(h) }
+func (h pathHeap) Less(i, j int) bool { return h[i].f < h[j].f }
+func (h pathHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+func (h *pathHeap) Push(x any) {
+	n := x.(*pathNode)
+	n.index = len(*h)
+	*h = append(*h, n)
+}
+func (h *pathHeap) Pop() any {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
+
+// pathSearch holds resumable A* state. Costs are scaled by 10 so diagonal
+// steps (14) stay integral.
+type pathSearch struct {
+	tilemap  *Tilemap
+	start    TilePoint
+	goal     TilePoint
+	walkable WalkableFunc
+	open     pathHeap
+	nodes    map[TilePoint]*pathNode
+	gScore   map[TilePoint]int
+	cameFrom map[TilePoint]TilePoint
+	closed   map[TilePoint]bool
+	found    bool
+}
+
+func newPathSearch(t *Tilemap, start, goal TilePoint, walkable WalkableFunc) *pathSearch {
+	s := &pathSearch{
+		tilemap:  t,
+		start:    start,
+		goal:     goal,
+		walkable: walkable,
+		nodes:    make(map[TilePoint]*pathNode),
+		gScore:   map[TilePoint]int{start: 0},
+		cameFrom: make(map[TilePoint]TilePoint),
+		closed:   make(map[TilePoint]bool),
+	}
+	if walkable(t, start) && walkable(t, goal) {
+		s.push(start, heuristic(start, goal))
+	}
+	return s
+}
+
+func (s *pathSearch) push(p TilePoint, f int) {
+	if n, ok := s.nodes[p]; ok {
+		n.f = f
+		heap.Fix(&s.open, n.index)
+		return
+	}
+	n := &pathNode{point: p, f: f}
+	s.nodes[p] = n
+	heap.Push(&s.open, n)
+}
+
+// step expands up to budget nodes and reports whether the search is over.
+func (s *pathSearch) step(budget int) (bool, int) {
+	used := 0
+	for used < budget {
+		if s.open.Len() == 0 {
+			return true, used
+		}
+		current := heap.Pop(&s.open).(*pathNode).point
+		delete(s.nodes, current)
+		used++
+
+		if current == s.goal {
+			s.found = true
+			return true, used
+		}
+		s.closed[current] = true
+
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				if dx == 0 && dy == 0 {
+					continue
+				}
+				next := TilePoint{current.X + dx, current.Y + dy}
+				if s.closed[next] || !s.walkable(s.tilemap, next) {
+					continue
+				}
+				cost := 10
+				if dx != 0 && dy != 0 {
+					// No cutting corners past solid tiles
+					if !s.walkable(s.tilemap, TilePoint{current.X + dx, current.Y}) ||
+						!s.walkable(s.tilemap, TilePoint{current.X, current.Y + dy}) {
+						continue
+					}
+					cost = 14
+				}
+				g := s.gScore[current] + cost
+				if old, ok := s.gScore[next]; ok && g >= old {
+					continue
+				}
+				s.gScore[next] = g
+				s.cameFrom[next] = current
+				s.push(next, g+heuristic(next, s.goal))
+			}
+		}
+	}
+	return false, used
+}
+
+func (s *pathSearch) result() []TilePoint {
+	if !s.found {
+		return nil
+	}
+	cells := []TilePoint{s.goal}
+	for p := s.goal; p != s.start; {
+		p = s.cameFrom[p]
+		cells = append(cells, p)
+	}
+	for i, j := 0, len(cells)-1; i < j; i, j = i+1, j-1 {
+		cells[i], cells[j] = cells[j], cells[i]
+	}
+	return cells
+}
+
+func heuristic(a, b TilePoint) int {
+	dx := abs(a.X - b.X)
+	dy := abs(a.Y - b.Y)
+	return 10*(dx+dy) - 6*min(dx, dy)
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// smoothPath drops intermediate cells whenever a straight line between
+// two kept cells crosses only walkable tiles, then converts to world space.
+func smoothPath(t *Tilemap, cells []TilePoint, walkable WalkableFunc) []rl.Vector2 {
+	kept := []TilePoint{cells[0]}
+	anchor := 0
+	for i := 2; i < len(cells); i++ {
+		if !lineWalkable(t, cells[anchor], cells[i], walkable) {
+			anchor = i - 1
+			kept = append(kept, cells[anchor])
+		}
+	}
+	if len(cells) > 1 {
+		kept = append(kept, cells[len(cells)-1])
+	}
+
+	path := make([]rl.Vector2, 0, len(kept))
+	for _, p := range kept {
+		path = append(path, t.TileCenter(p))
+	}
+	return path
+}
+
+// lineWalkable walks the cells along a Bresenham line between a and b.
+func lineWalkable(t *Tilemap, a, b TilePoint, walkable WalkableFunc) bool {
+	dx := abs(b.X - a.X)
+	dy := -abs(b.Y - a.Y)
+	sx, sy := 1, 1
+	if a.X > b.X {
+		sx = -1
+	}
+	if a.Y > b.Y {
+		sy = -1
+	}
+	err := dx + dy
+	x, y := a.X, a.Y
+	for {
+		if !walkable(t, TilePoint{x, y}) {
+			return false
+		}
+		if x == b.X && y == b.Y {
+			return true
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y += sy
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/metrics"
+	"strings"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"raylibgo/buildinfo"
+)
+
+const (
+	perfReportKey = rl.KeyF7
+	perfReportDir = "perf_reports"
+
+	perfNoteWindow = 500 * time.Millisecond // notes this old still count toward a stutter
+	perfMaxNotes   = 64
+	perfMaxStalls  = 100
+)
+
+// perfBuckets are the upper bounds of the frame time histogram
+var perfBuckets = []time.Duration{
+	4 * time.Millisecond,
+	8 * time.Millisecond,
+	12 * time.Millisecond,
+	17 * time.Millisecond,
+	25 * time.Millisecond,
+	34 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+}
+
+// PerfNote is something that happened recently and may explain a slow frame
+type PerfNote struct {
+	At     time.Time
+	Kind   string
+	Detail string
+}
+
+// Stutter is a frame that took longer than the threshold
+type Stutter struct {
+	Frame   uint64
+	At      time.Time
+	Total   time.Duration
+	CPU     time.Duration
+	Present time.Duration
+	Notes   []PerfNote
+}
+
+// PerfMonitor records frame times split into CPU work and present time, keeps
+// a histogram, and flags stutters together with the events just before them.
+// raylib has no GPU timer queries, so present time (EndDrawing, including the
+// driver flush and frame limiter wait) stands in for GPU time.
+type PerfMonitor struct {
+	// StutterFactor flags frames longer than this many display refreshes
+	StutterFactor float64
+
+	frame      uint64
+	frameStart time.Time
+	cpuDone    time.Time
+	histogram  []int
+	worstCPU   time.Duration
+	lastTotal  time.Duration
+	lastCPU    time.Duration
+	notes      []PerfNote
+	stutters   []Stutter
+	gcSample   []metrics.Sample
+	gcCycles   uint64
+}
+
+var perf = NewPerfMonitor()
+
+// NewPerfMonitor creates a PerfMonitor flagging frames over two refreshes
+func NewPerfMonitor() *PerfMonitor {
+	return &PerfMonitor{
+		StutterFactor: 2,
+		histogram:     make([]int, len(perfBuckets)+1),
+		gcSample:      []metrics.Sample{{Name: "/gc/cycles/total:gc-cycles"}},
+	}
+}
+
+// BeginFrame marks the start of a frame.
+func (p *PerfMonitor) BeginFrame() {
+	p.frameStart = time.Now()
+	p.cpuDone = time.Time{}
+}
+
+// MarkCPUDone marks the point where the frame is submitted, just before EndDrawing.
+func (p *PerfMonitor) MarkCPUDone() {
+	p.cpuDone = time.Now()
+}
+
+// Note records an event that may explain a later slow frame.
+func (p *PerfMonitor) Note(kind, detail string) {
+	p.notes = append(p.notes, PerfNote{At: time.Now(), Kind: kind, Detail: detail})
+	if len(p.notes) > perfMaxNotes {
+		p.notes = p.notes[len(p.notes)-perfMaxNotes:]
+	}
+}
+
+// Last returns the total and CPU time of the most recently finished frame.
+func (p *PerfMonitor) Last() (total, cpu time.Duration) {
+	return p.lastTotal, p.lastCPU
+}
+
+// EndFrame records the finished frame and checks it for a stutter.
+func (p *PerfMonitor) EndFrame() {
+	if p.frameStart.IsZero() {
+		return
+	}
+	end := time.Now()
+	total := end.Sub(p.frameStart)
+	cpu, present := total, time.Duration(0)
+	if !p.cpuDone.IsZero() {
+		cpu = p.cpuDone.Sub(p.frameStart)
+		present = end.Sub(p.cpuDone)
+	}
+	p.frame++
+	p.lastTotal, p.lastCPU = total, cpu
+	p.worstCPU = max(p.worstCPU, cpu)
+	p.histogram[p.bucket(total)]++
+
+	metrics.Read(p.gcSample)
+	if cycles := p.gcSample[0].Value.Uint64(); cycles != p.gcCycles {
+		if p.gcCycles != 0 {
+			p.Note("gc", fmt.Sprintf("%d cycle(s)", cycles-p.gcCycles))
+		}
+		p.gcCycles = cycles
+	}
+
+	limit := time.Duration(float64(pacing.FrameInterval()) * p.StutterFactor)
+	if total > limit {
+		s := Stutter{Frame: p.frame, At: end, Total: total, CPU: cpu, Present: present}
+		for _, n := range p.notes {
+			if end.Sub(n.At) <= perfNoteWindow {
+				s.Notes = append(s.Notes, n)
+			}
+		}
+		p.stutters = append(p.stutters, s)
+		if len(p.stutters) > perfMaxStalls {
+			p.stutters = p.stutters[1:]
+		}
+	}
+}
+
+func (p *PerfMonitor) bucket(d time.Duration) int {
+	for i, limit := range perfBuckets {
+		if d < limit {
+			return i
+		}
+	}
+	return len(perfBuckets)
+}
+
+func bucketLabel(i int) string {
+	if i == len(perfBuckets) {
+		return fmt.Sprintf(">%3.0f ms", ms(perfBuckets[i-1]))
+	}
+	return fmt.Sprintf("<%3.0f ms", ms(perfBuckets[i]))
+}
+
+// DebugLines shows the histogram and the latest stutter for the debug overlay.
+func (p *PerfMonitor) DebugLines() []string {
+	peak := 1
+	for _, n := range p.histogram {
+		peak = max(peak, n)
+	}
+	var lines []string
+	for i, n := range p.histogram {
+		bar := strings.Repeat("|", n*30/peak)
+		lines = append(lines, fmt.Sprintf("%s %-30s %d", bucketLabel(i), bar, n))
+	}
+	lines = append(lines, fmt.Sprintf("Stutters %d, worst CPU %.2f ms, F7 to save report", len(p.stutters), ms(p.worstCPU)))
+	if len(p.stutters) > 0 {
+		s := p.stutters[len(p.stutters)-1]
+		lines = append(lines, fmt.Sprintf("Last: frame %d %.1f ms%s", s.Frame, ms(s.Total), noteSummary(s.Notes)))
+	}
+	return lines
+}
+
+func noteSummary(notes []PerfNote) string {
+	if len(notes) == 0 {
+		return ""
+	}
+	kinds := make(map[string]int)
+	var order []string
+	for _, n := range notes {
+		if kinds[n.Kind] == 0 {
+			order = append(order, n.Kind)
+		}
+		kinds[n.Kind]++
+	}
+	parts := make([]string, len(order))
+	for i, k := range order {
+		parts[i] = fmt.Sprintf("%s x%d", k, kinds[k])
+	}
+	return " after " + strings.Join(parts, ", ")
+}
+
+// WriteReport saves the histogram and every recorded stutter as a text file
+// that can be attached to bug reports.
+func (p *PerfMonitor) WriteReport(path string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Performance report %s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(&b, "Build %s\n", buildinfo.Get())
+	fmt.Fprintf(&b, "%s/%s, %d CPUs, %s\n", runtime.GOOS, runtime.GOARCH, runtime.NumCPU(), runtime.Version())
+	fmt.Fprintf(&b, "Monitor %q @ %d Hz, %dx%d\n", rl.GetMonitorName(pacing.monitor), pacing.RefreshRate(), rl.GetScreenWidth(), rl.GetScreenHeight())
+	fmt.Fprintf(&b, "Frames %d, stutter threshold %.1f ms\n\n", p.frame, ms(time.Duration(float64(pacing.FrameInterval())*p.StutterFactor)))
+
+	b.WriteString("Frame time histogram\n")
+	for i, n := range p.histogram {
+		fmt.Fprintf(&b, "  %s  %d\n", bucketLabel(i), n)
+	}
+
+	fmt.Fprintf(&b, "\nStutters (%d)\n", len(p.stutters))
+	for _, s := range p.stutters {
+		fmt.Fprintf(&b, "  frame %d at %s: total %.2f ms, cpu %.2f ms, present %.2f ms\n",
+			s.Frame, s.At.Format("15:04:05.000"), ms(s.Total), ms(s.CPU), ms(s.Present))
+		for _, n := range s.Notes {
+			fmt.Fprintf(&b, "    -%4.0f ms %s: %s\n", ms(s.At.Sub(n.At)), n.Kind, n.Detail)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
+
+// HandlePerfReport writes a report on the debug hotkey.
+func HandlePerfReport() {
+	if !rl.IsKeyPressed(perfReportKey) {
+		return
+	}
+	path := filepath.Join(perfReportDir, "perf-"+time.Now().Format("20060102-150405")+".txt")
+	if err := perf.WriteReport(path); err != nil {
+		log.Printf("perf: %v", err)
+		return
+	}
+	log.Printf("perf: report saved to %s", path)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"slices"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"raylibgo/tween"
+)
+
+const (
+	pickupRadius = 14
+	// pickupBurstTime is how long a collected pickup takes to float away
+	pickupBurstTime = 400 * time.Millisecond
+)
+
+// Pickup is a collectible lying in the level. Touching it publishes
+// EventItemCollected with the pickup's name as the target.
+type Pickup struct {
+	Name  string
+	Pos   rl.Vector2
+	Taken bool
+}
+
+// pickups are the collectibles in the current level
+var pickups []Pickup
+
+// pickupBurst is a collected pickup rising, swelling and fading out
+type pickupBurst struct {
+	Pos   rl.Vector2
+	Scale float32
+	Color rl.Color
+}
+
+var pickupBursts []*pickupBurst
+
+// burst plays the collect effect for a pickup at pos.
+func burst(pos rl.Vector2) {
+	b := &pickupBurst{Pos: pos, Scale: 1, Color: rl.Gold}
+	pickupBursts = append(pickupBursts, b)
+	tweens.Add(tween.To(&b.Pos, rl.NewVector2(pos.X, pos.Y-28), pickupBurstTime, rl.Vector2Lerp).Ease(tween.OutQuad))
+	tweens.Add(tween.Float(&b.Scale, 1.6, pickupBurstTime).Ease(tween.OutBack))
+	tweens.Add(tween.To(&b.Color, rl.Fade(rl.White, 0), pickupBurstTime/2, rl.ColorLerp).Delay(pickupBurstTime / 2).
+		OnDone(func() {
+			if i := slices.Index(pickupBursts, b); i >= 0 {
+				pickupBursts = slices.Delete(pickupBursts, i, i+1)
+			}
+		}))
+}
+
+// UpdatePickups collects every pickup the player touches.
+func UpdatePickups() {
+	body := PlayerBounds()
+	for i := range pickups {
+		p := &pickups[i]
+		if p.Taken || !rl.CheckCollisionCircleRec(p.Pos, pickupRadius, body) {
+			continue
+		}
+		p.Taken = true
+		burst(p.Pos)
+		events.Publish(Event{Type: EventItemCollected, Target: p.Name, Amount: 1, Pos: p.Pos})
+	}
+}
+
+// PickupsLeft returns how many pickups have not been collected.
+func PickupsLeft() int {
+	n := 0
+	for _, p := range pickups {
+		if !p.Taken {
+			n++
+		}
+	}
+	return n
+}
+
+// DrawPickups draws the remaining pickups bobbing in place, and those just
+// collected floating away.
+func DrawPickups() {
+	// Up for half a second, down for the other half
+	bob := float32(clock.Tick%60) / 30
+	if bob > 1 {
+		bob = 2 - bob
+	}
+	for _, p := range pickups {
+		if p.Taken {
+			continue
+		}
+		y := p.Pos.Y - 4*tween.InOutSine(bob)
+		rl.DrawCircleV(rl.NewVector2(p.Pos.X, y), pickupRadius, rl.Gold)
+		rl.DrawCircleLinesV(rl.NewVector2(p.Pos.X, y), pickupRadius, contrast(rl.Orange, contrastPickup))
+	}
+	for _, b := range pickupBursts {
+		rl.DrawCircleLinesV(b.Pos, pickupRadius*b.Scale, b.Color)
+	}
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// shapeFeather is the width in pixels of the fringe that fades each shape edge
+// to transparent, which gives the helpers below their anti-aliasing.
+const shapeFeather = 1.0
+
+// arcSegments picks how many segments an arc of the given radius and sweep
+// needs to look round without wasting vertices on small corners.
+func arcSegments(radius, sweep float32) int {
+	n := int(math.Ceil(float64(radius*sweep) / 4))
+	return max(n, 2)
+}
+
+// shapeTriangle emits one triangle, swapping the winding if needed so raylib
+// doesn't cull it.
+func shapeTriangle(a, b, c rl.Vector2, ca, cb, cc rl.Color) {
+	if (b.X-a.X)*(c.Y-a.Y)-(b.Y-a.Y)*(c.X-a.X) > 0 {
+		b, c = c, b
+		cb, cc = cc, cb
+	}
+	for _, v := range [...]struct {
+		p rl.Vector2
+		c rl.Color
+	}{{a, ca}, {b, cb}, {c, cc}} {
+		rl.Color4ub(v.c.R, v.c.G, v.c.B, v.c.A)
+		rl.Vertex2f(v.p.X, v.p.Y)
+	}
+}
+
+// shapeQuad emits the quad a-b-c-d as two triangles.
+func shapeQuad(a, b, c, d rl.Vector2, ca, cb, cc, cd rl.Color) {
+	shapeTriangle(a, b, c, ca, cb, cc)
+	shapeTriangle(a, c, d, ca, cc, cd)
+}
+
+func transparent(c rl.Color) rl.Color {
+	c.A = 0
+	return c
+}
+
+// fillConvex fills a convex outline with per-vertex colors. The solid part is
+// shrunk by half the feather and a fading fringe is added outside it, so the
+// visible edge stays where the outline says.
+func fillConvex(points []rl.Vector2, colors []rl.Color) {
+	n := len(points)
+	if n < 3 {
+		return
+	}
+	var center rl.Vector2
+	for _, p := range points {
+		center = rl.Vector2Add(center, p)
+	}
+	center = rl.Vector2Scale(center, 1/float32(n))
+
+	inner := make([]rl.Vector2, n)
+	outer := make([]rl.Vector2, n)
+	for i, p := range points {
+		prev := points[(i+n-1)%n]
+		next := points[(i+1)%n]
+		normal := rl.Vector2Add(edgeNormal(prev, p, center), edgeNormal(p, next, center))
+		if rl.Vector2Length(normal) == 0 {
+			normal = rl.Vector2Subtract(p, center)
+		}
+		normal = rl.Vector2Scale(rl.Vector2Normalize(normal), shapeFeather/2)
+		inner[i] = rl.Vector2Subtract(p, normal)
+		outer[i] = rl.Vector2Add(p, normal)
+	}
+
+	var r, g, b, a int
+	for _, c := range colors {
+		r, g, b, a = r+int(c.R), g+int(c.G), b+int(c.B), a+int(c.A)
+	}
+	centerColor := rl.NewColor(uint8(r/n), uint8(g/n), uint8(b/n), uint8(a/n))
+
+	rl.CheckRenderBatchLimit(int32(n * 9))
+	rl.Begin(rl.Triangles)
+	for i := range n {
+		j := (i + 1) % n
+		shapeTriangle(center, inner[i], inner[j], centerColor, colors[i], colors[j])
+		shapeQuad(inner[i], outer[i], outer[j], inner[j], colors[i], transparent(colors[i]), transparent(colors[j]), colors[j])
+	}
+	rl.End()
+}
+
+// edgeNormal returns the unit normal of the edge a-b pointing away from center.
+func edgeNormal(a, b, center rl.Vector2) rl.Vector2 {
+	d := rl.Vector2Normalize(rl.Vector2Subtract(b, a))
+	normal := rl.NewVector2(d.Y, -d.X)
+	if rl.Vector2DotProduct(normal, rl.Vector2Subtract(a, center)) < 0 {
+		normal = rl.Vector2Negate(normal)
+	}
+	return normal
+}
+
+// arcPoints appends points along a circle from start to end, in radians.
+func arcPoints(points []rl.Vector2, center rl.Vector2, radius, start, end float32) []rl.Vector2 {
+	segments := arcSegments(radius, float32(math.Abs(float64(end-start))))
+	for i := 0; i <= segments; i++ {
+		angle := float64(start + (end-start)*float32(i)/float32(segments))
+		points = append(points, rl.NewVector2(
+			center.X+radius*float32(math.Cos(angle)),
+			center.Y+radius*float32(math.Sin(angle)),
+		))
+	}
+	return points
+}
+
+// roundedRectPoints outlines rect with corners of the given radius, clamped so
+// opposite corners never overlap.
+func roundedRectPoints(rect rl.Rectangle, radius float32) []rl.Vector2 {
+	radius = min(radius, rect.Width/2, rect.Height/2)
+	if radius <= 0 {
+		return []rl.Vector2{
+			{X: rect.X, Y: rect.Y},
+			{X: rect.X + rect.Width, Y: rect.Y},
+			{X: rect.X + rect.Width, Y: rect.Y + rect.Height},
+			{X: rect.X, Y: rect.Y + rect.Height},
+		}
+	}
+	left, top := rect.X+radius, rect.Y+radius
+	right, bottom := rect.X+rect.Width-radius, rect.Y+rect.Height-radius
+	var points []rl.Vector2
+	points = arcPoints(points, rl.NewVector2(left, top), radius, math.Pi, math.Pi*1.5)
+	points = arcPoints(points, rl.NewVector2(right, top), radius, math.Pi*1.5, math.Pi*2)
+	points = arcPoints(points, rl.NewVector2(right, bottom), radius, 0, math.Pi*0.5)
+	points = arcPoints(points, rl.NewVector2(left, bottom), radius, math.Pi*0.5, math.Pi)
+	return points
+}
+
+// DrawRoundedRect fills rect with anti-aliased corners of the given radius in
+// pixels.
+func DrawRoundedRect(rect rl.Rectangle, radius float32, color rl.Color) {
+	DrawRoundedRectGradient(rect, radius, color, color, false)
+}
+
+// DrawRoundedRectGradient fills rect blending from one color to the other,
+// top to bottom or left to right when horizontal is set.
+func DrawRoundedRectGradient(rect rl.Rectangle, radius float32, from, to rl.Color, horizontal bool) {
+	if rect.Width <= 0 || rect.Height <= 0 {
+		return
+	}
+	points := roundedRectPoints(rect, radius)
+	colors := make([]rl.Color, len(points))
+	for i, p := range points {
+		t := (p.Y - rect.Y) / rect.Height
+		if horizontal {
+			t = (p.X - rect.X) / rect.Width
+		}
+		colors[i] = rl.ColorLerp(from, to, rl.Clamp(t, 0, 1))
+	}
+	fillConvex(points, colors)
+}
+
+// DrawCapsule fills the rounded segment from a to b.
+func DrawCapsule(a, b rl.Vector2, radius float32, color rl.Color) {
+	if radius <= 0 {
+		return
+	}
+	d := rl.Vector2Subtract(b, a)
+	angle := float32(math.Atan2(float64(d.Y), float64(d.X)))
+	var points []rl.Vector2
+	points = arcPoints(points, b, radius, angle-math.Pi/2, angle+math.Pi/2)
+	points = arcPoints(points, a, radius, angle+math.Pi/2, angle+math.Pi*1.5)
+	colors := make([]rl.Color, len(points))
+	for i := range colors {
+		colors[i] = color
+	}
+	fillConvex(points, colors)
+}
+
+// DrawRing fills the band between inner and outer radius from startAngle to
+// endAngle, in degrees clockwise from the right. Both curved edges are
+// feathered; the ends of a partial ring are left hard.
+func DrawRing(center rl.Vector2, inner, outer, startAngle, endAngle float32, color rl.Color) {
+	if outer <= inner || endAngle == startAngle {
+		return
+	}
+	start := startAngle * rl.Deg2rad
+	end := endAngle * rl.Deg2rad
+	segments := arcSegments(outer, float32(math.Abs(float64(end-start))))
+	radii := [4]float32{max(inner-shapeFeather/2, 0), inner + shapeFeather/2, outer - shapeFeather/2, outer + shapeFeather/2}
+	colors := [4]rl.Color{transparent(color), color, color, transparent(color)}
+
+	rl.CheckRenderBatchLimit(int32(segments * 18))
+	rl.Begin(rl.Triangles)
+	var prev [4]rl.Vector2
+	for i := 0; i <= segments; i++ {
+		angle := float64(start + (end-start)*float32(i)/float32(segments))
+		dir := rl.NewVector2(float32(math.Cos(angle)), float32(math.Sin(angle)))
+		var cur [4]rl.Vector2
+		for k, r := range radii {
+			cur[k] = rl.Vector2Add(center, rl.Vector2Scale(dir, r))
+		}
+		if i > 0 {
+			for k := range 3 {
+				shapeQuad(prev[k], prev[k+1], cur[k+1], cur[k], colors[k], colors[k+1], colors[k+1], colors[k])
+			}
+		}
+		prev = cur
+	}
+	rl.End()
+}
+
+// DrawCooldown draws a ring that empties clockwise from the top as remaining
+// goes from 1 to 0, over a faint full ring for the spent part.
+func DrawCooldown(center rl.Vector2, radius, thickness, remaining float32, color rl.Color) {
+	DrawRing(center, radius-thickness, radius, 0, 360, rl.Fade(rl.Black, 0.5))
+	remaining = rl.Clamp(remaining, 0, 1)
+	if remaining > 0 {
+		DrawRing(center, radius-thickness, radius, -90, -90+360*remaining, color)
+	}
+}
+
+// DrawHUDBar draws a rounded meter filled to fill (0-1), shading the fill from
+// color down to a darker tone so it reads without a texture.
+func DrawHUDBar(bar rl.Rectangle, fill float32, color rl.Color) {
+	radius := bar.Height / 2
+	DrawRoundedRect(bar, radius, rl.Fade(rl.Black, 0.6))
+	fill = rl.Clamp(fill, 0, 1)
+	if fill <= 0 {
+		return
+	}
+	inset := float32(2)
+	inner := rl.NewRectangle(bar.X+inset, bar.Y+inset, (bar.Width-inset*2)*fill, bar.Height-inset*2)
+	DrawRoundedRectGradient(inner, radius-inset, rl.ColorBrightness(color, 0.25), rl.ColorBrightness(color, -0.35), false)
+}
@@ -0,0 +1,206 @@
+// Package procgen generates side-scrolling platform levels from a seed.
+// A level is a left-to-right run of rooms and corridors joined by steps and
+// gaps; every step and gap is sized from the player's movement so it can
+// always be jumped.
+package procgen
+
+import (
+	"errors"
+	"math/rand/v2"
+
+	"raylibgo/level"
+)
+
+// Movement is the player's movement in pixels and ticks, matching the
+// game's gravity, jump force and run speed
+type Movement struct {
+	Gravity   float32
+	JumpForce float32 // negative, as applied to the vertical velocity
+	Speed     float32
+}
+
+// JumpHeight returns the highest a jump rises, in pixels.
+func (m Movement) JumpHeight() float32 {
+	v := -m.JumpForce
+	return v * v / (2 * m.Gravity)
+}
+
+// JumpDistance returns how far a running jump carries on flat ground, in pixels.
+func (m Movement) JumpDistance() float32 {
+	return m.Speed * 2 * -m.JumpForce / m.Gravity
+}
+
+// Config controls a generated level. Zero values for the optional fields
+// fall back to sensible defaults.
+type Config struct {
+	Seed     uint64
+	Width    int // in tiles
+	Height   int
+	TileSize float32
+	Move     Movement
+	// Terrain is the id painted for solid ground
+	Terrain int
+	// Margin scales the movement limits down so jumps are comfortable
+	// rather than pixel perfect; defaults to 0.75
+	Margin float32
+	// ExitTo names the level the exit at the far end leads to
+	ExitTo string
+}
+
+// Segment kinds that make up the platform rhythm
+const (
+	segRoom = iota
+	segCorridor
+	segGap
+	segStep
+)
+
+const (
+	minRoom         = 8
+	maxRoom         = 16
+	minCorridor     = 4
+	maxCorridor     = 8
+	corridorHeadway = 4 // free rows between a corridor floor and its ceiling
+	landingWidth    = 3 // ground kept after every gap
+	maxJumpsInRow   = 2
+)
+
+type generator struct {
+	cfg        Config
+	rand       *rand.Rand
+	file       *level.File
+	x          int
+	floor      int // row of the ground surface
+	maxStep    int // tiles the player can climb with a jump
+	maxGap     int // tiles the player can clear with a jump
+	minFloor   int
+	maxFloor   int
+	jumpsInRow int
+}
+
+// Generate builds a level from cfg. The same config always produces the
+// same level.
+func Generate(cfg Config) (*level.File, error) {
+	if cfg.Width < 2*maxRoom || cfg.Height < 8 || cfg.TileSize <= 0 {
+		return nil, errors.New("procgen: level is too small")
+	}
+	if cfg.Move.Gravity <= 0 || cfg.Move.JumpForce >= 0 || cfg.Move.Speed <= 0 {
+		return nil, errors.New("procgen: movement cannot jump")
+	}
+	if cfg.Margin <= 0 {
+		cfg.Margin = 0.75
+	}
+	if cfg.Terrain == 0 {
+		cfg.Terrain = 1
+	}
+
+	g := &generator{
+		cfg:      cfg,
+		rand:     rand.New(rand.NewPCG(cfg.Seed, cfg.Seed^0x9e3779b97f4a7c15)),
+		file:     level.New(cfg.Width, cfg.Height, cfg.TileSize),
+		maxStep:  int(cfg.Move.JumpHeight() * cfg.Margin / cfg.TileSize),
+		maxGap:   int(cfg.Move.JumpDistance() * cfg.Margin / cfg.TileSize),
+		minFloor: cfg.Height / 3,
+		maxFloor: cfg.Height - 2,
+	}
+	if g.maxGap < 1 || g.maxStep < 1 {
+		return nil, errors.New("procgen: jumps don't clear one tile")
+	}
+	g.floor = (g.minFloor + g.maxFloor) / 2
+
+	// Always start on flat ground
+	g.room(minRoom)
+	g.file.Spawn = level.Point{X: 1, Y: g.floor - 1}
+
+	for g.x < cfg.Width-maxRoom {
+		g.next()
+	}
+	g.room(cfg.Width - g.x)
+
+	if cfg.ExitTo != "" {
+		g.file.Exits = []level.Exit{{To: cfg.ExitTo, X: cfg.Width - 2, Y: g.floor - 3, Width: 2, Height: 3}}
+	}
+	return g.file, nil
+}
+
+// next picks the following segment. Gaps and steps always end on ground
+// and no more than maxJumpsInRow jumps come back to back.
+func (g *generator) next() {
+	kind := g.rand.IntN(4)
+	if (kind == segGap || kind == segStep) && g.jumpsInRow >= maxJumpsInRow {
+		kind = segRoom
+	}
+	switch kind {
+	case segRoom:
+		g.jumpsInRow = 0
+		g.room(minRoom + g.rand.IntN(maxRoom-minRoom+1))
+	case segCorridor:
+		g.jumpsInRow = 0
+		g.corridor(minCorridor + g.rand.IntN(maxCorridor-minCorridor+1))
+	case segGap:
+		g.jumpsInRow++
+		g.gap(1 + g.rand.IntN(g.maxGap))
+		g.ground(landingWidth)
+	case segStep:
+		g.jumpsInRow++
+		g.step()
+		g.ground(landingWidth)
+	}
+}
+
+// column fills one column of ground from the floor down.
+func (g *generator) column(x int) {
+	for y := g.floor; y < g.cfg.Height; y++ {
+		g.file.Set(x, y, g.cfg.Terrain)
+	}
+}
+
+func (g *generator) ground(width int) {
+	for range width {
+		g.column(g.x)
+		g.x++
+	}
+}
+
+// room is open ground, with a floating platform in wider rooms.
+func (g *generator) room(width int) {
+	start := g.x
+	g.ground(width)
+	if width >= 10 && g.maxStep >= 2 {
+		rise := 2 + g.rand.IntN(g.maxStep-1)
+		px := start + 2 + g.rand.IntN(width-6)
+		for x := px; x < px+3; x++ {
+			g.file.Set(x, g.floor-rise, g.cfg.Terrain)
+		}
+	}
+}
+
+// corridor is ground with a low ceiling.
+func (g *generator) corridor(width int) {
+	ceiling := g.floor - corridorHeadway - 1
+	if ceiling < 0 {
+		g.ground(width)
+		return
+	}
+	for range width {
+		g.column(g.x)
+		for y := 0; y <= ceiling; y++ {
+			g.file.Set(g.x, y, g.cfg.Terrain)
+		}
+		g.x++
+	}
+}
+
+func (g *generator) gap(width int) {
+	g.x += width
+}
+
+// step moves the floor up by at most a jump's height, or down by any amount
+// that stays within the level.
+func (g *generator) step() {
+	delta := 1 + g.rand.IntN(g.maxStep)
+	if g.rand.IntN(2) == 0 {
+		delta = -delta
+	}
+	g.floor = max(g.minFloor, min(g.maxFloor, g.floor-delta))
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	profileAvatarSize = 48
+	profileNameLength = 16
+)
+
+// ProfileSelectScene lets the player pick who is playing, or create a new
+// profile with a name and avatar. It opens at startup and from the main menu.
+type ProfileSelectScene struct {
+	font    *Font
+	sounds  *UISounds
+	menu    *MenuList
+	avatars []*Texture
+
+	creating bool
+	name     *TextInput
+	avatar   int
+}
+
+// NewProfileSelectScene creates the profile screen.
+func NewProfileSelectScene() *ProfileSelectScene {
+	return &ProfileSelectScene{}
+}
+
+func (s *ProfileSelectScene) Load(scope *AssetScope) {
+	s.font = scope.Font("", 40)
+	s.sounds = LoadUISounds(menuMoveSound, menuSelectSound)
+	for _, path := range profileAvatars {
+		s.avatars = append(s.avatars, scope.Acquire(path, profileAvatarSize, profileAvatarSize))
+	}
+	if len(profiles.List()) == 0 {
+		s.showCreate()
+	} else {
+		s.showList()
+	}
+}
+
+func (s *ProfileSelectScene) Unload() {
+	s.sounds.Unload()
+	if s.name != nil {
+		s.name.Blur()
+	}
+}
+
+func (s *ProfileSelectScene) newMenu(items []MenuItem, onBack func()) {
+	s.menu = &MenuList{
+		Layout:   UIRect{Anchor: AnchorCenter, Offset: rl.NewVector2(0, 60), Size: rl.NewVector2(480, 420)},
+		Font:     s.font,
+		FontSize: 40,
+		Spacing:  20,
+		OnBack:   onBack,
+		Sounds:   s.sounds,
+	}
+	s.menu.SetItems(items)
+}
+
+func (s *ProfileSelectScene) showList() {
+	s.creating = false
+	var items []MenuItem
+	for _, p := range profiles.List() {
+		items = append(items, MenuItem{Label: p.Name, OnSelect: func() { s.choose(p) }})
+	}
+	items = append(items, MenuItem{Label: T("profile.new"), OnSelect: s.showCreate})
+	s.newMenu(items, nil)
+}
+
+// showCreate asks for a name, typed into a text field, and an avatar picked
+// with left and right.
+func (s *ProfileSelectScene) showCreate() {
+	s.creating = true
+	field := ui.Rect(UIRect{Anchor: AnchorCenter, Offset: rl.NewVector2(0, -60), Size: rl.NewVector2(480, 60)})
+	s.name = NewTextInput(field, T("profile.name"), profileNameLength)
+	s.name.OnSubmit = func(string) { s.create() }
+	s.name.Focus()
+
+	pick := func(dir int) func() {
+		return func() { s.avatar = avatarIndex(s.avatar + dir) }
+	}
+	var onBack func()
+	if len(profiles.List()) > 0 {
+		onBack = s.showList
+	}
+	s.newMenu([]MenuItem{
+		{Label: T("profile.avatar"), OnLeft: pick(-1), OnRight: pick(1)},
+		{Label: T("profile.create"), OnSelect: s.create},
+	}, onBack)
+	s.menu.Layout.Offset.Y = 160
+}
+
+func (s *ProfileSelectScene) create() {
+	name := strings.TrimSpace(s.name.Text())
+	if name == "" {
+		s.name.Focus()
+		return
+	}
+	s.name.Blur()
+	s.choose(profiles.Create(name, s.avatar))
+}
+
+func (s *ProfileSelectScene) choose(p *Profile) {
+	profiles.Select(p)
+	scenes.Replace(NewMainMenuScene())
+}
+
+func (s *ProfileSelectScene) Update() {
+	if s.creating {
+		// Keys typed into the name must not also move through the menu
+		typing := s.name.Focused()
+		s.name.Update()
+		if typing {
+			return
+		}
+	}
+	s.menu.Update()
+}
+
+func (s *ProfileSelectScene) Draw() {
+	title := T("profile.title")
+	if s.creating {
+		title = T("profile.new")
+	}
+	area := ui.Rect(UIRect{Anchor: AnchorTop, Offset: rl.NewVector2(0, 80), Size: rl.NewVector2(640, 60)})
+	w := s.font.Measure(title, 56).X
+	s.font.Draw(title, rl.NewVector2(area.X+(area.Width-w)/2, area.Y), 56, rl.Gold)
+
+	if s.creating {
+		s.name.Draw()
+		// The avatar sits beside its row
+		if len(s.menu.rects) > 0 {
+			r := s.menu.rects[0]
+			s.drawAvatar(s.avatar, "", rl.NewVector2(r.X+r.Width+16, r.Y+(r.Height-profileAvatarSize)/2))
+		}
+	} else {
+		for i, p := range profiles.List() {
+			if i < len(s.menu.rects) {
+				r := s.menu.rects[i]
+				s.drawAvatar(p.Avatar, p.Name, rl.NewVector2(r.X-profileAvatarSize-16, r.Y+(r.Height-profileAvatarSize)/2))
+			}
+		}
+	}
+	s.menu.Draw()
+}
+
+// drawAvatar draws the avatar image, or a colored badge with the name's
+// initial when the image is missing.
+func (s *ProfileSelectScene) drawAvatar(avatar int, name string, pos rl.Vector2) {
+	i := avatarIndex(avatar)
+	if tex := s.avatars[i]; tex.Loaded {
+		rl.DrawTexture(tex.Texture, int32(pos.X), int32(pos.Y), rl.White)
+		tex.Drawn()
+		return
+	}
+	colors := []rl.Color{rl.SkyBlue, rl.Lime, rl.Purple, rl.Orange}
+	center := rl.NewVector2(pos.X+profileAvatarSize/2, pos.Y+profileAvatarSize/2)
+	rl.DrawCircleV(center, profileAvatarSize/2, colors[i%len(colors)])
+	if name != "" {
+		initial := strings.ToUpper(string([]rune(name)[:1]))
+		size := s.font.Measure(initial, 28)
+		s.font.Draw(initial, rl.NewVector2(center.X-size.X/2, center.Y-size.Y/2), 28, rl.Black)
+	}
+}
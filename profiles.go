@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	profilesDir   = "profiles"
+	profilesIndex = "profiles/profiles.json"
+)
+
+// Avatars a profile can pick from; a missing image draws as a colored badge
+var profileAvatars = []string{
+	"assets/images/avatars/knight.png",
+	"assets/images/avatars/archer.png",
+	"assets/images/avatars/mage.png",
+	"assets/images/avatars/rogue.png",
+}
+
+// Profile is one person playing on this machine. Their settings, saves and
+// records live in their own directory under profilesDir.
+type Profile struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Avatar     int       `json:"avatar"` // index into profileAvatars
+	Created    time.Time `json:"created"`
+	LastPlayed time.Time `json:"lastPlayed"`
+	// Completion is set once the profile has finished the game
+	Completion *ProfileCompletion `json:"completion,omitempty"`
+}
+
+// avatarIndex wraps i into profileAvatars.
+func avatarIndex(i int) int {
+	return (i%len(profileAvatars) + len(profileAvatars)) % len(profileAvatars)
+}
+
+// ProfileStore keeps the list of profiles and which one is playing
+type ProfileStore struct {
+	list    []*Profile
+	current *Profile
+}
+
+var profiles = &ProfileStore{}
+
+// profilePath returns rel inside the current profile's directory, or rel
+// itself before a profile has been chosen.
+func profilePath(rel string) string {
+	if profiles.current == nil {
+		return rel
+	}
+	return path.Join(profilesDir, profiles.current.ID, rel)
+}
+
+// Load reads the profile index, most recently played first.
+func (s *ProfileStore) Load() {
+	data, err := os.ReadFile(profilesIndex)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("profiles: %v", err)
+		}
+		return
+	}
+	if err := json.Unmarshal(data, &s.list); err != nil {
+		log.Printf("profiles: %s: %v", profilesIndex, err)
+		return
+	}
+	slices.SortFunc(s.list, func(a, b *Profile) int { return b.LastPlayed.Compare(a.LastPlayed) })
+}
+
+func (s *ProfileStore) save() {
+	data, err := json.MarshalIndent(s.list, "", "  ")
+	if err == nil {
+		err = writeFileAtomic(profilesIndex, data)
+	}
+	if err != nil {
+		log.Printf("profiles: %v", err)
+	}
+}
+
+// List returns the profiles, most recently played first.
+func (s *ProfileStore) List() []*Profile {
+	return s.list
+}
+
+// Current returns the profile playing, or nil before one is chosen.
+func (s *ProfileStore) Current() *Profile {
+	return s.current
+}
+
+// Create adds a profile named name. Its directory name is derived from the
+// name and made unique.
+func (s *ProfileStore) Create(name string, avatar int) *Profile {
+	base := profileID(name)
+	id := base
+	for n := 2; slices.ContainsFunc(s.list, func(p *Profile) bool { return p.ID == id }); n++ {
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+	p := &Profile{ID: id, Name: name, Avatar: avatar, Created: time.Now()}
+	s.list = append(s.list, p)
+	s.save()
+	return p
+}
+
+// Select makes p the current profile and loads its settings. A profile
+// without a settings file keeps the machine's settings as its starting point.
+func (s *ProfileStore) Select(p *Profile) {
+	s.current = p
+	p.LastPlayed = time.Now()
+	s.save()
+
+	if loaded, err := LoadSettings(profilePath(settingsPath)); err == nil {
+		settings = loaded
+	} else if !os.IsNotExist(err) {
+		log.Printf("settings: %v", err)
+	}
+	settings.PlayerName = p.Name
+	settings.ValidateDisplay()
+	ApplySettings()
+	SetLanguage(settings.Language)
+	ConfigureCloudSaves()
+	stats.Load()
+	achievements.Load()
+	currentSlot = 0
+}
+
+// profileID turns a display name into a safe directory name.
+func profileID(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '_':
+			b.WriteByte('-')
+		}
+	}
+	if b.Len() == 0 {
+		return "player"
+	}
+	return b.String()
+}
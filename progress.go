@@ -0,0 +1,278 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	progressKey = rl.KeyP
+	// collectiblePrefix starts the pickup name, and the world flag raised
+	// once it is found, of every collectible: "collectible:<level>:<id>"
+	collectiblePrefix = "collectible:"
+)
+
+// Collectible is a pickup placed in a level for completionists
+type Collectible struct {
+	ID string  `json:"id"`
+	X  float32 `json:"x"`
+	Y  float32 `json:"y"`
+}
+
+func collectibleFlag(level, id string) string {
+	return collectiblePrefix + level + ":" + id
+}
+
+// collectiblesLevel is the level whose collectibles are placed
+var collectiblesLevel string
+
+// UpdateCollectibles places the collectibles of a level not yet found on
+// entering it, and takes away those of the level left.
+func UpdateCollectibles() {
+	if collectiblesLevel == currentLevel {
+		return
+	}
+	collectiblesLevel = currentLevel
+	pickups = slices.DeleteFunc(pickups, func(p Pickup) bool {
+		return !p.Taken && strings.HasPrefix(p.Name, collectiblePrefix)
+	})
+	for _, c := range assets.manifest.Levels[currentLevel].Collectibles {
+		if flag := collectibleFlag(currentLevel, c.ID); !worldFlags.Has(flag) {
+			pickups = append(pickups, Pickup{Name: flag, Pos: rl.NewVector2(c.X, c.Y)})
+		}
+	}
+}
+
+// ResetCollectibles places the current level's collectibles again, after
+// the found flags changed.
+func ResetCollectibles() {
+	collectiblesLevel = ""
+}
+
+// HandleCollectiblePickup remembers a found collectible in the world flags.
+func HandleCollectiblePickup(e Event) {
+	if strings.HasPrefix(e.Target, collectiblePrefix) {
+		worldFlags.Set(e.Target, true)
+	}
+}
+
+// CollectiblesFound returns how many of level's collectibles were found.
+func CollectiblesFound(level string) (found, total int) {
+	for _, c := range assets.manifest.Levels[level].Collectibles {
+		if worldFlags.Has(collectibleFlag(level, c.ID)) {
+			found++
+		}
+	}
+	return found, len(assets.manifest.Levels[level].Collectibles)
+}
+
+// Completion returns how much of the game this save has done, from 0 to 1:
+// collectibles found, abilities unlocked and quests completed, each
+// counting the same.
+func Completion() float32 {
+	done, total := quests.Completed()
+	for level := range assets.manifest.Levels {
+		found, n := CollectiblesFound(level)
+		done += found
+		total += n
+	}
+	for _, a := range abilities {
+		if unlocks.Has(a) {
+			done++
+		}
+	}
+	total += len(abilities)
+	if total == 0 {
+		return 0
+	}
+	return float32(done) / float32(total)
+}
+
+// HandleProgressToggle opens the progress screen over the game.
+func HandleProgressToggle() {
+	if inputContexts.KeyPressed(ContextGameplay, progressKey) {
+		scenes.Push(&ProgressScene{})
+	}
+}
+
+// ProgressScene shows how far the game is done: the completion
+// percentage, collectibles found in each level, abilities and the
+// profile's achievements. A list of tabs on the left picks the page.
+type ProgressScene struct {
+	font *Font
+	tabs *MenuList
+}
+
+type progressTab int
+
+const (
+	progressOverview progressTab = iota
+	progressLevels
+	progressAbilities
+	progressAchievements
+)
+
+func (p *ProgressScene) Load(scope *AssetScope) {
+	p.font = scope.Font("", 28)
+	p.tabs = &MenuList{
+		Layout:   UIRect{Anchor: AnchorLeft, Offset: rl.NewVector2(80, 40), Size: rl.NewVector2(320, 360)},
+		Font:     p.font,
+		FontSize: 32,
+		Spacing:  16,
+		OnBack:   scenes.Pop,
+	}
+	p.tabs.SetItems([]MenuItem{
+		{Label: T("progress.overview")},
+		{Label: T("progress.levels")},
+		{Label: T("progress.abilities")},
+		{Label: T("progress.achievements")},
+		{Label: T("menu.back"), OnSelect: scenes.Pop},
+	})
+}
+
+func (p *ProgressScene) Unload() {}
+
+func (p *ProgressScene) Update() {
+	if rl.IsKeyPressed(progressKey) {
+		scenes.Pop()
+		return
+	}
+	p.tabs.Update()
+}
+
+func (p *ProgressScene) Draw() {
+	rl.DrawRectangle(0, 0, int32(screenSize.X), int32(screenSize.Y), rl.Fade(rl.Black, 0.9))
+
+	title := T("progress.title")
+	top := ui.Rect(UIRect{Anchor: AnchorTop, Offset: rl.NewVector2(0, 60), Size: rl.NewVector2(800, 60)})
+	w := p.font.Measure(title, 56).X
+	p.font.Draw(title, rl.NewVector2(top.X+(top.Width-w)/2, top.Y), 56, rl.Gold)
+
+	p.tabs.Draw()
+	page := ui.Rect(UIRect{Anchor: AnchorRight, Offset: rl.NewVector2(80, 40), Size: rl.NewVector2(screenSize.X-560, 560)})
+	if !DrawNinePatch(SkinPanel, page, rl.White) {
+		rl.DrawRectangleRec(page, rl.Fade(rl.DarkGray, 0.3))
+	}
+	area := rl.NewRectangle(page.X+32, page.Y+24, page.Width-64, page.Height-48)
+	switch progressTab(p.tabs.Selected) {
+	case progressOverview:
+		p.drawOverview(area)
+	case progressLevels:
+		p.drawLevels(area)
+	case progressAbilities:
+		p.drawAbilities(area)
+	case progressAchievements:
+		p.drawAchievements(area)
+	}
+}
+
+func (p *ProgressScene) drawOverview(area rl.Rectangle) {
+	done := Completion()
+	percent := fmt.Sprintf("%d%%", int(done*100))
+	p.font.Draw(percent, rl.NewVector2(area.X, area.Y), 72, rl.RayWhite)
+	bar := rl.NewRectangle(area.X, area.Y+96, area.Width, 28)
+	DrawHUDBar(bar, done, rl.Gold)
+
+	questsDone, questsTotal := quests.Completed()
+	found, total := 0, 0
+	for level := range assets.manifest.Levels {
+		f, n := CollectiblesFound(level)
+		found, total = found+f, total+n
+	}
+	have := 0
+	for _, a := range abilities {
+		if unlocks.Has(a) {
+			have++
+		}
+	}
+	earned := 0
+	for _, def := range achievements.Defs() {
+		if _, ok := achievements.Unlocked(def.ID); ok {
+			earned++
+		}
+	}
+	lines := []string{
+		fmt.Sprintf("%s: %d/%d", T("progress.quests"), questsDone, questsTotal),
+		fmt.Sprintf("%s: %d/%d", T("progress.collectibles"), found, total),
+		fmt.Sprintf("%s: %d/%d", T("progress.abilities"), have, len(abilities)),
+		fmt.Sprintf("%s: %d/%d", T("progress.achievements"), earned, len(achievements.Defs())),
+	}
+	for i, line := range lines {
+		p.font.Draw(line, rl.NewVector2(area.X, area.Y+160+float32(i)*44), 28, rl.LightGray)
+	}
+}
+
+func (p *ProgressScene) drawLevels(area rl.Rectangle) {
+	var levels []string
+	for level, node := range assets.manifest.Levels {
+		if len(node.Collectibles) > 0 {
+			levels = append(levels, level)
+		}
+	}
+	sort.Strings(levels)
+	if len(levels) == 0 {
+		p.font.Draw(T("stats.none"), rl.NewVector2(area.X, area.Y), 24, rl.Gray)
+		return
+	}
+	y := area.Y
+	for _, level := range levels {
+		found, total := CollectiblesFound(level)
+		color := rl.RayWhite
+		if found == total {
+			color = rl.Gold
+		}
+		p.font.Draw(level, rl.NewVector2(area.X, y), 28, color)
+		count := fmt.Sprintf("%d/%d", found, total)
+		w := p.font.Measure(count, 28).X
+		p.font.Draw(count, rl.NewVector2(area.X+area.Width-w, y), 28, color)
+		y += 44
+		if y > area.Y+area.Height-28 {
+			return
+		}
+	}
+}
+
+func (p *ProgressScene) drawAbilities(area rl.Rectangle) {
+	for i, a := range abilities {
+		y := area.Y + float32(i)*56
+		name, state, color := T("ability.name."+a), T("progress.locked"), rl.Gray
+		if unlocks.Has(a) {
+			state, color = T("progress.unlocked"), rl.Gold
+		}
+		p.font.Draw(name, rl.NewVector2(area.X, y), 32, color)
+		w := p.font.Measure(state, 28).X
+		p.font.Draw(state, rl.NewVector2(area.X+area.Width-w, y), 28, color)
+	}
+}
+
+func (p *ProgressScene) drawAchievements(area rl.Rectangle) {
+	defs := achievements.Defs()
+	if len(defs) == 0 {
+		p.font.Draw(T("stats.none"), rl.NewVector2(area.X, area.Y), 24, rl.Gray)
+		return
+	}
+	y := area.Y
+	for _, def := range defs {
+		color, detail := rl.Gray, ""
+		if at, ok := achievements.Unlocked(def.ID); ok {
+			color, detail = rl.Gold, locale.Ago(at, time.Now())
+		} else if have, goal := achievements.Progress(def); goal > 1 {
+			detail = fmt.Sprintf("%s/%s", locale.Int(int64(have)), locale.Int(int64(goal)))
+		}
+		p.font.Draw(T(def.Title), rl.NewVector2(area.X, y), 28, color)
+		if detail != "" {
+			w := p.font.Measure(detail, 24).X
+			p.font.Draw(detail, rl.NewVector2(area.X+area.Width-w, y), 24, color)
+		}
+		p.font.Draw(T(def.Description), rl.NewVector2(area.X, y+32), 22, rl.LightGray)
+		y += 72
+		if y > area.Y+area.Height-60 {
+			return
+		}
+	}
+}
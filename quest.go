@@ -0,0 +1,230 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	questLogKey   = rl.KeyJ
+	questDefsPath = "assets/quests.json"
+)
+
+// ObjectiveDef is one goal of a quest: Count events of type Event on Target.
+// An empty Target matches any target.
+type ObjectiveDef struct {
+	Description string    `json:"description"`
+	Event       EventType `json:"event"`
+	Target      string    `json:"target"`
+	Count       int       `json:"count"`
+}
+
+// QuestDef describes a quest as written in quest files
+type QuestDef struct {
+	ID         string         `json:"id"`
+	Title      string         `json:"title"`
+	AutoStart  bool           `json:"autoStart"`
+	Objectives []ObjectiveDef `json:"objectives"`
+	// Ending quests finish the game when completed
+	Ending bool `json:"ending,omitempty"`
+}
+
+// QuestProgress is the saved state of a started quest
+type QuestProgress struct {
+	ID        string `json:"id"`
+	Counts    []int  `json:"counts"`
+	Completed bool   `json:"completed"`
+}
+
+// QuestLog tracks quest definitions and the player's progress on them
+type QuestLog struct {
+	defs     map[string]QuestDef
+	order    []string
+	progress map[string]*QuestProgress
+	Visible  bool
+	font     *Font
+}
+
+var quests = NewQuestLog()
+
+// NewQuestLog creates and returns an empty QuestLog
+func NewQuestLog() *QuestLog {
+	return &QuestLog{
+		defs:     make(map[string]QuestDef),
+		progress: make(map[string]*QuestProgress),
+	}
+}
+
+// LoadQuestDefs reads a JSON array of quest definitions.
+func LoadQuestDefs(path string) ([]QuestDef, error) {
+	data, err := ReadAsset(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var defs []QuestDef
+	if err := json.Unmarshal(data, &defs); err != nil {
+		return nil, fmt.Errorf("quests %s: %w", path, err)
+	}
+	return defs, nil
+}
+
+// Register adds definitions, typically from a level's quest file,
+// starting those marked AutoStart.
+func (q *QuestLog) Register(defs ...QuestDef) {
+	for _, def := range defs {
+		if _, ok := q.defs[def.ID]; !ok {
+			q.order = append(q.order, def.ID)
+		}
+		q.defs[def.ID] = def
+		if def.AutoStart {
+			q.Start(def.ID)
+		}
+	}
+}
+
+// Start begins tracking a registered quest. Starting an active quest does nothing.
+func (q *QuestLog) Start(id string) {
+	def, ok := q.defs[id]
+	if !ok {
+		return
+	}
+	if _, started := q.progress[id]; started {
+		return
+	}
+	q.progress[id] = &QuestProgress{ID: id, Counts: make([]int, len(def.Objectives))}
+}
+
+// HandleEvent advances objectives matching the event.
+func (q *QuestLog) HandleEvent(e Event) {
+	for _, id := range q.order {
+		p, ok := q.progress[id]
+		if !ok || p.Completed {
+			continue
+		}
+
+		def := q.defs[id]
+		done := true
+		for i, obj := range def.Objectives {
+			if obj.Event == e.Type && (obj.Target == "" || obj.Target == e.Target) {
+				p.Counts[i] = min(p.Counts[i]+e.Amount, max(obj.Count, 1))
+			}
+			if p.Counts[i] < max(obj.Count, 1) {
+				done = false
+			}
+		}
+
+		if done {
+			p.Completed = true
+			events.Publish(Event{Type: EventQuestCompleted, Target: id})
+		}
+	}
+}
+
+// Ending reports whether completing quest id finishes the game.
+func (q *QuestLog) Ending(id string) bool {
+	return q.defs[id].Ending
+}
+
+// Completed returns how many registered quests are completed, out of all.
+func (q *QuestLog) Completed() (done, total int) {
+	for _, id := range q.order {
+		if p, ok := q.progress[id]; ok && p.Completed {
+			done++
+		}
+	}
+	return done, len(q.order)
+}
+
+// Progress returns a copy of the state of every started quest for saving.
+// Autosaves encode it in the background while objectives keep counting.
+func (q *QuestLog) Progress() []QuestProgress {
+	var list []QuestProgress
+	for _, id := range q.order {
+		if p, ok := q.progress[id]; ok {
+			saved := *p
+			saved.Counts = slices.Clone(p.Counts)
+			list = append(list, saved)
+		}
+	}
+	return list
+}
+
+// Restore replaces quest progress with saved state. Quests that are no longer
+// defined are dropped and objective counts are resized to the current definition.
+func (q *QuestLog) Restore(list []QuestProgress) {
+	q.progress = make(map[string]*QuestProgress)
+	for _, saved := range list {
+		def, ok := q.defs[saved.ID]
+		if !ok {
+			continue
+		}
+		p := saved
+		p.Counts = make([]int, len(def.Objectives))
+		copy(p.Counts, saved.Counts)
+		q.progress[p.ID] = &p
+	}
+}
+
+// HandleQuestLogToggle shows or hides the quest log. While it is open it
+// holds the menu input context, and back closes it.
+func HandleQuestLogToggle() {
+	switch {
+	case inputContexts.KeyPressed(ContextMenu, questLogKey):
+		quests.Visible = !quests.Visible
+	case quests.Visible && menuPressed(rl.KeyEscape, rl.GamepadButtonRightFaceRight):
+		quests.Visible = false
+	}
+	inputContexts.Set(quests, ContextMenu, quests.Visible)
+}
+
+// Draw renders the quest log panel when visible.
+func (q *QuestLog) Draw() {
+	if !q.Visible {
+		return
+	}
+	if q.font == nil {
+		q.font = fonts.Acquire("", 18)
+	}
+
+	panel := ui.Rect(UIRect{Anchor: AnchorTopRight, Offset: rl.NewVector2(20, 100), Size: rl.NewVector2(500, 600)})
+	if !DrawNinePatch(SkinPanel, panel, rl.White) {
+		rl.DrawRectangleRec(panel, rl.Fade(rl.Black, 0.75))
+	}
+	y := panel.Y + 20
+	title := T("quest.log")
+	q.font.Draw(title, rl.NewVector2(ui.StartX(panel, q.font.Measure(title, 32).X, 20), y), 32, rl.White)
+	y += 50
+
+	if len(q.progress) == 0 {
+		empty := T("quest.none")
+		q.font.Draw(empty, rl.NewVector2(ui.StartX(panel, q.font.Measure(empty, 20).X, 20), y), 20, rl.Gray)
+		return
+	}
+
+	for _, id := range q.order {
+		p, ok := q.progress[id]
+		if !ok {
+			continue
+		}
+		def := q.defs[id]
+
+		color := rl.Gold
+		if p.Completed {
+			color = rl.Gray
+		}
+		q.font.Draw(def.Title, rl.NewVector2(ui.StartX(panel, q.font.Measure(def.Title, 24).X, 20), y), 24, color)
+		y += 30
+
+		for i, obj := range def.Objectives {
+			line := fmt.Sprintf("- %s (%d/%d)", obj.Description, p.Counts[i], max(obj.Count, 1))
+			x := ui.StartX(panel, MeasureGlyphText(q.font, line, 18), 30)
+			DrawGlyphText(q.font, line, rl.NewVector2(x, y), 18, rl.LightGray)
+			y += 24
+		}
+		y += 12
+	}
+}
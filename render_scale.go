@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	minRenderScale  = 50
+	maxRenderScale  = 200
+	renderScaleStep = 10
+
+	// Dynamic resolution steps down after this many frames over budget and
+	// back up after this many frames with headroom
+	dynamicSlowFrames = 30
+	dynamicFastFrames = 180
+)
+
+// RenderScaler draws the world into an offscreen target sized at a
+// percentage of the window and stretches it back over the screen. In
+// dynamic mode the percentage drops below the setting while frames miss
+// their budget and climbs back once there is headroom again.
+type RenderScaler struct {
+	current int // percent in use; below settings.RenderScale while dynamic mode holds it down
+	target  rl.RenderTexture2D
+	active  bool // Begin redirected drawing into target
+	slow    int
+	fast    int
+}
+
+var renderScale = &RenderScaler{}
+
+// Scale returns the percentage the world is currently rendered at.
+func (r *RenderScaler) Scale() int {
+	if r.current == 0 || !settings.DynamicResolution {
+		return settings.RenderScale
+	}
+	return min(r.current, settings.RenderScale)
+}
+
+// Update adjusts the dynamic scale from the last frame's timings. A frame
+// is over budget when it took longer than a refresh and a quarter; there is
+// headroom when its CPU work fit in half a refresh.
+func (r *RenderScaler) Update(total, cpu time.Duration) {
+	if !settings.DynamicResolution {
+		r.current = settings.RenderScale
+		r.slow, r.fast = 0, 0
+		return
+	}
+	if r.current == 0 {
+		r.current = settings.RenderScale
+	}
+
+	interval := pacing.FrameInterval()
+	switch {
+	case total > interval*5/4:
+		r.slow++
+		r.fast = 0
+	case cpu < interval/2:
+		r.fast++
+		r.slow = 0
+	default:
+		r.slow, r.fast = 0, 0
+	}
+
+	if r.slow >= dynamicSlowFrames && r.current > minRenderScale {
+		r.current = max(minRenderScale, r.current-renderScaleStep)
+		r.slow = 0
+		perf.Note("render scale", fmt.Sprintf("down to %d%%", r.current))
+	}
+	if r.fast >= dynamicFastFrames && r.current < settings.RenderScale {
+		r.current = min(settings.RenderScale, r.current+renderScaleStep)
+		r.fast = 0
+	}
+}
+
+// Begin redirects drawing into the scaled target. Everything drawn until
+// End uses screen coordinates as usual. At 100% it draws straight to the
+// screen.
+func (r *RenderScaler) Begin() {
+	scale := r.Scale()
+	if scale == 100 {
+		return
+	}
+	width := int32(screenSize.X * float32(scale) / 100)
+	height := int32(screenSize.Y * float32(scale) / 100)
+	if r.target.Texture.Width != width || r.target.Texture.Height != height {
+		r.Unload()
+		r.target = rl.LoadRenderTexture(width, height)
+		rl.SetTextureFilter(r.target.Texture, rl.FilterBilinear)
+	}
+	if !rl.IsRenderTextureValid(r.target) {
+		return
+	}
+
+	rl.BeginTextureMode(r.target)
+	rl.ClearBackground(rl.Black)
+	// Keep the screen-sized projection so callers need not know the scale
+	rl.MatrixMode(rl.Projection)
+	rl.LoadIdentity()
+	rl.Ortho(0, float64(screenSize.X), float64(screenSize.Y), 0, 0, 1)
+	rl.MatrixMode(rl.Modelview)
+	r.active = true
+}
+
+// End stretches the scaled target over the screen if Begin used it.
+func (r *RenderScaler) End() {
+	if !r.active {
+		return
+	}
+	rl.EndTextureMode()
+	r.active = false
+
+	// Render textures are stored upside down
+	src := rl.NewRectangle(0, 0, float32(r.target.Texture.Width), -float32(r.target.Texture.Height))
+	dst := rl.NewRectangle(0, 0, screenSize.X, screenSize.Y)
+	rl.DrawTexturePro(r.target.Texture, src, dst, rl.NewVector2(0, 0), 0, rl.White)
+}
+
+// Unload frees the offscreen target.
+func (r *RenderScaler) Unload() {
+	if rl.IsRenderTextureValid(r.target) {
+		rl.UnloadRenderTexture(r.target)
+	}
+	r.target = rl.RenderTexture2D{}
+}
+
+// DebugLines reports the scale in use for the debug overlay.
+func (r *RenderScaler) DebugLines() []string {
+	mode := "fixed"
+	if settings.DynamicResolution {
+		mode = "dynamic"
+	}
+	return []string{fmt.Sprintf("%d%% of %d%% (%s), %dx%d", r.Scale(), settings.RenderScale, mode,
+		int(screenSize.X)*r.Scale()/100, int(screenSize.Y)*r.Scale()/100)}
+}
+
+func init() {
+	RegisterSystem(&SystemFuncs{
+		ID:         "renderScale",
+		Requires:   []string{"pacing"},
+		OnUpdate:   func() { renderScale.Update(perf.Last()) },
+		OnShutdown: renderScale.Unload,
+	})
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// Version 2 added the aim to each tick
+	replayVersion = 2
+	// Demo shipped with the game for attract mode
+	attractReplayPath = "assets/replays/attract.json"
+	// Most recent recorded session, used when no demo ships
+	lastReplayPath = "replays/last.json"
+	// Sessions shorter than this aren't worth keeping
+	minReplayLength = 10 * time.Second
+)
+
+// Replay is a recorded session: the starting level and RNG state plus the
+// input of every tick. Played back from a fresh game it reproduces the run.
+type Replay struct {
+	Version int    `json:"version"`
+	Level   string `json:"level"`
+	RNG     []byte `json:"rng"`
+	// Frames holds Down, Pressed and Aim for each tick, interleaved.
+	// Version 1 replays have no Aim.
+	Frames []uint32 `json:"frames"`
+}
+
+// stride returns how many values each tick takes in Frames.
+func (r *Replay) stride() int {
+	if r.Version < 2 {
+		return 2
+	}
+	return 3
+}
+
+// Inputs returns the recorded input frames.
+func (r *Replay) Inputs() []InputFrame {
+	stride := r.stride()
+	frames := make([]InputFrame, 0, len(r.Frames)/stride)
+	for i := 0; i+stride <= len(r.Frames); i += stride {
+		frame := InputFrame{Down: r.Frames[i], Pressed: r.Frames[i+1]}
+		if stride > 2 {
+			frame.Aim = r.Frames[i+2]
+		}
+		frames = append(frames, frame)
+	}
+	return frames
+}
+
+// Length returns how long the replay plays for.
+func (r *Replay) Length() time.Duration {
+	return time.Duration(len(r.Frames)/r.stride()) * tickDuration
+}
+
+// ReplayRecorder captures the input of a game session. Frames are stored by
+// tick, so ticks replayed after a rewind overwrite what they replace.
+type ReplayRecorder struct {
+	replay    *Replay
+	startTick uint64
+}
+
+var recorder = &ReplayRecorder{}
+
+// Start begins a recording from the current game state.
+func (r *ReplayRecorder) Start() {
+	rngState, _ := rngSource.MarshalBinary()
+	r.replay = &Replay{Version: replayVersion, Level: currentLevel, RNG: rngState}
+	r.startTick = clock.Tick
+}
+
+// Add records the input consumed by the current tick.
+func (r *ReplayRecorder) Add(frame InputFrame) {
+	if r.replay == nil || clock.Tick <= r.startTick {
+		return
+	}
+	i := int(clock.Tick-r.startTick-1) * r.replay.stride()
+	r.replay.Frames = append(r.replay.Frames[:min(i, len(r.replay.Frames))], frame.Down, frame.Pressed, frame.Aim)
+}
+
+// Stop ends the recording and returns it, or nil when nothing was recording.
+func (r *ReplayRecorder) Stop() *Replay {
+	replay := r.replay
+	r.replay = nil
+	return replay
+}
+
+// SaveReplay writes a replay to path.
+func SaveReplay(path string, r *Replay) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func parseReplay(path string, data []byte) (*Replay, error) {
+	var r Replay
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("replay %s: %w", path, err)
+	}
+	if r.Version > replayVersion {
+		return nil, fmt.Errorf("replay %s: version %d is newer than supported %d", path, r.Version, replayVersion)
+	}
+	return &r, nil
+}
+
+// LoadAttractReplay returns the demo for attract mode: the shipped one if
+// present, otherwise the last recorded session.
+func LoadAttractReplay() (*Replay, error) {
+	if data, err := ReadAsset(attractReplayPath); err == nil {
+		return parseReplay(attractReplayPath, data)
+	}
+	data, err := os.ReadFile(lastReplayPath)
+	if err != nil {
+		return nil, err
+	}
+	return parseReplay(lastReplayPath, data)
+}
+
+// SaveLastReplay stops the recorder and keeps the session for attract mode
+// if it was long enough.
+func SaveLastReplay() {
+	r := recorder.Stop()
+	if r == nil || r.Length() < minReplayLength {
+		return
+	}
+	if err := SaveReplay(lastReplayPath, r); err != nil {
+		log.Printf("replay: %v", err)
+	}
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"slices"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	rewindKey      = rl.KeyF9
+	rewindSeconds  = 3
+	rewindCapacity = 10 * ticksPerSecond
+)
+
+// Snapshot is the simulation state at the start of a tick together with
+// the input that tick consumed. Nothing in it is shared with the live
+// game: animations keep only where they are, since their frames are
+// assets that stay with the live player.
+type Snapshot struct {
+	Tick uint64
+	// Player holds the player's values; its animations, layers, skeleton
+	// and state are captured apart
+	Player      Player
+	PlayerState PlayerState
+	Stand       animState
+	Hit         animState
+	Move        animState
+	Layers      []layerState
+	Skeleton    skeletonState
+	Background  animState
+	Enemies     []*Enemy
+	Pickups     []Pickup
+	Waves       WaveState
+	RNG         []byte
+	Input       InputFrame
+}
+
+// animState is how far an animation has played
+type animState struct {
+	frame     int
+	playing   bool
+	reversing bool
+	start     time.Time
+}
+
+func captureAnim(a *Animated) animState {
+	return animState{frame: a.CurrentFrame, playing: a.IsPlaying, reversing: a.Reversing, start: a.StartTime}
+}
+
+func (s animState) apply(a *Animated) {
+	a.CurrentFrame = s.frame
+	a.IsPlaying = s.playing
+	a.Reversing = s.reversing
+	a.StartTime = s.start
+}
+
+// layerState is an equipped layer and how far each of its animations has
+// played
+type layerState struct {
+	name  string
+	anims map[string]animState
+}
+
+// skeletonState is where the skeleton's animation is; the pose is worked
+// out from it again when drawn
+type skeletonState struct {
+	skin     string
+	anim     string
+	loop     bool
+	since    time.Time
+	progress float64
+}
+
+func captureSkeleton(s *SkeletonSprite) skeletonState {
+	if s == nil {
+		return skeletonState{}
+	}
+	st := skeletonState{skin: s.Skin, loop: s.loop, since: s.since, progress: s.progress}
+	if s.anim != nil {
+		st.anim = s.anim.Name
+	}
+	return st
+}
+
+func (st skeletonState) apply(s *SkeletonSprite) {
+	if s == nil {
+		return
+	}
+	s.Skin = st.skin
+	s.anim = s.Data.Animations[st.anim]
+	s.loop = st.loop
+	s.since = st.since
+	s.progress = st.progress
+}
+
+// Rewinder keeps a ring buffer of recent snapshots. Rewinding restores an
+// older snapshot and queues the recorded inputs so the same ticks replay.
+type Rewinder struct {
+	buffer []Snapshot
+	head   int // index of the oldest snapshot
+	count  int
+	replay []InputFrame
+}
+
+var rewinder = NewRewinder(rewindCapacity)
+
+// NewRewinder creates a Rewinder holding up to capacity ticks
+func NewRewinder(capacity int) *Rewinder {
+	return &Rewinder{buffer: make([]Snapshot, capacity)}
+}
+
+// NextInput returns the recorded input while replaying and the live input otherwise.
+func (r *Rewinder) NextInput() InputFrame {
+	if len(r.replay) > 0 {
+		frame := r.replay[0]
+		r.replay = r.replay[1:]
+		return frame
+	}
+	return TakeInput()
+}
+
+// Play queues recorded inputs to be fed to the next ticks, as when playing
+// back a replay.
+func (r *Rewinder) Play(frames []InputFrame) {
+	r.replay = append(r.replay, frames...)
+}
+
+// Replaying reports whether recorded inputs are still being fed back.
+func (r *Rewinder) Replaying() bool {
+	return len(r.replay) > 0
+}
+
+// Record captures the current state and the input about to be applied.
+func (r *Rewinder) Record(frame InputFrame) {
+	rngState, _ := rngSource.MarshalBinary()
+
+	snap := Snapshot{
+		Tick:        clock.Tick,
+		Player:      player,
+		PlayerState: *player.State,
+		Stand:       captureAnim(&player.Stand),
+		Hit:         captureAnim(&player.Hit),
+		Move:        captureAnim(&player.Move),
+		Skeleton:    captureSkeleton(player.Skeleton),
+		Background:  captureAnim(background),
+		Pickups:     append([]Pickup(nil), pickups...),
+		Waves:       director.Capture(),
+		RNG:         rngState,
+		Input:       frame,
+	}
+	snap.Player.Stand, snap.Player.Hit, snap.Player.Move = Animated{}, Animated{}, Animated{}
+	snap.Player.State, snap.Player.Layers, snap.Player.Skeleton = nil, nil, nil
+	snap.Player.Effects = player.Effects.Clone()
+	for _, l := range player.Layers {
+		ls := layerState{name: l.Name, anims: make(map[string]animState, len(l.Anims))}
+		for name, a := range l.Anims {
+			ls.anims[name] = captureAnim(a)
+		}
+		snap.Layers = append(snap.Layers, ls)
+	}
+	for _, e := range enemies {
+		snap.Enemies = append(snap.Enemies, e.clone())
+	}
+
+	idx := (r.head + r.count) % len(r.buffer)
+	r.buffer[idx] = snap
+	if r.count < len(r.buffer) {
+		r.count++
+	} else {
+		r.head = (r.head + 1) % len(r.buffer)
+	}
+}
+
+// Reset forgets every snapshot and any pending replay.
+func (r *Rewinder) Reset() {
+	r.head = 0
+	r.count = 0
+	r.replay = nil
+}
+
+// Rewind restores the snapshot taken ticks ago (or the oldest one kept) and
+// queues every input recorded since then for replay.
+func (r *Rewinder) Rewind(ticks int) {
+	if r.count == 0 {
+		return
+	}
+	ticks = min(ticks, r.count)
+	start := r.count - ticks
+
+	replay := make([]InputFrame, 0, ticks+len(r.replay))
+	for i := start; i < r.count; i++ {
+		replay = append(replay, r.at(i).Input)
+	}
+	r.replay = append(replay, r.replay...)
+
+	r.restore(r.at(start))
+	r.count = start
+}
+
+func (r *Rewinder) at(i int) *Snapshot {
+	return &r.buffer[(r.head+i)%len(r.buffer)]
+}
+
+func (r *Rewinder) restore(snap *Snapshot) {
+	live := player
+	player = snap.Player
+	player.Stand, player.Hit, player.Move = live.Stand, live.Hit, live.Move
+	player.State, player.Layers, player.Skeleton = live.State, live.Layers, live.Skeleton
+	player.Effects = snap.Player.Effects.Clone()
+	*player.State = snap.PlayerState
+	snap.Stand.apply(&player.Stand)
+	snap.Hit.apply(&player.Hit)
+	snap.Move.apply(&player.Move)
+	snap.Skeleton.apply(player.Skeleton)
+
+	names := make([]string, len(snap.Layers))
+	for i, ls := range snap.Layers {
+		names[i] = ls.name
+	}
+	player.RestoreEquipment(names)
+	for _, l := range player.Layers {
+		i := slices.IndexFunc(snap.Layers, func(ls layerState) bool { return ls.name == l.Name })
+		for name, a := range l.Anims {
+			if st, ok := snap.Layers[i].anims[name]; ok {
+				st.apply(a)
+			}
+		}
+	}
+
+	snap.Background.apply(background)
+
+	enemies = enemies[:0]
+	for _, e := range snap.Enemies {
+		enemies = append(enemies, e.clone())
+	}
+	pickups = append(pickups[:0], snap.Pickups...)
+	director.Restore(snap.Waves)
+
+	rngSource.UnmarshalBinary(snap.RNG)
+
+	// The next Advance lands on the restored tick again
+	clock.Tick = snap.Tick - 1
+}
+
+// HandleRewind rewinds a few seconds when the debug key is pressed.
+func HandleRewind() {
+	if inputContexts.KeyPressed(ContextGameplay, rewindKey) {
+		rewinder.Rewind(rewindSeconds * ticksPerSecond)
+	}
+}
+
+// DrawRewindIndicator marks the screen while a rewind is replaying.
+func DrawRewindIndicator() {
+	if rewinder.Replaying() {
+		pos := ui.Rect(UIRect{Anchor: AnchorTopRight, Offset: rl.NewVector2(20, 20), Size: rl.NewVector2(120, 30)})
+		rl.DrawText("REPLAY", int32(pos.X), int32(pos.Y), 30, rl.Red)
+	}
+}
@@ -0,0 +1,429 @@
+package main
+
+import (
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Rich text markup understood by DrawRichText:
+//
+//	[color=gold]...[/color]  named color or #rrggbb / #rrggbbaa
+//	[b]...[/b]               bold font variant
+//	[wave]...[/wave]         characters bob up and down
+//	[shake]...[/shake]       characters jitter
+//	[icon=coin]              assets/images/icons/coin.png, inline
+//	[Jump]                   glyph of the action's binding
+//	[[                       a literal "["
+//
+// Soft hyphens (U+00AD) mark where long words may break across lines.
+const (
+	richIconDir     = "assets/images/icons/"
+	softHyphen      = "\u00ad"
+	richLineSpacing = 1.2
+)
+
+var richColors = map[string]rl.Color{
+	"white":     rl.RayWhite,
+	"gray":      rl.Gray,
+	"lightgray": rl.LightGray,
+	"black":     rl.Black,
+	"red":       rl.Red,
+	"maroon":    rl.Maroon,
+	"green":     rl.Green,
+	"lime":      rl.Lime,
+	"blue":      rl.Blue,
+	"skyblue":   rl.SkyBlue,
+	"gold":      rl.Gold,
+	"yellow":    rl.Yellow,
+	"orange":    rl.Orange,
+	"purple":    rl.Purple,
+	"pink":      rl.Pink,
+}
+
+// richIcons caches inline icons by path, including failed loads
+var richIcons = make(map[string]*Texture)
+
+type richStyle struct {
+	color rl.Color
+	bold  bool
+	wave  bool
+	shake bool
+}
+
+// richRun is a word, space, line break, icon or glyph in the markup
+type richRun struct {
+	text   string
+	icon   string
+	action Action
+	glyph  bool
+	style  richStyle
+	x      float32
+}
+
+type richLine struct {
+	runs  []richRun
+	width float32
+}
+
+// parseRichText splits markup into runs of one word or one space each.
+// Unknown tags are kept as text, the way DrawGlyphText shows them.
+func parseRichText(text string, color rl.Color) []richRun {
+	var runs []richRun
+	style := richStyle{color: color}
+	var colors []rl.Color
+
+	addText := func(s string) {
+		for s != "" {
+			i := strings.IndexAny(s, " \n")
+			if i < 0 {
+				runs = append(runs, richRun{text: s, style: style})
+				return
+			}
+			if i > 0 {
+				runs = append(runs, richRun{text: s[:i], style: style})
+			}
+			runs = append(runs, richRun{text: s[i : i+1], style: style})
+			s = s[i+1:]
+		}
+	}
+
+	for text != "" {
+		open := strings.IndexByte(text, '[')
+		if open < 0 {
+			addText(text)
+			break
+		}
+		addText(text[:open])
+		text = text[open:]
+		if strings.HasPrefix(text, "[[") {
+			addText("[")
+			text = text[2:]
+			continue
+		}
+		end := strings.IndexByte(text, ']')
+		if end < 0 {
+			addText(text)
+			break
+		}
+		tag := text[1:end]
+		text = text[end+1:]
+
+		name, arg, _ := strings.Cut(tag, "=")
+		switch name {
+		case "b", "/b":
+			style.bold = name == "b"
+		case "wave", "/wave":
+			style.wave = name == "wave"
+		case "shake", "/shake":
+			style.shake = name == "shake"
+		case "color":
+			c, ok := parseRichColor(arg)
+			if !ok {
+				addText("[" + tag + "]")
+				break
+			}
+			colors = append(colors, style.color)
+			style.color = c
+		case "/color":
+			if n := len(colors); n > 0 {
+				style.color = colors[n-1]
+				colors = colors[:n-1]
+			}
+		case "icon":
+			runs = append(runs, richRun{icon: arg, style: style})
+		default:
+			if action, ok := actionNames[tag]; ok {
+				runs = append(runs, richRun{action: action, glyph: true, style: style})
+			} else {
+				addText("[" + tag + "]")
+			}
+		}
+	}
+	return runs
+}
+
+// parseRichColor reads a color name or a #rrggbb / #rrggbbaa value.
+func parseRichColor(s string) (rl.Color, bool) {
+	if c, ok := richColors[strings.ToLower(s)]; ok {
+		return c, true
+	}
+	hex, ok := strings.CutPrefix(s, "#")
+	if !ok || (len(hex) != 6 && len(hex) != 8) {
+		return rl.Color{}, false
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return rl.Color{}, false
+	}
+	if len(hex) == 6 {
+		v = v<<8 | 0xff
+	}
+	return rl.GetColor(uint(v)), true
+}
+
+// runFont returns the font a run is drawn with and whether bold has to be
+// faked because the font has no bold variant.
+func runFont(font *Font, r richRun) (*Font, bool) {
+	if !r.style.bold {
+		return font, false
+	}
+	if bold := font.Bold(); bold != nil {
+		return bold, false
+	}
+	return font, true
+}
+
+func runWidth(font *Font, r richRun, size float32) float32 {
+	switch {
+	case r.glyph:
+		return glyphWidth(font, r.action, size)
+	case r.icon != "":
+		return size + 4
+	}
+	f, fake := runFont(font, r)
+	w := f.Measure(strings.ReplaceAll(r.text, softHyphen, ""), size).X
+	if fake {
+		w++
+	}
+	return w
+}
+
+// breakRun splits a word so that its head plus any hyphen fits in avail. With
+// soft set it only breaks at soft hyphens, otherwise between any two
+// characters.
+func breakRun(font *Font, r richRun, size, avail float32, soft bool) (head, tail richRun, ok bool) {
+	if r.glyph || r.icon != "" {
+		return r, r, false
+	}
+	var cuts []int
+	if soft {
+		for i := 0; ; {
+			j := strings.Index(r.text[i:], softHyphen)
+			if j < 0 {
+				break
+			}
+			i += j
+			cuts = append(cuts, i)
+			i += len(softHyphen)
+		}
+	} else {
+		for i := range r.text {
+			if i > 0 {
+				cuts = append(cuts, i)
+			}
+		}
+	}
+
+	for k := len(cuts) - 1; k >= 0; k-- {
+		head = r
+		head.text = r.text[:cuts[k]] + hyphenAfter(r.text[:cuts[k]])
+		if runWidth(font, head, size) > avail {
+			continue
+		}
+		tail = r
+		tail.text = strings.TrimPrefix(r.text[cuts[k]:], softHyphen)
+		return head, tail, true
+	}
+	return r, r, false
+}
+
+// hyphenAfter returns the hyphen to add when a word breaks after text.
+// Scripts written without spaces, like Japanese, break without one.
+func hyphenAfter(text string) string {
+	if r, _ := utf8.DecodeLastRuneInString(text); r >= 0x2e80 {
+		return ""
+	}
+	return "-"
+}
+
+// layoutRichText places runs on lines no wider than width, which is in
+// screen pixels; zero means no wrapping. Words that don't fit move to the
+// next line, and words longer than a whole line are hyphenated.
+func layoutRichText(font *Font, runs []richRun, size, width float32) []richLine {
+	lines := []richLine{{}}
+	x := float32(0)
+	place := func(r richRun) {
+		r.x = x
+		x += runWidth(font, r, size)
+		lines[len(lines)-1].runs = append(lines[len(lines)-1].runs, r)
+	}
+	newline := func() {
+		line := &lines[len(lines)-1]
+		if n := len(line.runs); n > 0 && line.runs[n-1].text == " " {
+			x = line.runs[n-1].x
+			line.runs = line.runs[:n-1]
+		}
+		line.width = x
+		lines = append(lines, richLine{})
+		x = 0
+	}
+
+	for i := 0; i < len(runs); {
+		r := runs[i]
+		switch r.text {
+		case "\n":
+			newline()
+			i++
+			continue
+		case " ":
+			if x > 0 {
+				place(r)
+			}
+			i++
+			continue
+		}
+
+		// A word may be made of several runs when a tag changes mid-word
+		end := i
+		groupWidth := float32(0)
+		for end < len(runs) && runs[end].text != " " && runs[end].text != "\n" {
+			groupWidth += runWidth(font, runs[end], size)
+			end++
+		}
+
+		if width > 0 && x > 0 && x+groupWidth > width {
+			if end == i+1 {
+				if head, tail, ok := breakRun(font, r, size, width-x, true); ok {
+					place(head)
+					newline()
+					runs[i] = tail
+					continue
+				}
+			}
+			newline()
+		}
+
+		for k := i; k < end; k++ {
+			r := runs[k]
+			for width > 0 && x+runWidth(font, r, size) > width {
+				head, tail, ok := breakRun(font, r, size, width-x, true)
+				if !ok {
+					head, tail, ok = breakRun(font, r, size, width-x, false)
+				}
+				if !ok {
+					if x == 0 {
+						break // not even one character fits; let it overflow
+					}
+					newline()
+					continue
+				}
+				place(head)
+				newline()
+				r = tail
+			}
+			place(r)
+		}
+		i = end
+	}
+	lines[len(lines)-1].width = x
+	return lines
+}
+
+func richLineHeight(font *Font, size float32) float32 {
+	return font.Measure("A", size).Y * richLineSpacing
+}
+
+// MeasureRichText returns the size DrawRichText would use for text wrapped
+// to width.
+func MeasureRichText(font *Font, text string, size, width float32) rl.Vector2 {
+	lines := layoutRichText(font, parseRichText(text, rl.White), size, width)
+	w := float32(0)
+	for _, line := range lines {
+		w = max(w, line.width)
+	}
+	return rl.NewVector2(w, float32(len(lines))*richLineHeight(font, size))
+}
+
+// DrawRichText draws markup wrapped to width, which is zero for no wrapping.
+// color is used wherever no [color] tag applies.
+func DrawRichText(font *Font, text string, pos rl.Vector2, size, width float32, color rl.Color) {
+	lines := layoutRichText(font, parseRichText(text, color), size, width)
+	lineHeight := richLineHeight(font, size)
+	now := float32(rl.GetTime())
+	for n, line := range lines {
+		for _, r := range line.runs {
+			drawRichRun(font, r, rl.NewVector2(pos.X+r.x, pos.Y+float32(n)*lineHeight), size, now)
+		}
+	}
+}
+
+func drawRichRun(font *Font, r richRun, pos rl.Vector2, size, now float32) {
+	switch {
+	case r.glyph:
+		drawGlyph(font, r.action, pos, size)
+		return
+	case r.icon != "":
+		tex := richIcon(r.icon, size)
+		if tex.Loaded {
+			rl.DrawTextureEx(tex.Texture, rl.NewVector2(pos.X+2, pos.Y), 0, size/float32(tex.Texture.Height), rl.White)
+			tex.Drawn()
+		} else {
+			DrawRoundedRect(rl.NewRectangle(pos.X+2, pos.Y, size, size), size/4, rl.Fade(r.style.color, 0.6))
+		}
+		return
+	case r.text == " ":
+		return
+	}
+
+	f, fake := runFont(font, r)
+	text := strings.ReplaceAll(r.text, softHyphen, "")
+	if !r.style.wave && !r.style.shake {
+		drawRichString(f, text, pos, size, r.style.color, fake)
+		return
+	}
+
+	spacing := size * ui.Scale() / 10
+	for i, ch := range []rune(text) {
+		s := string(ch)
+		offset := rl.Vector2{}
+		if r.style.wave {
+			offset.Y = float32(math.Sin(float64(now*8-float32(i)*0.6))) * size * 0.12
+		}
+		if r.style.shake {
+			offset.X += (rand.Float32()*2 - 1) * size * 0.05
+			offset.Y += (rand.Float32()*2 - 1) * size * 0.05
+		}
+		drawRichString(f, s, rl.Vector2Add(pos, offset), size, r.style.color, fake)
+		pos.X += f.Measure(s, size).X + spacing
+	}
+}
+
+// drawRichString draws text, twice a pixel apart when bold is faked.
+func drawRichString(font *Font, text string, pos rl.Vector2, size float32, color rl.Color, fakeBold bool) {
+	font.Draw(text, pos, size, color)
+	if fakeBold {
+		font.Draw(text, rl.NewVector2(pos.X+1, pos.Y), size, color)
+	}
+}
+
+func richIcon(name string, size float32) *Texture {
+	path := richIconDir + name + ".png"
+	if tex, ok := richIcons[path]; ok {
+		return tex
+	}
+	tex := tm.Acquire(path, int32(size), int32(size))
+	richIcons[path] = tex
+	return tex
+}
+
+// plainRichText strips markup, reading glyphs as the binding's name and
+// icons as their name, for speech.
+func plainRichText(text string) string {
+	var b strings.Builder
+	for _, r := range parseRichText(text, rl.White) {
+		switch {
+		case r.glyph:
+			b.WriteString(GlyphLabel(r.action))
+		case r.icon != "":
+			b.WriteString(r.icon)
+		default:
+			b.WriteString(strings.ReplaceAll(r.text, softHyphen, ""))
+		}
+	}
+	return b.String()
+}
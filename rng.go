@@ -0,0 +1,12 @@
+package main
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// rngSource backs all gameplay randomness. Its state can be captured and
+// restored, which keeps rewinds deterministic.
+var rngSource = rand.NewPCG(uint64(time.Now().UnixNano()), 0)
+
+var rng = rand.New(rngSource)
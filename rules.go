@@ -0,0 +1,66 @@
+package main
+
+import (
+	"log"
+	"time"
+)
+
+// GameRules are the parts of the simulation tuned by difficulty. Combat
+// and movement read them from rules rather than from constants.
+type GameRules struct {
+	// EnemyHealth and EnemyDamage scale the health enemies spawn with and
+	// the damage they deal
+	EnemyHealth float32
+	EnemyDamage float32
+	// CheckpointEvery is how many areas the player enters between
+	// checkpoint autosaves
+	CheckpointEvery int
+	// CoyoteTime is how long after running off a ledge a jump still works
+	CoyoteTime time.Duration
+}
+
+// Difficulty names, as stored in saves
+const (
+	DifficultyEasy   = "easy"
+	DifficultyNormal = "normal"
+	DifficultyHard   = "hard"
+)
+
+// difficulties lists the presets in the order the menu offers them
+var difficulties = []string{DifficultyEasy, DifficultyNormal, DifficultyHard}
+
+var difficultyPresets = map[string]GameRules{
+	DifficultyEasy:   {EnemyHealth: 0.75, EnemyDamage: 0.5, CheckpointEvery: 1, CoyoteTime: 150 * time.Millisecond},
+	DifficultyNormal: {EnemyHealth: 1, EnemyDamage: 1, CheckpointEvery: 1, CoyoteTime: 100 * time.Millisecond},
+	DifficultyHard:   {EnemyHealth: 1.5, EnemyDamage: 2, CheckpointEvery: 3, CoyoteTime: 50 * time.Millisecond},
+}
+
+var (
+	difficulty = DifficultyNormal
+	rules      = difficultyPresets[DifficultyNormal]
+)
+
+// SetDifficulty switches to the named preset, toughened for the New Game
+// Plus cycle. An empty name, as in saves from before difficulties, means
+// normal.
+func SetDifficulty(name string) {
+	if name == "" {
+		name = DifficultyNormal
+	}
+	preset, ok := difficultyPresets[name]
+	if !ok {
+		log.Printf("rules: unknown difficulty %q, using normal", name)
+		name, preset = DifficultyNormal, difficultyPresets[DifficultyNormal]
+	}
+	difficulty, rules = name, plusRules(preset, ngCycle)
+}
+
+// EnemyHealthFor scales an enemy's base health, leaving it at least 1.
+func (r GameRules) EnemyHealthFor(base int) int {
+	return max(int(float32(base)*r.EnemyHealth+0.5), 1)
+}
+
+// EnemyDamageFor scales damage an enemy deals, leaving it at least 1.
+func (r GameRules) EnemyDamageFor(base int) int {
+	return max(int(float32(base)*r.EnemyDamage+0.5), 1)
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	saveVersion   = 2
+	quickSavePath = "saves/quicksave.json"
+	quickSaveKey  = rl.KeyF5
+	quickLoadKey  = rl.KeyF6
+)
+
+// SaveData is the on-disk save game format. World holds the full game
+// state, so a save can be made anywhere and resumed at the same tick.
+type SaveData struct {
+	Version   int             `json:"version"`
+	Quests    []QuestProgress `json:"quests"`
+	Tutorials []string        `json:"tutorials,omitempty"`
+	World     *WorldState     `json:"world,omitempty"`
+	Equipment []string        `json:"equipment,omitempty"`
+	Flags     []string        `json:"flags,omitempty"`
+	// Difficulty is the preset chosen at new game
+	Difficulty string `json:"difficulty,omitempty"`
+	// Cycle is the New Game Plus cycle, 0 on a first playthrough
+	Cycle int `json:"cycle,omitempty"`
+	// Unlocks are the abilities found
+	Unlocks []string `json:"unlocks,omitempty"`
+}
+
+// saveMigrations upgrade a raw save from the version it is keyed by to the
+// next one. LoadGame runs them in order until the save is current.
+var saveMigrations = map[int]func(save map[string]json.RawMessage) error{
+	// Version 1 saves had no world state; they load with the world as it is
+	1: func(save map[string]json.RawMessage) error { return nil },
+}
+
+// CaptureSave collects the current progress. It must run on the main
+// thread; the result can be encoded anywhere, so nothing in it may share a
+// slice or map with live game state.
+func CaptureSave() SaveData {
+	return SaveData{
+		Version:    saveVersion,
+		Quests:     quests.Progress(),
+		Tutorials:  tutorials.Shown(),
+		World:      CaptureWorld(),
+		Equipment:  player.Equipment(),
+		Flags:      worldFlags.Names(),
+		Difficulty: difficulty,
+		Cycle:      ngCycle,
+		Unlocks:    unlocks.Names(),
+	}
+}
+
+// SaveGame writes the current progress to path.
+func SaveGame(path string) error {
+	raw, err := json.MarshalIndent(CaptureSave(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, raw)
+}
+
+// writeFileAtomic writes to a temporary file next to path and renames it
+// into place, so a crash mid-write never leaves a truncated save.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadGame reads progress from path and applies it.
+func LoadGame(path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	raw, err = migrateSave(raw)
+	if err != nil {
+		return fmt.Errorf("save %s: %w", path, err)
+	}
+	var data SaveData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return fmt.Errorf("save %s: %w", path, err)
+	}
+
+	ngCycle = data.Cycle
+	SetDifficulty(data.Difficulty)
+	quests.Restore(data.Quests)
+	tutorials.Restore(data.Tutorials)
+	player.RestoreEquipment(data.Equipment)
+	unlocks.Restore(data.Unlocks)
+	worldFlags.Restore(data.Flags)
+	ResetCollectibles()
+	// Doors and levers take their state from the flags again
+	ResetInteractables()
+	if data.World != nil {
+		if err := RestoreWorld(data.World); err != nil {
+			return fmt.Errorf("save %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// migrateSave brings a raw save up to saveVersion.
+func migrateSave(raw []byte) ([]byte, error) {
+	var save map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &save); err != nil {
+		return nil, err
+	}
+	var version int
+	if err := json.Unmarshal(save["version"], &version); err != nil {
+		return nil, fmt.Errorf("missing version: %w", err)
+	}
+	if version > saveVersion {
+		return nil, fmt.Errorf("version %d is newer than supported %d", version, saveVersion)
+	}
+	if version == saveVersion {
+		return raw, nil
+	}
+
+	for ; version < saveVersion; version++ {
+		migrate, ok := saveMigrations[version]
+		if !ok {
+			return nil, fmt.Errorf("no migration from version %d", version)
+		}
+		if err := migrate(save); err != nil {
+			return nil, fmt.Errorf("migrating from version %d: %w", version, err)
+		}
+	}
+	save["version"], _ = json.Marshal(saveVersion)
+	return json.Marshal(save)
+}
+
+// ResetProgress returns the player and quests to the start of a new game.
+func ResetProgress() {
+	player.Pos = player.DefPos
+	player.VelocityY = 0
+	ngCycle = 0
+	SetDifficulty(DifficultyNormal)
+	player.Health = player.MaxHealth
+	player.Effects = StatusEffects{}
+	player.RestoreEquipment(nil)
+	pickups = nil
+	unlocks.Restore(nil)
+	pickupBursts = nil
+	tweens.Clear()
+	scheduler.Clear()
+	enemies = nil
+	director.Reset()
+	worldFlags.Restore(nil)
+	ResetCollectibles()
+	ResetInteractables()
+	cutscene.Reset()
+	videoPlayer.Stop()
+	dialogue.Close()
+	transition.Reset()
+	weather.Set(WeatherClear)
+	quests.Restore(nil)
+	tutorials.Restore(nil)
+	speedrun.Reset()
+	leaderboards.StartLevel()
+	rewinder.Reset()
+	stats.Teleported()
+}
+
+// HandleQuickSave saves or loads the quick save slot on F5/F6.
+func HandleQuickSave() {
+	if inputContexts.KeyPressed(ContextGameplay, quickSaveKey) {
+		if err := SaveGame(profilePath(quickSavePath)); err != nil {
+			log.Printf("save: quick save failed: %v", err)
+		}
+	}
+	if inputContexts.KeyPressed(ContextGameplay, quickLoadKey) {
+		if err := LoadGame(profilePath(quickSavePath)); err != nil {
+			log.Printf("save: quick load failed: %v", err)
+		}
+	}
+}
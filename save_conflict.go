@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// SaveConflictScene asks which copy of a save to keep when it changed both
+// on this machine and in the cloud. It pops itself once the player chooses.
+type SaveConflictScene struct {
+	conflict SaveConflict
+	font     *Font
+	sounds   *UISounds
+	menu     *MenuList
+	onDone   func()
+}
+
+// NewSaveConflictScene creates the prompt for conflict; onDone runs after
+// it has been resolved.
+func NewSaveConflictScene(conflict SaveConflict, onDone func()) *SaveConflictScene {
+	return &SaveConflictScene{conflict: conflict, onDone: onDone}
+}
+
+func (s *SaveConflictScene) Load(scope *AssetScope) {
+	s.font = scope.Font("", 40)
+	s.sounds = LoadUISounds(menuMoveSound, menuSelectSound)
+	s.menu = &MenuList{
+		Layout:   UIRect{Anchor: AnchorCenter, Offset: rl.NewVector2(0, 140), Size: rl.NewVector2(480, 140)},
+		Font:     s.font,
+		FontSize: 40,
+		Spacing:  16,
+		Sounds:   s.sounds,
+	}
+	s.menu.SetItems([]MenuItem{
+		{Label: T("sync.keep_local"), OnSelect: func() { s.resolve(true) }},
+		{Label: T("sync.keep_cloud"), OnSelect: func() { s.resolve(false) }},
+	})
+}
+
+func (s *SaveConflictScene) Unload() {
+	s.sounds.Unload()
+	if s.onDone != nil {
+		s.onDone()
+	}
+}
+
+func (s *SaveConflictScene) resolve(keepLocal bool) {
+	cloudSaves.Resolve(s.conflict, keepLocal)
+	scenes.Pop()
+}
+
+func (s *SaveConflictScene) Update() {
+	s.menu.Update()
+}
+
+func (s *SaveConflictScene) Draw() {
+	rl.DrawRectangle(0, 0, int32(screenSize.X), int32(screenSize.Y), rl.Fade(rl.Black, 0.85))
+
+	lines := []string{
+		T("sync.conflict"),
+		s.conflict.Name,
+		fmt.Sprintf("%s: %s", T("sync.local"), formatSaveTime(s.conflict.LocalTime)),
+		fmt.Sprintf("%s: %s", T("sync.cloud"), formatSaveTime(s.conflict.RemoteTime)),
+	}
+	area := ui.Rect(UIRect{Anchor: AnchorCenter, Offset: rl.NewVector2(0, -120), Size: rl.NewVector2(720, 240)})
+	y := area.Y
+	for i, line := range lines {
+		size := float32(32)
+		color := rl.LightGray
+		if i == 0 {
+			size, color = 44, rl.Gold
+		}
+		w := s.font.Measure(line, size).X
+		s.font.Draw(line, rl.NewVector2(area.X+(area.Width-w)/2, y), size, color)
+		y += size + 16
+	}
+
+	s.menu.Draw()
+}
+
+// formatSaveTime shows when a copy was written, or that it's unknown when
+// the backend doesn't report it.
+func formatSaveTime(t time.Time) string {
+	if t.IsZero() {
+		return "?"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
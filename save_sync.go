@@ -0,0 +1,358 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"raylibgo/buildinfo"
+)
+
+const (
+	syncStatePath = "saves/sync_state.json"
+	syncTimeout   = 20 * time.Second
+)
+
+// SaveSync is a remote copy of the save folder. Files are addressed by their
+// path relative to the game directory, such as "profiles/ana/saves/slot1.json".
+type SaveSync interface {
+	// Name is shown when the backend is mentioned in the UI or logs
+	Name() string
+	// Download returns the remote file and when it was last written. A file
+	// that doesn't exist yet returns an error wrapping fs.ErrNotExist.
+	Download(ctx context.Context, name string) ([]byte, time.Time, error)
+	// Upload replaces the remote file.
+	Upload(ctx context.Context, name string, data []byte) error
+}
+
+// FolderSync keeps the copy in another directory, such as a folder a
+// desktop sync client already shares between machines, or one listed in the
+// game's Steam Auto-Cloud paths so Steam carries it to other machines
+type FolderSync struct {
+	Dir string
+}
+
+func (f FolderSync) Name() string { return "folder" }
+
+func (f FolderSync) Download(ctx context.Context, name string) ([]byte, time.Time, error) {
+	path := filepath.Join(f.Dir, filepath.FromSlash(name))
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	data, err := os.ReadFile(path)
+	return data, info.ModTime(), err
+}
+
+func (f FolderSync) Upload(ctx context.Context, name string, data []byte) error {
+	return writeFileAtomic(filepath.Join(f.Dir, filepath.FromSlash(name)), data)
+}
+
+// HTTPSync stores the copy on any server that serves files with plain GET
+// and accepts them with PUT, such as a WebDAV share. Requests aren't signed,
+// so an S3 bucket needs a gateway or proxy that signs them. Credentials in
+// BaseURL are sent with basic auth; Token, if set, is sent as a bearer token.
+type HTTPSync struct {
+	BaseURL string
+	Token   string
+	Client  *http.Client
+}
+
+func (h HTTPSync) Name() string { return "http" }
+
+func (h HTTPSync) request(ctx context.Context, method, name string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(h.BaseURL, "/")+"/"+name, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", buildinfo.Get().UserAgent())
+	if h.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+h.Token)
+	}
+	client := h.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
+
+func (h HTTPSync) Download(ctx context.Context, name string) ([]byte, time.Time, error) {
+	resp, err := h.request(ctx, http.MethodGet, name, nil)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, time.Time{}, fmt.Errorf("%s: %w", name, fs.ErrNotExist)
+	case resp.StatusCode != http.StatusOK:
+		return nil, time.Time{}, fmt.Errorf("%s: %s", name, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	modTime, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	return data, modTime, err
+}
+
+func (h HTTPSync) Upload(ctx context.Context, name string, data []byte) error {
+	resp, err := h.request(ctx, http.MethodPut, name, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s: %s", name, resp.Status)
+	}
+	return nil
+}
+
+// NewSaveSync creates the backend named by kind, or nil when kind is empty.
+func NewSaveSync(kind, location, token string) (SaveSync, error) {
+	switch kind {
+	case "":
+		return nil, nil
+	case "folder":
+		return FolderSync{Dir: location}, nil
+	case "http":
+		return HTTPSync{BaseURL: location, Token: token, Client: &http.Client{Timeout: syncTimeout}}, nil
+	}
+	return nil, fmt.Errorf("unknown backend %q", kind)
+}
+
+// SaveConflict is a save that changed both here and remotely since the last
+// sync. The player picks which copy to keep.
+type SaveConflict struct {
+	Name       string
+	LocalTime  time.Time
+	RemoteTime time.Time
+
+	remote []byte
+	state  string // sync state path of the profile the save belongs to
+}
+
+// CloudSaves reconciles local saves with a SaveSync backend. It remembers the
+// hash of each file as of the last sync: when only one side differs from it
+// that side wins, and when both do the file becomes a conflict.
+type CloudSaves struct {
+	Backend SaveSync
+
+	mu        sync.Mutex
+	syncing   bool
+	state     string                       // sync state path of the current profile
+	synced    map[string]map[string]string // by sync state path, file name to hash at the last sync
+	conflicts map[string][]SaveConflict    // by sync state path
+}
+
+var cloudSaves = &CloudSaves{}
+
+// syncedSaves lists the files kept in sync.
+func syncedSaves() []string {
+	names := []string{profilePath(quickSavePath)}
+	for n := 1; n <= saveSlotCount; n++ {
+		names = append(names, slotPath(n))
+	}
+	return names
+}
+
+// ConfigureCloudSaves sets up the backend chosen in the settings for the
+// current profile.
+func ConfigureCloudSaves() {
+	backend, err := NewSaveSync(settings.CloudSync, settings.CloudSyncPath, settings.CloudSyncToken)
+	if err != nil {
+		log.Printf("cloud save: %v", err)
+	}
+	cloudSaves.Configure(backend)
+}
+
+// Configure sets the backend and loads the state of the last sync for the
+// current profile. The state of each profile is loaded once and kept, so a
+// sync or conflict still pending for a profile switched away from records
+// its result in that profile's state.
+func (c *CloudSaves) Configure(backend SaveSync) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Backend = backend
+	c.state = profilePath(syncStatePath)
+	if c.synced == nil {
+		c.synced = make(map[string]map[string]string)
+		c.conflicts = make(map[string][]SaveConflict)
+	}
+	if c.synced[c.state] != nil {
+		return
+	}
+	synced := make(map[string]string)
+	c.synced[c.state] = synced
+
+	data, err := os.ReadFile(c.state)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("cloud save: %v", err)
+		}
+		return
+	}
+	if err := json.Unmarshal(data, &synced); err != nil {
+		log.Printf("cloud save: %s: %v", c.state, err)
+	}
+}
+
+// Sync reconciles every save on a background goroutine. Conflicts are
+// collected for the player to resolve rather than overwritten. The files,
+// backend and sync state are fixed when it starts, so switching profiles
+// or backends meanwhile doesn't mix them up.
+func (c *CloudSaves) Sync() {
+	c.mu.Lock()
+	if c.Backend == nil || c.syncing {
+		c.mu.Unlock()
+		return
+	}
+	c.syncing = true
+	backend, state := c.Backend, c.state
+	synced, names := c.synced[state], syncedSaves()
+	c.mu.Unlock()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), syncTimeout)
+		defer cancel()
+		for _, name := range names {
+			if err := c.syncFile(ctx, backend, state, synced, name); err != nil {
+				log.Printf("cloud save: %s: %s: %v", backend.Name(), name, err)
+			}
+		}
+		c.mu.Lock()
+		c.syncing = false
+		saveSyncStateLocked(state, synced)
+		c.mu.Unlock()
+	}()
+}
+
+// syncFile reconciles one file with backend. synced is the state of the
+// profile being synced, saved at state; it is read and written under c.mu.
+func (c *CloudSaves) syncFile(ctx context.Context, backend SaveSync, state string, synced map[string]string, name string) error {
+	local, err := os.ReadFile(name)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	remote, remoteTime, err := backend.Download(ctx, name)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	localHash, remoteHash := saveHash(local), saveHash(remote)
+
+	c.mu.Lock()
+	base := synced[name]
+	c.mu.Unlock()
+
+	switch {
+	case localHash == remoteHash:
+	case remoteHash == "" || remoteHash == base:
+		if err := backend.Upload(ctx, name, local); err != nil {
+			return err
+		}
+	case localHash == "" || localHash == base:
+		if err := writeFileAtomic(name, remote); err != nil {
+			return err
+		}
+		localHash = remoteHash
+	default:
+		conflict := SaveConflict{Name: name, RemoteTime: remoteTime, remote: remote, state: state}
+		if info, err := os.Stat(name); err == nil {
+			conflict.LocalTime = info.ModTime()
+		}
+		c.mu.Lock()
+		if !slices.ContainsFunc(c.conflicts[state], func(other SaveConflict) bool { return other.Name == name }) {
+			c.conflicts[state] = append(c.conflicts[state], conflict)
+		}
+		c.mu.Unlock()
+		return nil
+	}
+
+	c.mu.Lock()
+	synced[name] = localHash
+	c.mu.Unlock()
+	return nil
+}
+
+// NextConflict returns the oldest unresolved conflict of the current
+// profile, if any.
+func (c *CloudSaves) NextConflict() (SaveConflict, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.conflicts[c.state]) == 0 {
+		return SaveConflict{}, false
+	}
+	return c.conflicts[c.state][0], true
+}
+
+// Resolve settles a conflict by keeping the local or the remote copy and
+// overwriting the other one. The result is recorded in the sync state of
+// the profile the conflict belongs to.
+func (c *CloudSaves) Resolve(conflict SaveConflict, keepLocal bool) {
+	c.mu.Lock()
+	c.conflicts[conflict.state] = slices.DeleteFunc(c.conflicts[conflict.state], func(other SaveConflict) bool {
+		return other.Name == conflict.Name
+	})
+	backend, synced := c.Backend, c.synced[conflict.state]
+	c.mu.Unlock()
+	if backend == nil {
+		// Cloud saves were turned off while the player was deciding
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), syncTimeout)
+		defer cancel()
+
+		data := conflict.remote
+		var err error
+		if keepLocal {
+			if data, err = os.ReadFile(conflict.Name); err == nil {
+				err = backend.Upload(ctx, conflict.Name, data)
+			}
+		} else {
+			err = writeFileAtomic(conflict.Name, data)
+		}
+		if err != nil {
+			log.Printf("cloud save: %s: %v", conflict.Name, err)
+			return
+		}
+
+		c.mu.Lock()
+		synced[conflict.Name] = saveHash(data)
+		saveSyncStateLocked(conflict.state, synced)
+		c.mu.Unlock()
+	}()
+}
+
+// saveSyncStateLocked writes the hashes of a sync to path. The caller holds
+// the CloudSaves lock, which guards synced.
+func saveSyncStateLocked(path string, synced map[string]string) {
+	data, err := json.MarshalIndent(synced, "", "  ")
+	if err == nil {
+		err = writeFileAtomic(path, data)
+	}
+	if err != nil {
+		log.Printf("cloud save: %v", err)
+	}
+}
+
+// saveHash identifies file contents; a missing file hashes to "".
+func saveHash(data []byte) string {
+	if data == nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,103 @@
+package main
+
+// Scene is one screen of the game, such as a menu or the gameplay. Update
+// runs once per rendered frame and Draw between BeginDrawing and EndDrawing.
+type Scene interface {
+	// Load is called when the scene becomes part of the stack. Assets
+	// acquired through scope are released automatically on unload.
+	Load(scope *AssetScope)
+	Update()
+	Draw()
+	Unload()
+}
+
+type sceneEntry struct {
+	scene Scene
+	scope *AssetScope
+}
+
+// SceneStack runs the topmost scene. Changes requested during a frame are
+// applied at the start of the next Update, so a scene can safely replace
+// itself from its own Update.
+type SceneStack struct {
+	stack   []sceneEntry
+	pending []func()
+}
+
+var scenes = &SceneStack{}
+
+// Push puts a scene on top of the current one.
+func (s *SceneStack) Push(scene Scene) {
+	s.pending = append(s.pending, func() { s.push(scene) })
+}
+
+// Pop unloads the top scene, returning to the one below.
+func (s *SceneStack) Pop() {
+	s.pending = append(s.pending, s.pop)
+}
+
+// Replace swaps the top scene for another. The new scene loads before the
+// old one unloads, so assets both use stay resident across the switch.
+func (s *SceneStack) Replace(scene Scene) {
+	s.pending = append(s.pending, func() {
+		if len(s.stack) == 0 {
+			s.push(scene)
+			return
+		}
+		old := s.stack[len(s.stack)-1]
+		s.push(scene)
+		s.stack = append(s.stack[:len(s.stack)-2], s.stack[len(s.stack)-1])
+		old.scene.Unload()
+		old.scope.Close()
+	})
+}
+
+func (s *SceneStack) push(scene Scene) {
+	scope := NewAssetScope("scene")
+	s.stack = append(s.stack, sceneEntry{scene: scene, scope: scope})
+	scene.Load(scope)
+}
+
+func (s *SceneStack) pop() {
+	if len(s.stack) == 0 {
+		return
+	}
+	top := s.stack[len(s.stack)-1]
+	s.stack = s.stack[:len(s.stack)-1]
+	top.scene.Unload()
+	top.scope.Close()
+}
+
+// Top returns the running scene, or nil when the stack is empty.
+func (s *SceneStack) Top() Scene {
+	if len(s.stack) == 0 {
+		return nil
+	}
+	return s.stack[len(s.stack)-1].scene
+}
+
+// Update applies pending changes and updates the top scene.
+func (s *SceneStack) Update() {
+	for len(s.pending) > 0 {
+		change := s.pending[0]
+		s.pending = s.pending[1:]
+		change()
+	}
+	if top := s.Top(); top != nil {
+		top.Update()
+	}
+}
+
+// Draw renders the top scene.
+func (s *SceneStack) Draw() {
+	if top := s.Top(); top != nil {
+		top.Draw()
+	}
+}
+
+// UnloadAll unloads every scene, top first.
+func (s *SceneStack) UnloadAll() {
+	for len(s.stack) > 0 {
+		s.pop()
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Timer is a callback waiting in the scheduler, and the handle that
+// cancels it.
+type Timer struct {
+	s        *Scheduler
+	due      uint64 // scheduler tick it runs on
+	every    uint64 // ticks between runs, 0 to run once
+	fn       func()
+	canceled bool
+}
+
+// Cancel stops the timer from running again. Canceling a nil or finished
+// timer does nothing.
+func (t *Timer) Cancel() {
+	if t != nil {
+		t.canceled = true
+	}
+}
+
+// Active reports whether the timer will still run.
+func (t *Timer) Active() bool {
+	return t != nil && !t.canceled
+}
+
+// Remaining returns the simulation time until the timer next runs.
+func (t *Timer) Remaining() time.Duration {
+	if !t.Active() {
+		return 0
+	}
+	return time.Duration(t.due-t.s.tick) * tickDuration
+}
+
+// timerState is when a timer next runs relative to the current tick, so
+// it can be captured and scheduled again after a rewind or a load.
+type timerState struct {
+	Left  uint64 `json:"left,omitempty"`
+	Every uint64 `json:"every,omitempty"`
+}
+
+func (t *Timer) state() timerState {
+	if !t.Active() {
+		return timerState{}
+	}
+	return timerState{Left: t.due - t.s.tick, Every: t.every}
+}
+
+// SequenceStep waits Wait, then runs Do
+type SequenceStep struct {
+	Wait time.Duration
+	Do   func()
+}
+
+// Scheduler runs callbacks after delays counted in simulation ticks. It
+// advances once per Update, so timers are exact to the tick, stop while
+// the game is paused or frozen by hitstop, and step along with frame
+// stepping.
+type Scheduler struct {
+	tick   uint64
+	timers []*Timer
+}
+
+var scheduler = &Scheduler{}
+
+// ticksFor converts d to whole ticks, rounding up.
+func ticksFor(d time.Duration) uint64 {
+	if d <= 0 {
+		return 0
+	}
+	return uint64((d + tickDuration - 1) / tickDuration)
+}
+
+func (s *Scheduler) add(t *Timer) *Timer {
+	t.s = s
+	s.timers = append(s.timers, t)
+	return t
+}
+
+// After runs fn once, d from now. A zero delay runs it on the next tick.
+func (s *Scheduler) After(d time.Duration, fn func()) *Timer {
+	return s.add(&Timer{due: s.tick + max(ticksFor(d), 1), fn: fn})
+}
+
+// Every runs fn every d, the first time d from now, until canceled.
+func (s *Scheduler) Every(d time.Duration, fn func()) *Timer {
+	every := max(ticksFor(d), 1)
+	return s.add(&Timer{due: s.tick + every, every: every, fn: fn})
+}
+
+// Sequence runs the steps in order, each after its own wait. A step with
+// no wait runs in the same tick as the one before it; canceling the
+// returned timer drops the steps not yet run.
+func (s *Scheduler) Sequence(steps ...SequenceStep) *Timer {
+	if len(steps) == 0 {
+		return nil
+	}
+	t := &Timer{due: s.tick + max(ticksFor(steps[0].Wait), 1)}
+	i := 0
+	t.fn = func() {
+		for i < len(steps) {
+			step := steps[i]
+			i++
+			if step.Do != nil {
+				step.Do()
+			}
+			if t.canceled || i == len(steps) {
+				return
+			}
+			if wait := ticksFor(steps[i].Wait); wait > 0 {
+				t.due = s.tick + wait
+				return
+			}
+		}
+	}
+	return s.add(t)
+}
+
+// resume schedules fn to run as a captured timer would have, or returns
+// nil when that timer wasn't running.
+func (s *Scheduler) resume(st timerState, fn func()) *Timer {
+	if st.Left == 0 {
+		return nil
+	}
+	return s.add(&Timer{due: s.tick + st.Left, every: st.Every, fn: fn})
+}
+
+// Update advances one tick and runs the timers that are due. Timers added
+// by callbacks meanwhile wait at least until the next tick.
+func (s *Scheduler) Update() {
+	s.tick++
+	n := len(s.timers)
+	for i := 0; i < n && i < len(s.timers); i++ {
+		t := s.timers[i]
+		if t.canceled || t.due > s.tick {
+			continue
+		}
+		t.fn()
+		switch {
+		case t.every > 0:
+			t.due += t.every
+		case t.due <= s.tick:
+			// Ran once and wasn't moved on, as sequences do between steps
+			t.canceled = true
+		}
+	}
+	kept := 0
+	for _, t := range s.timers {
+		if !t.canceled {
+			s.timers[kept] = t
+			kept++
+		}
+	}
+	clear(s.timers[kept:])
+	s.timers = s.timers[:kept]
+}
+
+// Clear cancels every timer, for when a new game starts.
+func (s *Scheduler) Clear() {
+	for _, t := range s.timers {
+		t.canceled = true
+	}
+	clear(s.timers)
+	s.timers = s.timers[:0]
+}
+
+// DebugLines reports how many timers are waiting.
+func (s *Scheduler) DebugLines() []string {
+	return []string{fmt.Sprintf("Timers: %d  Tick: %d", len(s.timers), s.tick)}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// ShakePreset describes a kind of shake. Displacement is trauma squared times
+// the amplitudes, so small trauma values stay subtle.
+type ShakePreset struct {
+	Trauma    float32 // added per trigger, total capped at 1
+	Amplitude float32 // max offset in pixels
+	Rotation  float32 // max rotation in degrees
+	Frequency float32 // oscillations per second
+	Decay     float32 // trauma lost per second
+}
+
+var (
+	ShakeLanding   = ShakePreset{Trauma: 0.3, Amplitude: 10, Rotation: 0, Frequency: 18, Decay: 2.5}
+	ShakeHit       = ShakePreset{Trauma: 0.45, Amplitude: 18, Rotation: 1.5, Frequency: 25, Decay: 2}
+	ShakeExplosion = ShakePreset{Trauma: 0.8, Amplitude: 40, Rotation: 4, Frequency: 12, Decay: 1.2}
+)
+
+type shake struct {
+	preset ShakePreset
+	trauma float32
+	seed   float64
+}
+
+// ScreenShake sums independent shakes into a camera offset and rotation
+type ScreenShake struct {
+	shakes []shake
+	time   float64
+	offset rl.Vector2
+	angle  float32
+}
+
+var screenShake = &ScreenShake{}
+
+// AddShake starts a shake or adds trauma to a running shake of the same preset.
+func AddShake(preset ShakePreset) {
+	s := screenShake
+	for i := range s.shakes {
+		if s.shakes[i].preset == preset {
+			s.shakes[i].trauma = min(1, s.shakes[i].trauma+preset.Trauma)
+			return
+		}
+	}
+	s.shakes = append(s.shakes, shake{
+		preset: preset,
+		trauma: min(1, preset.Trauma),
+		seed:   float64(len(s.shakes)+1) * 17.3,
+	})
+}
+
+// Update decays trauma and recomputes the displacement with real frame time,
+// scaled by the accessibility setting.
+func (s *ScreenShake) Update(dt float32) {
+	s.time += float64(dt)
+	s.offset = rl.NewVector2(0, 0)
+	s.angle = 0
+
+	scale := max(0, min(settings.ScreenShake, 1))
+	kept := s.shakes[:0]
+	for _, sh := range s.shakes {
+		amount := sh.trauma * sh.trauma * scale
+		t := s.time * float64(sh.preset.Frequency)
+		s.offset.X += amount * sh.preset.Amplitude * smoothNoise(t, sh.seed)
+		s.offset.Y += amount * sh.preset.Amplitude * smoothNoise(t, sh.seed+31.7)
+		s.angle += amount * sh.preset.Rotation * smoothNoise(t, sh.seed+63.1)
+
+		sh.trauma -= sh.preset.Decay * dt
+		if sh.trauma > 0 {
+			kept = append(kept, sh)
+		}
+	}
+	s.shakes = kept
+}
+
+// smoothNoise returns a value in [-1, 1] that varies smoothly with t.
+func smoothNoise(t, seed float64) float32 {
+	return float32((math.Sin(t+seed) + math.Sin(t*2.3+seed*1.7)*0.5) / 1.5)
+}
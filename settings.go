@@ -0,0 +1,286 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	settingsPath    = "settings.json"
+	settingsVersion = 3
+
+	// Smallest window the UI still lays out in
+	minWindowWidth  = 640
+	minWindowHeight = 360
+)
+
+// Settings are user preferences stored next to the game
+type Settings struct {
+	// Version is the settings format the file was written with
+	Version int `json:"version"`
+	// ScreenShake scales camera shake from 0 (off) to 1 (full)
+	ScreenShake float32 `json:"screenShake"`
+	// VRAMBudgetMB is the texture memory the debug overlay warns about;
+	// near it, prestreamed levels are released
+	VRAMBudgetMB int `json:"vramBudgetMB"`
+	// ImageCacheMB is the RAM kept for decoded images; 0 disables the cache
+	ImageCacheMB int `json:"imageCacheMB"`
+	// UpdateURL points at an UpdateInfo document checked at startup; empty disables updates
+	UpdateURL string `json:"updateURL,omitempty"`
+	// GCPercent overrides GOGC; 0 keeps the default and a negative value disables the collector
+	GCPercent int `json:"gcPercent,omitempty"`
+	// MemoryLimitMB is a soft heap limit for the collector; 0 means none
+	MemoryLimitMB int `json:"memoryLimitMB,omitempty"`
+	// Language selects the locale table used for UI text
+	Language string `json:"language"`
+	// PlayerName is shown on leaderboards
+	PlayerName string `json:"playerName"`
+	// LeaderboardURL is the leaderboard backend; empty keeps results offline
+	LeaderboardURL string `json:"leaderboardURL,omitempty"`
+	// LeaderboardKey authorizes submissions to the backend
+	LeaderboardKey string `json:"leaderboardKey,omitempty"`
+	// SpeedrunTimer shows the run timer and splits during gameplay
+	SpeedrunTimer bool `json:"speedrunTimer,omitempty"`
+	// CloudSync picks the save sync backend: "folder" or "http" (plain GET
+	// and PUT, such as WebDAV); empty keeps saves local
+	CloudSync string `json:"cloudSync,omitempty"`
+	// CloudSyncPath is the folder or URL the backend stores saves at
+	CloudSyncPath string `json:"cloudSyncPath,omitempty"`
+	// CloudSyncToken authorizes requests to an HTTP backend
+	CloudSyncToken string `json:"cloudSyncToken,omitempty"`
+	// MasterVolume and MusicVolume range from 0 (silent) to 1 (full)
+	MasterVolume float32 `json:"masterVolume"`
+	MusicVolume  float32 `json:"musicVolume"`
+	// WindowWidth and WindowHeight size the window when no resolution is
+	// given at launch; 0 keeps the launch default
+	WindowWidth  int `json:"windowWidth,omitempty"`
+	WindowHeight int `json:"windowHeight,omitempty"`
+	// InputProfile names the control preset: "default", "one_handed_left",
+	// "one_handed_right" or "custom"
+	InputProfile string `json:"inputProfile"`
+	// KeyBindings and ButtonBindings replace the default keys and gamepad
+	// buttons per action in the custom profile, keyed by the action names used
+	// in "[Jump]" markup. Every listed binding works at once.
+	KeyBindings    map[string][]int32 `json:"keyBindings,omitempty"`
+	ButtonBindings map[string][]int32 `json:"buttonBindings,omitempty"`
+	// Narration reads focused menu items and prompts aloud
+	Narration bool `json:"narration,omitempty"`
+	// NarrationCommand runs an external speech engine that reads text on
+	// stdin instead of the platform's own
+	NarrationCommand string `json:"narrationCommand,omitempty"`
+	// HighContrast simplifies the backdrop, outlines entities and enlarges text
+	HighContrast bool `json:"highContrast,omitempty"`
+	// Shadows is the drop shadow quality: "off", "blob" or "sprite"
+	Shadows string `json:"shadows"`
+	// RenderScale is the world's render resolution as a percentage of the
+	// window, from 50 to 200
+	RenderScale int `json:"renderScale"`
+	// DynamicResolution lowers the render scale while frames miss their
+	// budget and restores it when there is headroom
+	DynamicResolution bool `json:"dynamicResolution,omitempty"`
+	// PauseOnFocusLoss holds gameplay while the window is in the background
+	PauseOnFocusLoss bool `json:"pauseOnFocusLoss"`
+	// BackgroundAudio is what the music does while the window is in the
+	// background: "keep", "duck" or "mute"
+	BackgroundAudio string `json:"backgroundAudio"`
+	// AimAttacks points attacks at the mouse cursor or the right stick
+	// instead of the way the player faces
+	AimAttacks bool `json:"aimAttacks,omitempty"`
+}
+
+var settings = DefaultSettings()
+
+// DefaultSettings returns the settings used when no file exists.
+func DefaultSettings() Settings {
+	return Settings{
+		Version:          settingsVersion,
+		MasterVolume:     1,
+		MusicVolume:      1,
+		ScreenShake:      1,
+		VRAMBudgetMB:     512,
+		ImageCacheMB:     128,
+		Language:         "en",
+		PlayerName:       "Player",
+		InputProfile:     profileDefault,
+		Shadows:          ShadowsSprite,
+		RenderScale:      100,
+		PauseOnFocusLoss: true,
+		BackgroundAudio:  BackgroundAudioDuck,
+	}
+}
+
+// settingsMigrations upgrade a raw settings file from the version it is keyed
+// by to the next one.
+var settingsMigrations = map[int]func(raw map[string]json.RawMessage) error{
+	// Version 1 files had no version field; everything added since defaults
+	1: func(raw map[string]json.RawMessage) error { return nil },
+	// Version 2 applied key binding overrides directly; they now belong to
+	// the custom input profile
+	2: func(raw map[string]json.RawMessage) error {
+		if _, ok := raw["keyBindings"]; ok {
+			raw["inputProfile"], _ = json.Marshal(profileCustom)
+		}
+		return nil
+	},
+}
+
+// LoadSettings reads settings from path, keeping defaults for missing fields.
+// Older files are migrated, and fields that fail to decode keep their
+// defaults instead of discarding the whole file. The result is validated.
+func LoadSettings(path string) (Settings, error) {
+	s := DefaultSettings()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return s, err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return s, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := migrateSettings(raw); err != nil {
+		return s, fmt.Errorf("%s: %w", path, err)
+	}
+
+	for key, value := range raw {
+		field, _ := json.Marshal(map[string]json.RawMessage{key: value})
+		if err := json.Unmarshal(field, &s); err != nil {
+			log.Printf("settings: %s: ignoring %s: %v", path, key, err)
+		}
+	}
+	s.Version = settingsVersion
+	s.Validate()
+	return s, nil
+}
+
+// migrateSettings brings a raw settings file up to settingsVersion. A file
+// from a newer build is left as is; fields this build doesn't know are ignored.
+func migrateSettings(raw map[string]json.RawMessage) error {
+	version := 1
+	if v, ok := raw["version"]; ok {
+		if err := json.Unmarshal(v, &version); err != nil {
+			return fmt.Errorf("bad version: %w", err)
+		}
+	}
+	for ; version < settingsVersion; version++ {
+		migrate, ok := settingsMigrations[version]
+		if !ok {
+			return fmt.Errorf("no migration from version %d", version)
+		}
+		if err := migrate(raw); err != nil {
+			return fmt.Errorf("migrating from version %d: %w", version, err)
+		}
+	}
+	return nil
+}
+
+// Validate clamps out-of-range values and resets key bindings that can't be
+// used, logging each fix.
+func (s *Settings) Validate() {
+	clamp := func(name string, v *float32) {
+		if c := max(0, min(1, *v)); c != *v {
+			log.Printf("settings: %s %v out of range, using %v", name, *v, c)
+			*v = c
+		}
+	}
+	clamp("screenShake", &s.ScreenShake)
+	clamp("masterVolume", &s.MasterVolume)
+	clamp("musicVolume", &s.MusicVolume)
+
+	if s.VRAMBudgetMB <= 0 {
+		s.VRAMBudgetMB = DefaultSettings().VRAMBudgetMB
+	}
+	s.ImageCacheMB = max(0, s.ImageCacheMB)
+	s.MemoryLimitMB = max(0, s.MemoryLimitMB)
+
+	if (s.WindowWidth != 0 || s.WindowHeight != 0) &&
+		(s.WindowWidth < minWindowWidth || s.WindowHeight < minWindowHeight) {
+		log.Printf("settings: window size %dx%d too small, using the default", s.WindowWidth, s.WindowHeight)
+		s.WindowWidth, s.WindowHeight = 0, 0
+	}
+
+	if !slices.Contains(inputProfileNames(), s.InputProfile) {
+		log.Printf("settings: unknown input profile %q, using the default", s.InputProfile)
+		s.InputProfile = profileDefault
+	}
+	if !slices.Contains(shadowQualities, s.Shadows) {
+		log.Printf("settings: unknown shadow quality %q, using %q", s.Shadows, ShadowsSprite)
+		s.Shadows = ShadowsSprite
+	}
+	if c := max(minRenderScale, min(maxRenderScale, s.RenderScale)); c != s.RenderScale {
+		log.Printf("settings: render scale %d%% out of range, using %d%%", s.RenderScale, c)
+		s.RenderScale = c
+	}
+	if !slices.Contains(backgroundAudioModes, s.BackgroundAudio) {
+		log.Printf("settings: unknown background audio mode %q, using %q", s.BackgroundAudio, BackgroundAudioDuck)
+		s.BackgroundAudio = BackgroundAudioDuck
+	}
+	validateBindings("key", s.KeyBindings, rl.KeySpace, rl.KeyKbMenu)
+	validateBindings("button", s.ButtonBindings, rl.GamepadButtonLeftFaceUp, rl.GamepadButtonRightThumb)
+}
+
+// validateBindings drops bindings for unknown actions and resets actions
+// bound to nothing or to codes outside [lowest, highest].
+func validateBindings(kind string, bindings map[string][]int32, lowest, highest int32) {
+	for name, codes := range bindings {
+		if _, ok := actionNames[name]; !ok {
+			log.Printf("settings: unknown action %q in %s bindings", name, kind)
+			delete(bindings, name)
+			continue
+		}
+		broken := len(codes) == 0 || slices.ContainsFunc(codes, func(c int32) bool {
+			return c < lowest || c > highest
+		})
+		if broken {
+			log.Printf("settings: %s bindings for %s are invalid, using the defaults", kind, name)
+			delete(bindings, name)
+		}
+	}
+}
+
+// ValidateDisplay drops a window size the current monitor can't show. It
+// needs the window to exist. raylib doesn't list video modes, so any size
+// up to the monitor's current mode is accepted.
+func (s *Settings) ValidateDisplay() {
+	if s.WindowWidth == 0 {
+		return
+	}
+	monitor := rl.GetCurrentMonitor()
+	w, h := rl.GetMonitorWidth(monitor), rl.GetMonitorHeight(monitor)
+	if s.WindowWidth > w || s.WindowHeight > h {
+		log.Printf("settings: window size %dx%d exceeds the monitor's %dx%d, using the default", s.WindowWidth, s.WindowHeight, w, h)
+		s.WindowWidth, s.WindowHeight = 0, 0
+	}
+}
+
+// ApplySettings pushes volumes, the window size and key bindings to the
+// running game.
+func ApplySettings() {
+	if !launch.Mute {
+		rl.SetMasterVolume(settings.MasterVolume)
+	}
+	if rl.IsMusicValid(music) {
+		rl.SetMusicVolume(music, mixer.MusicVolume())
+	}
+	if settings.WindowWidth > 0 && !launch.ResolutionSet {
+		rl.SetWindowSize(settings.WindowWidth, settings.WindowHeight)
+		screenSize = rl.NewVector2(float32(settings.WindowWidth), float32(settings.WindowHeight))
+	}
+	ApplyInputProfile(settings.InputProfile)
+	narrator.Configure(settings.Narration, settings.NarrationCommand)
+	SetHighContrast(settings.HighContrast)
+}
+
+// SaveSettings writes settings to path.
+func SaveSettings(path string, s Settings) error {
+	s.Version = settingsVersion
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Shadow quality levels, as stored in the settings
+const (
+	ShadowsOff    = "off"
+	ShadowsBlob   = "blob"   // a soft ellipse
+	ShadowsSprite = "sprite" // a flattened copy of the current frame
+)
+
+var shadowQualities = []string{ShadowsOff, ShadowsBlob, ShadowsSprite}
+
+const (
+	shadowAlpha   = 0.45
+	shadowFlatten = 0.18 // height of a sprite shadow relative to the sprite
+	// Height above the ground at which a shadow reaches its smallest
+	shadowFadeHeight = 240
+	shadowMinScale   = 0.35
+)
+
+// shadowScale shrinks a shadow as its caster rises above the ground.
+func shadowScale(height float32) float32 {
+	return max(shadowMinScale, 1-max(height, 0)/shadowFadeHeight*(1-shadowMinScale))
+}
+
+// DrawSpriteShadow draws the shadow of a sprite drawn from src into dst onto
+// the ground at groundY. At sprite quality it is the frame itself flattened
+// and tinted; at blob quality an ellipse.
+func DrawSpriteShadow(tex rl.Texture2D, src, dst rl.Rectangle, groundY float32) {
+	if settings.Shadows == ShadowsBlob {
+		DrawShadowBlob(dst, groundY)
+		return
+	}
+	if settings.Shadows != ShadowsSprite {
+		return
+	}
+	scale := shadowScale(groundY - (dst.Y + dst.Height))
+	w := dst.Width * scale
+	h := dst.Height * shadowFlatten * scale
+	shadow := rl.NewRectangle(dst.X+(dst.Width-w)/2, groundY-h, w, h)
+	rl.DrawTexturePro(tex, src, shadow, rl.NewVector2(0, 0), 0, rl.Fade(rl.Black, shadowAlpha*scale))
+}
+
+// DrawShadowBlob draws an ellipse under bounds at groundY, for entities
+// without a sprite or when sprite shadows are turned down.
+func DrawShadowBlob(bounds rl.Rectangle, groundY float32) {
+	if settings.Shadows == ShadowsOff {
+		return
+	}
+	scale := shadowScale(groundY - (bounds.Y + bounds.Height))
+	center := bounds.X + bounds.Width/2
+	rl.DrawEllipse(int32(center), int32(groundY), bounds.Width*0.4*scale, 6*scale, rl.Fade(rl.Black, shadowAlpha*scale))
+}
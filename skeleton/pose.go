@@ -0,0 +1,179 @@
+package skeleton
+
+import (
+	"math"
+)
+
+// Pose is a skeleton in one position: the setup pose with animations
+// applied on top.
+type Pose struct {
+	Data        *Data
+	local       []Bone
+	world       []transform
+	attachments []string
+	colors      []Color
+}
+
+// transform maps bone space to skeleton space: x' = a*x + b*y + x0
+type transform struct {
+	a, b, c, d, x, y float64
+}
+
+func (t transform) apply(x, y float64) (float64, float64) {
+	return t.a*x + t.b*y + t.x, t.c*x + t.d*y + t.y
+}
+
+// Quad is a region attachment placed in skeleton space. Corners run
+// top-left, bottom-left, bottom-right, top-right of the image.
+type Quad struct {
+	Path    string
+	Corners [4][2]float64
+	Color   Color
+}
+
+// NewPose returns d in its setup pose.
+func NewPose(d *Data) *Pose {
+	p := &Pose{
+		Data:        d,
+		local:       make([]Bone, len(d.Bones)),
+		world:       make([]transform, len(d.Bones)),
+		attachments: make([]string, len(d.Slots)),
+		colors:      make([]Color, len(d.Slots)),
+	}
+	p.SetToSetup()
+	return p
+}
+
+// SetToSetup returns every bone and slot to the setup pose.
+func (p *Pose) SetToSetup() {
+	copy(p.local, p.Data.Bones)
+	for i, s := range p.Data.Slots {
+		p.attachments[i] = s.Attachment
+		p.colors[i] = s.Color
+	}
+}
+
+// Apply poses the skeleton at time seconds into anim, on top of the setup
+// pose. With loop the time wraps around the animation; otherwise it holds
+// the last frame.
+func (p *Pose) Apply(anim *Animation, time float64, loop bool) {
+	p.SetToSetup()
+	if anim.Duration > 0 {
+		if loop {
+			time = math.Mod(time, anim.Duration)
+		} else {
+			time = min(time, anim.Duration)
+		}
+	}
+	for _, t := range anim.bones {
+		bone := &p.local[t.bone]
+		setup := p.Data.Bones[t.bone]
+		if v, ok := sample(t.rotate, time, true); ok {
+			bone.Rotation = setup.Rotation + v[0]
+		}
+		if v, ok := sample(t.translate, time, false); ok {
+			bone.X, bone.Y = setup.X+v[0], setup.Y+v[1]
+		}
+		if v, ok := sample(t.scale, time, false); ok {
+			bone.ScaleX, bone.ScaleY = setup.ScaleX*v[0], setup.ScaleY*v[1]
+		}
+	}
+	for _, t := range anim.slots {
+		if len(t.attachment) > 0 {
+			i := keyBefore(len(t.attachment), func(i int) float64 { return t.attachment[i].time }, time)
+			p.attachments[t.slot] = t.attachment[i].name
+		}
+		if v, ok := sample(t.color, time, false); ok {
+			p.colors[t.slot] = Color(v)
+		}
+	}
+}
+
+// keyBefore returns the index of the last key at or before time, or 0 when
+// time is before every key.
+func keyBefore(n int, at func(int) float64, time float64) int {
+	i := 0
+	for i+1 < n && at(i+1) <= time {
+		i++
+	}
+	return i
+}
+
+// sample interpolates keys at time. Angles take the short way round.
+func sample(keys []key, time float64, angle bool) ([4]float64, bool) {
+	if len(keys) == 0 {
+		return [4]float64{}, false
+	}
+	i := keyBefore(len(keys), func(i int) float64 { return keys[i].time }, time)
+	k := keys[i]
+	if i+1 == len(keys) || k.stepped || time <= k.time {
+		return k.value, true
+	}
+	next := keys[i+1]
+	f := (time - k.time) / (next.time - k.time)
+	var v [4]float64
+	for c := range v {
+		delta := next.value[c] - k.value[c]
+		if angle {
+			delta -= 360 * math.Round(delta/360)
+		}
+		v[c] = k.value[c] + delta*f
+	}
+	return v, true
+}
+
+// UpdateWorld computes every bone's placement in skeleton space. Parents
+// always come before their children in Data.Bones.
+func (p *Pose) UpdateWorld() {
+	for i, b := range p.local {
+		r := b.Rotation * math.Pi / 180
+		cos, sin := math.Cos(r), math.Sin(r)
+		local := transform{a: cos * b.ScaleX, b: -sin * b.ScaleY, c: sin * b.ScaleX, d: cos * b.ScaleY, x: b.X, y: b.Y}
+		if b.Parent < 0 {
+			p.world[i] = local
+			continue
+		}
+		parent := p.world[b.Parent]
+		x, y := parent.apply(local.x, local.y)
+		p.world[i] = transform{
+			a: parent.a*local.a + parent.b*local.c,
+			b: parent.a*local.b + parent.b*local.d,
+			c: parent.c*local.a + parent.d*local.c,
+			d: parent.c*local.b + parent.d*local.d,
+			x: x,
+			y: y,
+		}
+	}
+}
+
+// Quads returns the visible region attachments of skin in draw order, in
+// skeleton space. Attachments missing from skin fall back to "default".
+// Call UpdateWorld first.
+func (p *Pose) Quads(skin string) []Quad {
+	var quads []Quad
+	for i, slot := range p.Data.Slots {
+		name := p.attachments[i]
+		if name == "" {
+			continue
+		}
+		region, ok := p.Data.Skins[skin][slot.Name][name]
+		if !ok {
+			region, ok = p.Data.Skins["default"][slot.Name][name]
+		}
+		if !ok {
+			continue
+		}
+		bone := p.world[slot.Bone]
+		r := region.Rotation * math.Pi / 180
+		cos, sin := math.Cos(r), math.Sin(r)
+		w, h := region.Width*region.ScaleX/2, region.Height*region.ScaleY/2
+		q := Quad{Path: region.Path, Color: p.colors[i]}
+		for c, corner := range [4][2]float64{{-w, h}, {-w, -h}, {w, -h}, {w, h}} {
+			x := region.X + corner[0]*cos - corner[1]*sin
+			y := region.Y + corner[0]*sin + corner[1]*cos
+			q.Corners[c][0], q.Corners[c][1] = bone.apply(x, y)
+		}
+		quads = append(quads, q)
+	}
+	return quads
+}
@@ -0,0 +1,370 @@
+// Package skeleton reads skeletal animations exported by Spine as JSON and
+// poses them. Bones, slots, region attachments and the bone rotate,
+// translate and scale and slot attachment and color timelines are
+// supported; meshes, constraints, events and shear are ignored. Curves
+// other than stepped play linearly. Coordinates are Spine's: y points up.
+package skeleton
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// Bone is a bone in its setup pose, relative to its parent
+type Bone struct {
+	Name     string
+	Parent   int // index into Data.Bones, -1 for the root
+	X, Y     float64
+	Rotation float64 // degrees
+	ScaleX   float64
+	ScaleY   float64
+}
+
+// Slot holds the attachment drawn for a bone. Slots draw in order.
+type Slot struct {
+	Name       string
+	Bone       int
+	Attachment string // shown in the setup pose, "" for none
+	Color      Color
+}
+
+// Color is RGBA with components from 0 to 1
+type Color [4]float64
+
+// Region is an image attachment placed relative to its slot's bone. Path
+// names the image, without extension, under the skeleton's images folder.
+type Region struct {
+	Path          string
+	X, Y          float64
+	Rotation      float64
+	ScaleX        float64
+	ScaleY        float64
+	Width, Height float64
+}
+
+// Data is a skeleton as exported. Skins map slot name, then attachment
+// name, to its region.
+type Data struct {
+	Width, Height float64
+	Images        string // images folder relative to the JSON file
+	Bones         []Bone
+	Slots         []Slot
+	Skins         map[string]map[string]map[string]Region
+	Animations    map[string]*Animation
+}
+
+// Animation changes bones and slots over Duration seconds
+type Animation struct {
+	Name     string
+	Duration float64
+	bones    []boneTimeline
+	slots    []slotTimeline
+}
+
+type key struct {
+	time    float64
+	value   [4]float64
+	stepped bool
+}
+
+type boneTimeline struct {
+	bone                     int
+	rotate, translate, scale []key
+}
+
+type attachmentKey struct {
+	time float64
+	name string
+}
+
+type slotTimeline struct {
+	slot       int
+	attachment []attachmentKey
+	color      []key
+}
+
+// Raw JSON shapes
+
+type rawBone struct {
+	Name     string   `json:"name"`
+	Parent   string   `json:"parent"`
+	X        float64  `json:"x"`
+	Y        float64  `json:"y"`
+	Rotation float64  `json:"rotation"`
+	ScaleX   *float64 `json:"scaleX"`
+	ScaleY   *float64 `json:"scaleY"`
+}
+
+type rawSlot struct {
+	Name       string `json:"name"`
+	Bone       string `json:"bone"`
+	Attachment string `json:"attachment"`
+	Color      string `json:"color"`
+}
+
+type rawAttachment struct {
+	Type     string   `json:"type"`
+	Name     string   `json:"name"`
+	Path     string   `json:"path"`
+	X        float64  `json:"x"`
+	Y        float64  `json:"y"`
+	Rotation float64  `json:"rotation"`
+	ScaleX   *float64 `json:"scaleX"`
+	ScaleY   *float64 `json:"scaleY"`
+	Width    float64  `json:"width"`
+	Height   float64  `json:"height"`
+}
+
+type rawSkin struct {
+	Name        string                              `json:"name"`
+	Attachments map[string]map[string]rawAttachment `json:"attachments"`
+}
+
+type rawKey struct {
+	Time  float64         `json:"time"`
+	Angle *float64        `json:"angle"`
+	Value *float64        `json:"value"`
+	X     *float64        `json:"x"`
+	Y     *float64        `json:"y"`
+	Name  *string         `json:"name"`
+	Color string          `json:"color"`
+	Curve json.RawMessage `json:"curve"`
+}
+
+type rawAnimation struct {
+	Bones map[string]map[string][]rawKey `json:"bones"`
+	Slots map[string]map[string][]rawKey `json:"slots"`
+}
+
+type rawData struct {
+	Skeleton struct {
+		Width  float64 `json:"width"`
+		Height float64 `json:"height"`
+		Images string  `json:"images"`
+	} `json:"skeleton"`
+	Bones      []rawBone               `json:"bones"`
+	Slots      []rawSlot               `json:"slots"`
+	Skins      json.RawMessage         `json:"skins"`
+	Animations map[string]rawAnimation `json:"animations"`
+}
+
+// Read decodes a Spine JSON export.
+func Read(r io.Reader) (*Data, error) {
+	var raw rawData
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("skeleton: %w", err)
+	}
+	d := &Data{
+		Width:      raw.Skeleton.Width,
+		Height:     raw.Skeleton.Height,
+		Images:     raw.Skeleton.Images,
+		Skins:      make(map[string]map[string]map[string]Region),
+		Animations: make(map[string]*Animation),
+	}
+	if d.Images == "" {
+		d.Images = "images/"
+	}
+
+	bones := make(map[string]int)
+	for i, b := range raw.Bones {
+		parent := -1
+		if b.Parent != "" {
+			p, ok := bones[b.Parent]
+			if !ok {
+				return nil, fmt.Errorf("skeleton: bone %q comes before its parent %q", b.Name, b.Parent)
+			}
+			parent = p
+		}
+		bones[b.Name] = i
+		d.Bones = append(d.Bones, Bone{
+			Name: b.Name, Parent: parent, X: b.X, Y: b.Y, Rotation: b.Rotation,
+			ScaleX: orOne(b.ScaleX), ScaleY: orOne(b.ScaleY),
+		})
+	}
+
+	slots := make(map[string]int)
+	for i, s := range raw.Slots {
+		bone, ok := bones[s.Bone]
+		if !ok {
+			return nil, fmt.Errorf("skeleton: slot %q has unknown bone %q", s.Name, s.Bone)
+		}
+		color, err := parseColor(s.Color)
+		if err != nil {
+			return nil, fmt.Errorf("skeleton: slot %q: %w", s.Name, err)
+		}
+		slots[s.Name] = i
+		d.Slots = append(d.Slots, Slot{Name: s.Name, Bone: bone, Attachment: s.Attachment, Color: color})
+	}
+
+	skins, err := readSkins(raw.Skins)
+	if err != nil {
+		return nil, err
+	}
+	for _, skin := range skins {
+		out := make(map[string]map[string]Region)
+		for slot, attachments := range skin.Attachments {
+			regions := make(map[string]Region)
+			for name, a := range attachments {
+				if a.Type != "" && a.Type != "region" {
+					continue
+				}
+				path := a.Path
+				if path == "" {
+					path = a.Name
+				}
+				if path == "" {
+					path = name
+				}
+				regions[name] = Region{
+					Path: path, X: a.X, Y: a.Y, Rotation: a.Rotation,
+					ScaleX: orOne(a.ScaleX), ScaleY: orOne(a.ScaleY),
+					Width: a.Width, Height: a.Height,
+				}
+			}
+			out[slot] = regions
+		}
+		d.Skins[skin.Name] = out
+	}
+
+	for name, a := range raw.Animations {
+		anim, err := readAnimation(name, a, bones, slots)
+		if err != nil {
+			return nil, err
+		}
+		d.Animations[name] = anim
+	}
+	return d, nil
+}
+
+// readSkins accepts both the list of skins written by Spine 3.8 and later
+// and the older object keyed by skin name.
+func readSkins(data json.RawMessage) ([]rawSkin, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var list []rawSkin
+	if err := json.Unmarshal(data, &list); err == nil {
+		return list, nil
+	}
+	var byName map[string]map[string]map[string]rawAttachment
+	if err := json.Unmarshal(data, &byName); err != nil {
+		return nil, fmt.Errorf("skeleton: skins: %w", err)
+	}
+	for name, attachments := range byName {
+		list = append(list, rawSkin{Name: name, Attachments: attachments})
+	}
+	return list, nil
+}
+
+func readAnimation(name string, raw rawAnimation, bones, slots map[string]int) (*Animation, error) {
+	anim := &Animation{Name: name}
+	for bone, timelines := range raw.Bones {
+		i, ok := bones[bone]
+		if !ok {
+			return nil, fmt.Errorf("skeleton: animation %q has unknown bone %q", name, bone)
+		}
+		t := boneTimeline{bone: i}
+		for kind, keys := range timelines {
+			switch kind {
+			case "rotate":
+				t.rotate = readKeys(keys, func(k rawKey) [4]float64 {
+					if k.Angle != nil {
+						return [4]float64{*k.Angle}
+					}
+					return [4]float64{orZero(k.Value)}
+				})
+			case "translate":
+				t.translate = readKeys(keys, func(k rawKey) [4]float64 { return [4]float64{orZero(k.X), orZero(k.Y)} })
+			case "scale":
+				t.scale = readKeys(keys, func(k rawKey) [4]float64 { return [4]float64{orOne(k.X), orOne(k.Y)} })
+			}
+		}
+		anim.bones = append(anim.bones, t)
+		anim.Duration = max(anim.Duration, lastTime(t.rotate), lastTime(t.translate), lastTime(t.scale))
+	}
+	for slot, timelines := range raw.Slots {
+		i, ok := slots[slot]
+		if !ok {
+			return nil, fmt.Errorf("skeleton: animation %q has unknown slot %q", name, slot)
+		}
+		t := slotTimeline{slot: i}
+		for _, k := range timelines["attachment"] {
+			ak := attachmentKey{time: k.Time}
+			if k.Name != nil {
+				ak.name = *k.Name
+			}
+			t.attachment = append(t.attachment, ak)
+			anim.Duration = max(anim.Duration, k.Time)
+		}
+		var err error
+		t.color = readKeys(timelines["color"], func(k rawKey) [4]float64 {
+			c, cerr := parseColor(k.Color)
+			if cerr != nil {
+				err = cerr
+			}
+			return c
+		})
+		if err != nil {
+			return nil, fmt.Errorf("skeleton: animation %q slot %q: %w", name, slot, err)
+		}
+		anim.slots = append(anim.slots, t)
+		anim.Duration = max(anim.Duration, lastTime(t.color))
+	}
+	// Map order is random; keep timelines in a fixed order so poses are
+	// applied the same way every run
+	sort.Slice(anim.bones, func(a, b int) bool { return anim.bones[a].bone < anim.bones[b].bone })
+	sort.Slice(anim.slots, func(a, b int) bool { return anim.slots[a].slot < anim.slots[b].slot })
+	return anim, nil
+}
+
+func readKeys(raw []rawKey, value func(rawKey) [4]float64) []key {
+	keys := make([]key, len(raw))
+	for i, k := range raw {
+		keys[i] = key{time: k.Time, value: value(k), stepped: string(k.Curve) == `"stepped"`}
+	}
+	return keys
+}
+
+func lastTime(keys []key) float64 {
+	if len(keys) == 0 {
+		return 0
+	}
+	return keys[len(keys)-1].time
+}
+
+// parseColor reads Spine's "rrggbbaa" hex; empty means white.
+func parseColor(s string) (Color, error) {
+	if s == "" {
+		return Color{1, 1, 1, 1}, nil
+	}
+	if len(s) == 6 {
+		s += "ff"
+	}
+	n, err := strconv.ParseUint(s, 16, 32)
+	if err != nil || len(s) != 8 {
+		return Color{}, fmt.Errorf("bad color %q", s)
+	}
+	return Color{
+		float64(n>>24&0xff) / 255,
+		float64(n>>16&0xff) / 255,
+		float64(n>>8&0xff) / 255,
+		float64(n&0xff) / 255,
+	}, nil
+}
+
+func orOne(v *float64) float64 {
+	if v == nil {
+		return 1
+	}
+	return *v
+}
+
+func orZero(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
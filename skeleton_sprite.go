@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bytes"
+	"log"
+	"path"
+	"time"
+
+	"raylibgo/skeleton"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// playerSkeletonPath is a Spine export that, when present, replaces the
+// player's frame-by-frame PNGs with bone animation. Its animations are
+// named like the frame animations ("stand", "hit", "move").
+const playerSkeletonPath = "assets/skeletons/player.json"
+
+// SkeletonSprite is a Spine skeleton posed on the simulation clock and
+// drawn as textured quads. Attachment images are loaded from the images
+// folder the export names, next to the JSON file.
+type SkeletonSprite struct {
+	Data *skeleton.Data
+	Skin string
+
+	dir      string
+	pose     *skeleton.Pose
+	anim     *skeleton.Animation
+	loop     bool
+	since    time.Time
+	progress float64 // fraction of the animation to show, or -1 to follow the clock
+	textures map[string]*Texture
+}
+
+// LoadSkeletonSprite reads a Spine JSON export and acquires its images.
+func LoadSkeletonSprite(file string) (*SkeletonSprite, error) {
+	data, err := ReadAsset(file)
+	if err != nil {
+		return nil, err
+	}
+	d, err := skeleton.Read(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	s := &SkeletonSprite{
+		Data:     d,
+		Skin:     "default",
+		dir:      path.Join(path.Dir(file), d.Images),
+		pose:     skeleton.NewPose(d),
+		progress: -1,
+		textures: make(map[string]*Texture),
+	}
+	for _, slots := range d.Skins {
+		for _, regions := range slots {
+			for _, region := range regions {
+				if _, ok := s.textures[region.Path]; !ok {
+					s.textures[region.Path] = tm.Acquire(s.imagePath(region.Path), 0, 0)
+				}
+			}
+		}
+	}
+	return s, nil
+}
+
+func (s *SkeletonSprite) imagePath(name string) string {
+	return path.Join(s.dir, name+".png")
+}
+
+// Unload releases the skeleton's images.
+func (s *SkeletonSprite) Unload() {
+	for name := range s.textures {
+		tm.Release(s.imagePath(name))
+	}
+	s.textures = nil
+}
+
+// Play switches to the named animation, starting it over only when it
+// changes. It reports whether the skeleton has that animation.
+func (s *SkeletonSprite) Play(name string, loop bool) bool {
+	anim, ok := s.Data.Animations[name]
+	if !ok {
+		return false
+	}
+	if anim != s.anim {
+		s.anim = anim
+		s.since = clock.Now()
+	}
+	s.loop = loop
+	s.progress = -1
+	return true
+}
+
+// SetProgress pins the animation to fraction f of its length, for
+// animations whose timing is driven by something else.
+func (s *SkeletonSprite) SetProgress(f float64) {
+	s.progress = min(max(f, 0), 1)
+}
+
+// Draw poses the skeleton and draws it with its root at pos, scaled and
+// mirrored when flip is set. Spine's y axis points up, so it is flipped
+// to screen space here.
+func (s *SkeletonSprite) Draw(pos rl.Vector2, scale float32, flip bool, tint rl.Color) {
+	if s.anim != nil {
+		at := max(clock.Now().Sub(s.since).Seconds(), 0)
+		if s.progress >= 0 {
+			at = s.progress * s.anim.Duration
+		}
+		s.pose.Apply(s.anim, at, s.loop)
+	} else {
+		s.pose.SetToSetup()
+	}
+	s.pose.UpdateWorld()
+
+	sx, sy := scale, -scale
+	if flip {
+		sx = -sx
+	}
+	uvs := [4]rl.Vector2{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 0}}
+	for _, q := range s.pose.Quads(s.Skin) {
+		tex := s.textures[q.Path]
+		if tex == nil || !tex.Loaded {
+			continue
+		}
+		var corners [4]rl.Vector2
+		for i, c := range q.Corners {
+			corners[i] = rl.NewVector2(pos.X+float32(c[0])*sx, pos.Y+float32(c[1])*sy)
+		}
+		order := [4]int{0, 1, 2, 3}
+		// Mirroring or a negative scale turns the quad over; raylib culls
+		// quads facing away, so wind it the other way
+		a, b, c := corners[0], corners[1], corners[2]
+		if (b.X-a.X)*(c.Y-a.Y)-(b.Y-a.Y)*(c.X-a.X) > 0 {
+			order = [4]int{0, 3, 2, 1}
+		}
+
+		color := rl.NewColor(
+			uint8(float64(tint.R)*q.Color[0]),
+			uint8(float64(tint.G)*q.Color[1]),
+			uint8(float64(tint.B)*q.Color[2]),
+			uint8(float64(tint.A)*q.Color[3]),
+		)
+		rl.CheckRenderBatchLimit(4)
+		rl.SetTexture(tex.Texture.ID)
+		rl.Begin(rl.Quads)
+		rl.Color4ub(color.R, color.G, color.B, color.A)
+		for _, i := range order {
+			rl.TexCoord2f(uvs[i].X, uvs[i].Y)
+			rl.Vertex2f(corners[i].X, corners[i].Y)
+		}
+		rl.End()
+		rl.SetTexture(0)
+		tex.Drawn()
+	}
+}
+
+// LoadPlayerSkeleton replaces the players' skeletons with the one at path,
+// or removes them when the file is gone.
+func LoadPlayerSkeleton(file string) {
+	for _, p := range localPlayers() {
+		p.loadSkeleton(file)
+	}
+}
+
+// loadSkeleton gives p its own skeleton from file, or none when the file
+// is gone.
+func (p *Player) loadSkeleton(file string) {
+	var next *SkeletonSprite
+	if AssetExists(file) {
+		s, err := LoadSkeletonSprite(file)
+		if err != nil {
+			log.Printf("skeleton: %v", err)
+			return
+		}
+		next = s
+	}
+	if p.Skeleton != nil {
+		p.Skeleton.Unload()
+	}
+	p.Skeleton = next
+}
+
+// DrawPlayerSkeleton draws the player with their skeleton for the body
+// animation name, standing where the frame in dst would. A hit follows the
+// attack's frames so it lasts exactly as long. It reports false when the
+// skeleton lacks the animation and the frames should be drawn instead.
+func DrawPlayerSkeleton(name string, anim *Animated, dst rl.Rectangle) bool {
+	s := player.Skeleton
+	if s == nil || !s.Play(name, name != "hit") {
+		return false
+	}
+	if name == "hit" && anim.Frames() > 0 {
+		frame := float64(anim.CurrentFrame)
+		if anim.FrameDelay > 0 {
+			frame += min(clock.Now().Sub(anim.StartTime).Seconds()/anim.FrameDelay.Seconds(), 1)
+		}
+		s.SetProgress(frame / float64(anim.Frames()))
+	}
+	scale := player.Scale
+	if s.Data.Height > 0 {
+		scale = dst.Height / float32(s.Data.Height)
+	}
+	tint := rl.White
+	if player.Material.framesLeft > 0 {
+		tint = player.Material.HitColor
+	}
+	s.Draw(rl.NewVector2(dst.X+dst.Width/2, dst.Y+dst.Height), scale, player.Flip, tint)
+	return true
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const personalBestPath = "saves/speedrun_pb.json"
+
+// Split is the run time at which a level was left
+type Split struct {
+	Level string        `json:"level"`
+	Time  time.Duration `json:"time"`
+}
+
+// SpeedrunTimer measures a run in simulation ticks, so the time is exact to
+// the tick regardless of frame rate, hitches or slow motion. A split is taken
+// whenever the player leaves a level; the run finishes on entering a level
+// with no exits, and a faster finish replaces the personal best on disk.
+type SpeedrunTimer struct {
+	ticks    uint64
+	splits   []Split
+	best     []Split
+	finished bool
+	font     *Font
+}
+
+var speedrun = &SpeedrunTimer{}
+
+// Elapsed returns the current run time.
+func (s *SpeedrunTimer) Elapsed() time.Duration {
+	return time.Duration(s.ticks) * tickDuration
+}
+
+// Reset clears the run and reloads the personal best.
+func (s *SpeedrunTimer) Reset() {
+	s.ticks = 0
+	s.splits = nil
+	s.finished = false
+	s.best = nil
+	path := profilePath(personalBestPath)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("speedrun: %v", err)
+		}
+		return
+	}
+	if err := json.Unmarshal(data, &s.best); err != nil {
+		log.Printf("speedrun: %s: %v", path, err)
+	}
+}
+
+// Tick advances the timer by one simulation step.
+func (s *SpeedrunTimer) Tick() {
+	if !s.finished {
+		s.ticks++
+	}
+}
+
+// HandleEvent is the autosplitter: it splits on every level exit.
+func (s *SpeedrunTimer) HandleEvent(e Event) {
+	if e.Type != EventLevelExited || s.finished {
+		return
+	}
+	s.splits = append(s.splits, Split{Level: e.Target, Time: s.Elapsed()})
+	if len(assets.Neighbors(currentLevel)) == 0 {
+		s.finish()
+	}
+}
+
+func (s *SpeedrunTimer) finish() {
+	s.finished = true
+	if len(s.best) > 0 && s.best[len(s.best)-1].Time <= s.Elapsed() {
+		return
+	}
+	s.best = append([]Split(nil), s.splits...)
+	if err := writePersonalBest(s.best); err != nil {
+		log.Printf("speedrun: saving personal best: %v", err)
+	}
+}
+
+func writePersonalBest(splits []Split) error {
+	data, err := json.MarshalIndent(splits, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := profilePath(personalBestPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// formatRunTime formats d as m:ss.mmm in the current language.
+func formatRunTime(d time.Duration) string {
+	return locale.Timer(d)
+}
+
+// formatDelta formats the difference to the personal best with a sign.
+func formatDelta(d time.Duration) string {
+	sign := "+"
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	return sign + locale.Float(d.Seconds(), 3)
+}
+
+// Draw renders the timer and splits in the top-right corner when enabled.
+func (s *SpeedrunTimer) Draw() {
+	if !settings.SpeedrunTimer {
+		return
+	}
+	if s.font == nil {
+		s.font = fonts.Acquire("", 24)
+	}
+
+	rows := max(len(s.splits), len(s.best))
+	panel := ui.Rect(UIRect{Anchor: AnchorTopRight, Offset: rl.NewVector2(20, 60), Size: rl.NewVector2(300, float32(rows)*28+64)})
+	if !DrawNinePatch(SkinPanel, panel, rl.White) {
+		rl.DrawRectangleRec(panel, rl.Fade(rl.Black, 0.6))
+	}
+
+	y := panel.Y + 12
+	for i := range rows {
+		level, text, color := "", "", rl.LightGray
+		switch {
+		case i < len(s.splits):
+			level = s.splits[i].Level
+			text = formatRunTime(s.splits[i].Time)
+			if i < len(s.best) {
+				delta := s.splits[i].Time - s.best[i].Time
+				text = formatDelta(delta) + "  " + text
+				color = rl.Green
+				if delta > 0 {
+					color = rl.Red
+				}
+			}
+		default:
+			level = s.best[i].Level
+			text = formatRunTime(s.best[i].Time)
+			color = rl.Gray
+		}
+		s.font.Draw(level, rl.NewVector2(panel.X+12, y), 24, rl.RayWhite)
+		w := s.font.Measure(text, 24).X
+		s.font.Draw(text, rl.NewVector2(panel.X+panel.Width-12-w, y), 24, color)
+		y += 28
+	}
+
+	total := formatRunTime(s.Elapsed())
+	color := rl.RayWhite
+	if s.finished {
+		color = rl.Gold
+	}
+	w := s.font.Measure(total, 36).X
+	s.font.Draw(total, rl.NewVector2(panel.X+panel.Width-12-w, y+4), 36, color)
+}
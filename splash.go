@@ -0,0 +1,113 @@
+package main
+
+import (
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"raylibgo/tween"
+)
+
+const (
+	splashFade = 500 * time.Millisecond
+	splashHold = 1500 * time.Millisecond
+)
+
+// splashCard is one logo in the intro sequence. Text is drawn when the
+// image is missing.
+type splashCard struct {
+	image string
+	text  string
+}
+
+var splashCards = []splashCard{
+	{image: "assets/images/splash_engine.png", text: "Made with raylib"},
+	{image: "assets/images/splash_studio.png", text: "Mohamed Sheta"},
+}
+
+// SplashScene fades each logo in and out before the main menu, preloading
+// the menu's asset group in the background meanwhile. Any key, mouse button
+// or gamepad button skips straight to the menu.
+type SplashScene struct {
+	font  *Font
+	logos []*Texture
+	card  int
+	alpha float32
+	fade  *tween.Tween
+}
+
+// NewSplashScene creates the intro sequence.
+func NewSplashScene() *SplashScene {
+	return &SplashScene{}
+}
+
+func (s *SplashScene) Load(scope *AssetScope) {
+	s.font = scope.Font("", 48)
+	for _, c := range splashCards {
+		s.logos = append(s.logos, scope.Acquire(c.image, 0, 0))
+	}
+	scope.RequestGroup(menuAssetGroup, PriorityLow)
+	s.play()
+}
+
+// play fades the current card in, holds it, then fades it out and moves on
+// to the next.
+func (s *SplashScene) play() {
+	s.alpha = 0
+	s.fade = tween.Float(&s.alpha, 1, splashFade).Ease(tween.OutQuad).
+		Then(tween.Float(&s.alpha, 0, splashFade).Ease(tween.InQuad).Delay(splashHold)).
+		Then(tween.Wait(0).OnDone(func() {
+			s.card++
+			if s.card < len(splashCards) {
+				s.play()
+			}
+		}))
+}
+
+func (s *SplashScene) Unload() {}
+
+func anyInputPressed() bool {
+	if rl.GetKeyPressed() != 0 {
+		return true
+	}
+	for b := rl.MouseButtonLeft; b <= rl.MouseButtonMiddle; b++ {
+		if rl.IsMouseButtonPressed(b) {
+			return true
+		}
+	}
+	if rl.IsGamepadAvailable(gamepadIndex) {
+		for b := int32(rl.GamepadButtonLeftFaceUp); b <= rl.GamepadButtonRightThumb; b++ {
+			if rl.IsGamepadButtonPressed(gamepadIndex, b) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *SplashScene) Update() {
+	s.fade.Update(FrameTime())
+	if s.card >= len(splashCards) || anyInputPressed() {
+		scenes.Replace(NewProfileSelectScene())
+	}
+}
+
+func (s *SplashScene) Draw() {
+	if s.card >= len(splashCards) {
+		return
+	}
+	tint := rl.Fade(rl.White, s.alpha)
+	if logo := s.logos[s.card]; logo.Loaded {
+		scale := min(1, 0.6*screenSize.X/float32(logo.Texture.Width), 0.6*screenSize.Y/float32(logo.Texture.Height))
+		pos := rl.NewVector2(
+			(screenSize.X-float32(logo.Texture.Width)*scale)/2,
+			(screenSize.Y-float32(logo.Texture.Height)*scale)/2,
+		)
+		rl.DrawTextureEx(logo.Texture, pos, 0, scale, tint)
+		logo.Drawn()
+		return
+	}
+	text := splashCards[s.card].text
+	size := s.font.Measure(text, 48)
+	s.font.Draw(text, rl.NewVector2((screenSize.X-size.X)/2, (screenSize.Y-size.Y)/2), 48, tint)
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	spriteLayerDefsPath = "assets/layers.json"
+	// Furthest an aim-following layer turns away from the way the body faces
+	maxLayerAimDegrees = 60
+)
+
+// SpriteLayerDef describes an equipment layer drawn with the player. Frames
+// lists the layer's frames under assets/images for each body animation
+// ("stand", "hit", "move"); the layer shows frame i while the body shows
+// frame i, wrapping when it has fewer. Offset is in frame pixels, mirrored
+// with the body. Layers with a negative Z draw behind the body, the rest in
+// front, lowest first. FollowAim turns the layer towards an aimed attack.
+type SpriteLayerDef struct {
+	Frames    map[string][]string `json:"frames"`
+	OffsetX   float32             `json:"offsetX,omitempty"`
+	OffsetY   float32             `json:"offsetY,omitempty"`
+	Z         int                 `json:"z"`
+	FollowAim bool                `json:"followAim,omitempty"`
+}
+
+// SpriteLayer is an equipped layer with its frames loaded
+type SpriteLayer struct {
+	Name  string
+	Def   SpriteLayerDef
+	Anims map[string]*Animated
+}
+
+// spriteLayerDefs are the layers that can be equipped, by name
+var spriteLayerDefs = make(map[string]SpriteLayerDef)
+
+// LoadSpriteLayerDefs reads layer definitions keyed by name.
+func LoadSpriteLayerDefs(path string) (map[string]SpriteLayerDef, error) {
+	data, err := ReadAsset(path)
+	if err != nil {
+		return nil, err
+	}
+	var defs map[string]SpriteLayerDef
+	if err := json.Unmarshal(data, &defs); err != nil {
+		return nil, fmt.Errorf("layers %s: %w", path, err)
+	}
+	return defs, nil
+}
+
+// ApplySpriteLayerDefs replaces the definitions and reloads equipped layers
+// from them. Layers whose definition was removed are unequipped.
+func ApplySpriteLayerDefs(defs map[string]SpriteLayerDef) {
+	spriteLayerDefs = defs
+	for _, p := range localPlayers() {
+		for _, layer := range slices.Clone(p.Layers) {
+			def, ok := defs[layer.Name]
+			if !ok {
+				p.Unequip(layer.Name)
+				continue
+			}
+			layer.load(def)
+		}
+		sortLayers(p.Layers)
+	}
+}
+
+// load points the layer at def, acquiring its frames before releasing the
+// old ones so shared frames stay resident.
+func (l *SpriteLayer) load(def SpriteLayerDef) {
+	if l.Anims == nil {
+		l.Anims = make(map[string]*Animated)
+	}
+	for name, frames := range def.Frames {
+		anim, ok := l.Anims[name]
+		if !ok {
+			anim = &Animated{}
+			l.Anims[name] = anim
+		}
+		applyAnimationDef(anim, AnimationDef{Frames: frames}, AnimationDef{Frames: l.Def.Frames[name]})
+	}
+	for name, anim := range l.Anims {
+		if _, ok := def.Frames[name]; !ok {
+			applyAnimationDef(anim, AnimationDef{}, AnimationDef{Frames: l.Def.Frames[name]})
+			delete(l.Anims, name)
+		}
+	}
+	l.Def = def
+}
+
+func sortLayers(layers []*SpriteLayer) {
+	slices.SortStableFunc(layers, func(a, b *SpriteLayer) int { return a.Def.Z - b.Def.Z })
+}
+
+// Equip adds the named layer to the player, keeping layers in Z order.
+func (p *Player) Equip(name string) error {
+	def, ok := spriteLayerDefs[name]
+	if !ok {
+		return fmt.Errorf("layers: no layer %q", name)
+	}
+	if slices.ContainsFunc(p.Layers, func(l *SpriteLayer) bool { return l.Name == name }) {
+		return nil
+	}
+	layer := &SpriteLayer{Name: name}
+	layer.load(def)
+	p.Layers = append(p.Layers, layer)
+	sortLayers(p.Layers)
+	return nil
+}
+
+// Unequip removes the named layer and releases its frames.
+func (p *Player) Unequip(name string) {
+	i := slices.IndexFunc(p.Layers, func(l *SpriteLayer) bool { return l.Name == name })
+	if i < 0 {
+		return
+	}
+	p.Layers[i].load(SpriteLayerDef{})
+	p.Layers = slices.Delete(p.Layers, i, i+1)
+}
+
+// Equipment returns the names of the equipped layers, for saving.
+func (p *Player) Equipment() []string {
+	names := make([]string, len(p.Layers))
+	for i, l := range p.Layers {
+		names[i] = l.Name
+	}
+	return names
+}
+
+// RestoreEquipment equips exactly the named layers.
+func (p *Player) RestoreEquipment(names []string) {
+	for _, l := range slices.Clone(p.Layers) {
+		if !slices.Contains(names, l.Name) {
+			p.Unequip(l.Name)
+		}
+	}
+	for _, name := range names {
+		if err := p.Equip(name); err != nil {
+			log.Printf("%v", err)
+		}
+	}
+}
+
+// HandleEquipmentPickup equips the layer named like a collected pickup, so
+// picking up a "hat" puts it on.
+func HandleEquipmentPickup(e Event) {
+	if _, ok := spriteLayerDefs[e.Target]; ok {
+		if err := player.Equip(e.Target); err != nil {
+			log.Printf("%v", err)
+		}
+	}
+}
+
+// layerAimRotation returns how far, in degrees, aim-following layers turn
+// while an aimed attack plays.
+func layerAimRotation() float32 {
+	dir, ok := input.AimDir()
+	if !ok || !player.Hit.IsPlaying {
+		return 0
+	}
+	if player.Flip {
+		dir.X = -dir.X
+		dir.Y = -dir.Y
+	}
+	angle := float32(math.Atan2(float64(dir.Y), float64(dir.X))) * rl.Rad2deg
+	return rl.Clamp(angle, -maxLayerAimDegrees, maxLayerAimDegrees)
+}
+
+// DrawSpriteLayers draws the player's layers for the body animation anim at
+// frame, placed over the body's dst. behind selects the layers below the
+// body or those above it.
+func DrawSpriteLayers(anim string, frame int, dst rl.Rectangle, behind bool) {
+	aim := layerAimRotation()
+	pivot := rl.NewVector2(dst.X+dst.Width/2, dst.Y+dst.Height/2)
+	for _, layer := range player.Layers {
+		if (layer.Def.Z < 0) != behind {
+			continue
+		}
+		a, ok := layer.Anims[anim]
+		if !ok || a.Frames() == 0 {
+			continue
+		}
+		i := frame % a.Frames()
+		tex, src := a.Frame(i)
+		if tex == nil {
+			continue
+		}
+
+		offset := rl.NewVector2(layer.Def.OffsetX*player.Scale, layer.Def.OffsetY*player.Scale)
+		if player.Flip {
+			src.X += src.Width
+			src.Width *= -1
+			offset.X = -offset.X
+		}
+		layerDst := rl.NewRectangle(dst.X+offset.X, dst.Y+offset.Y, dst.Width, dst.Height)
+		rotation := player.Rotation
+		if layer.Def.FollowAim && aim != 0 {
+			// DrawTexturePro turns around the top-left corner, so move that
+			// corner to where turning around the body's middle puts it
+			corner := rl.Vector2Rotate(rl.Vector2Subtract(rl.NewVector2(layerDst.X, layerDst.Y), pivot), aim*rl.Deg2rad)
+			layerDst.X, layerDst.Y = pivot.X+corner.X, pivot.Y+corner.Y
+			rotation += aim
+		}
+		normal := a.NormalFrame(i)
+		DrawLitSprite(tex.Texture, normal, src, layerDst, rotation, &player.Material)
+		a.FrameDrawn(i)
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// HitEffect is how a sprite reacts visually to taking damage
+type HitEffect int
+
+const (
+	HitEffectNone HitEffect = iota
+	HitEffectFlash
+	HitEffectOutline
+)
+
+// SpriteMaterial configures per-entity hit feedback drawn through a shader
+type SpriteMaterial struct {
+	HitEffect    HitEffect
+	HitColor     rl.Color
+	HitFrames    int     // ticks the effect lasts after a hit
+	OutlineWidth float32 // in source texels
+	framesLeft   int
+	active       bool // Begin enabled a shader
+}
+
+// spriteShader is a loaded fragment shader and its uniform locations
+type spriteShader struct {
+	shader    rl.Shader
+	loaded    bool
+	color     int32
+	strength  int32
+	texelSize int32
+	width     int32
+}
+
+var (
+	flashShader   spriteShader
+	outlineShader spriteShader
+)
+
+// LoadSpriteShaders loads the hit effect and lighting shaders. Sprites draw
+// without effects if a shader fails to compile.
+func LoadSpriteShaders() {
+	flashShader = loadSpriteShader("assets/shaders/flash.fs")
+	outlineShader = loadSpriteShader("assets/shaders/outline.fs")
+	spriteLighting = loadLitShader("assets/shaders/lit.fs")
+}
+
+// UnloadSpriteShaders frees the hit effect shaders.
+func UnloadSpriteShaders() {
+	for _, s := range []*spriteShader{&flashShader, &outlineShader} {
+		if s.loaded {
+			rl.UnloadShader(s.shader)
+			s.loaded = false
+		}
+	}
+	if spriteLighting.loaded {
+		rl.UnloadShader(spriteLighting.shader)
+		spriteLighting.loaded = false
+	}
+}
+
+func loadSpriteShader(path string) spriteShader {
+	code, err := ReadAsset(path)
+	if err != nil {
+		return spriteShader{}
+	}
+	shader := rl.LoadShaderFromMemory("", string(code))
+	if !rl.IsShaderValid(shader) {
+		return spriteShader{}
+	}
+	return spriteShader{
+		shader:    shader,
+		loaded:    true,
+		color:     rl.GetShaderLocation(shader, "hitColor"),
+		strength:  rl.GetShaderLocation(shader, "strength"),
+		texelSize: rl.GetShaderLocation(shader, "texelSize"),
+		width:     rl.GetShaderLocation(shader, "width"),
+	}
+}
+
+// Trigger starts the hit effect.
+func (m *SpriteMaterial) Trigger() {
+	if m.HitEffect != HitEffectNone {
+		m.framesLeft = m.HitFrames
+	}
+}
+
+// Update counts down the effect by one tick.
+func (m *SpriteMaterial) Update() {
+	if m.framesLeft > 0 {
+		m.framesLeft--
+	}
+}
+
+// Begin switches to the material's shader if an effect is active. Every
+// Begin must be paired with End.
+func (m *SpriteMaterial) Begin(tex rl.Texture2D) {
+	if m.framesLeft <= 0 {
+		if highContrast {
+			m.beginOutline(tex, contrastPlayer, highContrastOutline)
+		}
+		return
+	}
+
+	var s *spriteShader
+	switch m.HitEffect {
+	case HitEffectFlash:
+		s = &flashShader
+	case HitEffectOutline:
+		s = &outlineShader
+	}
+	if s == nil || !s.loaded {
+		return
+	}
+
+	c := rl.ColorNormalize(m.HitColor)
+	rl.SetShaderValue(s.shader, s.color, []float32{c.X, c.Y, c.Z, c.W}, rl.ShaderUniformVec4)
+	// Fade out over the last half of the effect
+	strength := min(1, float32(m.framesLeft)/float32(max(m.HitFrames/2, 1)))
+	rl.SetShaderValue(s.shader, s.strength, []float32{strength}, rl.ShaderUniformFloat)
+	rl.SetShaderValue(s.shader, s.texelSize, []float32{1 / float32(tex.Width), 1 / float32(tex.Height)}, rl.ShaderUniformVec2)
+	rl.SetShaderValue(s.shader, s.width, []float32{m.OutlineWidth}, rl.ShaderUniformFloat)
+	rl.BeginShaderMode(s.shader)
+	m.active = true
+}
+
+// beginOutline draws the sprite with a steady outline, as high contrast
+// mode does for entities not currently showing a hit effect.
+func (m *SpriteMaterial) beginOutline(tex rl.Texture2D, color rl.Color, width float32) {
+	s := &outlineShader
+	if !s.loaded {
+		return
+	}
+	c := rl.ColorNormalize(color)
+	rl.SetShaderValue(s.shader, s.color, []float32{c.X, c.Y, c.Z, c.W}, rl.ShaderUniformVec4)
+	rl.SetShaderValue(s.shader, s.texelSize, []float32{1 / float32(tex.Width), 1 / float32(tex.Height)}, rl.ShaderUniformVec2)
+	rl.SetShaderValue(s.shader, s.width, []float32{width}, rl.ShaderUniformFloat)
+	rl.BeginShaderMode(s.shader)
+	m.active = true
+}
+
+// End restores the default shader if Begin enabled one.
+func (m *SpriteMaterial) End() {
+	if m.active {
+		rl.EndShaderMode()
+		m.active = false
+	}
+}
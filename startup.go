@@ -0,0 +1,95 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	// Window size tried when the configured one fails to open
+	fallbackWidth  = 1280
+	fallbackHeight = 720
+
+	startupNoticeTime = 10 * time.Second
+)
+
+// audioReady is false when no audio device could be opened; sound and
+// music loading is skipped and the game runs silent
+var audioReady = true
+
+// startupNotices explain fallbacks taken at startup. They are shown in a
+// banner for the first seconds of the game.
+var (
+	startupNotices []string
+	startupShown   time.Time
+)
+
+// InitWindowWithFallback opens the window at the launch size. If that fails,
+// e.g. because fullscreen or the resolution is not supported, it retries in
+// a 1280x720 window with shaders disabled. It exits with a readable message
+// if no window can be opened at all.
+func InitWindowWithFallback() {
+	rl.InitWindow(int32(screenSize.X), int32(screenSize.Y), WindowTitle())
+	if rl.IsWindowReady() {
+		return
+	}
+	log.Printf("window: could not open a %.0fx%.0f window, retrying in safe mode", screenSize.X, screenSize.Y)
+
+	screenSize = rl.NewVector2(fallbackWidth, fallbackHeight)
+	launch.Windowed = true
+	launch.SafeMode = true
+	// Keep the settings from resizing the window again
+	launch.ResolutionSet = true
+	rl.InitWindow(fallbackWidth, fallbackHeight, WindowTitle())
+	if !rl.IsWindowReady() {
+		log.Fatal("window: could not open a window. The graphics driver may not support OpenGL 3.3; updating it usually helps.")
+	}
+	startupNotices = append(startupNotices, "The display could not be set up as configured. Running windowed at 1280x720 without shaders.")
+}
+
+// InitAudioWithFallback opens the default audio device, continuing without
+// sound if there is none.
+func InitAudioWithFallback() {
+	rl.InitAudioDevice()
+	if rl.IsAudioDeviceReady() {
+		return
+	}
+	log.Printf("audio: no audio device available, continuing without sound")
+	audioReady = false
+	startupNotices = append(startupNotices, "No audio device was found. The game will run without sound.")
+}
+
+// DrawStartupNotices shows the startup fallbacks in a banner along the top
+// of the screen until startupNoticeTime has passed.
+func DrawStartupNotices() {
+	if len(startupNotices) == 0 {
+		return
+	}
+	if startupShown.IsZero() {
+		startupShown = time.Now()
+	}
+	if time.Since(startupShown) > startupNoticeTime {
+		startupNotices = nil
+		return
+	}
+
+	const lineHeight = 26
+	banner := ui.Rect(UIRect{Anchor: AnchorTop, Offset: rl.NewVector2(0, 20), Size: rl.NewVector2(900, float32(24+len(startupNotices)*lineHeight))})
+	rl.DrawRectangleRec(banner, rl.Fade(rl.Black, 0.85))
+	rl.DrawRectangleLinesEx(banner, 2, rl.Orange)
+	y := int32(banner.Y) + 12
+	for _, notice := range startupNotices {
+		rl.DrawText(notice, int32(banner.X)+20, y, 20, rl.RayWhite)
+		y += lineHeight
+	}
+}
+
+func init() {
+	RegisterSystem(&SystemFuncs{ID: "audio", OnUpdate: func() {
+		rl.UpdateMusicStream(music)
+		mixer.Update()
+	}})
+	RegisterSystem(&SystemFuncs{ID: "startupNotices", Requires: []string{"assetProblems"}, OnDraw: DrawStartupNotices})
+}
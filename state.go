@@ -17,74 +17,275 @@ func NewPlayerState() *PlayerState {
 }
 
 func Update() {
-	now := time.Now()
+	now := clock.Advance()
+	gizmos.BeginTick()
+	input = rewinder.NextInput()
+	rewinder.Record(input)
+	recorder.Add(input)
+	scheduler.Update()
+
 	player.State.IsMoving = false
+	player.Effects.Update(DamagePlayer)
+	player.Material.Update()
 	HandleMovement(now)
+	HandleDash()
 	ApplyGravity()
 	HandleJump()
 	HandleHitAnimation(now)
 	HandleStandAnimation(now)
 	UpdateBackground(now)
+	tutorials.Update()
+	unlocks.Update()
+	UpdateCollectibles()
+	UpdatePickups()
+	tweens.Update(tickDuration)
+	UpdateInteractables()
+	UpdateEnemies()
+	director.Update()
+	weather.Update()
+	CheckLevelExits()
+	speedrun.Tick()
+	stats.Tick()
+	if activeBoss != nil {
+		activeBoss.Update()
+	}
+
+	// Systems that only touch their own data step in parallel
+	jobs.Go("weather", weather.Step)
+	jobs.Go("paths", func() { paths.Update(pathNodeBudget) })
+	jobs.Go("floating text", floatingText.Update)
+	jobs.Go("footsteps", footsteps.Step)
+	jobs.Wait()
+	paths.Deliver()
+
+	coop.Update(now)
+	camera.Follow(CameraTarget())
 }
 
 func HandleMovement(now time.Time) {
 	updateWidth := func() float32 {
-		if len(player.Move.FrameTextures) == 0 {
-			return 0
-		}
-		return float32(player.Move.FrameTextures[0].Texture.Width) * player.Scale
+		_, src := player.Move.Frame(0)
+		return src.Width * player.Scale
 	}
 
 	width := updateWidth()
+	speed := player.Speed * player.Effects.SpeedMultiplier() * cheatSpeedMultiplier()
+
+	bounds := camera.Bounds()
 
-	if rl.IsKeyDown(rl.KeyLeft) || rl.IsKeyDown(rl.KeyA) {
-		if player.Pos.X > 0 {
-			player.Pos.X -= player.Speed
+	// An attack with root motion carries the body, so walking would fight it
+	if player.Hit.IsPlaying && player.Hit.RootMotion != nil {
+		updateAnimation(&player.Move, false, now)
+		return
+	}
+	// A walk with root motion moves the body as its frames advance instead
+	rootWalk := player.Move.RootMotion != nil
+
+	if input.IsDown(ActionLeft) {
+		if player.Pos.X > bounds.X {
+			if !rootWalk {
+				player.Pos.X -= speed
+			}
 			player.State.IsMoving = true
 		}
 		player.Flip = true
 	}
 
-	if rl.IsKeyDown(rl.KeyRight) || rl.IsKeyDown(rl.KeyD) {
-		if player.Pos.X+width < screenSize.X {
-			player.Pos.X += player.Speed
+	if input.IsDown(ActionRight) {
+		if player.Pos.X+width < bounds.X+bounds.Width {
+			if !rootWalk {
+				player.Pos.X += speed
+			}
 			player.State.IsMoving = true
 		}
 		player.Flip = false
 	}
 
-	updateAnimation(&player.Move, player.State.IsMoving && !player.Hit.IsPlaying, now)
+	if updateAnimation(&player.Move, player.State.IsMoving && !player.Hit.IsPlaying, now) {
+		reachFrame(&player.Move, player.Effects.SpeedMultiplier()*cheatSpeedMultiplier())
+	}
+}
+
+// reachFrame applies what happens on reaching anim's current frame: its
+// root motion, scaled by motionScale, and its animation events.
+func reachFrame(anim *Animated, motionScale float32) {
+	applyRootMotion(anim, motionScale)
+	if names := anim.Events[anim.CurrentFrame]; len(names) > 0 {
+		body := PlayerBounds()
+		feet := rl.NewVector2(body.X+body.Width/2, body.Y+body.Height)
+		for _, name := range names {
+			events.Publish(Event{Type: EventAnimation, Target: name, Pos: feet})
+		}
+	}
+}
+
+// applyRootMotion moves the player by anim's root motion for the frame it
+// just reached, scaled and mirrored like the body. Like walking, it never
+// takes the player further outside the camera bounds.
+func applyRootMotion(anim *Animated, multiplier float32) {
+	if anim.CurrentFrame >= len(anim.RootMotion) {
+		return
+	}
+	d := rl.Vector2Scale(anim.RootMotion[anim.CurrentFrame], player.Scale*multiplier)
+	if player.Flip {
+		d.X = -d.X
+	}
+	bounds := camera.Bounds()
+	x := player.Pos.X + d.X
+	if d.X < 0 {
+		x = max(x, min(bounds.X, player.Pos.X))
+	} else {
+		x = min(x, max(bounds.X+bounds.Width-PlayerBounds().Width, player.Pos.X))
+	}
+	player.Pos.X = x
+	player.Pos.Y += d.Y
 }
 
 func ApplyGravity() {
+	if cheatNoclip() {
+		cheatFly()
+		return
+	}
 	player.VelocityY += gravity
 	player.Pos.Y += player.VelocityY
 
 	if player.Pos.Y >= player.DefPos.Y {
+		if !player.OnGround && player.VelocityY > landingShakeSpeed {
+			AddShake(ShakeLanding)
+		}
 		player.Pos.Y = player.DefPos.Y
 		player.VelocityY = 0
 		player.OnGround = true
+		player.airTime = 0
+		player.airJumped = false
 	} else {
 		player.OnGround = false
+		player.airTime += tickDuration
+	}
+}
+
+// DamagePlayer subtracts health unless an effect makes the player invulnerable.
+func DamagePlayer(amount int) {
+	if amount <= 0 || player.Effects.Invulnerable() || cheatInvincible() {
+		return
+	}
+	wasAlive := player.Health > 0
+	player.Health = max(player.Health-amount, 0)
+	player.Material.Trigger()
+	SpawnDamageNumber(rl.NewVector2(player.Pos.X+40, player.Pos.Y), amount, false)
+	if wasAlive && player.Health == 0 {
+		events.Publish(Event{Type: EventPlayerDied, Pos: player.Pos})
 	}
 }
 
+// PlayerBounds returns the area covered by the player's current frame.
+func PlayerBounds() rl.Rectangle {
+	_, src := player.Stand.Frame(0)
+	return rl.NewRectangle(player.Pos.X, player.Pos.Y, src.Width*player.Scale, src.Height*player.Scale)
+}
+
+// PlayerHitbox returns the area the attack reaches: in front of the player,
+// or from the middle of their body towards the aim when attacks are aimed.
+func PlayerHitbox() AttackBox {
+	body := PlayerBounds()
+	box := AttackBox{
+		Origin: rl.NewVector2(body.X+body.Width*0.5, body.Y+body.Height*0.5),
+		Dir:    rl.NewVector2(1, 0),
+		Reach:  body.Width * 0.8,
+		Width:  body.Height,
+	}
+	if player.Flip {
+		box.Dir.X = -1
+	}
+	if dir, ok := input.AimDir(); ok {
+		box.Dir = dir
+		// A body-high slab swung sideways would reach far above the player
+		box.Width = body.Width
+	}
+	return box
+}
+
 func HandleJump() {
-	if (rl.IsKeyPressed(rl.KeySpace) || rl.IsKeyPressed(rl.KeyUp)) && player.OnGround {
-		player.VelocityY = jumpForce
-		player.OnGround = false
+	// Coyote time lets a jump pressed just after running off a ledge count
+	grounded := player.OnGround || player.airTime < rules.CoyoteTime
+	if !input.IsPressed(ActionJump) {
+		return
 	}
+	if !grounded && !cheatInfiniteJumps() {
+		// The double jump, once unlocked, gives one more in the air
+		if !movement.DoubleJump || player.airJumped {
+			return
+		}
+		player.airJumped = true
+	}
+	player.VelocityY = jumpForce
+	player.OnGround = false
+	player.airTime = rules.CoyoteTime
+	events.Publish(Event{Type: EventPlayerJumped, Pos: player.Pos})
 }
 
+// Dash tuning
+const (
+	dashSpeed    = 18
+	dashTime     = 150 * time.Millisecond
+	dashCooldown = 600 * time.Millisecond
+)
+
+// HandleDash starts a dash, once unlocked, and carries the player along
+// it: a burst of speed the way they face that holds off falling.
+func HandleDash() {
+	player.dashWait = max(player.dashWait-tickDuration, 0)
+	if movement.Dash && player.dashWait == 0 && input.IsPressed(ActionDash) {
+		player.dashLeft = dashTime
+		player.dashWait = dashCooldown
+	}
+	if player.dashLeft <= 0 {
+		return
+	}
+	player.dashLeft -= tickDuration
+	dir := float32(1)
+	if player.Flip {
+		dir = -1
+	}
+	bounds := camera.Bounds()
+	x := player.Pos.X + dashSpeed*dir
+	player.Pos.X = rl.Clamp(x, min(bounds.X, player.Pos.X), max(bounds.X+bounds.Width-PlayerBounds().Width, player.Pos.X))
+	player.VelocityY = min(player.VelocityY, 0)
+}
+
+// OnPlayerAttack is called with the hitbox of every attack the player starts
+var OnPlayerAttack func(hitbox AttackBox)
+
 func HandleHitAnimation(now time.Time) {
-	if rl.IsKeyPressed(rl.KeyF) && !player.Hit.IsPlaying {
+	if input.IsPressed(ActionHit) && !player.Hit.IsPlaying {
+		if dir, ok := input.AimDir(); ok && dir.X != 0 {
+			player.Flip = dir.X < 0
+		}
 		player.Hit.IsPlaying = true
 		player.Hit.Reversing = false
 		player.Hit.CurrentFrame = 2
 		player.Hit.StartTime = now
+		reachFrame(&player.Hit, 1)
+		events.Publish(Event{Type: EventPlayerAttacked, Pos: player.Pos})
+		if OnPlayerAttack != nil {
+			OnPlayerAttack(PlayerHitbox())
+		}
+		if activeBoss != nil && PlayerHitbox().Overlaps(activeBoss.Hurtbox()) {
+			activeBoss.Damage(playerHitDamage + playerDamageBonus)
+			events.Publish(Event{Type: EventEnemyHit, Target: activeBoss.Name, Pos: activeBoss.Pos})
+			TriggerHitstop(90*time.Millisecond, 0.05)
+			AddShake(ShakeHit)
+		}
+		for _, e := range enemies {
+			if !e.Defeated && PlayerHitbox().Overlaps(e.Hurtbox()) {
+				e.Damage(playerHitDamage + playerDamageBonus)
+				events.Publish(Event{Type: EventEnemyHit, Target: e.Name, Pos: e.Pos})
+				TriggerHitstop(60*time.Millisecond, 0.05)
+			}
+		}
 	}
 
-	if player.Hit.IsPlaying && time.Since(player.Hit.StartTime) > player.Hit.FrameDelay {
+	if player.Hit.IsPlaying && now.Sub(player.Hit.StartTime) > pacing.Quantize(player.Hit.FrameDelay) {
 		player.Hit.StartTime = now
 		if player.Hit.Reversing {
 			player.Hit.CurrentFrame--
@@ -95,17 +296,21 @@ func HandleHitAnimation(now time.Time) {
 			}
 		} else {
 			player.Hit.CurrentFrame++
-			if player.Hit.CurrentFrame >= len(player.Hit.FrameTextures) {
-				player.Hit.CurrentFrame = len(player.Hit.FrameTextures) - 1
+			if player.Hit.CurrentFrame >= player.Hit.Frames() {
+				player.Hit.CurrentFrame = player.Hit.Frames() - 1
 				player.Hit.Reversing = true
+			} else {
+				// Only the swing moves the body and fires events; the recovery
+				// plays in place
+				reachFrame(&player.Hit, 1)
 			}
 		}
 	}
 }
 
 func HandleStandAnimation(now time.Time) {
-	if !player.State.IsMoving && !player.Hit.IsPlaying {
-		updateAnimation(&player.Stand, true, now)
+	if !player.State.IsMoving && !player.Hit.IsPlaying && updateAnimation(&player.Stand, true, now) {
+		reachFrame(&player.Stand, 1)
 	}
 }
 
@@ -113,14 +318,18 @@ func UpdateBackground(now time.Time) {
 	updateAnimation(background, true, now)
 }
 
-func updateAnimation(anim *Animated, shouldUpdate bool, now time.Time) {
+// updateAnimation steps a looping animation, or rewinds it when it should
+// not play. It reports whether a new frame was reached.
+func updateAnimation(anim *Animated, shouldUpdate bool, now time.Time) bool {
 	if shouldUpdate {
-		if time.Since(anim.StartTime) > anim.FrameDelay {
+		if now.Sub(anim.StartTime) > pacing.Quantize(anim.FrameDelay) && anim.Frames() > 0 {
 			anim.StartTime = now
-			anim.CurrentFrame = (anim.CurrentFrame + 1) % len(anim.FrameTextures)
+			anim.CurrentFrame = (anim.CurrentFrame + 1) % anim.Frames()
+			return true
 		}
 	} else {
 		anim.CurrentFrame = 0
 		anim.StartTime = now
 	}
+	return false
 }
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const statsPath = "stats.json"
+
+// Stats are lifetime totals for the current profile. Counts come from the
+// event bus; distance and playtime are sampled every tick. Ticks replayed
+// by rewind or attract mode, and co-op sessions, aren't counted.
+type Stats struct {
+	Jumps           int               `json:"jumps"`
+	Attacks         int               `json:"attacks"`
+	Hits            int               `json:"hits"`
+	Deaths          int               `json:"deaths"`
+	EnemiesDefeated int               `json:"enemiesDefeated"`
+	ItemsCollected  int               `json:"itemsCollected"`
+	Distance        float64           `json:"distance"` // in world pixels
+	Playtime        map[string]uint64 `json:"playtime"` // ticks per level
+
+	lastPos rl.Vector2
+	moved   bool // lastPos is valid
+}
+
+var stats = &Stats{}
+
+func (s *Stats) recording() bool {
+	return !rewinder.Replaying() && !coop.Active
+}
+
+// HandleEvent counts gameplay events.
+func (s *Stats) HandleEvent(e Event) {
+	if !s.recording() {
+		return
+	}
+	switch e.Type {
+	case EventPlayerJumped:
+		s.Jumps++
+	case EventPlayerAttacked:
+		s.Attacks++
+	case EventEnemyHit:
+		s.Hits++
+	case EventPlayerDied:
+		s.Deaths++
+	case EventEnemyDefeated:
+		s.EnemiesDefeated++
+	case EventItemCollected:
+		s.ItemsCollected += e.Amount
+	}
+}
+
+// Tick adds the distance the player moved and one tick of playtime in the
+// current level.
+func (s *Stats) Tick() {
+	if !s.recording() {
+		s.moved = false
+		return
+	}
+	if s.moved {
+		s.Distance += float64(rl.Vector2Distance(s.lastPos, player.Pos))
+	}
+	s.lastPos = player.Pos
+	s.moved = true
+
+	if s.Playtime == nil {
+		s.Playtime = make(map[string]uint64)
+	}
+	s.Playtime[currentLevel]++
+}
+
+// Teleported keeps a jump in position, like loading a save or changing
+// level, from counting as distance.
+func (s *Stats) Teleported() {
+	s.moved = false
+}
+
+// Load replaces the totals with the current profile's.
+func (s *Stats) Load() {
+	*s = Stats{}
+	path := profilePath(statsPath)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("stats: %v", err)
+		}
+		return
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		log.Printf("stats: %s: %v", path, err)
+	}
+}
+
+// Save writes the totals to the current profile.
+func (s *Stats) Save() {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err == nil {
+		err = writeFileAtomic(profilePath(statsPath), data)
+	}
+	if err != nil {
+		log.Printf("stats: %v", err)
+	}
+}
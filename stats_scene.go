@@ -0,0 +1,107 @@
+package main
+
+import (
+	"slices"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	// World pixels per meter when showing distance
+	statsPixelsPerMeter = 64
+
+	statsBarHeight = 26
+	statsBarGap    = 12
+)
+
+// statBar is one row of a chart
+type statBar struct {
+	label string
+	value float64
+	text  string // the value as shown
+}
+
+// StatsScene shows the profile's statistics as two bar charts: action
+// counts and playtime per level. Any back input returns to the main menu.
+type StatsScene struct {
+	font *Font
+}
+
+func (s *StatsScene) Load(scope *AssetScope) {
+	s.font = scope.Font("", 28)
+}
+
+func (s *StatsScene) Unload() {}
+
+func (s *StatsScene) Update() {
+	back := rl.IsKeyPressed(rl.KeyEscape) || rl.IsKeyPressed(rl.KeyBackspace) || rl.IsKeyPressed(rl.KeyEnter) ||
+		rl.IsGamepadButtonPressed(gamepadIndex, rl.GamepadButtonRightFaceRight)
+	if back {
+		scenes.Replace(NewMainMenuScene())
+	}
+}
+
+func (s *StatsScene) Draw() {
+	rl.DrawRectangle(0, 0, int32(screenSize.X), int32(screenSize.Y), rl.Fade(rl.Black, 0.9))
+
+	title := T("stats.title")
+	top := ui.Rect(UIRect{Anchor: AnchorTop, Offset: rl.NewVector2(0, 60), Size: rl.NewVector2(800, 60)})
+	w := s.font.Measure(title, 56).X
+	s.font.Draw(title, rl.NewVector2(top.X+(top.Width-w)/2, top.Y), 56, rl.Gold)
+
+	counts := []statBar{
+		{label: T("stats.jumps"), value: float64(stats.Jumps)},
+		{label: T("stats.attacks"), value: float64(stats.Attacks)},
+		{label: T("stats.hits"), value: float64(stats.Hits)},
+		{label: T("stats.defeated"), value: float64(stats.EnemiesDefeated)},
+		{label: T("stats.items"), value: float64(stats.ItemsCollected)},
+		{label: T("stats.deaths"), value: float64(stats.Deaths)},
+	}
+	for i := range counts {
+		counts[i].text = locale.Int(int64(counts[i].value))
+	}
+	left := ui.Rect(UIRect{Anchor: AnchorLeft, Offset: rl.NewVector2(80, 40), Size: rl.NewVector2(screenSize.X/2-120, 480)})
+	s.drawChart(left, T("stats.actions"), counts, rl.SkyBlue)
+
+	distance := T("stats.distance") + ": " + locale.Int(int64(stats.Distance/statsPixelsPerMeter)) + " m"
+	s.font.Draw(distance, rl.NewVector2(left.X, left.Y+left.Height), 28, rl.RayWhite)
+
+	var playtime []statBar
+	for level, ticks := range stats.Playtime {
+		minutes := int(ticks / (60 * ticksPerSecond))
+		playtime = append(playtime, statBar{label: level, value: float64(ticks), text: locale.Plural("stats.minutes", minutes)})
+	}
+	slices.SortFunc(playtime, func(a, b statBar) int { return strings.Compare(a.label, b.label) })
+	right := ui.Rect(UIRect{Anchor: AnchorRight, Offset: rl.NewVector2(80, 40), Size: rl.NewVector2(screenSize.X/2-120, 480)})
+	s.drawChart(right, T("stats.playtime"), playtime, rl.Lime)
+}
+
+// drawChart draws a titled horizontal bar chart, scaling bars to the
+// largest value.
+func (s *StatsScene) drawChart(area rl.Rectangle, title string, bars []statBar, color rl.Color) {
+	s.font.Draw(title, rl.NewVector2(area.X, area.Y), 32, rl.RayWhite)
+	y := area.Y + 48
+	if len(bars) == 0 {
+		s.font.Draw(T("stats.none"), rl.NewVector2(area.X, y), 24, rl.Gray)
+		return
+	}
+
+	largest := float64(1)
+	for _, b := range bars {
+		largest = max(largest, b.value)
+	}
+	labelWidth := area.Width * 0.35
+	barWidth := area.Width - labelWidth - 120
+	for _, b := range bars {
+		s.font.Draw(b.label, rl.NewVector2(area.X, y), 24, rl.LightGray)
+		bar := rl.NewRectangle(area.X+labelWidth, y, float32(b.value/largest)*barWidth, statsBarHeight)
+		rl.DrawRectangleRec(rl.NewRectangle(bar.X, bar.Y, barWidth, bar.Height), rl.Fade(rl.DarkGray, 0.4))
+		rl.DrawRectangleRec(bar, color)
+		s.font.Draw(b.text, rl.NewVector2(bar.X+barWidth+12, y), 24, rl.RayWhite)
+		y += statsBarHeight + statsBarGap
+		if y > area.Y+area.Height-statsBarHeight {
+			return
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"slices"
+	"strconv"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const statusIconSize = 32
+
+// StatusKind identifies a buff or debuff
+type StatusKind int
+
+const (
+	StatusSpeedBoost StatusKind = iota
+	StatusSlow
+	StatusPoison
+	StatusInvulnerable
+)
+
+// StackRule decides what happens when an effect is applied while already active
+type StackRule int
+
+const (
+	// StackRefresh keeps one instance and resets its duration
+	StackRefresh StackRule = iota
+	// StackIntensity adds a stack up to MaxStacks and resets the duration
+	StackIntensity
+	// StackExtend adds the new duration to the remaining time
+	StackExtend
+)
+
+// StatusDef describes how an effect behaves. Multipliers and damage apply per stack.
+type StatusDef struct {
+	Name            string
+	Icon            string
+	Color           rl.Color
+	Stacking        StackRule
+	MaxStacks       int
+	SpeedMultiplier float32
+	TickDamage      int
+	TickEvery       time.Duration
+	Invulnerable    bool
+}
+
+var statusDefs = map[StatusKind]StatusDef{
+	StatusSpeedBoost: {
+		Name:            "Speed",
+		Icon:            "assets/images/icons/speed.png",
+		Color:           rl.SkyBlue,
+		Stacking:        StackRefresh,
+		SpeedMultiplier: 1.5,
+	},
+	StatusSlow: {
+		Name:            "Slow",
+		Icon:            "assets/images/icons/slow.png",
+		Color:           rl.DarkBlue,
+		Stacking:        StackRefresh,
+		SpeedMultiplier: 0.5,
+	},
+	StatusPoison: {
+		Name:       "Poison",
+		Icon:       "assets/images/icons/poison.png",
+		Color:      rl.Lime,
+		Stacking:   StackIntensity,
+		MaxStacks:  3,
+		TickDamage: 5,
+		TickEvery:  time.Second,
+	},
+	StatusInvulnerable: {
+		Name:         "Invulnerable",
+		Icon:         "assets/images/icons/invulnerable.png",
+		Color:        rl.Gold,
+		Stacking:     StackExtend,
+		Invulnerable: true,
+	},
+}
+
+// StatusEffect is an active instance of a status on an entity
+type StatusEffect struct {
+	Kind      StatusKind    `json:"kind"`
+	Duration  time.Duration `json:"duration"`
+	Remaining time.Duration `json:"remaining"`
+	Stacks    int           `json:"stacks"`
+	SinceTick time.Duration `json:"sinceTick,omitempty"`
+}
+
+// StatusEffects is the set of effects active on one entity
+type StatusEffects struct {
+	effects []StatusEffect
+}
+
+// Apply adds the effect or stacks it onto an existing one following its StackRule.
+func (s *StatusEffects) Apply(kind StatusKind, duration time.Duration) {
+	def := statusDefs[kind]
+	for i := range s.effects {
+		e := &s.effects[i]
+		if e.Kind != kind {
+			continue
+		}
+		switch def.Stacking {
+		case StackRefresh:
+			e.Remaining = max(e.Remaining, duration)
+		case StackIntensity:
+			e.Stacks = min(e.Stacks+1, max(def.MaxStacks, 1))
+			e.Remaining = max(e.Remaining, duration)
+		case StackExtend:
+			e.Remaining += duration
+		}
+		e.Duration = max(e.Duration, e.Remaining)
+		return
+	}
+
+	s.effects = append(s.effects, StatusEffect{
+		Kind:      kind,
+		Duration:  duration,
+		Remaining: duration,
+		Stacks:    1,
+	})
+}
+
+// Remove ends the effect immediately.
+func (s *StatusEffects) Remove(kind StatusKind) {
+	s.effects = slices.DeleteFunc(s.effects, func(e StatusEffect) bool { return e.Kind == kind })
+}
+
+// Has reports whether the effect is active.
+func (s *StatusEffects) Has(kind StatusKind) bool {
+	return slices.ContainsFunc(s.effects, func(e StatusEffect) bool { return e.Kind == kind })
+}
+
+// Update advances durations by one tick, calling damage for every periodic tick.
+func (s *StatusEffects) Update(damage func(amount int)) {
+	for i := range s.effects {
+		e := &s.effects[i]
+		def := statusDefs[e.Kind]
+		e.Remaining -= tickDuration
+
+		if def.TickDamage > 0 && def.TickEvery > 0 {
+			e.SinceTick += tickDuration
+			if e.SinceTick >= def.TickEvery {
+				e.SinceTick -= def.TickEvery
+				damage(def.TickDamage * e.Stacks)
+			}
+		}
+	}
+	s.effects = slices.DeleteFunc(s.effects, func(e StatusEffect) bool { return e.Remaining <= 0 })
+}
+
+// SpeedMultiplier combines the movement modifiers of all active effects.
+func (s *StatusEffects) SpeedMultiplier() float32 {
+	mult := float32(1)
+	for _, e := range s.effects {
+		if m := statusDefs[e.Kind].SpeedMultiplier; m != 0 {
+			for range e.Stacks {
+				mult *= m
+			}
+		}
+	}
+	return mult
+}
+
+// Invulnerable reports whether any active effect blocks damage.
+func (s *StatusEffects) Invulnerable() bool {
+	for _, e := range s.effects {
+		if statusDefs[e.Kind].Invulnerable {
+			return true
+		}
+	}
+	return false
+}
+
+// Clone returns a copy that shares no memory with s.
+func (s StatusEffects) Clone() StatusEffects {
+	return StatusEffects{effects: slices.Clone(s.effects)}
+}
+
+var statusIcons = make(map[StatusKind]*Texture)
+
+// DrawStatusIcons draws the player's active effects in the top-left HUD corner,
+// each ringed by a cooldown showing the remaining duration.
+func DrawStatusIcons() {
+	origin := ui.Rect(UIRect{Anchor: AnchorTopLeft, Offset: rl.NewVector2(20, 20)})
+	x := int32(origin.X)
+	y := int32(origin.Y)
+	for _, e := range player.Effects.effects {
+		def := statusDefs[e.Kind]
+		icon, ok := statusIcons[e.Kind]
+		if !ok {
+			icon = tm.Acquire(def.Icon, statusIconSize, statusIconSize)
+			statusIcons[e.Kind] = icon
+		}
+
+		if icon.Loaded {
+			rl.DrawTexture(icon.Texture, x, y, rl.White)
+			icon.Drawn()
+		} else {
+			rl.DrawRectangle(x, y, statusIconSize, statusIconSize, def.Color)
+			rl.DrawText(def.Name[:1], x+10, y+6, 20, rl.Black)
+		}
+		if e.Stacks > 1 {
+			rl.DrawText(strconv.Itoa(e.Stacks), x+statusIconSize-10, y+statusIconSize-14, 14, rl.White)
+		}
+
+		if e.Duration > 0 {
+			center := rl.NewVector2(float32(x)+statusIconSize/2, float32(y)+statusIconSize/2)
+			DrawCooldown(center, statusIconSize/2+5, 3, float32(e.Remaining)/float32(e.Duration), def.Color)
+		}
+		x += statusIconSize + 16
+	}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"slices"
+)
+
+// System is a self-contained part of the game driven by the main loop.
+// Systems register themselves from an init function, so adding or removing
+// one needs no change to main.go.
+type System interface {
+	// Name identifies the system to others listing it as a dependency
+	Name() string
+	// Dependencies names the systems that must init, update and draw before
+	// this one. Names that are not registered are ignored, so a system can be
+	// ordered after an optional one such as cheats.
+	Dependencies() []string
+	Init() error
+	// Update runs once per frame before the scenes update
+	Update()
+	// Draw runs once per frame on top of the scenes
+	Draw()
+	Shutdown()
+}
+
+// SystemFuncs adapts plain functions to a System; nil functions do nothing
+type SystemFuncs struct {
+	ID         string
+	Requires   []string
+	OnInit     func() error
+	OnUpdate   func()
+	OnDraw     func()
+	OnShutdown func()
+}
+
+func (s *SystemFuncs) Name() string           { return s.ID }
+func (s *SystemFuncs) Dependencies() []string { return s.Requires }
+
+func (s *SystemFuncs) Init() error {
+	if s.OnInit == nil {
+		return nil
+	}
+	return s.OnInit()
+}
+
+func (s *SystemFuncs) Update() {
+	if s.OnUpdate != nil {
+		s.OnUpdate()
+	}
+}
+
+func (s *SystemFuncs) Draw() {
+	if s.OnDraw != nil {
+		s.OnDraw()
+	}
+}
+
+func (s *SystemFuncs) Shutdown() {
+	if s.OnShutdown != nil {
+		s.OnShutdown()
+	}
+}
+
+// SystemRegistry keeps the registered systems in dependency order
+type SystemRegistry struct {
+	registered map[string]System
+	order      []System
+}
+
+var systems = &SystemRegistry{registered: make(map[string]System)}
+
+// RegisterSystem adds s to the global registry.
+func RegisterSystem(s System) {
+	systems.Register(s)
+}
+
+// Register adds s, replacing any system with the same name.
+func (r *SystemRegistry) Register(s System) {
+	r.registered[s.Name()] = s
+	r.order = nil
+}
+
+// Remove drops the named system without shutting it down.
+func (r *SystemRegistry) Remove(name string) {
+	delete(r.registered, name)
+	r.order = nil
+}
+
+// sort orders the systems so each comes after its dependencies, breaking
+// ties by name so the order is the same on every run.
+func (r *SystemRegistry) sort() error {
+	pending := make(map[string]int, len(r.registered))
+	dependents := make(map[string][]string)
+	for name, s := range r.registered {
+		for _, dep := range s.Dependencies() {
+			if _, ok := r.registered[dep]; ok {
+				pending[name]++
+				dependents[dep] = append(dependents[dep], name)
+			}
+		}
+	}
+
+	var ready []string
+	for name := range r.registered {
+		if pending[name] == 0 {
+			ready = append(ready, name)
+		}
+	}
+	order := make([]System, 0, len(r.registered))
+	for len(ready) > 0 {
+		slices.Sort(ready)
+		name := ready[0]
+		ready = ready[1:]
+		order = append(order, r.registered[name])
+		for _, next := range dependents[name] {
+			if pending[next]--; pending[next] == 0 {
+				ready = append(ready, next)
+			}
+		}
+	}
+
+	if len(order) < len(r.registered) {
+		var cycle []string
+		for name, n := range pending {
+			if n > 0 {
+				cycle = append(cycle, name)
+			}
+		}
+		slices.Sort(cycle)
+		return fmt.Errorf("systems: dependency cycle between %v", cycle)
+	}
+	r.order = order
+	return nil
+}
+
+// Init orders the systems and initializes them. A system that fails to
+// initialize is logged and removed along with every system depending on it.
+func (r *SystemRegistry) Init() error {
+	if err := r.sort(); err != nil {
+		return err
+	}
+	failed := make(map[string]bool)
+	kept := r.order[:0]
+	for _, s := range r.order {
+		if slices.ContainsFunc(s.Dependencies(), func(dep string) bool { return failed[dep] }) {
+			log.Printf("systems: %s: skipped, a dependency failed", s.Name())
+			failed[s.Name()] = true
+			delete(r.registered, s.Name())
+			continue
+		}
+		if err := s.Init(); err != nil {
+			log.Printf("systems: %s: %v", s.Name(), err)
+			failed[s.Name()] = true
+			delete(r.registered, s.Name())
+			continue
+		}
+		kept = append(kept, s)
+	}
+	r.order = kept
+	return nil
+}
+
+// systemsInOrder returns the systems sorted, resorting after a change.
+func (r *SystemRegistry) systemsInOrder() []System {
+	if r.order == nil {
+		if err := r.sort(); err != nil {
+			log.Print(err)
+		}
+	}
+	return r.order
+}
+
+// Update updates every system in dependency order.
+func (r *SystemRegistry) Update() {
+	for _, s := range r.systemsInOrder() {
+		s.Update()
+	}
+}
+
+// Draw draws every system in dependency order.
+func (r *SystemRegistry) Draw() {
+	for _, s := range r.systemsInOrder() {
+		s.Draw()
+	}
+}
+
+// Shutdown shuts the systems down in reverse dependency order.
+func (r *SystemRegistry) Shutdown() {
+	order := r.systemsInOrder()
+	for i := len(order) - 1; i >= 0; i-- {
+		order[i].Shutdown()
+	}
+}
+
+// Names lists the systems in the order they run, for the debug overlay.
+func (r *SystemRegistry) Names() []string {
+	var names []string
+	for _, s := range r.systemsInOrder() {
+		names = append(names, s.Name())
+	}
+	return names
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const caretBlink = 530 * time.Millisecond
+
+// focusedInput receives keyboard text. It holds the console input context,
+// so gameplay and menus ignore the keys typed into it.
+var focusedInput *TextInput
+
+// TextInput is a single-line text field with a caret, selection and clipboard support.
+// Text is edited as runes so multi-byte characters from an IME stay intact.
+type TextInput struct {
+	Bounds      rl.Rectangle
+	Placeholder string
+	MaxLength   int
+	FontSize    float32
+	OnSubmit    func(text string)
+
+	text   []rune
+	caret  int
+	anchor int // other end of the selection; equal to caret when nothing is selected
+	blink  time.Time
+	font   *Font
+}
+
+// NewTextInput creates an empty field at the given bounds
+func NewTextInput(bounds rl.Rectangle, placeholder string, maxLength int) *TextInput {
+	return &TextInput{
+		Bounds:      bounds,
+		Placeholder: placeholder,
+		MaxLength:   maxLength,
+		FontSize:    bounds.Height * 0.6,
+	}
+}
+
+// Text returns the current contents.
+func (t *TextInput) Text() string {
+	return string(t.text)
+}
+
+// SetText replaces the contents and moves the caret to the end.
+func (t *TextInput) SetText(text string) {
+	t.text = []rune(text)
+	if t.MaxLength > 0 && len(t.text) > t.MaxLength {
+		t.text = t.text[:t.MaxLength]
+	}
+	t.caret = len(t.text)
+	t.anchor = t.caret
+}
+
+// Focus makes this field receive keyboard input.
+func (t *TextInput) Focus() {
+	if focusedInput != nil {
+		inputContexts.Set(focusedInput, ContextConsole, false)
+	}
+	focusedInput = t
+	inputContexts.Set(t, ContextConsole, true)
+	t.blink = time.Now()
+}
+
+// Blur removes focus from the field.
+func (t *TextInput) Blur() {
+	if focusedInput == t {
+		focusedInput = nil
+		inputContexts.Set(t, ContextConsole, false)
+	}
+}
+
+// Focused reports whether the field has keyboard focus.
+func (t *TextInput) Focused() bool {
+	return focusedInput == t
+}
+
+func (t *TextInput) selection() (int, int) {
+	return min(t.caret, t.anchor), max(t.caret, t.anchor)
+}
+
+func (t *TextInput) hasSelection() bool {
+	return t.caret != t.anchor
+}
+
+func (t *TextInput) deleteSelection() {
+	start, end := t.selection()
+	t.text = append(t.text[:start], t.text[end:]...)
+	t.caret = start
+	t.anchor = start
+}
+
+func (t *TextInput) insert(runes []rune) {
+	if t.hasSelection() {
+		t.deleteSelection()
+	}
+	if t.MaxLength > 0 {
+		room := t.MaxLength - len(t.text)
+		if room <= 0 {
+			return
+		}
+		if len(runes) > room {
+			runes = runes[:room]
+		}
+	}
+	tail := append([]rune{}, t.text[t.caret:]...)
+	t.text = append(append(t.text[:t.caret], runes...), tail...)
+	t.caret += len(runes)
+	t.anchor = t.caret
+}
+
+func (t *TextInput) moveCaret(to int, selecting bool) {
+	t.caret = max(0, min(to, len(t.text)))
+	if !selecting {
+		t.anchor = t.caret
+	}
+	t.blink = time.Now()
+}
+
+func keyHit(key int32) bool {
+	return rl.IsKeyPressed(key) || rl.IsKeyPressedRepeat(key)
+}
+
+// Update handles mouse focus and, while focused, keyboard editing.
+func (t *TextInput) Update() {
+	if t.font == nil {
+		t.font = fonts.Acquire("", int32(t.FontSize))
+	}
+
+	if rl.IsMouseButtonPressed(rl.MouseButtonLeft) {
+		mouse := rl.GetMousePosition()
+		if rl.CheckCollisionPointRec(mouse, t.Bounds) {
+			t.Focus()
+			t.moveCaret(t.indexAt(mouse.X), rl.IsKeyDown(rl.KeyLeftShift))
+		} else {
+			t.Blur()
+		}
+	}
+	if !t.Focused() {
+		return
+	}
+
+	ctrl := rl.IsKeyDown(rl.KeyLeftControl) || rl.IsKeyDown(rl.KeyRightControl)
+	shift := rl.IsKeyDown(rl.KeyLeftShift) || rl.IsKeyDown(rl.KeyRightShift)
+
+	// Characters arrive as composed runes, so IME input works unchanged
+	for r := rl.GetCharPressed(); r > 0; r = rl.GetCharPressed() {
+		if !ctrl {
+			t.insert([]rune{rune(r)})
+			t.blink = time.Now()
+		}
+	}
+
+	switch {
+	case ctrl && rl.IsKeyPressed(rl.KeyA):
+		t.anchor = 0
+		t.caret = len(t.text)
+	case ctrl && rl.IsKeyPressed(rl.KeyC):
+		if t.hasSelection() {
+			start, end := t.selection()
+			rl.SetClipboardText(string(t.text[start:end]))
+		}
+	case ctrl && rl.IsKeyPressed(rl.KeyX):
+		if t.hasSelection() {
+			start, end := t.selection()
+			rl.SetClipboardText(string(t.text[start:end]))
+			t.deleteSelection()
+		}
+	case ctrl && rl.IsKeyPressed(rl.KeyV):
+		paste := strings.NewReplacer("\r", "", "\n", " ").Replace(rl.GetClipboardText())
+		t.insert([]rune(paste))
+	case keyHit(rl.KeyBackspace):
+		if t.hasSelection() {
+			t.deleteSelection()
+		} else if t.caret > 0 {
+			t.anchor = t.caret - 1
+			t.deleteSelection()
+		}
+	case keyHit(rl.KeyDelete):
+		if t.hasSelection() {
+			t.deleteSelection()
+		} else if t.caret < len(t.text) {
+			t.anchor = t.caret + 1
+			t.deleteSelection()
+		}
+	case keyHit(rl.KeyLeft):
+		if t.hasSelection() && !shift {
+			start, _ := t.selection()
+			t.moveCaret(start, false)
+		} else {
+			t.moveCaret(t.caret-1, shift)
+		}
+	case keyHit(rl.KeyRight):
+		if t.hasSelection() && !shift {
+			_, end := t.selection()
+			t.moveCaret(end, false)
+		} else {
+			t.moveCaret(t.caret+1, shift)
+		}
+	case rl.IsKeyPressed(rl.KeyHome):
+		t.moveCaret(0, shift)
+	case rl.IsKeyPressed(rl.KeyEnd):
+		t.moveCaret(len(t.text), shift)
+	case rl.IsKeyPressed(rl.KeyEnter):
+		if t.OnSubmit != nil {
+			t.OnSubmit(t.Text())
+		}
+	case rl.IsKeyPressed(rl.KeyEscape):
+		t.Blur()
+	}
+}
+
+// indexAt returns the caret position closest to screen x.
+func (t *TextInput) indexAt(x float32) int {
+	left := t.Bounds.X + 8
+	for i := range t.text {
+		w := t.font.Measure(string(t.text[:i+1]), t.FontSize).X
+		prev := t.font.Measure(string(t.text[:i]), t.FontSize).X
+		if x < left+(w+prev)/2 {
+			return i
+		}
+	}
+	return len(t.text)
+}
+
+// Draw renders the field, selection highlight and blinking caret.
+func (t *TextInput) Draw() {
+	if t.font == nil {
+		return
+	}
+
+	border := rl.Gray
+	if t.Focused() {
+		border = rl.RayWhite
+	}
+	rl.DrawRectangleRec(t.Bounds, rl.Fade(rl.Black, 0.8))
+	rl.DrawRectangleLinesEx(t.Bounds, 2, border)
+
+	textPos := rl.NewVector2(t.Bounds.X+8, t.Bounds.Y+(t.Bounds.Height-t.FontSize)/2)
+	if len(t.text) == 0 && !t.Focused() {
+		t.font.Draw(t.Placeholder, textPos, t.FontSize, rl.DarkGray)
+		return
+	}
+
+	if t.hasSelection() {
+		start, end := t.selection()
+		x0 := t.font.Measure(string(t.text[:start]), t.FontSize).X
+		x1 := t.font.Measure(string(t.text[:end]), t.FontSize).X
+		rl.DrawRectangleRec(rl.NewRectangle(textPos.X+x0, textPos.Y, x1-x0, t.FontSize), rl.Fade(rl.SkyBlue, 0.5))
+	}
+	t.font.Draw(string(t.text), textPos, t.FontSize, rl.White)
+
+	if t.Focused() && time.Since(t.blink)%(2*caretBlink) < caretBlink {
+		cx := textPos.X + t.font.Measure(string(t.text[:t.caret]), t.FontSize).X
+		rl.DrawLineEx(rl.NewVector2(cx, textPos.Y), rl.NewVector2(cx, textPos.Y+t.FontSize), 2, rl.White)
+	}
+}
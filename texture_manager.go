@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"path/filepath"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
@@ -16,7 +17,8 @@ type Texture struct {
 	Texture rl.Texture2D
 	Loaded  bool
 	Err     error
-	refs    int // reference count
+	refs    int    // reference count
+	path    string // asset the texture came from, for usage tracking
 }
 
 // NewTextureManager creates and returns a new TextureManager
@@ -35,11 +37,11 @@ func (tm *TextureManager) Acquire(path string, width int32, height int32) *Textu
 		return handle
 	}
 
-	handle := &Texture{refs: 1}
+	handle := &Texture{refs: 1, path: path}
 	tm.textures[path] = handle
 
-	img := rl.LoadImage(path)
-	if img.Data == nil {
+	img := loadImageAsset(path)
+	if img == nil || img.Data == nil {
 		handle.Err = fmt.Errorf("failed to load image: %s", path)
 		handle.Loaded = false
 		return handle
@@ -56,6 +58,34 @@ func (tm *TextureManager) Acquire(path string, width int32, height int32) *Textu
 	return handle
 }
 
+// Insert registers a texture that was uploaded elsewhere under path with the
+// given number of references. If path is already tracked, the new texture is
+// unloaded and the existing handle gains the references instead.
+func (tm *TextureManager) Insert(path string, texture rl.Texture2D, refs int) *Texture {
+	if handle, ok := tm.textures[path]; ok {
+		rl.UnloadTexture(texture)
+		handle.refs += refs
+		return handle
+	}
+
+	handle := &Texture{Texture: texture, Loaded: true, refs: refs, path: path}
+	tm.textures[path] = handle
+	return handle
+}
+
+// Lookup returns the handle for path without changing its reference count.
+func (tm *TextureManager) Lookup(path string) (*Texture, bool) {
+	handle, ok := tm.textures[path]
+	return handle, ok
+}
+
+// ForEach calls fn for every tracked texture.
+func (tm *TextureManager) ForEach(fn func(path string, t *Texture)) {
+	for path, handle := range tm.textures {
+		fn(path, handle)
+	}
+}
+
 // Release decrements the reference count for the texture at the given path.
 // If the reference count reaches zero, it unloads the texture and removes it from the manager.
 func (tm *TextureManager) Release(path string) {
@@ -82,3 +112,12 @@ func (tm *TextureManager) ReleaseAll() {
 		delete(tm.textures, path)
 	}
 }
+
+// loadImageAsset decodes the image at path with raylib, or returns nil.
+func loadImageAsset(path string) *rl.Image {
+	data, err := ReadAsset(path)
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	return rl.LoadImageFromMemory(filepath.Ext(path), data, int32(len(data)))
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// emptyTile marks a cell with nothing drawn
+const emptyTile = -1
+
+// TilePoint is a cell coordinate on a Tilemap
+type TilePoint struct {
+	X, Y int
+}
+
+// Tileset is an atlas texture cut into square tiles, numbered row by row
+type Tileset struct {
+	Texture  *Texture
+	TileSize int32
+	Columns  int32
+}
+
+// TileAnimation cycles a tile id through a list of atlas tiles,
+// using the same timing as sprite animations
+type TileAnimation struct {
+	Tiles []int
+	Anim  Animated
+}
+
+// Tilemap is a grid of square tiles with a visual layer, a terrain layer
+// used for auto-tiling and a collision layer
+type Tilemap struct {
+	Width      int
+	Height     int
+	TileSize   float32
+	Tiles      []int
+	Terrain    []int
+	Collision  []bool
+	Animations map[int]*TileAnimation
+}
+
+// NewTilemap creates an empty tilemap of the given size in tiles
+func NewTilemap(width, height int, tileSize float32) *Tilemap {
+	tiles := make([]int, width*height)
+	for i := range tiles {
+		tiles[i] = emptyTile
+	}
+	return &Tilemap{
+		Width:      width,
+		Height:     height,
+		TileSize:   tileSize,
+		Tiles:      tiles,
+		Terrain:    make([]int, width*height),
+		Collision:  make([]bool, width*height),
+		Animations: make(map[int]*TileAnimation),
+	}
+}
+
+// SetTile sets the atlas tile drawn at a cell, or emptyTile to clear it.
+func (t *Tilemap) SetTile(x, y, tile int) {
+	if t.InBounds(x, y) {
+		t.Tiles[y*t.Width+x] = tile
+	}
+}
+
+// Tile returns the atlas tile at a cell, or emptyTile outside the map.
+func (t *Tilemap) Tile(x, y int) int {
+	if !t.InBounds(x, y) {
+		return emptyTile
+	}
+	return t.Tiles[y*t.Width+x]
+}
+
+// AddAnimation makes every cell showing tile cycle through frames.
+func (t *Tilemap) AddAnimation(tile int, frames []int, delay time.Duration) {
+	t.Animations[tile] = &TileAnimation{
+		Tiles: frames,
+		Anim: Animated{
+			IsPlaying:  true,
+			StartTime:  clock.Now(),
+			FrameDelay: delay,
+			FrameCount: len(frames),
+		},
+	}
+}
+
+// UpdateAnimations advances all animated tiles. Every cell sharing a tile id
+// stays in sync because the animation is stored per id, not per cell.
+func (t *Tilemap) UpdateAnimations(now time.Time) {
+	for _, a := range t.Animations {
+		updateAnimation(&a.Anim, true, now)
+	}
+}
+
+// displayTile resolves animated tiles to their current frame.
+func (t *Tilemap) displayTile(tile int) int {
+	if a, ok := t.Animations[tile]; ok && len(a.Tiles) > 0 {
+		return a.Tiles[a.Anim.CurrentFrame%len(a.Tiles)]
+	}
+	return tile
+}
+
+// Draw renders the visual layer with the given tileset.
+func (t *Tilemap) Draw(ts *Tileset) {
+	if ts == nil || !ts.Texture.Loaded || ts.Columns == 0 {
+		return
+	}
+	for y := 0; y < t.Height; y++ {
+		for x := 0; x < t.Width; x++ {
+			tile := t.Tiles[y*t.Width+x]
+			if tile == emptyTile {
+				continue
+			}
+			tile = t.displayTile(tile)
+			src := rl.NewRectangle(
+				float32(int32(tile)%ts.Columns*ts.TileSize),
+				float32(int32(tile)/ts.Columns*ts.TileSize),
+				float32(ts.TileSize),
+				float32(ts.TileSize),
+			)
+			dst := rl.NewRectangle(float32(x)*t.TileSize, float32(y)*t.TileSize, t.TileSize, t.TileSize)
+			rl.DrawTexturePro(ts.Texture.Texture, src, dst, rl.NewVector2(0, 0), 0, rl.White)
+			ts.Texture.Drawn()
+		}
+	}
+}
+
+// InBounds reports whether the cell lies inside the map.
+func (t *Tilemap) InBounds(x, y int) bool {
+	return x >= 0 && y >= 0 && x < t.Width && y < t.Height
+}
+
+// IsSolid reports whether the cell blocks movement. Cells outside the map are solid.
+func (t *Tilemap) IsSolid(x, y int) bool {
+	if !t.InBounds(x, y) {
+		return true
+	}
+	return t.Collision[y*t.Width+x]
+}
+
+// SetSolid marks the cell as blocking or free.
+func (t *Tilemap) SetSolid(x, y int, solid bool) {
+	if t.InBounds(x, y) {
+		t.Collision[y*t.Width+x] = solid
+	}
+}
+
+// IsStandable reports whether the cell is free and rests on a solid cell.
+func (t *Tilemap) IsStandable(x, y int) bool {
+	return !t.IsSolid(x, y) && t.IsSolid(x, y+1)
+}
+
+// WorldToTile converts a world position to the cell containing it.
+func (t *Tilemap) WorldToTile(pos rl.Vector2) TilePoint {
+	return TilePoint{
+		X: int(pos.X / t.TileSize),
+		Y: int(pos.Y / t.TileSize),
+	}
+}
+
+// TileCenter returns the world position of the center of a cell.
+func (t *Tilemap) TileCenter(p TilePoint) rl.Vector2 {
+	return rl.NewVector2(
+		(float32(p.X)+0.5)*t.TileSize,
+		(float32(p.Y)+0.5)*t.TileSize,
+	)
+}
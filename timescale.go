@@ -0,0 +1,104 @@
+package main
+
+import (
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	frameStepToggleKey = rl.KeyF10
+	frameStepKey       = rl.KeyF11
+	maxStepsPerFrame   = 5
+)
+
+// TimeScale multiplies how fast simulation time passes.
+// Drawing and UI keep running at real speed.
+var TimeScale float32 = 1
+
+// TimeControl turns real frame time into fixed simulation ticks,
+// applying the time scale, temporary slow motion and frame stepping
+type TimeControl struct {
+	accumulator time.Duration
+	slowScale   float32
+	slowUntil   time.Time
+	StepMode    bool
+	stepPending bool
+	skipFrame   bool
+}
+
+var timeControl = &TimeControl{}
+
+// SlowMotion scales simulation speed by scale for the given real-time duration.
+// A new call replaces any slow motion already running.
+func SlowMotion(scale float32, duration time.Duration) {
+	timeControl.slowScale = scale
+	timeControl.slowUntil = time.Now().Add(duration)
+}
+
+// Scale returns the combined global and slow-motion scale.
+func (tc *TimeControl) Scale() float32 {
+	scale := TimeScale
+	if time.Now().Before(tc.slowUntil) {
+		scale *= tc.slowScale
+	}
+	return scale
+}
+
+// Reset drops any accumulated time and ignores the next frame's duration,
+// e.g. after the game sat paused in the background.
+func (tc *TimeControl) Reset() {
+	tc.accumulator = 0
+	tc.skipFrame = true
+}
+
+// Steps returns how many simulation ticks to run for a frame that took frameTime.
+func (tc *TimeControl) Steps(frameTime time.Duration) int {
+	if tc.skipFrame {
+		tc.skipFrame = false
+		return 0
+	}
+	if tc.StepMode {
+		if tc.stepPending {
+			tc.stepPending = false
+			return 1
+		}
+		return 0
+	}
+
+	tc.accumulator += time.Duration(float32(frameTime) * tc.Scale())
+	steps := 0
+	for tc.accumulator >= tickDuration && steps < maxStepsPerFrame {
+		tc.accumulator -= tickDuration
+		steps++
+	}
+	if steps == maxStepsPerFrame {
+		// Drop the backlog instead of spiralling after a long hitch
+		tc.accumulator = 0
+	}
+	return steps
+}
+
+// HandleTimeControls toggles frame-step mode and queues single steps.
+func HandleTimeControls() {
+	if inputContexts.KeyPressed(ContextGameplay, frameStepToggleKey) {
+		timeControl.StepMode = !timeControl.StepMode
+		timeControl.accumulator = 0
+	}
+	if timeControl.StepMode && inputContexts.KeyPressed(ContextGameplay, frameStepKey) {
+		timeControl.stepPending = true
+	}
+}
+
+// FrameTime returns the duration of the last rendered frame.
+func FrameTime() time.Duration {
+	return time.Duration(float64(rl.GetFrameTime()) * float64(time.Second))
+}
+
+// DrawTimeControls shows the frame-step indicator.
+func DrawTimeControls() {
+	if timeControl.StepMode {
+		pos := ui.Rect(UIRect{Anchor: AnchorTopRight, Offset: rl.NewVector2(20, 60), Size: rl.NewVector2(180, 30)})
+		rl.DrawText("STEP (F11)", int32(pos.X), int32(pos.Y), 30, rl.Yellow)
+	}
+}
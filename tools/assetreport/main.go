@@ -0,0 +1,143 @@
+// Command assetreport lists assets that can likely be trimmed: files in the
+// manifest that were never drawn in the recorded play sessions, images on
+// disk the manifest does not mention and were never drawn, and images whose
+// contents are identical.
+//
+// Record usage first by playing with -track-assets, which adds draw counts
+// to asset_usage.json on exit.
+//
+// Usage:
+//
+//	go run ./tools/assetreport -manifest assets/manifest.json -usage asset_usage.json
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+var imageExts = []string{".png", ".jpg", ".jpeg", ".gif", ".bmp", ".qoi"}
+
+func main() {
+	manifestPath := flag.String("manifest", "assets/manifest.json", "asset manifest")
+	usagePath := flag.String("usage", "asset_usage.json", "draw counts recorded with -track-assets")
+	dir := flag.String("dir", "assets", "asset folder to scan for images")
+	flag.Parse()
+
+	manifest, err := manifestPaths(*manifestPath)
+	if err != nil {
+		fail(err)
+	}
+	usage := make(map[string]int64)
+	data, err := os.ReadFile(*usagePath)
+	if err != nil {
+		fail(fmt.Errorf("%w (play with -track-assets first)", err))
+	}
+	if err := json.Unmarshal(data, &usage); err != nil {
+		fail(fmt.Errorf("%s: %w", *usagePath, err))
+	}
+
+	var images []string
+	err = filepath.WalkDir(*dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && slices.Contains(imageExts, strings.ToLower(filepath.Ext(path))) {
+			images = append(images, filepath.ToSlash(path))
+		}
+		return nil
+	})
+	if err != nil {
+		fail(err)
+	}
+	slices.Sort(images)
+
+	var neverDrawn []string
+	for _, path := range manifest {
+		if usage[path] == 0 {
+			neverDrawn = append(neverDrawn, path)
+		}
+	}
+	printSection("In the manifest but never drawn", neverDrawn)
+
+	var stray []string
+	for _, path := range images {
+		if !slices.Contains(manifest, path) && usage[path] == 0 {
+			stray = append(stray, path)
+		}
+	}
+	printSection("Not in the manifest and never drawn", stray)
+
+	byHash := make(map[string][]string)
+	for _, path := range images {
+		sum, err := hashFile(path)
+		if err != nil {
+			fail(err)
+		}
+		byHash[sum] = append(byHash[sum], path)
+	}
+	var duplicates []string
+	for _, paths := range byHash {
+		if len(paths) > 1 {
+			duplicates = append(duplicates, strings.Join(paths, " = "))
+		}
+	}
+	slices.Sort(duplicates)
+	printSection("Identical images", duplicates)
+}
+
+// manifestPaths returns every path listed in the manifest's groups, sorted.
+func manifestPaths(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var manifest struct {
+		Groups map[string][]struct {
+			Path string `json:"path"`
+		} `json:"groups"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	var paths []string
+	for _, entries := range manifest.Groups {
+		for _, e := range entries {
+			if !slices.Contains(paths, e.Path) {
+				paths = append(paths, e.Path)
+			}
+		}
+	}
+	slices.Sort(paths)
+	return paths, nil
+}
+
+func printSection(title string, lines []string) {
+	fmt.Printf("%s (%d)\n", title, len(lines))
+	for _, line := range lines {
+		fmt.Println("  " + line)
+	}
+	fmt.Println()
+}
+
+func hashFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "assetreport:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,71 @@
+// Command checksums fills in the sha256 field of every entry in the asset
+// manifest, so release builds can detect damaged or incomplete downloads.
+//
+// Usage:
+//
+//	go run ./tools/checksums -manifest assets/manifest.json
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	manifestPath := flag.String("manifest", "assets/manifest.json", "asset manifest to update")
+	flag.Parse()
+
+	data, err := os.ReadFile(*manifestPath)
+	if err != nil {
+		fail(err)
+	}
+
+	// Decode loosely so fields this tool doesn't know about survive the rewrite
+	var manifest map[string]any
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		fail(err)
+	}
+
+	groups, _ := manifest["groups"].(map[string]any)
+	for _, group := range groups {
+		entries, _ := group.([]any)
+		for _, e := range entries {
+			entry, ok := e.(map[string]any)
+			if !ok {
+				continue
+			}
+			path, _ := entry["path"].(string)
+			sum, err := hashFile(path)
+			if err != nil {
+				fail(err)
+			}
+			entry["sha256"] = sum
+		}
+	}
+
+	out, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		fail(err)
+	}
+	if err := os.WriteFile(*manifestPath, append(out, '\n'), 0o644); err != nil {
+		fail(err)
+	}
+}
+
+func hashFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "checksums:", err)
+	os.Exit(1)
+}
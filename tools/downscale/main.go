@@ -0,0 +1,98 @@
+// Command downscale pre-generates the resolution variants listed in the asset
+// manifest, so the game can load small textures on low resolution screens
+// instead of resizing large ones at runtime.
+//
+// Usage:
+//
+//	go run ./tools/downscale -manifest assets/manifest.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"raylibgo/imaging"
+)
+
+type variant struct {
+	ScreenHeight int    `json:"screenHeight"`
+	Path         string `json:"path"`
+}
+
+type entry struct {
+	Path     string    `json:"path"`
+	Width    int       `json:"width"`
+	Height   int       `json:"height"`
+	Filter   string    `json:"filter"`
+	Variants []variant `json:"variants"`
+}
+
+type manifest struct {
+	Groups map[string][]entry `json:"groups"`
+}
+
+func main() {
+	manifestPath := flag.String("manifest", "assets/manifest.json", "asset manifest to read")
+	baseHeight := flag.Int("base", 1080, "screen height the manifest sizes are authored for")
+	flag.Parse()
+
+	data, err := os.ReadFile(*manifestPath)
+	if err != nil {
+		fail(err)
+	}
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		fail(err)
+	}
+
+	for _, group := range m.Groups {
+		for _, e := range group {
+			if len(e.Variants) == 0 {
+				continue
+			}
+			if err := generate(e, *baseHeight); err != nil {
+				fail(fmt.Errorf("%s: %w", e.Path, err))
+			}
+		}
+	}
+}
+
+func generate(e entry, baseHeight int) error {
+	file, err := os.Open(e.Path)
+	if err != nil {
+		return err
+	}
+	src, err := imaging.Decode(file)
+	file.Close()
+	if err != nil {
+		return err
+	}
+
+	w, h := e.Width, e.Height
+	if w <= 0 || h <= 0 {
+		w, h = src.Rect.Dx(), src.Rect.Dy()
+	}
+
+	filter := imaging.ParseFilter(e.Filter)
+	if e.Filter == "" {
+		// Offline there's time for the best kernel
+		filter = imaging.Lanczos
+	}
+
+	for _, v := range e.Variants {
+		vw := max(1, w*v.ScreenHeight/baseHeight)
+		vh := max(1, h*v.ScreenHeight/baseHeight)
+		if err := imaging.SavePNG(v.Path, imaging.Resize(src, vw, vh, filter)); err != nil {
+			return err
+		}
+		fmt.Printf("%s -> %s (%dx%d)\n", e.Path, v.Path, vw, vh)
+	}
+	return nil
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "downscale:", err)
+	os.Exit(1)
+}
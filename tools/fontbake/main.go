@@ -0,0 +1,111 @@
+// Command fontbake rasterizes the glyphs used by the localization tables
+// into BMFont atlases, so the game loads a fixed set of glyphs instead of
+// rasterizing fonts at runtime.
+//
+// Usage:
+//
+//	go run ./tools/fontbake -font assets/fonts/main.ttf -sizes 20,32
+//
+// For each size it writes <name>_<size>.fnt and <name>_<size>.png to -out,
+// which FontManager picks up in place of the TTF.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"raylibgo/i18n"
+)
+
+const atlasPadding = 2
+
+func main() {
+	fontPath := flag.String("font", "", "TTF or OTF font to bake")
+	locales := flag.String("locales", "assets/locales", "directory of localization tables")
+	sizes := flag.String("sizes", "20,32", "comma separated pixel sizes")
+	out := flag.String("out", "assets/fonts/baked", "output directory")
+	flag.Parse()
+
+	if *fontPath == "" {
+		fail(fmt.Errorf("-font is required"))
+	}
+	data, err := os.ReadFile(*fontPath)
+	if err != nil {
+		fail(err)
+	}
+	tables, err := i18n.LoadDir(*locales)
+	if err != nil {
+		fail(err)
+	}
+
+	all := make([]i18n.Table, 0, len(tables)+1)
+	ascii := i18n.Table{}
+	for r := ' '; r <= '~'; r++ {
+		ascii[string(r)] = string(r)
+	}
+	all = append(all, ascii)
+	for _, t := range tables {
+		all = append(all, t)
+	}
+	codepoints := i18n.Codepoints(all...)
+
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		fail(err)
+	}
+	name := strings.TrimSuffix(filepath.Base(*fontPath), filepath.Ext(*fontPath))
+	for _, s := range strings.Split(*sizes, ",") {
+		size, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil || size <= 0 {
+			fail(fmt.Errorf("invalid size %q", s))
+		}
+		if err := bake(data, name, int32(size), codepoints, *out); err != nil {
+			fail(err)
+		}
+	}
+	fmt.Printf("baked %d glyphs from %d tables\n", len(codepoints), len(tables))
+}
+
+func bake(data []byte, name string, size int32, codepoints []rune, out string) error {
+	glyphs := rl.LoadFontData(data, size, codepoints, int32(len(codepoints)), rl.FontDefault)
+	defer rl.UnloadFontData(glyphs)
+
+	// raylib allocates the rectangle array and stores it in recs[0]
+	recs := []*rl.Rectangle{nil}
+	atlas := rl.GenImageFontAtlas(glyphs, recs, size, atlasPadding, 0)
+	defer rl.UnloadImage(&atlas)
+	rects := unsafe.Slice(recs[0], len(glyphs))
+
+	base := fmt.Sprintf("%s_%d", name, size)
+	if !rl.ExportImage(atlas, filepath.Join(out, base+".png")) {
+		return fmt.Errorf("export %s.png failed", base)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "info face=%q size=%d unicode=1 padding=0,0,0,0 spacing=0,0\n", name, size)
+	fmt.Fprintf(&b, "common lineHeight=%d base=%d scaleW=%d scaleH=%d pages=1 packed=0\n", size, size, atlas.Width, atlas.Height)
+	fmt.Fprintf(&b, "page id=0 file=\"%s.png\"\n", base)
+	fmt.Fprintf(&b, "chars count=%d\n", len(glyphs))
+	for i, g := range glyphs {
+		r := rects[i]
+		fmt.Fprintf(&b, "char id=%d x=%d y=%d width=%d height=%d xoffset=%d yoffset=%d xadvance=%d page=0 chnl=15\n",
+			g.Value, int(r.X), int(r.Y), int(r.Width), int(r.Height), g.OffsetX, g.OffsetY, g.AdvanceX)
+	}
+	path := filepath.Join(out, base+".fnt")
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("%s: %dx%d atlas\n", path, atlas.Width, atlas.Height)
+	return nil
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "fontbake:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,65 @@
+// Command levelgen writes a procedurally generated level file. The movement
+// flags default to the game's gravity, jump force and run speed.
+//
+// Usage:
+//
+//	go run ./tools/levelgen -seed 42 -out assets/levels/gen42.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"raylibgo/level"
+	"raylibgo/procgen"
+)
+
+func main() {
+	seed := flag.Uint64("seed", 1, "generation seed")
+	width := flag.Int("width", 120, "level width in tiles")
+	height := flag.Int("height", 34, "level height in tiles")
+	tileSize := flag.Float64("tile", 32, "tile size in pixels")
+	terrain := flag.Int("terrain", 1, "terrain id painted for ground")
+	gravity := flag.Float64("gravity", 0.5, "gravity per tick")
+	jump := flag.Float64("jump", -12, "jump force")
+	speed := flag.Float64("speed", 5, "run speed per tick")
+	exitTo := flag.String("exit", "", "level the far exit leads to")
+	out := flag.String("out", "", "output file (default stdout)")
+	flag.Parse()
+
+	f, err := procgen.Generate(procgen.Config{
+		Seed:     *seed,
+		Width:    *width,
+		Height:   *height,
+		TileSize: float32(*tileSize),
+		Terrain:  *terrain,
+		ExitTo:   *exitTo,
+		Move: procgen.Movement{
+			Gravity:   float32(*gravity),
+			JumpForce: float32(*jump),
+			Speed:     float32(*speed),
+		},
+	})
+	if err != nil {
+		fail(err)
+	}
+
+	w := os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			fail(err)
+		}
+		defer file.Close()
+		w = file
+	}
+	if err := level.Write(w, f); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "levelgen:", err)
+	os.Exit(1)
+}
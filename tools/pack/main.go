@@ -0,0 +1,70 @@
+// Command pack bundles a directory of assets into a pack file, optionally
+// encrypted. The same key must be built into the game with
+//
+//	go build -ldflags "-X main.packKey=<64 hex chars>"
+//
+// Usage:
+//
+//	go run ./tools/pack -out assets.pak -key <64 hex chars> assets
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"raylibgo/pack"
+)
+
+func main() {
+	out := flag.String("out", "assets.pak", "pack file to write")
+	keyHex := flag.String("key", "", "hex AES-256 key; empty writes an unencrypted pack")
+	flag.Parse()
+
+	var key []byte
+	if *keyHex != "" {
+		var err error
+		if key, err = hex.DecodeString(*keyHex); err != nil {
+			fail(err)
+		}
+	}
+
+	var files []pack.File
+	for _, dir := range flag.Args() {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			files = append(files, pack.File{Name: filepath.ToSlash(path), Data: data})
+			return nil
+		})
+		if err != nil {
+			fail(err)
+		}
+	}
+
+	file, err := os.Create(*out)
+	if err != nil {
+		fail(err)
+	}
+	if err := pack.Write(file, files, key); err != nil {
+		file.Close()
+		fail(err)
+	}
+	if err := file.Close(); err != nil {
+		fail(err)
+	}
+	fmt.Printf("packed %d files into %s\n", len(files), *out)
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "pack:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	tooltipFontSize   = 20
+	tooltipWidth      = 420
+	tooltipPadding    = 12
+	tooltipGap        = 8
+	tooltipHoverDelay = 500 * time.Millisecond
+	tooltipFocusDelay = 250 * time.Millisecond
+
+	// tooltipFocusRange is how close a world object must be to the player to
+	// get focus when playing without a mouse
+	tooltipFocusRange = 160
+)
+
+// TooltipTrigger is how a target got the player's attention
+type TooltipTrigger int
+
+const (
+	TooltipHover TooltipTrigger = iota // under the mouse
+	TooltipFocus                       // selected with the keyboard or a gamepad
+)
+
+// Tooltips shows one tooltip at a time for whatever is hovered or focused.
+// Targets request it every frame they have attention; it appears after a
+// short delay, and disappears the first frame nobody asks for it.
+type Tooltips struct {
+	id        string
+	text      string
+	anchor    rl.Rectangle
+	trigger   TooltipTrigger
+	since     time.Time
+	requested bool
+	font      *Font
+}
+
+var tooltips = &Tooltips{}
+
+// Request asks for text, which may contain rich text markup, to be shown
+// next to anchor, a rectangle in screen coordinates. id identifies the
+// target so the delay restarts when attention moves elsewhere.
+func (t *Tooltips) Request(id, text string, anchor rl.Rectangle, trigger TooltipTrigger) {
+	if text == "" {
+		return
+	}
+	if id != t.id || trigger != t.trigger {
+		t.id = id
+		t.trigger = trigger
+		t.since = time.Now()
+	}
+	t.text = text
+	t.anchor = anchor
+	t.requested = true
+}
+
+// RequestWorld is Request for a target given in world coordinates.
+func (t *Tooltips) RequestWorld(id, text string, bounds rl.Rectangle, trigger TooltipTrigger) {
+	view := camera.View()
+	topLeft := rl.GetWorldToScreen2D(rl.NewVector2(bounds.X, bounds.Y), view)
+	bottomRight := rl.GetWorldToScreen2D(rl.NewVector2(bounds.X+bounds.Width, bounds.Y+bounds.Height), view)
+	t.Request(id, text, rl.NewRectangle(topLeft.X, topLeft.Y, bottomRight.X-topLeft.X, bottomRight.Y-topLeft.Y), trigger)
+}
+
+// Visible reports whether a tooltip has been requested long enough to show.
+func (t *Tooltips) Visible() bool {
+	delay := tooltipHoverDelay
+	if t.trigger == TooltipFocus {
+		delay = tooltipFocusDelay
+	}
+	return t.requested && time.Since(t.since) >= delay
+}
+
+// Draw renders the tooltip on top of the frame and clears this frame's
+// request.
+func (t *Tooltips) Draw() {
+	if !t.requested {
+		t.id = ""
+		return
+	}
+	defer func() { t.requested = false }()
+	if !t.Visible() {
+		return
+	}
+	if t.font == nil {
+		t.font = fonts.Acquire("", tooltipFontSize)
+	}
+
+	wrap := tooltipWidth * ui.Scale()
+	size := MeasureRichText(t.font, t.text, tooltipFontSize, wrap)
+	panel := placeTooltip(rl.NewVector2(size.X+tooltipPadding*2, size.Y+tooltipPadding*2), t.anchor, ui.SafeArea())
+	if !DrawNinePatch(SkinPanel, panel, rl.White) {
+		DrawRoundedRect(panel, 8, rl.Fade(rl.Black, 0.85))
+	}
+	DrawRichText(t.font, t.text, rl.NewVector2(panel.X+tooltipPadding, panel.Y+tooltipPadding), tooltipFontSize, wrap, rl.LightGray)
+}
+
+// placeTooltip puts a panel of the given size below anchor, or above it
+// when there is no room below, then slides it to stay inside bounds.
+func placeTooltip(size rl.Vector2, anchor, bounds rl.Rectangle) rl.Rectangle {
+	x := anchor.X + (anchor.Width-size.X)/2
+	y := anchor.Y + anchor.Height + tooltipGap
+	if y+size.Y > bounds.Y+bounds.Height {
+		if above := anchor.Y - tooltipGap - size.Y; above >= bounds.Y {
+			y = above
+		} else {
+			y = bounds.Y + bounds.Height - size.Y
+		}
+	}
+	x = rl.Clamp(x, bounds.X, max(bounds.X+bounds.Width-size.X, bounds.X))
+	y = max(y, bounds.Y)
+	return rl.NewRectangle(x, y, size.X, size.Y)
+}
+
+// worldTooltipTarget is something in the level that can show a tooltip
+type worldTooltipTarget struct {
+	id     string
+	text   string
+	bounds rl.Rectangle
+}
+
+func worldTooltipTargets() []worldTooltipTarget {
+	var targets []worldTooltipTarget
+	for i, p := range pickups {
+		if !p.Taken {
+			bounds := rl.NewRectangle(p.Pos.X-pickupRadius, p.Pos.Y-pickupRadius, pickupRadius*2, pickupRadius*2)
+			targets = append(targets, worldTooltipTarget{fmt.Sprintf("pickup:%d", i), fmt.Sprintf("[color=gold]%s[/color]", p.Name), bounds})
+		}
+	}
+	for i, e := range enemies {
+		if !e.Defeated {
+			text := fmt.Sprintf("[b]%s[/b]\n%s %d/%d", e.Name, T("tooltip.health"), e.Health, e.MaxHealth)
+			targets = append(targets, worldTooltipTarget{fmt.Sprintf("enemy:%d", i), text, e.Hurtbox()})
+		}
+	}
+	return targets
+}
+
+// UpdateWorldTooltips describes the pickup or enemy under the mouse. Without
+// a mouse, the nearest one in range of the player has focus instead.
+func UpdateWorldTooltips() {
+	targets := worldTooltipTargets()
+	if activeDevice == DeviceKeyboard {
+		mouse := rl.GetScreenToWorld2D(rl.GetMousePosition(), camera.View())
+		for _, target := range targets {
+			if rl.CheckCollisionPointRec(mouse, target.bounds) {
+				tooltips.RequestWorld(target.id, target.text, target.bounds, TooltipHover)
+				return
+			}
+		}
+		return
+	}
+
+	body := PlayerBounds()
+	center := rl.NewVector2(body.X+body.Width/2, body.Y+body.Height/2)
+	var nearest *worldTooltipTarget
+	best := float32(tooltipFocusRange)
+	for i, target := range targets {
+		c := rl.NewVector2(target.bounds.X+target.bounds.Width/2, target.bounds.Y+target.bounds.Height/2)
+		if d := rl.Vector2Distance(center, c); d < best {
+			best = d
+			nearest = &targets[i]
+		}
+	}
+	if nearest != nil {
+		tooltips.RequestWorld(nearest.id, nearest.text, nearest.bounds, TooltipFocus)
+	}
+}
+
+func init() {
+	// Tooltips draw over the HUD and menus but under the cursor
+	RegisterSystem(&SystemFuncs{ID: "tooltips", Requires: []string{"debug"}, OnDraw: tooltips.Draw})
+}
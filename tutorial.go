@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	tutorialDefsPath = "assets/tutorials.json"
+
+	tutorialDuration = 5 * time.Second
+	tutorialFontSize = 28
+	tutorialWidth    = 720
+)
+
+// TutorialDef is a prompt shown the first time the player enters Area.
+// Text may be a locale key and may contain rich text markup, including
+// "[Action]" glyphs. The
+// prompt closes once Dismiss (an action name) is pressed or after DurationMs.
+type TutorialDef struct {
+	ID         string    `json:"id"`
+	Text       string    `json:"text"`
+	Area       WorldRect `json:"area"`
+	Dismiss    string    `json:"dismiss,omitempty"`
+	DurationMs int       `json:"durationMs,omitempty"`
+}
+
+// WorldRect is a rectangle in world coordinates as written in data files
+type WorldRect struct {
+	X float32 `json:"x"`
+	Y float32 `json:"y"`
+	W float32 `json:"w"`
+	H float32 `json:"h"`
+}
+
+// Rect converts to a raylib rectangle.
+func (r WorldRect) Rect() rl.Rectangle {
+	return rl.NewRectangle(r.X, r.Y, r.W, r.H)
+}
+
+// Tutorials shows contextual prompts and remembers which were already seen
+type Tutorials struct {
+	defs   []TutorialDef
+	shown  map[string]bool
+	active *TutorialDef
+	expire *Timer
+	font   *Font
+}
+
+var tutorials = &Tutorials{shown: make(map[string]bool)}
+
+// LoadTutorialDefs reads a JSON array of tutorial prompts.
+func LoadTutorialDefs(path string) ([]TutorialDef, error) {
+	data, err := ReadAsset(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var defs []TutorialDef
+	if err := json.Unmarshal(data, &defs); err != nil {
+		return nil, fmt.Errorf("tutorials %s: %w", path, err)
+	}
+	return defs, nil
+}
+
+// SetDefs replaces the prompt definitions, closing the active prompt.
+func (t *Tutorials) SetDefs(defs []TutorialDef) {
+	t.defs = defs
+	t.close()
+}
+
+// close hides the active prompt.
+func (t *Tutorials) close() {
+	t.active = nil
+	t.expire.Cancel()
+	t.expire = nil
+}
+
+// Update opens the first unseen prompt whose area the player is in and
+// closes the active one when it is dismissed or expires. A prompt counts as
+// seen as soon as it opens.
+func (t *Tutorials) Update() {
+	if t.active != nil {
+		if action, ok := actionNames[t.active.Dismiss]; ok && input.IsPressed(action) {
+			t.close()
+		}
+		return
+	}
+
+	body := PlayerBounds()
+	for i := range t.defs {
+		def := &t.defs[i]
+		if !t.shown[def.ID] && rl.CheckCollisionRecs(body, def.Area.Rect()) {
+			t.shown[def.ID] = true
+			t.active = def
+			duration := tutorialDuration
+			if def.DurationMs > 0 {
+				duration = time.Duration(def.DurationMs) * time.Millisecond
+			}
+			t.expire = scheduler.After(duration, t.close)
+			events.Publish(Event{Type: EventTextShown, Target: T(def.Text)})
+			return
+		}
+	}
+}
+
+// Shown returns the IDs of prompts already seen, sorted, for saving.
+func (t *Tutorials) Shown() []string {
+	ids := make([]string, 0, len(t.shown))
+	for id := range t.shown {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// Restore replaces the seen prompts with saved state.
+func (t *Tutorials) Restore(ids []string) {
+	t.shown = make(map[string]bool, len(ids))
+	for _, id := range ids {
+		t.shown[id] = true
+	}
+	t.close()
+}
+
+// Draw renders the active prompt above the bottom edge of the screen.
+func (t *Tutorials) Draw() {
+	if t.active == nil {
+		return
+	}
+	if t.font == nil {
+		t.font = fonts.Acquire("", tutorialFontSize)
+	}
+
+	text := T(t.active.Text)
+	wrap := tutorialWidth * ui.Scale()
+	size := MeasureRichText(t.font, text, tutorialFontSize, wrap)
+	panel := ui.Rect(UIRect{Anchor: AnchorBottom, Offset: rl.NewVector2(0, 120), Size: rl.NewVector2(size.X+48, size.Y+24)})
+	if !DrawNinePatch(SkinDialogue, panel, rl.White) {
+		rl.DrawRectangleRounded(panel, 0.3, 8, rl.Fade(rl.Black, 0.75))
+	}
+	DrawRichText(t.font, text, rl.NewVector2(panel.X+24, panel.Y+12), tutorialFontSize, wrap, rl.RayWhite)
+}
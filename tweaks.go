@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	tweakPanelKey = rl.KeyF8
+	tuningPath    = "tuning.json"
+
+	tweakRowHeight   = 34
+	tweakPanelWidth  = 460
+	tweakSliderWidth = 220
+)
+
+// Tweak is a named gameplay value that can be adjusted at runtime
+type Tweak struct {
+	Name     string
+	Min, Max float32
+	Default  float32
+	Get      func() float32
+	Set      func(float32)
+}
+
+// TweakPanel lists registered tweaks as sliders and saves them to a tuning file
+type TweakPanel struct {
+	Visible  bool
+	tweaks   []*Tweak
+	dragging *Tweak
+	status   string
+}
+
+var tweaks = &TweakPanel{}
+
+// Register adds a tweak, remembering its current value as the default.
+func (p *TweakPanel) Register(t *Tweak) {
+	t.Default = t.Get()
+	p.tweaks = append(p.tweaks, t)
+}
+
+// TweakFloat registers a float32 variable.
+func TweakFloat(name string, v *float32, minValue, maxValue float32) {
+	tweaks.Register(&Tweak{
+		Name: name, Min: minValue, Max: maxValue,
+		Get: func() float32 { return *v },
+		Set: func(f float32) { *v = f },
+	})
+}
+
+// TweakDuration registers a duration edited in milliseconds.
+func TweakDuration(name string, d *time.Duration, minValue, maxValue time.Duration) {
+	tweaks.Register(&Tweak{
+		Name: name, Min: float32(ms(minValue)), Max: float32(ms(maxValue)),
+		Get: func() float32 { return float32(ms(*d)) },
+		Set: func(f float32) { *d = time.Duration(f * float32(time.Millisecond)) },
+	})
+}
+
+// RegisterTweaks exposes the movement and animation constants to the panel.
+func RegisterTweaks() {
+	TweakFloat("gravity", &gravity, 0.05, 2)
+	TweakFloat("jumpForce", &jumpForce, -30, -2)
+	TweakFloat("player.Speed", &player.Speed, 1, 20)
+	TweakDuration("stand.FrameDelay", &player.Stand.FrameDelay, 10*time.Millisecond, 500*time.Millisecond)
+	TweakDuration("hit.FrameDelay", &player.Hit.FrameDelay, 10*time.Millisecond, 500*time.Millisecond)
+	TweakDuration("move.FrameDelay", &player.Move.FrameDelay, 10*time.Millisecond, 500*time.Millisecond)
+}
+
+// LoadTuning applies values saved in path. Unknown names are ignored.
+func (p *TweakPanel) LoadTuning(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var values map[string]float32
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("tuning %s: %w", path, err)
+	}
+	for _, t := range p.tweaks {
+		if v, ok := values[t.Name]; ok {
+			t.Set(min(max(v, t.Min), t.Max))
+		}
+	}
+	return nil
+}
+
+// SaveTuning writes every value that differs from its default to path.
+func (p *TweakPanel) SaveTuning(path string) error {
+	values := make(map[string]float32)
+	for _, t := range p.tweaks {
+		if v := t.Get(); v != t.Default {
+			values[t.Name] = v
+		}
+	}
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func (p *TweakPanel) bounds() rl.Rectangle {
+	height := float32(tweakRowHeight*(len(p.tweaks)+1) + 50)
+	return ui.Rect(UIRect{Anchor: AnchorRight, Offset: rl.NewVector2(20, 0), Size: rl.NewVector2(tweakPanelWidth, height)})
+}
+
+func (p *TweakPanel) sliderRect(i int) rl.Rectangle {
+	b := p.bounds()
+	y := b.Y + 44 + float32(i*tweakRowHeight)
+	return rl.NewRectangle(b.X+b.Width-tweakSliderWidth-70, y+8, tweakSliderWidth, 12)
+}
+
+func (p *TweakPanel) buttonRect(i int) rl.Rectangle {
+	b := p.bounds()
+	return rl.NewRectangle(b.X+12+float32(i)*110, b.Y+b.Height-40, 100, 28)
+}
+
+// HandleTweakPanel toggles the panel and handles slider drags and its buttons.
+func HandleTweakPanel() {
+	if rl.IsKeyPressed(tweakPanelKey) {
+		tweaks.Visible = !tweaks.Visible
+	}
+	if !tweaks.Visible {
+		tweaks.dragging = nil
+		return
+	}
+
+	mouse := rl.GetMousePosition()
+	if rl.IsMouseButtonPressed(rl.MouseButtonLeft) {
+		for i, t := range tweaks.tweaks {
+			r := tweaks.sliderRect(i)
+			r.Y -= 8
+			r.Height += 16
+			if rl.CheckCollisionPointRec(mouse, r) {
+				tweaks.dragging = t
+			}
+		}
+		switch {
+		case rl.CheckCollisionPointRec(mouse, tweaks.buttonRect(0)):
+			if err := tweaks.SaveTuning(tuningPath); err != nil {
+				log.Printf("tweaks: %v", err)
+				tweaks.status = "Save failed"
+			} else {
+				tweaks.status = "Saved to " + tuningPath
+			}
+		case rl.CheckCollisionPointRec(mouse, tweaks.buttonRect(1)):
+			for _, t := range tweaks.tweaks {
+				t.Set(t.Default)
+			}
+			tweaks.status = "Reset to defaults"
+		}
+	}
+	if rl.IsMouseButtonReleased(rl.MouseButtonLeft) {
+		tweaks.dragging = nil
+	}
+
+	if t := tweaks.dragging; t != nil {
+		for i, other := range tweaks.tweaks {
+			if other != t {
+				continue
+			}
+			r := tweaks.sliderRect(i)
+			frac := min(max((mouse.X-r.X)/r.Width, 0), 1)
+			t.Set(t.Min + frac*(t.Max-t.Min))
+		}
+	}
+}
+
+// Draw renders the panel with one slider per tweak.
+func (p *TweakPanel) Draw() {
+	if !p.Visible {
+		return
+	}
+
+	b := p.bounds()
+	rl.DrawRectangleRec(b, rl.Fade(rl.Black, 0.75))
+	rl.DrawText("Tweaks (F8)", int32(b.X)+12, int32(b.Y)+10, 20, rl.Yellow)
+
+	for i, t := range p.tweaks {
+		r := p.sliderRect(i)
+		v := t.Get()
+		frac := (v - t.Min) / (t.Max - t.Min)
+		rl.DrawText(t.Name, int32(b.X)+12, int32(r.Y)-2, 16, rl.RayWhite)
+		rl.DrawRectangleRec(r, rl.DarkGray)
+		rl.DrawRectangleRec(rl.NewRectangle(r.X, r.Y, r.Width*frac, r.Height), rl.SkyBlue)
+		rl.DrawCircle(int32(r.X+r.Width*frac), int32(r.Y+r.Height/2), 8, rl.RayWhite)
+		rl.DrawText(fmt.Sprintf("%.2f", v), int32(r.X+r.Width)+10, int32(r.Y)-2, 16, rl.RayWhite)
+	}
+
+	for i, label := range []string{"Save", "Reset"} {
+		r := p.buttonRect(i)
+		color := rl.Gray
+		if rl.CheckCollisionPointRec(rl.GetMousePosition(), r) {
+			color = rl.LightGray
+		}
+		rl.DrawRectangleRec(r, color)
+		rl.DrawText(label, int32(r.X)+10, int32(r.Y)+5, 18, rl.Black)
+	}
+	if p.status != "" {
+		rl.DrawText(p.status, int32(b.X)+240, int32(b.Y+b.Height)-34, 16, rl.Gray)
+	}
+}
+
+func init() {
+	RegisterSystem(&SystemFuncs{ID: "tweaks", Requires: []string{"debug"}, OnUpdate: HandleTweakPanel, OnDraw: tweaks.Draw})
+}
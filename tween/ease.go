@@ -0,0 +1,126 @@
+package tween
+
+import "math"
+
+// Ease maps the progress of a tween, 0 to 1, to how far along its value
+// is. Most start at 0 and end at 1; back and elastic eases overshoot in
+// between.
+type Ease func(t float32) float32
+
+func Linear(t float32) float32 { return t }
+
+func InQuad(t float32) float32  { return t * t }
+func OutQuad(t float32) float32 { return t * (2 - t) }
+func InOutQuad(t float32) float32 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return 1 - 2*(1-t)*(1-t)
+}
+
+func InCubic(t float32) float32  { return t * t * t }
+func OutCubic(t float32) float32 { return 1 - InCubic(1-t) }
+func InOutCubic(t float32) float32 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	return 1 - 4*(1-t)*(1-t)*(1-t)
+}
+
+func InSine(t float32) float32    { return 1 - float32(math.Cos(float64(t)*math.Pi/2)) }
+func OutSine(t float32) float32   { return float32(math.Sin(float64(t) * math.Pi / 2)) }
+func InOutSine(t float32) float32 { return (1 - float32(math.Cos(float64(t)*math.Pi))) / 2 }
+
+func InExpo(t float32) float32 {
+	if t <= 0 {
+		return 0
+	}
+	return float32(math.Pow(2, 10*float64(t)-10))
+}
+func OutExpo(t float32) float32 { return 1 - InExpo(1-t) }
+func InOutExpo(t float32) float32 {
+	if t < 0.5 {
+		return InExpo(2*t) / 2
+	}
+	return 1 - InExpo(2-2*t)/2
+}
+
+// backOvershoot is how far back eases pull past their ends
+const backOvershoot = 1.70158
+
+func InBack(t float32) float32  { return t * t * ((backOvershoot+1)*t - backOvershoot) }
+func OutBack(t float32) float32 { return 1 - InBack(1-t) }
+func InOutBack(t float32) float32 {
+	if t < 0.5 {
+		return InBack(2*t) / 2
+	}
+	return 1 - InBack(2-2*t)/2
+}
+
+func OutElastic(t float32) float32 {
+	if t <= 0 || t >= 1 {
+		return t
+	}
+	return float32(math.Pow(2, -10*float64(t))*math.Sin((float64(t)*10-0.75)*2*math.Pi/3)) + 1
+}
+func InElastic(t float32) float32 { return 1 - OutElastic(1-t) }
+
+func OutBounce(t float32) float32 {
+	const n, d = 7.5625, 2.75
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + 0.75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + 0.9375
+	default:
+		t -= 2.625 / d
+		return n*t*t + 0.984375
+	}
+}
+func InBounce(t float32) float32 { return 1 - OutBounce(1-t) }
+
+// Hold keeps the start value until the very end, then jumps, for cuts
+func Hold(t float32) float32 {
+	if t < 1 {
+		return 0
+	}
+	return 1
+}
+
+// eases are the eases by the names data files use
+var eases = map[string]Ease{
+	"linear":     Linear,
+	"inQuad":     InQuad,
+	"outQuad":    OutQuad,
+	"inOutQuad":  InOutQuad,
+	"inCubic":    InCubic,
+	"outCubic":   OutCubic,
+	"inOutCubic": InOutCubic,
+	"inSine":     InSine,
+	"outSine":    OutSine,
+	"inOutSine":  InOutSine,
+	"inExpo":     InExpo,
+	"outExpo":    OutExpo,
+	"inOutExpo":  InOutExpo,
+	"inBack":     InBack,
+	"outBack":    OutBack,
+	"inOutBack":  InOutBack,
+	"inElastic":  InElastic,
+	"outElastic": OutElastic,
+	"inBounce":   InBounce,
+	"outBounce":  OutBounce,
+	"hold":       Hold,
+}
+
+// ByName returns the ease called name, such as "inOutQuad", or Linear for
+// an empty or unknown name.
+func ByName(name string) Ease {
+	if e, ok := eases[name]; ok {
+		return e
+	}
+	return Linear
+}
@@ -0,0 +1,211 @@
+// Package tween animates values toward targets over time with easing. It
+// has no raylib dependency; callers pass a lerp for their own types and
+// advance tweens with whatever clock suits them, real frame time for UI
+// and simulation ticks for gameplay.
+package tween
+
+import "time"
+
+// Tween moves a value from wherever it is when the tween starts to a
+// target over a duration, after an optional delay. Tweens chained with
+// Then play one after another, each starting where the last left off.
+type Tween struct {
+	duration time.Duration
+	delay    time.Duration
+	ease     Ease
+	// begin captures the start value; set applies eased progress
+	begin func()
+	set   func(t float32)
+
+	onStart func()
+	onDone  func()
+
+	elapsed time.Duration
+	started bool
+	next    *Tween
+	// cur is the playing link of the chain this tween heads, nil once the
+	// chain has ended
+	cur *Tween
+}
+
+// Lerp blends from a to b by t, where t may leave 0 to 1 when an ease
+// overshoots.
+type Lerp[T any] func(a, b T, t float32) T
+
+// To tweens *target to to over d, blending with lerp.
+func To[T any](target *T, to T, d time.Duration, lerp Lerp[T]) *Tween {
+	var from T
+	t := &Tween{duration: d, ease: Linear}
+	t.begin = func() { from = *target }
+	t.set = func(p float32) { *target = lerp(from, to, p) }
+	t.cur = t
+	return t
+}
+
+// Float tweens *target to to over d.
+func Float(target *float32, to float32, d time.Duration) *Tween {
+	return To(target, to, d, func(a, b, t float32) float32 { return a + (b-a)*t })
+}
+
+// Wait does nothing for d, for pauses in a chain.
+func Wait(d time.Duration) *Tween {
+	t := &Tween{duration: d, ease: Linear}
+	t.cur = t
+	return t
+}
+
+// Ease sets how the value moves; tweens start out Linear.
+func (t *Tween) Ease(e Ease) *Tween {
+	t.ease = e
+	return t
+}
+
+// Delay waits d before starting.
+func (t *Tween) Delay(d time.Duration) *Tween {
+	t.delay = d
+	return t
+}
+
+// OnStart runs fn when the tween starts, after its delay.
+func (t *Tween) OnStart(fn func()) *Tween {
+	t.onStart = fn
+	return t
+}
+
+// OnDone runs fn when the tween reaches its target.
+func (t *Tween) OnDone(fn func()) *Tween {
+	t.onDone = fn
+	return t
+}
+
+// Then plays next, and whatever is chained to it, after the end of this
+// tween's chain. It returns t so chains read in order:
+// a.Then(b).Then(c).
+func (t *Tween) Then(next *Tween) *Tween {
+	last := t
+	for last.next != nil {
+		last = last.next
+	}
+	last.next = next
+	return t
+}
+
+// Update advances the chain by dt. It reports whether the chain is still
+// playing. A nil tween is never playing.
+func (t *Tween) Update(dt time.Duration) bool {
+	if t == nil {
+		return false
+	}
+	for t.cur != nil {
+		left, done := t.cur.step(dt)
+		if !done {
+			return true
+		}
+		t.cur = t.cur.next
+		dt = left
+	}
+	return false
+}
+
+// step advances one link, returning the time left over once it is done.
+func (t *Tween) step(dt time.Duration) (time.Duration, bool) {
+	t.elapsed += dt
+	if t.elapsed < t.delay {
+		return 0, false
+	}
+	t.start()
+	run := t.elapsed - t.delay
+	if run < t.duration {
+		if t.set != nil {
+			t.set(t.ease(float32(run) / float32(t.duration)))
+		}
+		return 0, false
+	}
+	t.end()
+	return run - t.duration, true
+}
+
+func (t *Tween) start() {
+	if t.started {
+		return
+	}
+	t.started = true
+	if t.begin != nil {
+		t.begin()
+	}
+	if t.onStart != nil {
+		t.onStart()
+	}
+}
+
+func (t *Tween) end() {
+	if t.set != nil {
+		t.set(t.ease(1))
+	}
+	if t.onDone != nil {
+		t.onDone()
+	}
+}
+
+// Playing reports whether the chain has yet to end.
+func (t *Tween) Playing() bool {
+	return t != nil && t.cur != nil
+}
+
+// Finish jumps the rest of the chain to its end, running every callback
+// on the way.
+func (t *Tween) Finish() {
+	if t == nil {
+		return
+	}
+	for t.cur != nil {
+		t.cur.start()
+		t.cur.end()
+		t.cur = t.cur.next
+	}
+}
+
+// Stop abandons the chain where it is, without running its callbacks.
+func (t *Tween) Stop() {
+	if t != nil {
+		t.cur = nil
+	}
+}
+
+// Group plays many tweens side by side, dropping each once its chain has
+// ended.
+type Group struct {
+	tweens []*Tween
+}
+
+// Add starts playing t in the group and returns it.
+func (g *Group) Add(t *Tween) *Tween {
+	g.tweens = append(g.tweens, t)
+	return t
+}
+
+// Update advances every tween by dt. Tweens added by callbacks meanwhile
+// start with the next update.
+func (g *Group) Update(dt time.Duration) {
+	n, kept := len(g.tweens), 0
+	for i := range n {
+		if t := g.tweens[i]; t.Update(dt) {
+			g.tweens[kept] = t
+			kept++
+		}
+	}
+	kept += copy(g.tweens[kept:], g.tweens[n:])
+	clear(g.tweens[kept:])
+	g.tweens = g.tweens[:kept]
+}
+
+// Clear stops every tween. It must not be called from a tween's callback.
+func (g *Group) Clear() {
+	clear(g.tweens)
+	g.tweens = g.tweens[:0]
+}
+
+// Len returns how many tweens are playing.
+func (g *Group) Len() int {
+	return len(g.tweens)
+}
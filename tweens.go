@@ -0,0 +1,7 @@
+package main
+
+import "raylibgo/tween"
+
+// tweens play gameplay effects on simulation ticks, so they stop with the
+// simulation and step with it frame by frame
+var tweens = &tween.Group{}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Anchor is the point of the safe area a UI element is positioned against
+type Anchor int
+
+const (
+	AnchorTopLeft Anchor = iota
+	AnchorTop
+	AnchorTopRight
+	AnchorLeft
+	AnchorCenter
+	AnchorRight
+	AnchorBottomLeft
+	AnchorBottom
+	AnchorBottomRight
+	// AnchorStretch fills the safe area minus the element's margin
+	AnchorStretch
+)
+
+// Insets are distances in pixels from each edge
+type Insets struct {
+	Left, Top, Right, Bottom float32
+}
+
+// UIRect places an element relative to an anchor. Offset moves it inward from
+// the anchored edges; Margin is only used by AnchorStretch.
+type UIRect struct {
+	Anchor Anchor
+	Offset rl.Vector2
+	Size   rl.Vector2
+	Margin Insets
+}
+
+// UILayout tracks the screen and safe area and resolves anchored rects
+// against them. It recalculates whenever the resolution changes.
+type UILayout struct {
+	// SafeInsets reserves pixels for notches or rounded corners
+	SafeInsets Insets
+	// Overscan reserves a fraction of each dimension for TV overscan
+	Overscan float32
+	// Mirrored flips anchors horizontally for right-to-left languages, so
+	// elements anchored to the left sit on the right and vice versa
+	Mirrored bool
+	// TextScale enlarges all text; 0 is treated as 1
+	TextScale float32
+
+	virtual  rl.Vector2
+	screen   rl.Vector2
+	safeArea rl.Rectangle
+	dirty    bool
+}
+
+var ui = &UILayout{dirty: true}
+
+// SetVirtualResolution lays the UI out against a fixed resolution instead of the window size.
+// A zero vector switches back to the window size.
+func (l *UILayout) SetVirtualResolution(size rl.Vector2) {
+	l.virtual = size
+	l.dirty = true
+}
+
+// SetSafeArea changes the insets and overscan and schedules a relayout.
+func (l *UILayout) SetSafeArea(insets Insets, overscan float32) {
+	l.SafeInsets = insets
+	l.Overscan = overscan
+	l.dirty = true
+}
+
+// Update recalculates the safe area if the resolution changed since the last frame.
+func (l *UILayout) Update() {
+	size := l.virtual
+	if size.X == 0 || size.Y == 0 {
+		size = rl.NewVector2(float32(rl.GetScreenWidth()), float32(rl.GetScreenHeight()))
+	}
+	if !l.dirty && size == l.screen {
+		return
+	}
+	l.screen = size
+	l.dirty = false
+
+	ox := size.X * l.Overscan / 2
+	oy := size.Y * l.Overscan / 2
+	left := ox + l.SafeInsets.Left
+	top := oy + l.SafeInsets.Top
+	l.safeArea = rl.NewRectangle(
+		left,
+		top,
+		size.X-left-ox-l.SafeInsets.Right,
+		size.Y-top-oy-l.SafeInsets.Bottom,
+	)
+}
+
+// SafeArea returns the region UI should stay inside.
+func (l *UILayout) SafeArea() rl.Rectangle {
+	return l.safeArea
+}
+
+// Rect resolves an anchored element to screen coordinates.
+func (l *UILayout) Rect(r UIRect) rl.Rectangle {
+	safe := l.safeArea
+	if l.Mirrored {
+		r.Margin.Left, r.Margin.Right = r.Margin.Right, r.Margin.Left
+	}
+	if r.Anchor == AnchorStretch {
+		return rl.NewRectangle(
+			safe.X+r.Margin.Left,
+			safe.Y+r.Margin.Top,
+			safe.Width-r.Margin.Left-r.Margin.Right,
+			safe.Height-r.Margin.Top-r.Margin.Bottom,
+		)
+	}
+
+	col := int(r.Anchor) % 3
+	row := int(r.Anchor) / 3
+	if l.Mirrored {
+		col = 2 - col
+		if col == 1 {
+			r.Offset.X = -r.Offset.X
+		}
+	}
+
+	x := safe.X + r.Offset.X
+	switch col {
+	case 1:
+		x = safe.X + (safe.Width-r.Size.X)/2 + r.Offset.X
+	case 2:
+		x = safe.X + safe.Width - r.Size.X - r.Offset.X
+	}
+
+	y := safe.Y + r.Offset.Y
+	switch row {
+	case 1:
+		y = safe.Y + (safe.Height-r.Size.Y)/2 + r.Offset.Y
+	case 2:
+		y = safe.Y + safe.Height - r.Size.Y - r.Offset.Y
+	}
+
+	return rl.NewRectangle(x, y, r.Size.X, r.Size.Y)
+}
+
+// Scale returns the factor text sizes are multiplied by.
+func (l *UILayout) Scale() float32 {
+	if l.TextScale <= 0 {
+		return 1
+	}
+	return l.TextScale
+}
+
+// StartX returns where text of the given width starts inside box: padding
+// from the left edge, or from the right edge when the layout is mirrored.
+func (l *UILayout) StartX(box rl.Rectangle, width, padding float32) float32 {
+	if l.Mirrored {
+		return box.X + box.Width - padding - width
+	}
+	return box.X + padding
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"raylibgo/tween"
+)
+
+// menuSlideTime is how long the highlight takes to slide to a new row
+const menuSlideTime = 120 * time.Millisecond
+
+// MenuItem is one selectable row. OnLeft and OnRight, when set, let the
+// item adjust a value in place, as option rows do. Tooltip is rich text
+// shown next to the row while it is selected.
+type MenuItem struct {
+	Label    string
+	Tooltip  string
+	Disabled bool
+	OnSelect func()
+	OnLeft   func()
+	OnRight  func()
+}
+
+// MenuList is a vertical list navigated with the keyboard, the first
+// gamepad or the mouse. OnBack runs on Escape, Backspace or gamepad B.
+type MenuList struct {
+	Items    []MenuItem
+	Selected int
+	Layout   UIRect
+	Font     *Font
+	FontSize float32
+	Spacing  float32
+	OnBack   func()
+	Sounds   *UISounds
+
+	rects     []rl.Rectangle
+	announced string // label last published as focused
+	// highlight is the selected row's highlight as drawn, sliding after the
+	// selection
+	highlight rl.Rectangle
+	slide     *tween.Tween
+	slideTo   int
+}
+
+// menuPressed reads navigation in the menu input context, consuming the press.
+func menuPressed(key, button int32) bool {
+	return inputContexts.KeyHit(ContextMenu, key) || inputContexts.ButtonPressed(ContextMenu, button)
+}
+
+// SetItems replaces the rows, keeping the selection on an enabled row.
+func (m *MenuList) SetItems(items []MenuItem) {
+	m.Items = items
+	m.Selected = min(m.Selected, len(items)-1)
+	if m.Selected < 0 || (len(items) > 0 && items[m.Selected].Disabled) {
+		m.Selected = -1
+		m.move(1)
+	}
+}
+
+// move steps the selection by dir, skipping disabled rows and wrapping around.
+func (m *MenuList) move(dir int) {
+	n := len(m.Items)
+	for range n {
+		m.Selected = ((m.Selected+dir)%n + n) % n
+		if !m.Items[m.Selected].Disabled {
+			return
+		}
+	}
+}
+
+func (m *MenuList) layout() {
+	height := m.FontSize * ui.Scale()
+	row := height + m.Spacing
+	area := ui.Rect(m.Layout)
+	m.rects = m.rects[:0]
+	for i := range m.Items {
+		m.rects = append(m.rects, rl.NewRectangle(area.X, area.Y+float32(i)*row, area.Width, height))
+	}
+}
+
+// Update handles navigation and activation for one frame.
+func (m *MenuList) Update() {
+	m.layout()
+	if len(m.Items) == 0 {
+		return
+	}
+	prev := m.Selected
+
+	if delta := rl.GetMouseDelta(); delta.X != 0 || delta.Y != 0 {
+		mouse := rl.GetMousePosition()
+		for i, r := range m.rects {
+			if !m.Items[i].Disabled && rl.CheckCollisionPointRec(mouse, r) {
+				m.Selected = i
+			}
+		}
+	}
+	switch {
+	case menuPressed(rl.KeyUp, rl.GamepadButtonLeftFaceUp) || inputContexts.KeyHit(ContextMenu, rl.KeyW):
+		m.move(-1)
+	case menuPressed(rl.KeyDown, rl.GamepadButtonLeftFaceDown) || inputContexts.KeyHit(ContextMenu, rl.KeyS):
+		m.move(1)
+	}
+	if m.Selected != prev {
+		m.Sounds.PlayMove()
+	}
+	if m.Selected < 0 {
+		return
+	}
+	m.updateHighlight()
+
+	item := m.Items[m.Selected]
+	if item.Label != m.announced {
+		m.announced = item.Label
+		events.Publish(Event{Type: EventUIFocused, Target: item.Label})
+	}
+	hovered := rl.CheckCollisionPointRec(rl.GetMousePosition(), m.rects[m.Selected])
+	trigger := TooltipFocus
+	if hovered {
+		trigger = TooltipHover
+	}
+	tooltips.Request(fmt.Sprintf("menu:%p:%d", m, m.Selected), item.Tooltip, m.rects[m.Selected], trigger)
+	clicked := rl.IsMouseButtonPressed(rl.MouseButtonLeft) && hovered
+	switch {
+	case menuPressed(rl.KeyEnter, rl.GamepadButtonRightFaceDown) || inputContexts.KeyPressed(ContextMenu, rl.KeySpace) || clicked:
+		if item.OnSelect != nil {
+			m.Sounds.PlaySelect()
+			item.OnSelect()
+		}
+	case menuPressed(rl.KeyLeft, rl.GamepadButtonLeftFaceLeft) && item.OnLeft != nil:
+		m.Sounds.PlayMove()
+		item.OnLeft()
+	case menuPressed(rl.KeyRight, rl.GamepadButtonLeftFaceRight) && item.OnRight != nil:
+		m.Sounds.PlayMove()
+		item.OnRight()
+	case menuPressed(rl.KeyEscape, rl.GamepadButtonRightFaceRight) || inputContexts.KeyPressed(ContextMenu, rl.KeyBackspace):
+		if m.OnBack != nil {
+			m.Sounds.PlaySelect()
+			m.OnBack()
+		}
+	}
+}
+
+// updateHighlight slides the highlight toward the selected row. It jumps
+// there when nothing was highlighted yet, such as when the menu opens.
+func (m *MenuList) updateHighlight() {
+	r := m.rects[m.Selected]
+	to := rl.NewRectangle(r.X-16, r.Y-4, r.Width+32, r.Height+8)
+	switch {
+	case m.highlight.Width == 0:
+		m.highlight = to
+	case m.Selected != m.slideTo:
+		m.slide = tween.To(&m.highlight, to, menuSlideTime, lerpRect).Ease(tween.OutCubic)
+	case !m.slide.Playing():
+		// Follow layout changes such as a resized window
+		m.highlight = to
+	}
+	m.slideTo = m.Selected
+	m.slide.Update(FrameTime())
+}
+
+func lerpRect(a, b rl.Rectangle, t float32) rl.Rectangle {
+	return rl.NewRectangle(a.X+(b.X-a.X)*t, a.Y+(b.Y-a.Y)*t, a.Width+(b.Width-a.Width)*t, a.Height+(b.Height-a.Height)*t)
+}
+
+// Draw renders the rows, highlighting the selected one.
+func (m *MenuList) Draw() {
+	if m.Font == nil || len(m.rects) != len(m.Items) {
+		return
+	}
+	if m.Selected >= 0 && m.highlight.Width > 0 {
+		if !DrawNinePatch(SkinButton, m.highlight, rl.White) {
+			rl.DrawRectangleRec(m.highlight, rl.Fade(rl.Black, 0.5))
+		}
+	}
+	for i, item := range m.Items {
+		r := m.rects[i]
+		color := rl.RayWhite
+		switch {
+		case item.Disabled:
+			color = rl.DarkGray
+		case i == m.Selected:
+			color = rl.Gold
+		}
+		size := m.Font.Measure(item.Label, m.FontSize)
+		m.Font.Draw(item.Label, rl.NewVector2(r.X+(r.Width-size.X)/2, r.Y), m.FontSize, color)
+	}
+}
+
+// UISounds are the feedback sounds shared by menus. Missing files leave
+// the sound silent.
+type UISounds struct {
+	Move   rl.Sound
+	Select rl.Sound
+}
+
+// LoadUISounds loads the move and select sounds from the asset files.
+func LoadUISounds(movePath, selectPath string) *UISounds {
+	return &UISounds{Move: loadSound(movePath), Select: loadSound(selectPath)}
+}
+
+func loadSound(path string) rl.Sound {
+	if !audioReady {
+		return rl.Sound{}
+	}
+	data, err := ReadAsset(path)
+	if err != nil {
+		log.Printf("sound: %v", err)
+		return rl.Sound{}
+	}
+	wave := rl.LoadWaveFromMemory(filepath.Ext(path), data, int32(len(data)))
+	defer rl.UnloadWave(wave)
+	return rl.LoadSoundFromWave(wave)
+}
+
+// PlayMove plays the navigation sound.
+func (s *UISounds) PlayMove() {
+	if s != nil {
+		mixer.Play("ui move", BusUI, s.Move, 1)
+	}
+}
+
+// PlaySelect plays the activation sound.
+func (s *UISounds) PlaySelect() {
+	if s != nil {
+		mixer.Play("ui select", BusUI, s.Select, 1)
+	}
+}
+
+// Unload frees both sounds.
+func (s *UISounds) Unload() {
+	if rl.IsSoundValid(s.Move) {
+		rl.UnloadSound(s.Move)
+	}
+	if rl.IsSoundValid(s.Select) {
+		rl.UnloadSound(s.Select)
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Abilities the player can unlock by collecting the pickup of that name
+const (
+	AbilityDash       = "dash"
+	AbilityDoubleJump = "double_jump"
+)
+
+var abilities = []string{AbilityDash, AbilityDoubleJump}
+
+// MovementConfig switches the player's optional movement features. It is
+// rebuilt from the unlocks whenever they change.
+type MovementConfig struct {
+	Dash       bool
+	DoubleJump bool
+}
+
+var movement MovementConfig
+
+// AbilityPickup places the pickup that unlocks Ability in a level
+type AbilityPickup struct {
+	Ability string  `json:"ability"`
+	X       float32 `json:"x"`
+	Y       float32 `json:"y"`
+}
+
+// Unlocks are the abilities found in this game, saved with it. On entering
+// a level they place the ability pickups it holds that are still missing.
+type Unlocks struct {
+	have  map[string]bool
+	level string // whose ability pickups are placed
+}
+
+var unlocks = &Unlocks{have: make(map[string]bool)}
+
+// Has reports whether ability is unlocked.
+func (u *Unlocks) Has(ability string) bool {
+	return u.have[ability]
+}
+
+// Unlock grants ability and turns on its movement feature.
+func (u *Unlocks) Unlock(ability string) {
+	if u.have[ability] {
+		return
+	}
+	u.have[ability] = true
+	u.apply()
+	floatingText.Spawn(rl.NewVector2(player.Pos.X+40, player.Pos.Y-20), T("ability."+ability), rl.Gold, true)
+}
+
+// Names returns the unlocked abilities for saving.
+func (u *Unlocks) Names() []string {
+	names := make([]string, 0, len(u.have))
+	for name := range u.have {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Restore replaces the unlocks with saved ones and places the current
+// level's pickups again.
+func (u *Unlocks) Restore(names []string) {
+	clear(u.have)
+	for _, name := range names {
+		u.have[name] = true
+	}
+	u.apply()
+	u.level = ""
+}
+
+func (u *Unlocks) apply() {
+	movement = MovementConfig{
+		Dash:       u.have[AbilityDash],
+		DoubleJump: u.have[AbilityDoubleJump],
+	}
+}
+
+// Update swaps the placed ability pickups on entering another level.
+func (u *Unlocks) Update() {
+	if u.level == currentLevel {
+		return
+	}
+	u.level = currentLevel
+	pickups = slices.DeleteFunc(pickups, func(p Pickup) bool {
+		return !p.Taken && slices.Contains(abilities, p.Name)
+	})
+	for _, a := range assets.manifest.Levels[currentLevel].Abilities {
+		if !u.have[a.Ability] {
+			pickups = append(pickups, Pickup{Name: a.Ability, Pos: rl.NewVector2(a.X, a.Y)})
+		}
+	}
+}
+
+// HandleAbilityPickup unlocks the ability named like a collected pickup.
+func HandleAbilityPickup(e Event) {
+	if slices.Contains(abilities, e.Target) {
+		unlocks.Unlock(e.Target)
+	}
+}
+
+// CheckAbilityGates walks the level graph from start the way a player
+// could, entering a level only with the abilities it requires and picking
+// up the abilities placed in the levels reached. It reports levels that
+// can never be entered and abilities that don't exist.
+func CheckAbilityGates(levels map[string]LevelNode, start string) []string {
+	var problems []string
+	names := make([]string, 0, len(levels))
+	for name := range levels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		node := levels[name]
+		for _, a := range node.Requires {
+			if !slices.Contains(abilities, a) {
+				problems = append(problems, fmt.Sprintf("level %q requires unknown ability %q", name, a))
+			}
+		}
+		for _, p := range node.Abilities {
+			if !slices.Contains(abilities, p.Ability) {
+				problems = append(problems, fmt.Sprintf("level %q places unknown ability %q", name, p.Ability))
+			}
+		}
+	}
+
+	have := make(map[string]bool)
+	reached := map[string]bool{start: true}
+	enterable := func(name string) bool {
+		for _, a := range levels[name].Requires {
+			if !have[a] {
+				return false
+			}
+		}
+		return true
+	}
+	// Abilities found open up more levels, which may hold more abilities,
+	// so walk until nothing new is reached
+	for changed := true; changed; {
+		changed = false
+		for _, name := range names {
+			if !reached[name] {
+				continue
+			}
+			for _, p := range levels[name].Abilities {
+				have[p.Ability] = true
+			}
+			for _, exit := range levels[name].Exits {
+				if _, ok := levels[exit.To]; ok && !reached[exit.To] && enterable(exit.To) {
+					reached[exit.To] = true
+					changed = true
+				}
+			}
+		}
+	}
+
+	for _, name := range names {
+		if reached[name] || len(levels[name].Requires) == 0 {
+			continue
+		}
+		var missing []string
+		for _, a := range levels[name].Requires {
+			if !have[a] {
+				missing = append(missing, a)
+			}
+		}
+		if len(missing) > 0 {
+			problems = append(problems, fmt.Sprintf("level %q can't be reached: %s is never found before it", name, strings.Join(missing, ", ")))
+		}
+	}
+	return problems
+}
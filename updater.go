@@ -0,0 +1,317 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"raylibgo/buildinfo"
+)
+
+const (
+	updateInfoTimeout = 15 * time.Second
+	// A download that receives nothing for this long is given up on; it
+	// resumes from the partial file on the next start
+	updateIdleTimeout = 30 * time.Second
+)
+
+var errUpdateStalled = errors.New("update: download stalled")
+
+// UpdateInfo is the JSON document served at Settings.UpdateURL describing
+// the latest asset pack
+type UpdateInfo struct {
+	URL    string `json:"url"`
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// Updater downloads a newer asset pack in the background. Downloads go to a
+// .part file that is resumed on the next start if interrupted, and only
+// replace the target once the checksum matches.
+type Updater struct {
+	InfoURL string
+	Target  string
+	// Client fetches the update info. DownloadClient fetches the pack; it
+	// has no overall timeout, since a large pack takes a while, and is
+	// given up on when it stalls instead.
+	Client         *http.Client
+	DownloadClient *http.Client
+
+	downloaded atomic.Int64
+	total      atomic.Int64
+	cancel     context.CancelFunc
+	done       chan struct{}
+
+	mu     sync.Mutex
+	status string
+	err    error
+}
+
+// NewUpdater creates an Updater that installs the pack described at infoURL to target
+func NewUpdater(infoURL, target string) *Updater {
+	return &Updater{
+		InfoURL:        infoURL,
+		Target:         target,
+		Client:         &http.Client{Timeout: updateInfoTimeout},
+		DownloadClient: &http.Client{},
+		done:           make(chan struct{}),
+	}
+}
+
+// Start begins checking for and downloading an update.
+func (u *Updater) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	u.cancel = cancel
+	go func() {
+		defer close(u.done)
+		err := u.run(ctx)
+		u.mu.Lock()
+		u.err = err
+		u.mu.Unlock()
+	}()
+}
+
+// Cancel stops the download; the partial file is kept for resuming.
+func (u *Updater) Cancel() {
+	if u.cancel != nil {
+		u.cancel()
+	}
+	<-u.done
+}
+
+// Done reports whether the updater has finished, successfully or not.
+func (u *Updater) Done() bool {
+	select {
+	case <-u.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Progress returns the bytes downloaded so far and the expected total.
+func (u *Updater) Progress() (int64, int64) {
+	return u.downloaded.Load(), u.total.Load()
+}
+
+// Status returns a short description of the current step and the final error, if any.
+func (u *Updater) Status() (string, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.status, u.err
+}
+
+func (u *Updater) setStatus(s string) {
+	u.mu.Lock()
+	u.status = s
+	u.mu.Unlock()
+}
+
+func (u *Updater) run(ctx context.Context) error {
+	u.setStatus("Checking for updates")
+	info, err := u.fetchInfo(ctx)
+	if err != nil {
+		return err
+	}
+	if sum, err := fileSHA256OnDisk(u.Target); err == nil && strings.EqualFold(sum, info.SHA256) {
+		u.setStatus("Up to date")
+		return nil
+	}
+
+	u.setStatus("Downloading update")
+	u.total.Store(info.Size)
+	part := u.Target + ".part"
+	if err := u.download(ctx, info, part); err != nil {
+		return err
+	}
+
+	u.setStatus("Verifying")
+	sum, err := fileSHA256OnDisk(part)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(sum, info.SHA256) {
+		os.Remove(part)
+		return errors.New("update: checksum mismatch, download discarded")
+	}
+	if err := os.Rename(part, u.Target); err != nil {
+		return err
+	}
+	u.setStatus("Update installed")
+	return nil
+}
+
+func (u *Updater) fetchInfo(ctx context.Context) (UpdateInfo, error) {
+	var info UpdateInfo
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.InfoURL, nil)
+	if err != nil {
+		return info, err
+	}
+	req.Header.Set("User-Agent", buildinfo.Get().UserAgent())
+	resp, err := u.Client.Do(req)
+	if err != nil {
+		return info, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return info, fmt.Errorf("update: %s: %s", u.InfoURL, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return info, fmt.Errorf("update: %w", err)
+	}
+	if info.URL == "" || info.SHA256 == "" {
+		return info, errors.New("update: info is missing url or sha256")
+	}
+	return info, nil
+}
+
+// download fetches info.URL into part, continuing from the bytes already there.
+func (u *Updater) download(ctx context.Context, info UpdateInfo, part string) error {
+	file, err := os.OpenFile(part, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	offset, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if info.Size > 0 && offset >= info.Size {
+		u.downloaded.Store(offset)
+		return nil
+	}
+
+	// Any wait longer than updateIdleTimeout, for the response or for the
+	// next bytes of the body, cancels the request
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	idle := time.AfterFunc(updateIdleTimeout, func() { cancel(errUpdateStalled) })
+	defer idle.Stop()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, info.URL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", buildinfo.Get().UserAgent())
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := u.DownloadClient.Do(req)
+	if err != nil {
+		return stalled(ctx, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		// The server ignored the range; start over
+		if err := file.Truncate(0); err != nil {
+			return err
+		}
+		if offset, err = file.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("update: %s: %s", info.URL, resp.Status)
+	}
+
+	u.downloaded.Store(offset)
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := resp.Body.Read(buf)
+		if n > 0 {
+			idle.Reset(updateIdleTimeout)
+			if _, werr := file.Write(buf[:n]); werr != nil {
+				return werr
+			}
+			u.downloaded.Add(int64(n))
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return stalled(ctx, err)
+		}
+	}
+}
+
+// stalled reports a request canceled by the idle timer as a stall rather
+// than as a canceled context.
+func stalled(ctx context.Context, err error) error {
+	if errors.Is(context.Cause(ctx), errUpdateStalled) {
+		return errUpdateStalled
+	}
+	return err
+}
+
+// fileSHA256OnDisk hashes a file directly, bypassing the asset sources.
+func fileSHA256OnDisk(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	return readerSHA256(file)
+}
+
+// RunStartupUpdate checks Settings.UpdateURL for a newer asset pack and shows
+// a progress screen while it downloads. Enter skips the update; the partial
+// download resumes next time.
+func RunStartupUpdate() {
+	if settings.UpdateURL == "" {
+		return
+	}
+	u := NewUpdater(settings.UpdateURL, assetPackPath)
+	u.Start()
+
+	for !u.Done() {
+		if rl.WindowShouldClose() || rl.IsKeyPressed(rl.KeyEnter) {
+			u.Cancel()
+			break
+		}
+		drawUpdateProgress(u)
+	}
+
+	if status, err := u.Status(); err != nil {
+		log.Printf("update: %v", err)
+	} else {
+		attention.Notify()
+		log.Printf("update: %s", strings.ToLower(status))
+	}
+}
+
+func drawUpdateProgress(u *Updater) {
+	status, _ := u.Status()
+	downloaded, total := u.Progress()
+
+	w := float32(rl.GetScreenWidth())
+	h := float32(rl.GetScreenHeight())
+	bar := rl.NewRectangle(w/2-300, h/2, 600, 24)
+
+	rl.BeginDrawing()
+	rl.ClearBackground(rl.Black)
+	rl.DrawText(status, int32(bar.X), int32(bar.Y)-40, 24, rl.RayWhite)
+	rl.DrawRectangleLinesEx(bar, 2, rl.Gray)
+	if total > 0 {
+		fill := bar
+		fill.Width *= min(float32(downloaded)/float32(total), 1)
+		rl.DrawRectangleRec(fill, rl.SkyBlue)
+		label := fmt.Sprintf("%.1f / %.1f MB", float64(downloaded)/megabyte, float64(total)/megabyte)
+		rl.DrawText(label, int32(bar.X), int32(bar.Y+bar.Height)+10, 20, rl.Gray)
+	}
+	rl.DrawText("Enter to skip", int32(bar.X+bar.Width)-130, int32(bar.Y+bar.Height)+10, 20, rl.DarkGray)
+	rl.EndDrawing()
+}
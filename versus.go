@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	versusRoundTime   = 60 * time.Second
+	versusRoundsToWin = 2 // best of three
+	versusHitDamage   = 10
+	versusKnockback   = 40
+	versusBanner      = 2 * time.Second
+)
+
+type versusState int
+
+const (
+	versusIntro versusState = iota // round banner before the fight
+	versusFight
+	versusRoundOver
+	versusResults
+)
+
+// VersusScene pits the two local players against each other with their
+// attacks. Rounds end on a knockout or when the timer runs out, the
+// healthier player taking the round; the first to win two takes the match.
+type VersusScene struct {
+	GameScene
+
+	state   versusState
+	round   int
+	wins    [2]int
+	winner  int // of the last round or the match; -1 for a draw
+	left    time.Duration
+	bannerT time.Time
+	font    *Font
+}
+
+// NewVersusScene creates a best-of-three match.
+func NewVersusScene() *VersusScene {
+	return &VersusScene{GameScene: GameScene{Coop: true}}
+}
+
+func (v *VersusScene) Load(scope *AssetScope) {
+	v.font = scope.Font("", 48)
+	currentSlot = 0
+	ResetProgress()
+	v.GameScene.Load(scope)
+	OnPlayerAttack = versusHit
+	v.startRound()
+}
+
+func (v *VersusScene) Unload() {
+	OnPlayerAttack = nil
+	v.GameScene.Unload()
+}
+
+// versusHit damages the other player when the attack reaches them. It runs
+// inside the attacker's step, so swapping players puts the target in the
+// player globals whichever side attacked.
+func versusHit(hitbox AttackBox) {
+	attacker := player.Pos
+	coop.as(func() {
+		if !hitbox.Overlaps(PlayerBounds()) {
+			return
+		}
+		DamagePlayer(versusHitDamage)
+		if player.Pos.X < attacker.X {
+			player.Pos.X -= versusKnockback
+		} else {
+			player.Pos.X += versusKnockback
+		}
+		TriggerHitstop(90*time.Millisecond, 0.05)
+		AddShake(ShakeHit)
+	})
+}
+
+func (v *VersusScene) startRound() {
+	v.round++
+	v.state = versusIntro
+	v.bannerT = time.Now()
+	v.left = versusRoundTime
+
+	world := camera.World
+	for i, p := range []*Player{&player, &coop.Player} {
+		p.Health = p.MaxHealth
+		p.Effects = StatusEffects{}
+		p.VelocityY = 0
+		p.Pos = rl.NewVector2(world.X+world.Width*(0.25+0.5*float32(i)), p.DefPos.Y)
+		p.Flip = i == 1
+	}
+	rewinder.Reset()
+}
+
+func (v *VersusScene) Update() {
+	if rl.IsKeyPressed(rl.KeyEscape) {
+		scenes.Replace(NewMainMenuScene())
+		return
+	}
+
+	switch v.state {
+	case versusIntro:
+		if time.Since(v.bannerT) >= versusBanner {
+			v.state = versusFight
+		}
+	case versusFight:
+		before := clock.Tick
+		v.GameScene.Update()
+		v.left -= time.Duration(clock.Tick-before) * tickDuration
+		if player.Health == 0 || coop.Player.Health == 0 || v.left <= 0 {
+			v.endRound()
+		}
+	case versusRoundOver:
+		if time.Since(v.bannerT) >= versusBanner {
+			v.startRound()
+		}
+	case versusResults:
+		if rl.IsKeyPressed(rl.KeyEnter) || rl.IsGamepadButtonPressed(gamepadIndex, rl.GamepadButtonRightFaceDown) {
+			scenes.Replace(NewMainMenuScene())
+		}
+	}
+}
+
+func (v *VersusScene) endRound() {
+	v.bannerT = time.Now()
+	v.left = max(v.left, 0)
+	switch {
+	case player.Health > coop.Player.Health:
+		v.winner = 0
+	case coop.Player.Health > player.Health:
+		v.winner = 1
+	default:
+		v.winner = -1
+	}
+	if v.winner >= 0 {
+		v.wins[v.winner]++
+		if v.wins[v.winner] >= versusRoundsToWin {
+			v.state = versusResults
+			return
+		}
+	}
+	v.state = versusRoundOver
+}
+
+func (v *VersusScene) Draw() {
+	v.GameScene.Draw()
+
+	// Round timer and a pip per round won on each side
+	timer := fmt.Sprintf("%d", int(v.left.Seconds()+0.999))
+	top := ui.Rect(UIRect{Anchor: AnchorTop, Offset: rl.NewVector2(0, 20), Size: rl.NewVector2(300, 48)})
+	w := v.font.Measure(timer, 48).X
+	v.font.Draw(timer, rl.NewVector2(top.X+(top.Width-w)/2, top.Y), 48, rl.RayWhite)
+	for side, color := range []rl.Color{rl.SkyBlue, rl.Orange} {
+		for i := range versusRoundsToWin {
+			x := top.X + 40 + float32(i)*24
+			if side == 1 {
+				x = top.X + top.Width - 40 - float32(i)*24
+			}
+			center := rl.NewVector2(x, top.Y+24)
+			if i < v.wins[side] {
+				rl.DrawCircleV(center, 8, color)
+			} else {
+				rl.DrawCircleLinesV(center, 8, color)
+			}
+		}
+	}
+
+	switch v.state {
+	case versusIntro:
+		v.banner(fmt.Sprintf(T("versus.round"), v.round), "")
+	case versusRoundOver:
+		v.banner(v.winnerText(), "")
+	case versusResults:
+		v.banner(v.winnerText(), T("daily.continue"))
+	}
+}
+
+func (v *VersusScene) winnerText() string {
+	if v.winner < 0 {
+		return T("versus.draw")
+	}
+	key := "versus.round_winner"
+	if v.state == versusResults {
+		key = "versus.match_winner"
+	}
+	return fmt.Sprintf(T(key), v.winner+1)
+}
+
+func (v *VersusScene) banner(title, subtitle string) {
+	panel := ui.Rect(UIRect{Anchor: AnchorCenter, Size: rl.NewVector2(screenSize.X, 160)})
+	rl.DrawRectangleRec(panel, rl.Fade(rl.Black, 0.7))
+	w := v.font.Measure(title, 64).X
+	v.font.Draw(title, rl.NewVector2(panel.X+(panel.Width-w)/2, panel.Y+24), 64, rl.Gold)
+	if subtitle != "" {
+		w = v.font.Measure(subtitle, 32).X
+		v.font.Draw(subtitle, rl.NewVector2(panel.X+(panel.Width-w)/2, panel.Y+104), 32, rl.RayWhite)
+	}
+}
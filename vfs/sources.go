@@ -0,0 +1,176 @@
+package vfs
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"raylibgo/pack"
+)
+
+// Dir serves files from a directory on disk. Absolute names are opened as
+// they are, so files dropped onto the window still load.
+type Dir string
+
+func (d Dir) path(name string) string {
+	name = filepath.FromSlash(name)
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(string(d), name)
+}
+
+// Open implements Source.
+func (d Dir) Open(name string) (io.ReadCloser, error) {
+	return os.Open(d.path(name))
+}
+
+// Exists implements Source.
+func (d Dir) Exists(name string) bool {
+	info, err := os.Stat(d.path(name))
+	return err == nil && !info.IsDir()
+}
+
+// FSSource serves files from an fs.FS such as an embed.FS, or an
+// fstest.MapFS to mock assets in tests
+type FSSource struct {
+	FS fs.FS
+}
+
+// FromFS wraps fsys as a Source.
+func FromFS(fsys fs.FS) *FSSource {
+	return &FSSource{FS: fsys}
+}
+
+// Open implements Source.
+func (s *FSSource) Open(name string) (io.ReadCloser, error) {
+	return s.FS.Open(name)
+}
+
+// Exists implements Source.
+func (s *FSSource) Exists(name string) bool {
+	info, err := fs.Stat(s.FS, name)
+	return err == nil && !info.IsDir()
+}
+
+// ZipSource serves files from a zip archive
+type ZipSource struct {
+	FSSource
+	archive *zip.ReadCloser
+}
+
+// OpenZip opens the archive at path.
+func OpenZip(path string) (*ZipSource, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	return &ZipSource{FSSource: FSSource{FS: archive}, archive: archive}, nil
+}
+
+// Close releases the archive.
+func (z *ZipSource) Close() error {
+	return z.archive.Close()
+}
+
+// PackSource serves files from a pack, decrypting them as they are read
+type PackSource struct {
+	Pack *pack.Reader
+}
+
+// Open implements Source.
+func (p PackSource) Open(name string) (io.ReadCloser, error) {
+	r, err := p.Pack.Open(name)
+	if err == pack.ErrNotFound {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(r), nil
+}
+
+// Exists implements Source.
+func (p PackSource) Exists(name string) bool {
+	return p.Pack.Has(name)
+}
+
+// HTTPSource fetches files from a web server, for development servers or
+// assets hosted separately from the game. Every call is a request, so it is
+// best mounted underneath local sources.
+type HTTPSource struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+func (h *HTTPSource) url(name string) string {
+	return strings.TrimSuffix(h.BaseURL, "/") + "/" + name
+}
+
+func (h *HTTPSource) client() *http.Client {
+	if h.Client != nil {
+		return h.Client
+	}
+	return http.DefaultClient
+}
+
+// Open implements Source.
+func (h *HTTPSource) Open(name string) (io.ReadCloser, error) {
+	resp, err := h.client().Get(h.url(name))
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		resp.Body.Close()
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	case resp.StatusCode != http.StatusOK:
+		resp.Body.Close()
+		return nil, fmt.Errorf("vfs: GET %s: %s", name, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// Exists implements Source.
+func (h *HTTPSource) Exists(name string) bool {
+	resp, err := h.client().Head(h.url(name))
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+// Sub serves the files of Source under Prefix, so a directory can stand in
+// for one folder of the game layout
+type Sub struct {
+	Prefix string
+	Source Source
+}
+
+func (s Sub) trim(name string) (string, bool) {
+	if !strings.HasPrefix(name, s.Prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(name, s.Prefix), true
+}
+
+// Open implements Source.
+func (s Sub) Open(name string) (io.ReadCloser, error) {
+	rest, ok := s.trim(name)
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return s.Source.Open(rest)
+}
+
+// Exists implements Source.
+func (s Sub) Exists(name string) bool {
+	rest, ok := s.trim(name)
+	return ok && s.Source.Exists(rest)
+}
@@ -0,0 +1,104 @@
+// Package vfs merges several asset sources (directories, embedded files,
+// pack files, zip archives, HTTP servers) behind one read API. Paths are
+// slash separated and relative, e.g. "assets/images/stand1.png".
+package vfs
+
+import (
+	"io"
+	"io/fs"
+	"sync"
+)
+
+// Source provides files by path
+type Source interface {
+	// Open returns a stream of the file's contents or an error wrapping
+	// fs.ErrNotExist when the source does not have it.
+	Open(name string) (io.ReadCloser, error)
+	// Exists reports whether the source has the file.
+	Exists(name string) bool
+}
+
+// Result is the outcome of an asynchronous read
+type Result struct {
+	Name string
+	Data []byte
+	Err  error
+}
+
+// FS looks files up in its mounted sources, most recently mounted first.
+// It is safe for concurrent use.
+type FS struct {
+	mu     sync.RWMutex
+	mounts []Source
+}
+
+// New creates an FS with no sources
+func New() *FS {
+	return &FS{}
+}
+
+// Mount adds a source that takes precedence over everything mounted before it.
+func (f *FS) Mount(s Source) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.mounts = append(f.mounts, s)
+}
+
+// Unmount removes a previously mounted source.
+func (f *FS) Unmount(s Source) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, m := range f.mounts {
+		if m == s {
+			f.mounts = append(f.mounts[:i], f.mounts[i+1:]...)
+			return
+		}
+	}
+}
+
+func (f *FS) find(name string) Source {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for i := len(f.mounts) - 1; i >= 0; i-- {
+		if f.mounts[i].Exists(name) {
+			return f.mounts[i]
+		}
+	}
+	return nil
+}
+
+// Open returns a stream of name from the first source that has it.
+// Large files such as music can be read without loading them whole.
+func (f *FS) Open(name string) (io.ReadCloser, error) {
+	s := f.find(name)
+	if s == nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return s.Open(name)
+}
+
+// ReadFile returns the whole contents of name.
+func (f *FS) ReadFile(name string) ([]byte, error) {
+	r, err := f.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// ReadAsync reads name on a new goroutine and delivers the result on the
+// returned channel, which has room for it so the reader never blocks.
+func (f *FS) ReadAsync(name string) <-chan Result {
+	out := make(chan Result, 1)
+	go func() {
+		data, err := f.ReadFile(name)
+		out <- Result{Name: name, Data: data, Err: err}
+	}()
+	return out
+}
+
+// Exists reports whether any source has name.
+func (f *FS) Exists(name string) bool {
+	return f.find(name) != nil
+}
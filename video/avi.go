@@ -0,0 +1,276 @@
+package video
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"io"
+	"math"
+	"time"
+)
+
+// avi reads Motion JPEG video with PCM sound from an AVI file. Chunks are
+// read in file order; video chunks met while reading ahead for sound are
+// queued until their frame is due.
+type avi struct {
+	r      *bufio.Reader
+	closer io.Closer
+	info   Info
+
+	streams []string // "vids" or "auds" by stream number
+	format  uint16   // WAVE_FORMAT_PCM or WAVE_FORMAT_IEEE_FLOAT
+	bits    int      // bits per sample
+
+	frames [][]byte  // queued compressed frames
+	audio  []float32 // decoded samples not yet read
+	done   bool      // the movie data has ended
+	img    *image.RGBA
+}
+
+const (
+	wavePCM   = 1
+	waveFloat = 3
+)
+
+func openAVI(rc io.ReadCloser) (Decoder, error) {
+	a := &avi{r: bufio.NewReaderSize(rc, 64<<10), closer: rc}
+	var head [12]byte
+	if _, err := io.ReadFull(a.r, head[:]); err != nil {
+		return nil, err
+	}
+	if string(head[0:4]) != "RIFF" || string(head[8:12]) != "AVI " {
+		return nil, errors.New("not an AVI file")
+	}
+	if err := a.readHeaders(); err != nil {
+		return nil, err
+	}
+	if a.info.Width <= 0 || a.info.Height <= 0 || a.info.FrameTime <= 0 {
+		return nil, errors.New("no video stream")
+	}
+	a.img = image.NewRGBA(image.Rect(0, 0, a.info.Width, a.info.Height))
+	return a, nil
+}
+
+func (a *avi) chunk() (id string, size uint32, err error) {
+	var head [8]byte
+	if _, err := io.ReadFull(a.r, head[:]); err != nil {
+		return "", 0, err
+	}
+	return string(head[0:4]), binary.LittleEndian.Uint32(head[4:8]), nil
+}
+
+// body reads a chunk's data and its padding byte.
+func (a *avi) body(size uint32) ([]byte, error) {
+	data := make([]byte, size)
+	if _, err := io.ReadFull(a.r, data); err != nil {
+		return nil, err
+	}
+	if size%2 == 1 {
+		a.r.ReadByte()
+	}
+	return data, nil
+}
+
+func (a *avi) skip(size uint32) error {
+	_, err := a.r.Discard(int(size + size%2))
+	return err
+}
+
+// readHeaders walks the header lists until the movie data starts. Lists are
+// entered rather than read whole, so their chunks come in file order.
+func (a *avi) readHeaders() error {
+	for {
+		id, size, err := a.chunk()
+		if err != nil {
+			return err
+		}
+		switch id {
+		case "LIST":
+			var kind [4]byte
+			if _, err := io.ReadFull(a.r, kind[:]); err != nil {
+				return err
+			}
+			if string(kind[:]) == "movi" {
+				return nil
+			}
+		case "avih":
+			data, err := a.body(size)
+			if err != nil {
+				return err
+			}
+			if len(data) < 40 {
+				return errors.New("short main header")
+			}
+			a.info.FrameTime = time.Duration(binary.LittleEndian.Uint32(data[0:4])) * time.Microsecond
+			a.info.Width = int(binary.LittleEndian.Uint32(data[32:36]))
+			a.info.Height = int(binary.LittleEndian.Uint32(data[36:40]))
+		case "strh":
+			data, err := a.body(size)
+			if err != nil {
+				return err
+			}
+			if len(data) < 32 {
+				return errors.New("short stream header")
+			}
+			kind := string(data[0:4])
+			a.streams = append(a.streams, kind)
+			scale, rate := binary.LittleEndian.Uint32(data[20:24]), binary.LittleEndian.Uint32(data[24:28])
+			if kind == "vids" && scale > 0 && rate > 0 {
+				// More precise than the main header's microseconds
+				a.info.FrameTime = time.Duration(float64(time.Second) * float64(scale) / float64(rate))
+			}
+		case "strf":
+			data, err := a.body(size)
+			if err != nil {
+				return err
+			}
+			if len(a.streams) == 0 {
+				continue
+			}
+			if err := a.readFormat(a.streams[len(a.streams)-1], data); err != nil {
+				return err
+			}
+		default:
+			if err := a.skip(size); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// readFormat checks a stream's format: a BITMAPINFOHEADER for video, a
+// WAVEFORMATEX for sound.
+func (a *avi) readFormat(kind string, data []byte) error {
+	switch kind {
+	case "vids":
+		if len(data) < 20 {
+			return errors.New("short video format")
+		}
+		if codec := string(data[16:20]); codec != "MJPG" {
+			return fmt.Errorf("unsupported video codec %q", codec)
+		}
+	case "auds":
+		if len(data) < 16 {
+			return errors.New("short audio format")
+		}
+		a.format = binary.LittleEndian.Uint16(data[0:2])
+		a.bits = int(binary.LittleEndian.Uint16(data[14:16]))
+		supported := (a.format == wavePCM && (a.bits == 8 || a.bits == 16)) || (a.format == waveFloat && a.bits == 32)
+		if !supported {
+			// Play it silent rather than not at all
+			return nil
+		}
+		a.info.Channels = int(binary.LittleEndian.Uint16(data[2:4]))
+		a.info.SampleRate = int(binary.LittleEndian.Uint32(data[4:8]))
+	}
+	return nil
+}
+
+// next reads the following movie chunk, queuing it by kind. It returns
+// io.EOF once the movie data has ended.
+func (a *avi) next() error {
+	if a.done {
+		return io.EOF
+	}
+	for {
+		id, size, err := a.chunk()
+		if err != nil || id == "idx1" {
+			a.done = true
+			return io.EOF
+		}
+		if id == "LIST" || id == "RIFF" {
+			// "rec " groups chunks that belong together, and large files
+			// continue in further "AVIX" RIFFs; read into them
+			if _, err := a.r.Discard(4); err != nil {
+				a.done = true
+				return io.EOF
+			}
+			continue
+		}
+		stream := int(id[0]-'0')*10 + int(id[1]-'0')
+		if stream < 0 || stream >= len(a.streams) {
+			if err := a.skip(size); err != nil {
+				a.done = true
+				return io.EOF
+			}
+			continue
+		}
+		data, err := a.body(size)
+		if err != nil {
+			a.done = true
+			return io.EOF
+		}
+		switch a.streams[stream] {
+		case "vids":
+			a.frames = append(a.frames, data)
+		case "auds":
+			if a.info.SampleRate > 0 {
+				a.audio = a.appendSamples(a.audio, data)
+			}
+		}
+		return nil
+	}
+}
+
+func (a *avi) appendSamples(dst []float32, data []byte) []float32 {
+	switch {
+	case a.format == waveFloat:
+		for i := 0; i+4 <= len(data); i += 4 {
+			dst = append(dst, math.Float32frombits(binary.LittleEndian.Uint32(data[i:])))
+		}
+	case a.bits == 16:
+		for i := 0; i+2 <= len(data); i += 2 {
+			dst = append(dst, float32(int16(binary.LittleEndian.Uint16(data[i:])))/32768)
+		}
+	default:
+		for _, b := range data {
+			dst = append(dst, (float32(b)-128)/128)
+		}
+	}
+	return dst
+}
+
+func (a *avi) Info() Info {
+	return a.info
+}
+
+func (a *avi) NextFrame() (*image.RGBA, error) {
+	for len(a.frames) == 0 {
+		if err := a.next(); err != nil {
+			return nil, err
+		}
+	}
+	data := a.frames[0]
+	a.frames = a.frames[1:]
+	if len(data) == 0 {
+		// An empty chunk repeats the previous frame
+		return a.img, nil
+	}
+	src, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	draw.Draw(a.img, a.img.Bounds(), src, src.Bounds().Min, draw.Src)
+	return a.img, nil
+}
+
+func (a *avi) ReadAudio(dst []float32) int {
+	for len(a.audio) < len(dst) {
+		if a.next() != nil {
+			break
+		}
+	}
+	n := copy(dst, a.audio)
+	a.audio = a.audio[:copy(a.audio, a.audio[n:])]
+	return n
+}
+
+func (a *avi) Close() error {
+	a.frames, a.audio = nil, nil
+	return a.closer.Close()
+}
@@ -0,0 +1,95 @@
+package video
+
+import (
+	"errors"
+	"image"
+	"io"
+	"time"
+
+	"github.com/gen2brain/mpeg"
+)
+
+// mpg reads MPEG-1 video with MP2 sound from an MPEG program stream, as
+// written by ffmpeg -c:v mpeg1video -c:a mp2 -f mpeg.
+type mpg struct {
+	m      *mpeg.MPEG
+	closer io.Closer
+	info   Info
+
+	audio      []float32 // decoded samples not yet read
+	videoEnded bool
+	audioEnded bool
+}
+
+func openMPEG(rc io.ReadCloser) (Decoder, error) {
+	m, err := mpeg.New(rc)
+	if err != nil {
+		return nil, err
+	}
+	if !m.HasHeaders() || m.NumVideoStreams() == 0 {
+		return nil, errors.New("no video stream")
+	}
+	d := &mpg{m: m, closer: rc}
+	d.info.Width, d.info.Height = m.Width(), m.Height()
+	if rate := m.Framerate(); rate > 0 {
+		d.info.FrameTime = time.Duration(float64(time.Second) / rate)
+	}
+	if d.info.Width <= 0 || d.info.Height <= 0 || d.info.FrameTime <= 0 {
+		return nil, errors.New("bad video header")
+	}
+	if m.NumAudioStreams() > 0 && m.Samplerate() > 0 {
+		// Samples always come interleaved as stereo, mono copied to both
+		m.SetAudioFormat(mpeg.AudioF32N)
+		d.info.SampleRate = m.Samplerate()
+		d.info.Channels = 2
+	} else {
+		m.SetAudioEnabled(false)
+	}
+	return d, nil
+}
+
+// settle forgets the end of stream signal the decoder sends each time it
+// runs out, so a later call doesn't block on the full channel.
+func (d *mpg) settle() {
+	select {
+	case <-d.m.Done():
+	default:
+	}
+}
+
+func (d *mpg) Info() Info {
+	return d.info
+}
+
+func (d *mpg) NextFrame() (*image.RGBA, error) {
+	if d.videoEnded {
+		return nil, io.EOF
+	}
+	frame := d.m.DecodeVideo()
+	d.settle()
+	if frame == nil {
+		d.videoEnded = true
+		return nil, io.EOF
+	}
+	return frame.RGBA(), nil
+}
+
+func (d *mpg) ReadAudio(dst []float32) int {
+	for len(d.audio) < len(dst) && !d.audioEnded && d.info.SampleRate > 0 {
+		samples := d.m.DecodeAudio()
+		d.settle()
+		if samples == nil {
+			d.audioEnded = true
+			break
+		}
+		d.audio = append(d.audio, samples.Interleaved...)
+	}
+	n := copy(dst, d.audio)
+	d.audio = d.audio[:copy(d.audio, d.audio[n:])]
+	return n
+}
+
+func (d *mpg) Close() error {
+	d.audio = nil
+	return d.closer.Close()
+}
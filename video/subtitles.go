@@ -0,0 +1,91 @@
+package video
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cue is a subtitle shown from Start until End
+type Cue struct {
+	Start, End time.Duration
+	Text       string
+}
+
+// ParseSRT reads SubRip subtitles: numbered cues, each a
+// "00:00:01,000 --> 00:00:03,500" timing line followed by text lines and a
+// blank line. WebVTT's dot before the milliseconds is accepted too.
+func ParseSRT(data []byte) ([]Cue, error) {
+	var cues []Cue
+	var cue *Cue
+	s := bufio.NewScanner(bytes.NewReader(bytes.TrimPrefix(data, []byte("\uFEFF"))))
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		switch {
+		case line == "":
+			cue = nil
+		case strings.Contains(line, "-->"):
+			from, to, _ := strings.Cut(line, "-->")
+			start, err := parseTimestamp(from)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			// Position settings may follow the end time
+			end, err := parseTimestamp(strings.Fields(to + " ")[0])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			cues = append(cues, Cue{Start: start, End: end})
+			cue = &cues[len(cues)-1]
+		case cue != nil:
+			if cue.Text != "" {
+				cue.Text += "\n"
+			}
+			cue.Text += line
+		}
+		// Anything else is a cue number or a header
+	}
+	return cues, s.Err()
+}
+
+func parseTimestamp(s string) (time.Duration, error) {
+	s = strings.Replace(strings.TrimSpace(s), ",", ".", 1)
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("bad timestamp %q", s)
+	}
+	var d time.Duration
+	for i, part := range parts {
+		unit := time.Minute
+		if len(parts) == 3 && i == 0 {
+			unit = time.Hour
+		}
+		if i == len(parts)-1 {
+			secs, err := strconv.ParseFloat(part, 64)
+			if err != nil {
+				return 0, fmt.Errorf("bad timestamp %q", s)
+			}
+			d += time.Duration(secs * float64(time.Second))
+			break
+		}
+		v, err := strconv.Atoi(part)
+		if err != nil {
+			return 0, fmt.Errorf("bad timestamp %q", s)
+		}
+		d += time.Duration(v) * unit
+	}
+	return d, nil
+}
+
+// CueAt returns the text shown at t, or "" between cues.
+func CueAt(cues []Cue, t time.Duration) string {
+	for _, c := range cues {
+		if t >= c.Start && t < c.End {
+			return c.Text
+		}
+	}
+	return ""
+}
@@ -0,0 +1,64 @@
+// Package video decodes pre-rendered videos and their subtitles for
+// cutscenes. It has no raylib dependency; decoders read a plain stream so
+// videos can come from any asset source, including ones that can't seek.
+package video
+
+import (
+	"fmt"
+	"image"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// Info describes a video and its sound track
+type Info struct {
+	Width, Height int
+	// FrameTime is how long each frame is shown
+	FrameTime time.Duration
+	// SampleRate and Channels are zero when there is no sound
+	SampleRate int
+	Channels   int
+}
+
+// Decoder reads a video frame by frame in presentation order.
+type Decoder interface {
+	Info() Info
+	// NextFrame decodes the next frame, returning io.EOF after the last.
+	// The image is reused by the following call.
+	NextFrame() (*image.RGBA, error)
+	// ReadAudio fills dst with interleaved samples in [-1, 1] and returns
+	// how many it wrote, zero once the sound track has ended.
+	ReadAudio(dst []float32) int
+	Close() error
+}
+
+// decoders open a stream by lower case file extension
+var decoders = map[string]func(io.ReadCloser) (Decoder, error){
+	".mpg":  openMPEG,
+	".mpeg": openMPEG,
+	".avi":  openAVI,
+}
+
+// Register adds a decoder for files with extension ext, such as ".mpg".
+func Register(ext string, open func(io.ReadCloser) (Decoder, error)) {
+	decoders[strings.ToLower(ext)] = open
+}
+
+// Open starts decoding r, picking the decoder by the extension of name.
+// The decoder closes r when it is closed.
+func Open(name string, r io.ReadCloser) (Decoder, error) {
+	ext := strings.ToLower(path.Ext(name))
+	open, ok := decoders[ext]
+	if !ok {
+		r.Close()
+		return nil, fmt.Errorf("video %s: no decoder for %q files", name, ext)
+	}
+	d, err := open(r)
+	if err != nil {
+		r.Close()
+		return nil, fmt.Errorf("video %s: %w", name, err)
+	}
+	return d, nil
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"image/color"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"raylibgo/video"
+)
+
+const (
+	// videoAudioFrames is the size of each sound buffer handed to the audio
+	// device; small enough that sound and picture stay within a frame or
+	// two of each other
+	videoAudioFrames  = 1024
+	videoSubtitleSize = 30
+)
+
+// VideoPlayer plays a pre-rendered video full screen, decoding frames in
+// software into a streaming texture and feeding its sound to an audio
+// stream. Subtitles come from an SRT file next to the video, in the UI
+// language if there is one. While it plays the music pauses, it holds the
+// dialogue input context and gameplay waits; Escape or Start skips it.
+type VideoPlayer struct {
+	Path string
+
+	dec    video.Decoder
+	info   video.Info
+	tex    rl.Texture2D
+	stream rl.AudioStream
+	sound  bool
+	paused bool
+	// samples holds one buffer of interleaved sound
+	samples []float32
+	pixels  []color.RGBA
+	// clock is how far into the video playback is; frame is when the frame
+	// on the texture is replaced by the next
+	clock, frame time.Duration
+	cues         []video.Cue
+	onDone       func()
+	font         *Font
+}
+
+var videoPlayer = &VideoPlayer{}
+
+// Play starts the video at path. onDone, if set, runs when it ends, is
+// skipped, or fails to open.
+func (v *VideoPlayer) Play(file string, onDone func()) {
+	v.Stop()
+	v.onDone = onDone
+	r, err := OpenAsset(file)
+	if err != nil {
+		log.Printf("video: %v", err)
+		v.Stop()
+		return
+	}
+	dec, err := video.Open(file, r)
+	if err != nil {
+		log.Printf("%v", err)
+		v.Stop()
+		return
+	}
+	v.Path = file
+	v.dec = dec
+	v.info = dec.Info()
+	v.clock, v.frame = 0, 0
+	v.paused = false
+	v.pixels = make([]color.RGBA, v.info.Width*v.info.Height)
+	img := rl.GenImageColor(v.info.Width, v.info.Height, rl.Black)
+	v.tex = rl.LoadTextureFromImage(img)
+	rl.UnloadImage(img)
+	rl.SetTextureFilter(v.tex, rl.FilterBilinear)
+	if v.info.SampleRate > 0 && audioReady {
+		rl.SetAudioStreamBufferSizeDefault(videoAudioFrames)
+		v.stream = rl.LoadAudioStream(uint32(v.info.SampleRate), 32, uint32(v.info.Channels))
+		rl.SetAudioStreamBufferSizeDefault(0)
+		v.sound = rl.IsAudioStreamValid(v.stream)
+		v.samples = make([]float32, videoAudioFrames*v.info.Channels)
+		if v.sound {
+			v.feed()
+			rl.SetAudioStreamVolume(v.stream, mixer.Gain(BusVideo))
+			rl.PlayAudioStream(v.stream)
+		}
+	}
+	v.cues = loadSubtitles(file)
+	if rl.IsMusicValid(music) {
+		rl.PauseMusicStream(music)
+	}
+	inputContexts.Set(v, ContextDialogue, true)
+	v.show()
+}
+
+// loadSubtitles reads the subtitles for a video: "intro.ja.srt" for
+// "intro.mpg" in Japanese, otherwise "intro.srt".
+func loadSubtitles(file string) []video.Cue {
+	base := strings.TrimSuffix(file, path.Ext(file))
+	for _, name := range []string{base + "." + locale.Language() + ".srt", base + ".srt"} {
+		if !AssetExists(name) {
+			continue
+		}
+		data, err := ReadAsset(name)
+		if err != nil {
+			log.Printf("video: %v", err)
+			return nil
+		}
+		cues, err := video.ParseSRT(data)
+		if err != nil {
+			log.Printf("video: subtitles %s: %v", name, err)
+		}
+		return cues
+	}
+	return nil
+}
+
+// Active reports whether a video is playing.
+func (v *VideoPlayer) Active() bool {
+	return v.dec != nil
+}
+
+// SetVolume sets the volume of the video's sound.
+func (v *VideoPlayer) SetVolume(volume float32) {
+	if v.sound {
+		rl.SetAudioStreamVolume(v.stream, volume)
+	}
+}
+
+// Position returns how far into the video playback is.
+func (v *VideoPlayer) Position() time.Duration {
+	return v.clock
+}
+
+// Stop ends the video and frees its texture and sound.
+func (v *VideoPlayer) Stop() {
+	if v.dec != nil {
+		v.dec.Close()
+		v.dec = nil
+		rl.UnloadTexture(v.tex)
+		if v.sound {
+			rl.StopAudioStream(v.stream)
+			rl.UnloadAudioStream(v.stream)
+			v.sound = false
+		}
+		if rl.IsMusicValid(music) {
+			rl.ResumeMusicStream(music)
+		}
+		inputContexts.Set(v, ContextDialogue, false)
+		// Don't carry a press held through the video into gameplay
+		live = InputFrame{}
+		coop.live = InputFrame{}
+	}
+	v.cues = nil
+	v.pixels = nil
+	if done := v.onDone; done != nil {
+		v.onDone = nil
+		done()
+	}
+}
+
+// Update advances playback by the frame time, or pauses it with the game
+// while the window is in the background. It reports whether gameplay
+// should hold this frame, which includes the frame the video ends on.
+func (v *VideoPlayer) Update() bool {
+	if !v.Active() {
+		return false
+	}
+	if v.paused != focus.Paused() {
+		v.paused = focus.Paused()
+		if v.sound && v.paused {
+			rl.PauseAudioStream(v.stream)
+		} else if v.sound {
+			rl.ResumeAudioStream(v.stream)
+		}
+	}
+	if v.paused {
+		return true
+	}
+	if inputContexts.KeyPressed(ContextDialogue, rl.KeyEscape) ||
+		inputContexts.ButtonPressed(ContextDialogue, rl.GamepadButtonMiddleRight) {
+		v.Stop()
+		return true
+	}
+	v.clock += FrameTime()
+	for v.frame <= v.clock {
+		if !v.show() {
+			v.Stop()
+			return true
+		}
+	}
+	if v.sound {
+		v.feed()
+	}
+	return true
+}
+
+// show decodes the next frame onto the texture. Frames that are already
+// late are decoded in turn by Update, so only the last is uploaded.
+func (v *VideoPlayer) show() bool {
+	img, err := v.dec.NextFrame()
+	if err != nil {
+		return false
+	}
+	v.frame += v.info.FrameTime
+	if v.frame <= v.clock {
+		return true
+	}
+	for i := range v.pixels {
+		p := img.Pix[i*4 : i*4+4 : i*4+4]
+		v.pixels[i] = color.RGBA{p[0], p[1], p[2], p[3]}
+	}
+	rl.UpdateTexture(v.tex, v.pixels)
+	return true
+}
+
+// feed refills the sound buffers the audio device has finished with,
+// padding with silence once the sound track ends.
+func (v *VideoPlayer) feed() {
+	for rl.IsAudioStreamProcessed(v.stream) {
+		n := v.dec.ReadAudio(v.samples)
+		clear(v.samples[n:])
+		// raylib-go passes the slice length as the frame count, so the
+		// slice is one frame long per entry over the interleaved buffer
+		rl.UpdateAudioStream(v.stream, v.samples[:videoAudioFrames])
+	}
+}
+
+// Draw shows the video fitted to the screen over black, its subtitle and
+// how to skip.
+func (v *VideoPlayer) Draw() {
+	if !v.Active() {
+		return
+	}
+	rl.DrawRectangle(0, 0, int32(screenSize.X), int32(screenSize.Y), rl.Black)
+	w, h := float32(v.info.Width), float32(v.info.Height)
+	scale := min(screenSize.X/w, screenSize.Y/h)
+	dst := rl.NewRectangle((screenSize.X-w*scale)/2, (screenSize.Y-h*scale)/2, w*scale, h*scale)
+	rl.DrawTexturePro(v.tex, rl.NewRectangle(0, 0, w, h), dst, rl.Vector2{}, 0, rl.White)
+
+	if v.font == nil {
+		v.font = fonts.Acquire("", videoSubtitleSize)
+	}
+	if text := video.CueAt(v.cues, v.clock); text != "" {
+		lines := strings.Split(text, "\n")
+		y := screenSize.Y - 48 - float32(len(lines))*videoSubtitleSize*1.2
+		for _, line := range lines {
+			size := v.font.Measure(line, videoSubtitleSize)
+			pos := rl.NewVector2((screenSize.X-size.X)/2, y)
+			rl.DrawRectangleRec(rl.NewRectangle(pos.X-8, pos.Y-2, size.X+16, size.Y+4), rl.Fade(rl.Black, 0.6))
+			v.font.Draw(line, pos, videoSubtitleSize, rl.RayWhite)
+			y += videoSubtitleSize * 1.2
+		}
+	}
+	hint := T("cutscene.skip")
+	size := v.font.Measure(hint, cutsceneFontSize)
+	v.font.Draw(hint, rl.NewVector2(screenSize.X-size.X-24, 16), cutsceneFontSize, rl.Fade(rl.LightGray, 0.7))
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	vramEvictKey     = rl.KeyF4
+	vramWarnFraction = 0.9
+	// Frames between checks of texture memory against the budget
+	vramCheckFrames = 60
+	megabyte        = 1024 * 1024
+)
+
+var formatBits = map[rl.PixelFormat]int64{
+	rl.UncompressedGrayscale:    8,
+	rl.UncompressedGrayAlpha:    16,
+	rl.UncompressedR5g6b5:       16,
+	rl.UncompressedR8g8b8:       24,
+	rl.UncompressedR5g5b5a1:     16,
+	rl.UncompressedR4g4b4a4:     16,
+	rl.UncompressedR8g8b8a8:     32,
+	rl.UncompressedR32:          32,
+	rl.UncompressedR32g32b32:    96,
+	rl.UncompressedR32g32b32a32: 128,
+	rl.CompressedDxt1Rgb:        4,
+	rl.CompressedDxt1Rgba:       4,
+	rl.CompressedDxt3Rgba:       8,
+	rl.CompressedDxt5Rgba:       8,
+	rl.CompressedEtc1Rgb:        4,
+	rl.CompressedEtc2Rgb:        4,
+	rl.CompressedEtc2EacRgba:    8,
+	rl.CompressedPvrtRgb:        4,
+	rl.CompressedPvrtRgba:       4,
+	rl.CompressedAstc4x4Rgba:    8,
+	rl.CompressedAstc8x8Rgba:    2,
+}
+
+// textureBytes estimates the GPU memory used by a texture, including its mipmap chain.
+func textureBytes(tex rl.Texture2D) int64 {
+	bits, ok := formatBits[tex.Format]
+	if !ok {
+		bits = 32
+	}
+
+	var total int64
+	w, h := int64(tex.Width), int64(tex.Height)
+	for level := int32(0); level < max(tex.Mipmaps, 1); level++ {
+		total += w * h * bits / 8
+		w = max(w/2, 1)
+		h = max(h/2, 1)
+	}
+	return total
+}
+
+// VRAMUsage is the estimated GPU memory used by one asset group
+type VRAMUsage struct {
+	Group    string
+	Bytes    int64
+	Textures int
+}
+
+// VRAMUsage returns the estimated memory per manifest group, plus an
+// "ungrouped" entry for textures not listed in the manifest, largest first.
+// Textures in several groups count toward each of them.
+func (am *AssetManager) VRAMUsage() (total int64, groups []VRAMUsage) {
+	byGroup := make(map[string]*VRAMUsage)
+	add := func(group string, bytes int64) {
+		u, ok := byGroup[group]
+		if !ok {
+			u = &VRAMUsage{Group: group}
+			byGroup[group] = u
+		}
+		u.Bytes += bytes
+		u.Textures++
+	}
+
+	membership := make(map[string][]string)
+	for name, entries := range am.manifest.Groups {
+		for _, e := range entries {
+			membership[e.Path] = append(membership[e.Path], name)
+		}
+	}
+
+	am.textures.ForEach(func(path string, t *Texture) {
+		if !t.Loaded {
+			return
+		}
+		bytes := textureBytes(t.Texture)
+		total += bytes
+		names := membership[path]
+		if len(names) == 0 {
+			add("ungrouped", bytes)
+		}
+		for _, name := range names {
+			add(name, bytes)
+		}
+	})
+
+	for _, u := range byGroup {
+		groups = append(groups, *u)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Bytes > groups[j].Bytes })
+	return total, groups
+}
+
+// NearBudget reports whether textures use enough of the VRAM budget for
+// the debug overlay to warn about it.
+func (am *AssetManager) NearBudget() bool {
+	budget := int64(settings.VRAMBudgetMB) * megabyte
+	if budget <= 0 {
+		return false
+	}
+	var total int64
+	am.textures.ForEach(func(path string, t *Texture) {
+		if t.Loaded {
+			total += textureBytes(t.Texture)
+		}
+	})
+	return float64(total) >= float64(budget)*vramWarnFraction
+}
+
+// evictForBudget runs once every vramCheckFrames. Near the budget it
+// releases the levels that aren't adjacent and then the prestreamed
+// neighbours the player isn't close to; those stream again when the player
+// approaches their exit.
+func (am *AssetManager) evictForBudget(level string, pos rl.Vector2) {
+	if am.budgetCheck++; am.budgetCheck < vramCheckFrames {
+		return
+	}
+	am.budgetCheck = 0
+	if !am.NearBudget() {
+		return
+	}
+	am.EvictUnused(level)
+
+	near := make(map[string]bool)
+	for _, way := range am.ways(level) {
+		if distanceToRect(pos, rl.NewRectangle(way.X, way.Y, way.Width, way.Height)) <= prestreamDistance {
+			near[way.To] = true
+		}
+	}
+	released := 0
+	for name, handles := range am.streamed {
+		if name == level || near[name] {
+			continue
+		}
+		for _, h := range handles {
+			am.Release(h)
+		}
+		delete(am.streamed, name)
+		released++
+	}
+	if released > 0 {
+		log.Printf("vram: near the %d MB budget, released %d prestreamed levels", settings.VRAMBudgetMB, released)
+	}
+}
+
+// EvictUnused releases prestreamed levels that are neither level nor one
+// of its neighbours.
+func (am *AssetManager) EvictUnused(level string) {
+	keep := map[string]bool{level: true}
+	for _, n := range am.Neighbors(level) {
+		keep[n] = true
+	}
+
+	for level, handles := range am.streamed {
+		if keep[level] {
+			continue
+		}
+		for _, h := range handles {
+			am.Release(h)
+		}
+		delete(am.streamed, level)
+	}
+}
+
+// HandleVRAMEvict runs an eviction pass on the debug hotkey.
+func HandleVRAMEvict() {
+	if rl.IsKeyPressed(vramEvictKey) {
+		assets.EvictUnused(currentLevel)
+	}
+}
+
+// VRAMDebugLines describes GPU memory use for the debug overlay.
+func (am *AssetManager) VRAMDebugLines() []string {
+	total, groups := am.VRAMUsage()
+	budget := int64(settings.VRAMBudgetMB) * megabyte
+
+	lines := []string{fmt.Sprintf("Total %.1f / %d MB", float64(total)/megabyte, settings.VRAMBudgetMB)}
+	if budget > 0 && float64(total) >= float64(budget)*vramWarnFraction {
+		lines = append(lines, "WARNING: near VRAM budget, evicting distant levels")
+	}
+	for _, g := range groups {
+		lines = append(lines, fmt.Sprintf("%-12s %7.1f MB  (%d)", g.Group, float64(g.Bytes)/megabyte, g.Textures))
+	}
+	return lines
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"math/rand/v2"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Weather is the ambient condition of a level
+type Weather int
+
+const (
+	WeatherClear Weather = iota
+	WeatherRain
+	WeatherSnow
+	WeatherWind
+)
+
+const weatherParticles = 300
+
+// Horizontal push on the player per tick in windy weather
+const windPush = 0.8
+
+type weatherParticle struct {
+	pos rl.Vector2
+	vel rl.Vector2
+}
+
+// WeatherSystem animates rain, snow or wind streaks over the level. Particles
+// use their own random source so they never disturb gameplay randomness.
+type WeatherSystem struct {
+	Kind      Weather
+	particles []weatherParticle
+	rand      *rand.Rand
+}
+
+var weather = &WeatherSystem{rand: rand.New(rand.NewPCG(1, 2))}
+
+// Set switches the weather and respawns the particles.
+func (w *WeatherSystem) Set(kind Weather) {
+	w.Kind = kind
+	w.particles = w.particles[:0]
+	if kind == WeatherClear {
+		return
+	}
+	for range weatherParticles {
+		p := weatherParticle{pos: rl.NewVector2(w.rand.Float32()*screenSize.X, w.rand.Float32()*screenSize.Y)}
+		w.respawn(&p, false)
+		w.particles = append(w.particles, p)
+	}
+}
+
+func (w *WeatherSystem) respawn(p *weatherParticle, top bool) {
+	if top {
+		p.pos = rl.NewVector2(w.rand.Float32()*screenSize.X, -10)
+	}
+	switch w.Kind {
+	case WeatherRain:
+		p.vel = rl.NewVector2(-2, 14+w.rand.Float32()*6)
+	case WeatherSnow:
+		p.vel = rl.NewVector2(w.rand.Float32()-0.5, 1+w.rand.Float32()*1.5)
+	case WeatherWind:
+		p.vel = rl.NewVector2(12+w.rand.Float32()*8, w.rand.Float32()-0.5)
+		if top {
+			p.pos = rl.NewVector2(-20, w.rand.Float32()*screenSize.Y)
+		}
+	}
+}
+
+// Update applies wind to the player.
+func (w *WeatherSystem) Update() {
+	if w.Kind == WeatherWind && !player.OnGround {
+		player.Pos.X += windPush
+	}
+}
+
+// Step moves the particles one tick. It touches only the particles, so it
+// may run as a job.
+func (w *WeatherSystem) Step() {
+	for i := range w.particles {
+		p := &w.particles[i]
+		p.pos = rl.Vector2Add(p.pos, p.vel)
+		if p.pos.Y > screenSize.Y || p.pos.X < -20 || p.pos.X > screenSize.X+20 {
+			w.respawn(p, true)
+		}
+	}
+}
+
+// Draw renders the particles in screen space.
+func (w *WeatherSystem) Draw() {
+	// Particles are decoration only, and noise to players with low vision
+	if highContrast {
+		return
+	}
+	for _, p := range w.particles {
+		switch w.Kind {
+		case WeatherRain:
+			rl.DrawLineV(p.pos, rl.Vector2Add(p.pos, rl.Vector2Scale(p.vel, 0.6)), rl.Fade(rl.SkyBlue, 0.6))
+		case WeatherSnow:
+			rl.DrawCircleV(p.pos, 2.5, rl.Fade(rl.White, 0.8))
+		case WeatherWind:
+			rl.DrawLineV(p.pos, rl.Vector2Add(p.pos, rl.Vector2Scale(p.vel, 2)), rl.Fade(rl.LightGray, 0.3))
+		}
+	}
+}
+
+// String returns the weather's locale key.
+func (w Weather) String() string {
+	return [...]string{"weather.clear", "weather.rain", "weather.snow", "weather.wind"}[w]
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"raylibgo/buildinfo"
+)
+
+const (
+	windowTitle = "Raylib - Mohamed Sheta"
+
+	windowIconPattern  = "assets/icons/icon_%d.png"
+	windowIconFallback = "assets/images/character.png"
+
+	// A load has to run this long before its end is worth the user's attention
+	longLoadThreshold = 2 * time.Second
+)
+
+// windowIconSizes are the square icon sizes offered to the window system,
+// which picks the best match for the title bar, taskbar and task switcher
+var windowIconSizes = []int32{16, 24, 32, 48, 64, 128, 256}
+
+// WindowTitle returns the title bar text with the version and commit.
+func WindowTitle() string {
+	return fmt.Sprintf("%s %s", windowTitle, buildinfo.Get().Short())
+}
+
+// LoadWindowIcons sets the window icon from assets/icons/icon_<size>.png for
+// every size present. Without any, the character sprite is scaled to each
+// size instead.
+func LoadWindowIcons() {
+	var icons []rl.Image
+	for _, size := range windowIconSizes {
+		if img := loadIconImage(fmt.Sprintf(windowIconPattern, size), 0); img != nil {
+			icons = append(icons, *img)
+		}
+	}
+	if len(icons) == 0 {
+		if src := loadIconImage(windowIconFallback, 0); src != nil {
+			for _, size := range windowIconSizes {
+				icons = append(icons, *squareIcon(src, size))
+			}
+			rl.UnloadImage(src)
+		}
+	}
+	if len(icons) == 0 {
+		log.Printf("window: no icon found")
+		return
+	}
+	rl.SetWindowIcons(icons, int32(len(icons)))
+	// The window system keeps its own copy of the pixels
+	for i := range icons {
+		rl.UnloadImage(&icons[i])
+	}
+}
+
+// squareIcon scales a copy of src to fit a size×size square, centered on
+// a transparent canvas.
+func squareIcon(src *rl.Image, size int32) *rl.Image {
+	img := rl.ImageCopy(src)
+	scale := float32(size) / float32(max(img.Width, img.Height))
+	w, h := max(1, int32(float32(img.Width)*scale)), max(1, int32(float32(img.Height)*scale))
+	rl.ImageResize(img, w, h)
+	rl.ImageResizeCanvas(img, size, size, (size-w)/2, (size-h)/2, rl.Blank)
+	return img
+}
+
+// loadIconImage decodes path as 32-bit RGBA, resized to size when size > 0.
+func loadIconImage(path string, size int32) *rl.Image {
+	if !AssetExists(path) {
+		return nil
+	}
+	data, err := ReadAsset(path)
+	if err != nil {
+		log.Printf("window: icon %s: %v", path, err)
+		return nil
+	}
+	img := rl.LoadImageFromMemory(".png", data, int32(len(data)))
+	if !rl.IsImageValid(img) {
+		log.Printf("window: icon %s: not a valid image", path)
+		return nil
+	}
+	if size > 0 {
+		rl.ImageResize(img, size, size)
+	}
+	rl.ImageFormat(img, rl.UncompressedR8g8b8a8)
+	return img
+}
+
+// AttentionTracker flashes the taskbar entry when something the user is
+// waiting on finishes while the window is in the background
+type AttentionTracker struct {
+	loadStarted time.Time
+}
+
+var attention = &AttentionTracker{}
+
+// Notify asks for the user's attention if the window is not focused.
+func (a *AttentionTracker) Notify() {
+	if !rl.IsWindowFocused() {
+		requestWindowAttention()
+	}
+}
+
+// Update watches the asset queue and notifies when a long load finishes.
+func (a *AttentionTracker) Update() {
+	if assets.Pending() > 0 {
+		if a.loadStarted.IsZero() {
+			a.loadStarted = time.Now()
+		}
+		return
+	}
+	if !a.loadStarted.IsZero() && time.Since(a.loadStarted) >= longLoadThreshold {
+		a.Notify()
+	}
+	a.loadStarted = time.Time{}
+}
+
+func init() {
+	RegisterSystem(&SystemFuncs{ID: "attention", Requires: []string{"assets"}, OnUpdate: attention.Update})
+}
@@ -0,0 +1,7 @@
+//go:build !windows
+
+package main
+
+// requestWindowAttention does nothing where raylib exposes no way to mark
+// the window urgent.
+func requestWindowAttention() {}
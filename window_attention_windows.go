@@ -0,0 +1,36 @@
+//go:build windows
+
+package main
+
+import (
+	"syscall"
+	"unsafe"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	flashwTray      = 0x2
+	flashwTimerNoFG = 0xc // flash until the window comes to the foreground
+)
+
+var procFlashWindowEx = syscall.NewLazyDLL("user32.dll").NewProc("FlashWindowEx")
+
+// flashWInfo mirrors the Win32 FLASHWINFO structure
+type flashWInfo struct {
+	size    uint32
+	hwnd    uintptr
+	flags   uint32
+	count   uint32
+	timeout uint32
+}
+
+// requestWindowAttention flashes the taskbar button until the window is focused.
+func requestWindowAttention() {
+	info := flashWInfo{
+		hwnd:  uintptr(rl.GetWindowHandle()),
+		flags: flashwTray | flashwTimerNoFG,
+	}
+	info.size = uint32(unsafe.Sizeof(info))
+	procFlashWindowEx.Call(uintptr(unsafe.Pointer(&info)))
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// WorldFlags are named facts about the world that outlast a visit to a
+// level, such as "opened_door_3" or "boss_defeated". They are saved with
+// the game, and triggers, dialogue and levels check them with conditions.
+type WorldFlags struct {
+	set map[string]bool
+}
+
+var worldFlags = &WorldFlags{set: make(map[string]bool)}
+
+// Set raises or clears a flag.
+func (f *WorldFlags) Set(name string, on bool) {
+	if name == "" {
+		return
+	}
+	if on {
+		f.set[name] = true
+	} else {
+		delete(f.set, name)
+	}
+}
+
+// Has reports whether a flag is raised.
+func (f *WorldFlags) Has(name string) bool {
+	return f.set[name]
+}
+
+// Check evaluates a condition: comma separated flags that must all be
+// raised, where "!name" requires the flag to be clear instead. An empty
+// condition always holds.
+func (f *WorldFlags) Check(cond string) bool {
+	for term := range strings.SplitSeq(cond, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		if name, negated := strings.CutPrefix(term, "!"); negated {
+			if f.Has(name) {
+				return false
+			}
+		} else if !f.Has(term) {
+			return false
+		}
+	}
+	return true
+}
+
+// Names returns the raised flags sorted, for saving.
+func (f *WorldFlags) Names() []string {
+	names := make([]string, 0, len(f.set))
+	for name := range f.set {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Restore raises exactly the named flags.
+func (f *WorldFlags) Restore(names []string) {
+	clear(f.set)
+	for _, name := range names {
+		f.Set(name, true)
+	}
+}
+
+// HandleEvent raises "defeated:<name>" for every defeated enemy, and
+// "boss_defeated" when it was the boss.
+func (f *WorldFlags) HandleEvent(e Event) {
+	if e.Type != EventEnemyDefeated {
+		return
+	}
+	f.Set("defeated:"+e.Target, true)
+	if activeBoss != nil && activeBoss.Name == e.Target {
+		f.Set("boss_defeated", true)
+	}
+}
+
+// DebugLines lists the raised flags.
+func (f *WorldFlags) DebugLines() []string {
+	return []string{fmt.Sprintf("Flags: %s", strings.Join(f.Names(), " "))}
+}
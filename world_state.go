@@ -0,0 +1,128 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// WorldState is everything needed to resume play at the exact tick a save
+// was made: the level, clock and RNG plus the state of every entity
+type WorldState struct {
+	Level   string      `json:"level"`
+	Tick    uint64      `json:"tick"`
+	RNG     []byte      `json:"rng"`
+	Player  PlayerSave  `json:"player"`
+	Pickups []Pickup    `json:"pickups,omitempty"`
+	Enemies []EnemySave `json:"enemies,omitempty"`
+	Weather Weather     `json:"weather"`
+	Waves   WaveState   `json:"waves"`
+	Boss    *BossSave   `json:"boss,omitempty"`
+	// CameraLock is the area the camera is held in, as during a boss fight
+	CameraLock *rl.Rectangle `json:"cameraLock,omitempty"`
+}
+
+// PlayerSave is the part of the player that changes during play
+type PlayerSave struct {
+	Pos       rl.Vector2     `json:"pos"`
+	VelocityY float32        `json:"velocityY"`
+	OnGround  bool           `json:"onGround"`
+	Flip      bool           `json:"flip"`
+	Health    int            `json:"health"`
+	Effects   []StatusEffect `json:"effects,omitempty"`
+}
+
+// EnemySave is one enemy, including where it is along its patrol
+type EnemySave struct {
+	Name      string     `json:"name"`
+	Pos       rl.Vector2 `json:"pos"`
+	Health    int        `json:"health"`
+	MaxHealth int        `json:"maxHealth"`
+	Speed     float32    `json:"speed"`
+	Patrol    float32    `json:"patrol"`
+	Origin    float32    `json:"origin"`
+	Dir       float32    `json:"dir"`
+	Chase     bool       `json:"chase,omitempty"`
+	Defeated  bool       `json:"defeated"`
+}
+
+// CaptureWorld snapshots the running game.
+func CaptureWorld() *WorldState {
+	rngState, _ := rngSource.MarshalBinary()
+	w := &WorldState{
+		Level: currentLevel,
+		Tick:  clock.Tick,
+		RNG:   rngState,
+		Player: PlayerSave{
+			Pos:       player.Pos,
+			VelocityY: player.VelocityY,
+			OnGround:  player.OnGround,
+			Flip:      player.Flip,
+			Health:    player.Health,
+			Effects:   player.Effects.Clone().effects,
+		},
+		Pickups: append([]Pickup(nil), pickups...),
+		Weather: weather.Kind,
+		Waves:   director.Capture(),
+		Boss:    CaptureBoss(),
+	}
+	w.Waves.Def = w.Waves.Def.clone()
+	if camera.lock != nil {
+		area := *camera.lock
+		w.CameraLock = &area
+	}
+	for _, e := range enemies {
+		w.Enemies = append(w.Enemies, EnemySave{
+			Name:      e.Name,
+			Pos:       e.Pos,
+			Health:    e.Health,
+			MaxHealth: e.MaxHealth,
+			Speed:     e.Speed,
+			Patrol:    e.Patrol,
+			Origin:    e.origin,
+			Dir:       e.dir,
+			Chase:     e.Chase,
+			Defeated:  e.Defeated,
+		})
+	}
+	return w
+}
+
+// RestoreWorld puts the game back into a captured state. The rewind
+// history is cleared, since it belongs to the timeline being replaced.
+func RestoreWorld(w *WorldState) error {
+	if err := rngSource.UnmarshalBinary(w.RNG); err != nil {
+		return err
+	}
+	currentLevel = w.Level
+	clock.Tick = w.Tick
+
+	player.Pos = w.Player.Pos
+	player.VelocityY = w.Player.VelocityY
+	player.OnGround = w.Player.OnGround
+	player.Flip = w.Player.Flip
+	player.Health = w.Player.Health
+	player.Effects = StatusEffects{effects: w.Player.Effects}
+
+	pickups = append([]Pickup(nil), w.Pickups...)
+	enemies = enemies[:0]
+	for _, s := range w.Enemies {
+		e := NewEnemy(s.Name, s.Pos, s.MaxHealth, s.Patrol)
+		e.Health = s.Health
+		e.Speed = s.Speed
+		e.origin = s.Origin
+		e.dir = s.Dir
+		e.Chase = s.Chase
+		e.Defeated = s.Defeated
+		enemies = append(enemies, e)
+	}
+	weather.Set(w.Weather)
+	director.Restore(w.Waves)
+	RestoreBoss(w.Boss)
+	if w.CameraLock != nil {
+		camera.Lock(*w.CameraLock)
+	} else {
+		camera.Unlock()
+	}
+	rewinder.Reset()
+	stats.Teleported()
+	return nil
+}